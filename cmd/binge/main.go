@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"github.com/kmgreen2/agglo/pkg/util"
 	"github.com/kmgreen2/agglo/pkg/observability"
+	"github.com/kmgreen2/agglo/internal/core"
 	"github.com/kmgreen2/agglo/internal/core/process"
 	"github.com/kmgreen2/agglo/internal/server"
+	"github.com/kmgreen2/agglo/pkg/streaming"
 	"go.uber.org/zap"
 	"io"
 	"io/ioutil"
@@ -25,6 +27,7 @@ const (
 	RunStatelessDaemon
 	RunPersistentDaemon
 	RunLambda
+	RunSubscriber
 )
 
 type CommandArgs struct {
@@ -33,12 +36,17 @@ type CommandArgs struct {
 	runType RunType
 	daemonPort int
 	daemonPath string
+	// daemonListener is set if -daemonPort or -daemonPath is on the command line
+	daemonListener bool
 	maintenancePort int
 	maintenancePath string
 	maxConnections int
 	numThreads int
 	force bool
 	stateDbPath string
+	subscriber string
+	deadLetter string
+	subscriberRetries int
 	exporter observability.Exporter
 	profileStopFn func()
 }
@@ -69,7 +77,7 @@ func parseArgs() *CommandArgs {
 	configPtr := flag.String("config", "", "path to config file for binge")
 	outfilePtr := flag.String("outfile", "/dev/stdout", "path to file to store output")
 	runTypePtr := flag.String("runType", "standalone",
-		"run type for the process: standalone (default), lambda, stateless-daemon, persistent-daemon")
+		"run type for the process: standalone (default), lambda, stateless-daemon, persistent-daemon, subscriber")
 	daemonPortPtr := flag.Int("daemonPort", 8080, "daemon listening port (default 8080)")
 	daemonPathPtr := flag.String("daemonPath", "/binge", "daemon processing path (default /binge)")
 	maintenancePortPtr := flag.Int("maintenancePort", 8081, "daemon listening port (default 8080)")
@@ -78,8 +86,14 @@ func parseArgs() *CommandArgs {
 	numThreadsPtr := flag.Int("numThreads", 16, "number of worker threads")
 	stateDbPathPtr := flag.String("stateDbPath", "/tmp/bingeDb", "location of the state db file")
 	exporterPtr := flag.String("exporter", "none", "OpenTelemetry exporter type")
+	subscriberPtr := flag.String("subscriber", "",
+		"subscriber connection string, e.g. kafka:servers=localhost:9092,topicName=events,group=binge")
+	deadLetterPtr := flag.String("deadLetter", "",
+		"publisher connection string for messages that cannot be processed (default stops the subscriber)")
+	subscriberRetriesPtr := flag.Int("subscriberRetries", 3, "number of times a failed message is retried")
 	forcePtr := flag.Bool("force", false, "force overwrite state entries")
 	cpuProfilePtr := flag.String("cpuprofile", "", "write the CPU profile to a file")
+	instanceIDPtr := flag.String("instanceID", "", "ID of this instance for derived:instanceID (default random)")
 
 	flag.Parse()
 
@@ -110,6 +124,8 @@ func parseArgs() *CommandArgs {
 		args.runType = RunStatelessDaemon
 	} else if strings.Compare(*runTypePtr, "lambda") == 0 {
 		args.runType = RunLambda
+	} else if strings.Compare(*runTypePtr, "subscriber") == 0 {
+		args.runType = RunSubscriber
 	} else {
 		panic(fmt.Sprintf("invalid runType '%s'", *runTypePtr))
 	}
@@ -119,6 +135,10 @@ func parseArgs() *CommandArgs {
 	args.force = *forcePtr
 	args.stateDbPath = *stateDbPathPtr
 
+	if len(*instanceIDPtr) > 0 {
+		core.SetInstanceID(*instanceIDPtr)
+	}
+
 	if strings.Compare(*exporterPtr, "stdout") == 0 {
 		args.exporter, err = observability.NewStdoutExporter()
 		if err != nil {
@@ -153,6 +173,20 @@ func parseArgs() *CommandArgs {
 	} else if args.runType == RunStatelessDaemon || args.runType == RunPersistentDaemon {
 		args.daemonPath = *daemonPathPtr
 		args.daemonPort = *daemonPortPtr
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "daemonPort" || f.Name == "daemonPath" {
+				args.daemonListener = true
+			}
+		})
+		args.maintenancePath = *maintenancePathPtr
+		args.maintenancePort = *maintenancePortPtr
+	} else if args.runType == RunSubscriber {
+		if len(*subscriberPtr) == 0 {
+			usage("must specify -subscriber", 1)
+		}
+		args.subscriber = *subscriberPtr
+		args.deadLetter = *deadLetterPtr
+		args.subscriberRetries = *subscriberRetriesPtr
 		args.maintenancePath = *maintenancePathPtr
 		args.maintenancePort = *maintenancePortPtr
 	}
@@ -170,11 +204,21 @@ func main() {
 		panic(err)
 	}
 
+	// Warnings logged while loading the config go to the global logger
+	if logger, err := zap.NewProduction(); err == nil {
+		zap.ReplaceGlobals(logger)
+	}
+
 	pipelines, err := process.PipelinesFromJson(configBytes)
 	if err != nil {
 		panic(fmt.Sprintf("%+v", err))
 	}
 
+	// Configured sources replace the default HTTP listener, unless it is set on the command line
+	if len(pipelines.Sources()) > 0 && !args.daemonListener {
+		args.daemonPort = 0
+	}
+
 	if args.exporter != nil {
 		err = args.exporter.Start()
 		if err != nil {
@@ -245,6 +289,34 @@ func main() {
 		if !daemon.IsShutdown() && err != nil {
 			panic(err)
 		}
+	} else if args.runType == RunSubscriber {
+		subscriber, err := streaming.NewSubscriberFromConnectionString(args.subscriber)
+		if err != nil {
+			panic(err)
+		}
+		var deadLetter streaming.Publisher
+		if len(args.deadLetter) > 0 {
+			deadLetter, err = streaming.NewPublisherFromConnectionString(args.deadLetter)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		daemon, err := server.NewSubscriberDaemon(args.maintenancePort,
+			args.maintenancePath,
+			subscriber,
+			deadLetter,
+			args.subscriberRetries,
+			pipelines)
+		if err != nil {
+			panic(err)
+		}
+		waiter := registerSignalHandler(daemon)
+		err = daemon.Run()
+		waiter.Wait()
+		if !daemon.IsShutdown() && err != nil {
+			panic(err)
+		}
 	} else if args.runType == RunStatelessDaemon {
 		daemon, err := server.NewStatelessDaemon(args.daemonPort,
 			args.maintenancePort,
@@ -294,6 +294,78 @@ func (TransformationType) EnumDescriptor() ([]byte, []int) {
 	return file_pipeline_proto_rawDescGZIP(), []int{3}
 }
 
+// FoldExec runs the executable at `path` for each element; all other
+// fold types are built-in
+type FoldType int32
+
+const (
+	FoldType_FoldExec     FoldType = 0
+	FoldType_FoldMin      FoldType = 1
+	FoldType_FoldMax      FoldType = 2
+	FoldType_FoldCount    FoldType = 3
+	FoldType_FoldSum      FoldType = 4
+	FoldType_FoldAvg      FoldType = 5
+	FoldType_FoldConcat   FoldType = 6
+	FoldType_FoldDistinct FoldType = 7
+	FoldType_FoldFirst    FoldType = 8
+	FoldType_FoldLast     FoldType = 9
+)
+
+// Enum value maps for FoldType.
+var (
+	FoldType_name = map[int32]string{
+		0: "FoldExec",
+		1: "FoldMin",
+		2: "FoldMax",
+		3: "FoldCount",
+		4: "FoldSum",
+		5: "FoldAvg",
+		6: "FoldConcat",
+		7: "FoldDistinct",
+		8: "FoldFirst",
+		9: "FoldLast",
+	}
+	FoldType_value = map[string]int32{
+		"FoldExec":     0,
+		"FoldMin":      1,
+		"FoldMax":      2,
+		"FoldCount":    3,
+		"FoldSum":      4,
+		"FoldAvg":      5,
+		"FoldConcat":   6,
+		"FoldDistinct": 7,
+		"FoldFirst":    8,
+		"FoldLast":     9,
+	}
+)
+
+func (x FoldType) Enum() *FoldType {
+	p := new(FoldType)
+	*p = x
+	return p
+}
+
+func (x FoldType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FoldType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pipeline_proto_enumTypes[4].Descriptor()
+}
+
+func (FoldType) Type() protoreflect.EnumType {
+	return &file_pipeline_proto_enumTypes[4]
+}
+
+func (x FoldType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FoldType.Descriptor instead.
+func (FoldType) EnumDescriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{4}
+}
+
 type OperatorType int32
 
 const (
@@ -336,11 +408,11 @@ func (x OperatorType) String() string {
 }
 
 func (OperatorType) Descriptor() protoreflect.EnumDescriptor {
-	return file_pipeline_proto_enumTypes[4].Descriptor()
+	return file_pipeline_proto_enumTypes[5].Descriptor()
 }
 
 func (OperatorType) Type() protoreflect.EnumType {
-	return &file_pipeline_proto_enumTypes[4]
+	return &file_pipeline_proto_enumTypes[5]
 }
 
 func (x OperatorType) Number() protoreflect.EnumNumber {
@@ -349,7 +421,7 @@ func (x OperatorType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OperatorType.Descriptor instead.
 func (OperatorType) EnumDescriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{4}
+	return file_pipeline_proto_rawDescGZIP(), []int{5}
 }
 
 type ExistsOperator int32
@@ -385,11 +457,11 @@ func (x ExistsOperator) String() string {
 }
 
 func (ExistsOperator) Descriptor() protoreflect.EnumDescriptor {
-	return file_pipeline_proto_enumTypes[5].Descriptor()
+	return file_pipeline_proto_enumTypes[6].Descriptor()
 }
 
 func (ExistsOperator) Type() protoreflect.EnumType {
-	return &file_pipeline_proto_enumTypes[5]
+	return &file_pipeline_proto_enumTypes[6]
 }
 
 func (x ExistsOperator) Number() protoreflect.EnumNumber {
@@ -398,7 +470,7 @@ func (x ExistsOperator) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ExistsOperator.Descriptor instead.
 func (ExistsOperator) EnumDescriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{5}
+	return file_pipeline_proto_rawDescGZIP(), []int{6}
 }
 
 type UnaryOperator int32
@@ -437,11 +509,11 @@ func (x UnaryOperator) String() string {
 }
 
 func (UnaryOperator) Descriptor() protoreflect.EnumDescriptor {
-	return file_pipeline_proto_enumTypes[6].Descriptor()
+	return file_pipeline_proto_enumTypes[7].Descriptor()
 }
 
 func (UnaryOperator) Type() protoreflect.EnumType {
-	return &file_pipeline_proto_enumTypes[6]
+	return &file_pipeline_proto_enumTypes[7]
 }
 
 func (x UnaryOperator) Number() protoreflect.EnumNumber {
@@ -450,7 +522,7 @@ func (x UnaryOperator) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use UnaryOperator.Descriptor instead.
 func (UnaryOperator) EnumDescriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{6}
+	return file_pipeline_proto_rawDescGZIP(), []int{7}
 }
 
 type BinaryOperator int32
@@ -513,11 +585,11 @@ func (x BinaryOperator) String() string {
 }
 
 func (BinaryOperator) Descriptor() protoreflect.EnumDescriptor {
-	return file_pipeline_proto_enumTypes[7].Descriptor()
+	return file_pipeline_proto_enumTypes[8].Descriptor()
 }
 
 func (BinaryOperator) Type() protoreflect.EnumType {
-	return &file_pipeline_proto_enumTypes[7]
+	return &file_pipeline_proto_enumTypes[8]
 }
 
 func (x BinaryOperator) Number() protoreflect.EnumNumber {
@@ -526,7 +598,7 @@ func (x BinaryOperator) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BinaryOperator.Descriptor instead.
 func (BinaryOperator) EnumDescriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{7}
+	return file_pipeline_proto_rawDescGZIP(), []int{8}
 }
 
 type LogicalOperator int32
@@ -562,11 +634,11 @@ func (x LogicalOperator) String() string {
 }
 
 func (LogicalOperator) Descriptor() protoreflect.EnumDescriptor {
-	return file_pipeline_proto_enumTypes[8].Descriptor()
+	return file_pipeline_proto_enumTypes[9].Descriptor()
 }
 
 func (LogicalOperator) Type() protoreflect.EnumType {
-	return &file_pipeline_proto_enumTypes[8]
+	return &file_pipeline_proto_enumTypes[9]
 }
 
 func (x LogicalOperator) Number() protoreflect.EnumNumber {
@@ -575,7 +647,7 @@ func (x LogicalOperator) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LogicalOperator.Descriptor instead.
 func (LogicalOperator) EnumDescriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{8}
+	return file_pipeline_proto_rawDescGZIP(), []int{9}
 }
 
 type ComparatorOperator int32
@@ -629,11 +701,11 @@ func (x ComparatorOperator) String() string {
 }
 
 func (ComparatorOperator) Descriptor() protoreflect.EnumDescriptor {
-	return file_pipeline_proto_enumTypes[9].Descriptor()
+	return file_pipeline_proto_enumTypes[10].Descriptor()
 }
 
 func (ComparatorOperator) Type() protoreflect.EnumType {
-	return &file_pipeline_proto_enumTypes[9]
+	return &file_pipeline_proto_enumTypes[10]
 }
 
 func (x ComparatorOperator) Number() protoreflect.EnumNumber {
@@ -642,7 +714,108 @@ func (x ComparatorOperator) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ComparatorOperator.Descriptor instead.
 func (ComparatorOperator) EnumDescriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{9}
+	return file_pipeline_proto_rawDescGZIP(), []int{10}
+}
+
+type AnomalyScoring int32
+
+const (
+	AnomalyScoring_ScoreZ   AnomalyScoring = 0
+	AnomalyScoring_ScoreMAD AnomalyScoring = 1
+)
+
+// Enum value maps for AnomalyScoring.
+var (
+	AnomalyScoring_name = map[int32]string{
+		0: "ScoreZ",
+		1: "ScoreMAD",
+	}
+	AnomalyScoring_value = map[string]int32{
+		"ScoreZ":   0,
+		"ScoreMAD": 1,
+	}
+)
+
+func (x AnomalyScoring) Enum() *AnomalyScoring {
+	p := new(AnomalyScoring)
+	*p = x
+	return p
+}
+
+func (x AnomalyScoring) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnomalyScoring) Descriptor() protoreflect.EnumDescriptor {
+	return file_pipeline_proto_enumTypes[11].Descriptor()
+}
+
+func (AnomalyScoring) Type() protoreflect.EnumType {
+	return &file_pipeline_proto_enumTypes[11]
+}
+
+func (x AnomalyScoring) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnomalyScoring.Descriptor instead.
+func (AnomalyScoring) EnumDescriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{11}
+}
+
+type ParserType int32
+
+const (
+	ParserType_ParseGrok     ParserType = 0
+	ParserType_ParseLogfmt   ParserType = 1
+	ParserType_ParseKeyValue ParserType = 2
+	ParserType_ParseRFC5424  ParserType = 3
+	ParserType_ParseCEF      ParserType = 4
+)
+
+// Enum value maps for ParserType.
+var (
+	ParserType_name = map[int32]string{
+		0: "ParseGrok",
+		1: "ParseLogfmt",
+		2: "ParseKeyValue",
+		3: "ParseRFC5424",
+		4: "ParseCEF",
+	}
+	ParserType_value = map[string]int32{
+		"ParseGrok":     0,
+		"ParseLogfmt":   1,
+		"ParseKeyValue": 2,
+		"ParseRFC5424":  3,
+		"ParseCEF":      4,
+	}
+)
+
+func (x ParserType) Enum() *ParserType {
+	p := new(ParserType)
+	*p = x
+	return p
+}
+
+func (x ParserType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ParserType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pipeline_proto_enumTypes[12].Descriptor()
+}
+
+func (ParserType) Type() protoreflect.EnumType {
+	return &file_pipeline_proto_enumTypes[12]
+}
+
+func (x ParserType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ParserType.Descriptor instead.
+func (ParserType) EnumDescriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{12}
 }
 
 type PipelinesCreateRequest struct {
@@ -811,6 +984,14 @@ type Pipelines struct {
 	Pipelines          []*Pipeline          `protobuf:"bytes,2,rep,name=pipelines,proto3" json:"pipelines,omitempty"`
 	ProcessDefinitions []*ProcessDefinition `protobuf:"bytes,3,rep,name=processDefinitions,proto3" json:"processDefinitions,omitempty"`
 	ExternalSystems    []*External          `protobuf:"bytes,4,rep,name=externalSystems,proto3" json:"externalSystems,omitempty"`
+	// version is the value of derived:configVersion; defaults to a hash of the config
+	Version string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// ntpServer is used to correct derived:ntpTime
+	NtpServer            string `protobuf:"bytes,6,opt,name=ntpServer,proto3" json:"ntpServer,omitempty"`
+	NtpSyncIntervalInSec int64  `protobuf:"varint,7,opt,name=ntpSyncIntervalInSec,proto3" json:"ntpSyncIntervalInSec,omitempty"`
+	// sources read events into the pipelines when binge runs as a daemon (default is an HTTP listener
+	// configured by the command line)
+	Sources []*Source `protobuf:"bytes,8,rep,name=sources,proto3" json:"sources,omitempty"`
 }
 
 func (x *Pipelines) Reset() {
@@ -873,6 +1054,34 @@ func (x *Pipelines) GetExternalSystems() []*External {
 	return nil
 }
 
+func (x *Pipelines) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Pipelines) GetNtpServer() string {
+	if x != nil {
+		return x.NtpServer
+	}
+	return ""
+}
+
+func (x *Pipelines) GetNtpSyncIntervalInSec() int64 {
+	if x != nil {
+		return x.NtpSyncIntervalInSec
+	}
+	return 0
+}
+
+func (x *Pipelines) GetSources() []*Source {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
 type Pipeline struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1085,6 +1294,11 @@ type ProcessDefinition struct {
 	//	*ProcessDefinition_Transformer
 	//	*ProcessDefinition_Continuation
 	//	*ProcessDefinition_Entwine
+	//	*ProcessDefinition_Script
+	//	*ProcessDefinition_Parser
+	//	*ProcessDefinition_Absence
+	//	*ProcessDefinition_ChangeDetector
+	//	*ProcessDefinition_AnomalyDetector
 	ProcessDefinition isProcessDefinition_ProcessDefinition `protobuf_oneof:"processDefinition"`
 }
 
@@ -1190,6 +1404,41 @@ func (x *ProcessDefinition) GetEntwine() *Entwine {
 	return nil
 }
 
+func (x *ProcessDefinition) GetScript() *Script {
+	if x, ok := x.GetProcessDefinition().(*ProcessDefinition_Script); ok {
+		return x.Script
+	}
+	return nil
+}
+
+func (x *ProcessDefinition) GetParser() *Parser {
+	if x, ok := x.GetProcessDefinition().(*ProcessDefinition_Parser); ok {
+		return x.Parser
+	}
+	return nil
+}
+
+func (x *ProcessDefinition) GetAbsence() *Absence {
+	if x, ok := x.GetProcessDefinition().(*ProcessDefinition_Absence); ok {
+		return x.Absence
+	}
+	return nil
+}
+
+func (x *ProcessDefinition) GetChangeDetector() *ChangeDetector {
+	if x, ok := x.GetProcessDefinition().(*ProcessDefinition_ChangeDetector); ok {
+		return x.ChangeDetector
+	}
+	return nil
+}
+
+func (x *ProcessDefinition) GetAnomalyDetector() *AnomalyDetector {
+	if x, ok := x.GetProcessDefinition().(*ProcessDefinition_AnomalyDetector); ok {
+		return x.AnomalyDetector
+	}
+	return nil
+}
+
 type isProcessDefinition_ProcessDefinition interface {
 	isProcessDefinition_ProcessDefinition()
 }
@@ -1230,6 +1479,26 @@ type ProcessDefinition_Entwine struct {
 	Entwine *Entwine `protobuf:"bytes,9,opt,name=entwine,proto3,oneof"`
 }
 
+type ProcessDefinition_Script struct {
+	Script *Script `protobuf:"bytes,10,opt,name=script,proto3,oneof"`
+}
+
+type ProcessDefinition_Parser struct {
+	Parser *Parser `protobuf:"bytes,11,opt,name=parser,proto3,oneof"`
+}
+
+type ProcessDefinition_Absence struct {
+	Absence *Absence `protobuf:"bytes,12,opt,name=absence,proto3,oneof"`
+}
+
+type ProcessDefinition_ChangeDetector struct {
+	ChangeDetector *ChangeDetector `protobuf:"bytes,13,opt,name=changeDetector,proto3,oneof"`
+}
+
+type ProcessDefinition_AnomalyDetector struct {
+	AnomalyDetector *AnomalyDetector `protobuf:"bytes,14,opt,name=anomalyDetector,proto3,oneof"`
+}
+
 func (*ProcessDefinition_Annotator) isProcessDefinition_ProcessDefinition() {}
 
 func (*ProcessDefinition_Aggregator) isProcessDefinition_ProcessDefinition() {}
@@ -1248,23 +1517,38 @@ func (*ProcessDefinition_Continuation) isProcessDefinition_ProcessDefinition() {
 
 func (*ProcessDefinition_Entwine) isProcessDefinition_ProcessDefinition() {}
 
-type Entwine struct {
+func (*ProcessDefinition_Script) isProcessDefinition_ProcessDefinition() {}
+
+func (*ProcessDefinition_Parser) isProcessDefinition_ProcessDefinition() {}
+
+func (*ProcessDefinition_Absence) isProcessDefinition_ProcessDefinition() {}
+
+func (*ProcessDefinition_ChangeDetector) isProcessDefinition_ProcessDefinition() {}
+
+func (*ProcessDefinition_AnomalyDetector) isProcessDefinition_ProcessDefinition() {}
+
+// Absence records when each key (the values of `keyFields`) was last seen in
+// `stateStore`, and sends an "absent" event when a key has been silent for
+// longer than `thresholdInMs`, and a "recovered" event when it is seen again.
+// The events are sent to the Tee `outputTee`, which must be defined before the
+// Absence, or run through the pipeline `outputPipeline`.
+type Absence struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name             string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	StreamStateStore string     `protobuf:"bytes,2,opt,name=streamStateStore,proto3" json:"streamStateStore,omitempty"`
-	ObjectStore      string     `protobuf:"bytes,3,opt,name=objectStore,proto3" json:"objectStore,omitempty"`
-	PemPath          string     `protobuf:"bytes,4,opt,name=pemPath,proto3" json:"pemPath,omitempty"`
-	SubStreamID      string     `protobuf:"bytes,5,opt,name=subStreamID,proto3" json:"subStreamID,omitempty"`
-	TickerEndpoint   string     `protobuf:"bytes,6,opt,name=tickerEndpoint,proto3" json:"tickerEndpoint,omitempty"`
-	TickerInterval   int32      `protobuf:"varint,7,opt,name=tickerInterval,proto3" json:"tickerInterval,omitempty"`
-	Condition        *Condition `protobuf:"bytes,8,opt,name=condition,proto3" json:"condition,omitempty"`
+	Name              string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition         *Condition `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	KeyFields         []string   `protobuf:"bytes,3,rep,name=keyFields,proto3" json:"keyFields,omitempty"`
+	StateStore        string     `protobuf:"bytes,4,opt,name=stateStore,proto3" json:"stateStore,omitempty"`
+	ThresholdInMs     int64      `protobuf:"varint,5,opt,name=thresholdInMs,proto3" json:"thresholdInMs,omitempty"`
+	CheckIntervalInMs int64      `protobuf:"varint,6,opt,name=checkIntervalInMs,proto3" json:"checkIntervalInMs,omitempty"`
+	OutputTee         string     `protobuf:"bytes,7,opt,name=outputTee,proto3" json:"outputTee,omitempty"`
+	OutputPipeline    string     `protobuf:"bytes,8,opt,name=outputPipeline,proto3" json:"outputPipeline,omitempty"`
 }
 
-func (x *Entwine) Reset() {
-	*x = Entwine{}
+func (x *Absence) Reset() {
+	*x = Absence{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pipeline_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1272,13 +1556,13 @@ func (x *Entwine) Reset() {
 	}
 }
 
-func (x *Entwine) String() string {
+func (x *Absence) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Entwine) ProtoMessage() {}
+func (*Absence) ProtoMessage() {}
 
-func (x *Entwine) ProtoReflect() protoreflect.Message {
+func (x *Absence) ProtoReflect() protoreflect.Message {
 	mi := &file_pipeline_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1290,78 +1574,85 @@ func (x *Entwine) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Entwine.ProtoReflect.Descriptor instead.
-func (*Entwine) Descriptor() ([]byte, []int) {
+// Deprecated: Use Absence.ProtoReflect.Descriptor instead.
+func (*Absence) Descriptor() ([]byte, []int) {
 	return file_pipeline_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Entwine) GetName() string {
+func (x *Absence) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Entwine) GetStreamStateStore() string {
+func (x *Absence) GetCondition() *Condition {
 	if x != nil {
-		return x.StreamStateStore
+		return x.Condition
 	}
-	return ""
+	return nil
 }
 
-func (x *Entwine) GetObjectStore() string {
+func (x *Absence) GetKeyFields() []string {
 	if x != nil {
-		return x.ObjectStore
+		return x.KeyFields
 	}
-	return ""
+	return nil
 }
 
-func (x *Entwine) GetPemPath() string {
+func (x *Absence) GetStateStore() string {
 	if x != nil {
-		return x.PemPath
+		return x.StateStore
 	}
 	return ""
 }
 
-func (x *Entwine) GetSubStreamID() string {
+func (x *Absence) GetThresholdInMs() int64 {
 	if x != nil {
-		return x.SubStreamID
+		return x.ThresholdInMs
 	}
-	return ""
+	return 0
 }
 
-func (x *Entwine) GetTickerEndpoint() string {
+func (x *Absence) GetCheckIntervalInMs() int64 {
 	if x != nil {
-		return x.TickerEndpoint
+		return x.CheckIntervalInMs
 	}
-	return ""
+	return 0
 }
 
-func (x *Entwine) GetTickerInterval() int32 {
+func (x *Absence) GetOutputTee() string {
 	if x != nil {
-		return x.TickerInterval
+		return x.OutputTee
 	}
-	return 0
+	return ""
 }
 
-func (x *Entwine) GetCondition() *Condition {
+func (x *Absence) GetOutputPipeline() string {
 	if x != nil {
-		return x.Condition
+		return x.OutputPipeline
 	}
-	return nil
+	return ""
 }
 
-type Annotator struct {
+// ChangeDetector continues the pipeline only when one of `fields` changes for
+// the key (the values of `keyFields`).  The last reported values are kept in
+// `stateStore`.  A numeric change must exceed the field's `absoluteDeadband`
+// and its `relativeDeadband` (a fraction of the last reported value), if set.
+type ChangeDetector struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Annotations []*Annotation `protobuf:"bytes,2,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	Name       string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition  *Condition     `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	KeyFields  []string       `protobuf:"bytes,3,rep,name=keyFields,proto3" json:"keyFields,omitempty"`
+	Fields     []*ChangeField `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	StateStore string         `protobuf:"bytes,5,opt,name=stateStore,proto3" json:"stateStore,omitempty"`
 }
 
-func (x *Annotator) Reset() {
-	*x = Annotator{}
+func (x *ChangeDetector) Reset() {
+	*x = ChangeDetector{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pipeline_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1369,13 +1660,13 @@ func (x *Annotator) Reset() {
 	}
 }
 
-func (x *Annotator) String() string {
+func (x *ChangeDetector) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Annotator) ProtoMessage() {}
+func (*ChangeDetector) ProtoMessage() {}
 
-func (x *Annotator) ProtoReflect() protoreflect.Message {
+func (x *ChangeDetector) ProtoReflect() protoreflect.Message {
 	mi := &file_pipeline_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1387,37 +1678,58 @@ func (x *Annotator) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Annotator.ProtoReflect.Descriptor instead.
-func (*Annotator) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeDetector.ProtoReflect.Descriptor instead.
+func (*ChangeDetector) Descriptor() ([]byte, []int) {
 	return file_pipeline_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Annotator) GetName() string {
+func (x *ChangeDetector) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Annotator) GetAnnotations() []*Annotation {
+func (x *ChangeDetector) GetCondition() *Condition {
 	if x != nil {
-		return x.Annotations
+		return x.Condition
 	}
 	return nil
 }
 
-type Annotation struct {
+func (x *ChangeDetector) GetKeyFields() []string {
+	if x != nil {
+		return x.KeyFields
+	}
+	return nil
+}
+
+func (x *ChangeDetector) GetFields() []*ChangeField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ChangeDetector) GetStateStore() string {
+	if x != nil {
+		return x.StateStore
+	}
+	return ""
+}
+
+type ChangeField struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FieldKey  string     `protobuf:"bytes,1,opt,name=fieldKey,proto3" json:"fieldKey,omitempty"`
-	Value     string     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Condition *Condition `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	Path             string  `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	AbsoluteDeadband float64 `protobuf:"fixed64,2,opt,name=absoluteDeadband,proto3" json:"absoluteDeadband,omitempty"`
+	RelativeDeadband float64 `protobuf:"fixed64,3,opt,name=relativeDeadband,proto3" json:"relativeDeadband,omitempty"`
 }
 
-func (x *Annotation) Reset() {
-	*x = Annotation{}
+func (x *ChangeField) Reset() {
+	*x = ChangeField{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pipeline_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1425,13 +1737,13 @@ func (x *Annotation) Reset() {
 	}
 }
 
-func (x *Annotation) String() string {
+func (x *ChangeField) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Annotation) ProtoMessage() {}
+func (*ChangeField) ProtoMessage() {}
 
-func (x *Annotation) ProtoReflect() protoreflect.Message {
+func (x *ChangeField) ProtoReflect() protoreflect.Message {
 	mi := &file_pipeline_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1443,47 +1755,61 @@ func (x *Annotation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Annotation.ProtoReflect.Descriptor instead.
-func (*Annotation) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeField.ProtoReflect.Descriptor instead.
+func (*ChangeField) Descriptor() ([]byte, []int) {
 	return file_pipeline_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Annotation) GetFieldKey() string {
+func (x *ChangeField) GetPath() string {
 	if x != nil {
-		return x.FieldKey
+		return x.Path
 	}
 	return ""
 }
 
-func (x *Annotation) GetValue() string {
+func (x *ChangeField) GetAbsoluteDeadband() float64 {
 	if x != nil {
-		return x.Value
+		return x.AbsoluteDeadband
 	}
-	return ""
+	return 0
 }
 
-func (x *Annotation) GetCondition() *Condition {
+func (x *ChangeField) GetRelativeDeadband() float64 {
 	if x != nil {
-		return x.Condition
+		return x.RelativeDeadband
 	}
-	return nil
+	return 0
 }
 
-type Aggregator struct {
+// AnomalyDetector scores the numeric field `key` against an exponentially
+// weighted baseline for each group (the values of `groupByKeys`), which is
+// checkpointed in `stateStore`.  `decayFactor` (default 0.05), `warmUp`
+// (default 30 values) and `threshold` (default 3) tune the detection.  Setting
+// `seasonPeriodInMs` and `seasonBuckets` keeps a baseline for each bucket of
+// the period, using the time at `timeKey`.  If `onlyAnomalies` is set,
+// processing stops for values that are not anomalous.
+type AnomalyDetector struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name            string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Condition       *Condition   `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
-	StateStore      string       `protobuf:"bytes,3,opt,name=stateStore,proto3" json:"stateStore,omitempty"`
-	Aggregation     *Aggregation `protobuf:"bytes,4,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
-	AsyncCheckpoint bool         `protobuf:"varint,5,opt,name=asyncCheckpoint,proto3" json:"asyncCheckpoint,omitempty"`
-	ForwardState    bool         `protobuf:"varint,6,opt,name=forwardState,proto3" json:"forwardState,omitempty"`
-}
-
-func (x *Aggregator) Reset() {
-	*x = Aggregator{}
+	Name             string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition        *Condition     `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	StateStore       string         `protobuf:"bytes,3,opt,name=stateStore,proto3" json:"stateStore,omitempty"`
+	Key              string         `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	GroupByKeys      []string       `protobuf:"bytes,5,rep,name=groupByKeys,proto3" json:"groupByKeys,omitempty"`
+	DecayFactor      float64        `protobuf:"fixed64,6,opt,name=decayFactor,proto3" json:"decayFactor,omitempty"`
+	WarmUp           int64          `protobuf:"varint,7,opt,name=warmUp,proto3" json:"warmUp,omitempty"`
+	Threshold        float64        `protobuf:"fixed64,8,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Scoring          AnomalyScoring `protobuf:"varint,9,opt,name=scoring,proto3,enum=pipeline.AnomalyScoring" json:"scoring,omitempty"`
+	SeasonPeriodInMs int64          `protobuf:"varint,10,opt,name=seasonPeriodInMs,proto3" json:"seasonPeriodInMs,omitempty"`
+	SeasonBuckets    int32          `protobuf:"varint,11,opt,name=seasonBuckets,proto3" json:"seasonBuckets,omitempty"`
+	TimeKey          string         `protobuf:"bytes,12,opt,name=timeKey,proto3" json:"timeKey,omitempty"`
+	OnlyAnomalies    bool           `protobuf:"varint,13,opt,name=onlyAnomalies,proto3" json:"onlyAnomalies,omitempty"`
+}
+
+func (x *AnomalyDetector) Reset() {
+	*x = AnomalyDetector{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pipeline_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1491,13 +1817,13 @@ func (x *Aggregator) Reset() {
 	}
 }
 
-func (x *Aggregator) String() string {
+func (x *AnomalyDetector) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Aggregator) ProtoMessage() {}
+func (*AnomalyDetector) ProtoMessage() {}
 
-func (x *Aggregator) ProtoReflect() protoreflect.Message {
+func (x *AnomalyDetector) ProtoReflect() protoreflect.Message {
 	mi := &file_pipeline_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1509,144 +1835,142 @@ func (x *Aggregator) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Aggregator.ProtoReflect.Descriptor instead.
-func (*Aggregator) Descriptor() ([]byte, []int) {
+// Deprecated: Use AnomalyDetector.ProtoReflect.Descriptor instead.
+func (*AnomalyDetector) Descriptor() ([]byte, []int) {
 	return file_pipeline_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Aggregator) GetName() string {
+func (x *AnomalyDetector) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Aggregator) GetCondition() *Condition {
+func (x *AnomalyDetector) GetCondition() *Condition {
 	if x != nil {
 		return x.Condition
 	}
 	return nil
 }
 
-func (x *Aggregator) GetStateStore() string {
+func (x *AnomalyDetector) GetStateStore() string {
 	if x != nil {
 		return x.StateStore
 	}
 	return ""
 }
 
-func (x *Aggregator) GetAggregation() *Aggregation {
+func (x *AnomalyDetector) GetKey() string {
 	if x != nil {
-		return x.Aggregation
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-func (x *Aggregator) GetAsyncCheckpoint() bool {
+func (x *AnomalyDetector) GetGroupByKeys() []string {
 	if x != nil {
-		return x.AsyncCheckpoint
+		return x.GroupByKeys
 	}
-	return false
+	return nil
 }
 
-func (x *Aggregator) GetForwardState() bool {
+func (x *AnomalyDetector) GetDecayFactor() float64 {
 	if x != nil {
-		return x.ForwardState
+		return x.DecayFactor
 	}
-	return false
-}
-
-type Aggregation struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Key             string          `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	AggregationType AggregationType `protobuf:"varint,2,opt,name=aggregationType,proto3,enum=pipeline.AggregationType" json:"aggregationType,omitempty"`
-	GroupByKeys     []string        `protobuf:"bytes,3,rep,name=groupByKeys,proto3" json:"groupByKeys,omitempty"`
+	return 0
 }
 
-func (x *Aggregation) Reset() {
-	*x = Aggregation{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *AnomalyDetector) GetWarmUp() int64 {
+	if x != nil {
+		return x.WarmUp
 	}
+	return 0
 }
 
-func (x *Aggregation) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AnomalyDetector) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
 }
 
-func (*Aggregation) ProtoMessage() {}
-
-func (x *Aggregation) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *AnomalyDetector) GetScoring() AnomalyScoring {
+	if x != nil {
+		return x.Scoring
 	}
-	return mi.MessageOf(x)
+	return AnomalyScoring_ScoreZ
 }
 
-// Deprecated: Use Aggregation.ProtoReflect.Descriptor instead.
-func (*Aggregation) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{12}
+func (x *AnomalyDetector) GetSeasonPeriodInMs() int64 {
+	if x != nil {
+		return x.SeasonPeriodInMs
+	}
+	return 0
 }
 
-func (x *Aggregation) GetKey() string {
+func (x *AnomalyDetector) GetSeasonBuckets() int32 {
 	if x != nil {
-		return x.Key
+		return x.SeasonBuckets
 	}
-	return ""
+	return 0
 }
 
-func (x *Aggregation) GetAggregationType() AggregationType {
+func (x *AnomalyDetector) GetTimeKey() string {
 	if x != nil {
-		return x.AggregationType
+		return x.TimeKey
 	}
-	return AggregationType_AggUnknown
+	return ""
 }
 
-func (x *Aggregation) GetGroupByKeys() []string {
+func (x *AnomalyDetector) GetOnlyAnomalies() bool {
 	if x != nil {
-		return x.GroupByKeys
+		return x.OnlyAnomalies
 	}
-	return nil
+	return false
 }
 
-type Completer struct {
+// Parser extracts fields from the string at `sourceField` (default: "message")
+// and writes them under `targetField` (default: the top-level map).
+// `patterns` are grok expressions, which may reference the built-in patterns,
+// `patternDefinitions` and the definitions in the file at `patternsPath`.
+// `pairSeparator` and `kvSeparator` are used by ParseKeyValue.
+type Parser struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name       string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Condition  *Condition  `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
-	StateStore string      `protobuf:"bytes,3,opt,name=stateStore,proto3" json:"stateStore,omitempty"`
-	Completion *Completion `protobuf:"bytes,4,opt,name=completion,proto3" json:"completion,omitempty"`
-}
-
-func (x *Completer) Reset() {
-	*x = Completer{}
+	Name               string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition          *Condition        `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	ParserType         ParserType        `protobuf:"varint,3,opt,name=parserType,proto3,enum=pipeline.ParserType" json:"parserType,omitempty"`
+	SourceField        string            `protobuf:"bytes,4,opt,name=sourceField,proto3" json:"sourceField,omitempty"`
+	TargetField        string            `protobuf:"bytes,5,opt,name=targetField,proto3" json:"targetField,omitempty"`
+	Patterns           []string          `protobuf:"bytes,6,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	PatternDefinitions map[string]string `protobuf:"bytes,7,rep,name=patternDefinitions,proto3" json:"patternDefinitions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PatternsPath       string            `protobuf:"bytes,8,opt,name=patternsPath,proto3" json:"patternsPath,omitempty"`
+	PairSeparator      string            `protobuf:"bytes,9,opt,name=pairSeparator,proto3" json:"pairSeparator,omitempty"`
+	KvSeparator        string            `protobuf:"bytes,10,opt,name=kvSeparator,proto3" json:"kvSeparator,omitempty"`
+	FailOnError        bool              `protobuf:"varint,11,opt,name=failOnError,proto3" json:"failOnError,omitempty"`
+}
+
+func (x *Parser) Reset() {
+	*x = Parser{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[13]
+		mi := &file_pipeline_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Completer) String() string {
+func (x *Parser) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Completer) ProtoMessage() {}
+func (*Parser) ProtoMessage() {}
 
-func (x *Completer) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[13]
+func (x *Parser) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1657,121 +1981,123 @@ func (x *Completer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Completer.ProtoReflect.Descriptor instead.
-func (*Completer) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use Parser.ProtoReflect.Descriptor instead.
+func (*Parser) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Completer) GetName() string {
+func (x *Parser) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Completer) GetCondition() *Condition {
+func (x *Parser) GetCondition() *Condition {
 	if x != nil {
 		return x.Condition
 	}
 	return nil
 }
 
-func (x *Completer) GetStateStore() string {
+func (x *Parser) GetParserType() ParserType {
 	if x != nil {
-		return x.StateStore
+		return x.ParserType
 	}
-	return ""
+	return ParserType_ParseGrok
 }
 
-func (x *Completer) GetCompletion() *Completion {
+func (x *Parser) GetSourceField() string {
 	if x != nil {
-		return x.Completion
+		return x.SourceField
 	}
-	return nil
+	return ""
 }
 
-type Completion struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	JoinKeys  []string `protobuf:"bytes,1,rep,name=joinKeys,proto3" json:"joinKeys,omitempty"`
-	TimeoutMs int64    `protobuf:"varint,2,opt,name=timeoutMs,proto3" json:"timeoutMs,omitempty"`
+func (x *Parser) GetTargetField() string {
+	if x != nil {
+		return x.TargetField
+	}
+	return ""
 }
 
-func (x *Completion) Reset() {
-	*x = Completion{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[14]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Parser) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
 	}
+	return nil
 }
 
-func (x *Completion) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Parser) GetPatternDefinitions() map[string]string {
+	if x != nil {
+		return x.PatternDefinitions
+	}
+	return nil
 }
 
-func (*Completion) ProtoMessage() {}
-
-func (x *Completion) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[14]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Parser) GetPatternsPath() string {
+	if x != nil {
+		return x.PatternsPath
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use Completion.ProtoReflect.Descriptor instead.
-func (*Completion) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{14}
+func (x *Parser) GetPairSeparator() string {
+	if x != nil {
+		return x.PairSeparator
+	}
+	return ""
 }
 
-func (x *Completion) GetJoinKeys() []string {
+func (x *Parser) GetKvSeparator() string {
 	if x != nil {
-		return x.JoinKeys
+		return x.KvSeparator
 	}
-	return nil
+	return ""
 }
 
-func (x *Completion) GetTimeoutMs() int64 {
+func (x *Parser) GetFailOnError() bool {
 	if x != nil {
-		return x.TimeoutMs
+		return x.FailOnError
 	}
-	return 0
+	return false
 }
 
-type Filter struct {
+// Script runs a Starlark script that defines process(event), which returns
+// the modified event or DROP.  The script is read from the local file at
+// `path`, or from the key `path` in `objectStore` if set.
+type Script struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Regex       string `protobuf:"bytes,2,opt,name=regex,proto3" json:"regex,omitempty"`
-	KeepMatched bool   `protobuf:"varint,3,opt,name=keepMatched,proto3" json:"keepMatched,omitempty"`
+	Name        string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition   *Condition `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	Path        string     `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	ObjectStore string     `protobuf:"bytes,4,opt,name=objectStore,proto3" json:"objectStore,omitempty"`
+	MaxSteps    uint64     `protobuf:"varint,5,opt,name=maxSteps,proto3" json:"maxSteps,omitempty"`
+	// maxEventBytes bounds the estimated size of the event passed to and returned from the script, not
+	// the memory allocated while it runs
+	MaxEventBytes uint64 `protobuf:"varint,6,opt,name=maxEventBytes,proto3" json:"maxEventBytes,omitempty"`
 }
 
-func (x *Filter) Reset() {
-	*x = Filter{}
+func (x *Script) Reset() {
+	*x = Script{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[15]
+		mi := &file_pipeline_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Filter) String() string {
+func (x *Script) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filter) ProtoMessage() {}
+func (*Script) ProtoMessage() {}
 
-func (x *Filter) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[15]
+func (x *Script) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1782,57 +2108,85 @@ func (x *Filter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
-func (*Filter) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use Script.ProtoReflect.Descriptor instead.
+func (*Script) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Filter) GetName() string {
+func (x *Script) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Filter) GetRegex() string {
+func (x *Script) GetCondition() *Condition {
 	if x != nil {
-		return x.Regex
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *Script) GetPath() string {
+	if x != nil {
+		return x.Path
 	}
 	return ""
 }
 
-func (x *Filter) GetKeepMatched() bool {
+func (x *Script) GetObjectStore() string {
 	if x != nil {
-		return x.KeepMatched
+		return x.ObjectStore
 	}
-	return false
+	return ""
 }
 
-type Checkpoint struct {
+func (x *Script) GetMaxSteps() uint64 {
+	if x != nil {
+		return x.MaxSteps
+	}
+	return 0
+}
+
+func (x *Script) GetMaxEventBytes() uint64 {
+	if x != nil {
+		return x.MaxEventBytes
+	}
+	return 0
+}
+
+type Entwine struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OutputConnectorRef string `protobuf:"bytes,1,opt,name=outputConnectorRef,proto3" json:"outputConnectorRef,omitempty"`
+	Name             string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	StreamStateStore string     `protobuf:"bytes,2,opt,name=streamStateStore,proto3" json:"streamStateStore,omitempty"`
+	ObjectStore      string     `protobuf:"bytes,3,opt,name=objectStore,proto3" json:"objectStore,omitempty"`
+	PemPath          string     `protobuf:"bytes,4,opt,name=pemPath,proto3" json:"pemPath,omitempty"`
+	SubStreamID      string     `protobuf:"bytes,5,opt,name=subStreamID,proto3" json:"subStreamID,omitempty"`
+	TickerEndpoint   string     `protobuf:"bytes,6,opt,name=tickerEndpoint,proto3" json:"tickerEndpoint,omitempty"`
+	TickerInterval   int32      `protobuf:"varint,7,opt,name=tickerInterval,proto3" json:"tickerInterval,omitempty"`
+	Condition        *Condition `protobuf:"bytes,8,opt,name=condition,proto3" json:"condition,omitempty"`
 }
 
-func (x *Checkpoint) Reset() {
-	*x = Checkpoint{}
+func (x *Entwine) Reset() {
+	*x = Entwine{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[16]
+		mi := &file_pipeline_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Checkpoint) String() string {
+func (x *Entwine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Checkpoint) ProtoMessage() {}
+func (*Entwine) ProtoMessage() {}
 
-func (x *Checkpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[16]
+func (x *Entwine) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1843,124 +2197,93 @@ func (x *Checkpoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
-func (*Checkpoint) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use Entwine.ProtoReflect.Descriptor instead.
+func (*Entwine) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Checkpoint) GetOutputConnectorRef() string {
+func (x *Entwine) GetName() string {
 	if x != nil {
-		return x.OutputConnectorRef
+		return x.Name
 	}
 	return ""
 }
 
-type Spawner struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Name      string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Condition *Condition `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
-	DelayInMs int64      `protobuf:"varint,3,opt,name=delayInMs,proto3" json:"delayInMs,omitempty"`
-	DoSync    bool       `protobuf:"varint,4,opt,name=doSync,proto3" json:"doSync,omitempty"`
-	Job       *Job       `protobuf:"bytes,5,opt,name=job,proto3" json:"job,omitempty"`
-}
-
-func (x *Spawner) Reset() {
-	*x = Spawner{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[17]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Entwine) GetStreamStateStore() string {
+	if x != nil {
+		return x.StreamStateStore
 	}
+	return ""
 }
 
-func (x *Spawner) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Spawner) ProtoMessage() {}
-
-func (x *Spawner) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[17]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Entwine) GetObjectStore() string {
+	if x != nil {
+		return x.ObjectStore
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Spawner.ProtoReflect.Descriptor instead.
-func (*Spawner) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{17}
+	return ""
 }
 
-func (x *Spawner) GetName() string {
+func (x *Entwine) GetPemPath() string {
 	if x != nil {
-		return x.Name
+		return x.PemPath
 	}
 	return ""
 }
 
-func (x *Spawner) GetCondition() *Condition {
+func (x *Entwine) GetSubStreamID() string {
 	if x != nil {
-		return x.Condition
+		return x.SubStreamID
 	}
-	return nil
+	return ""
 }
 
-func (x *Spawner) GetDelayInMs() int64 {
+func (x *Entwine) GetTickerEndpoint() string {
 	if x != nil {
-		return x.DelayInMs
+		return x.TickerEndpoint
 	}
-	return 0
+	return ""
 }
 
-func (x *Spawner) GetDoSync() bool {
+func (x *Entwine) GetTickerInterval() int32 {
 	if x != nil {
-		return x.DoSync
+		return x.TickerInterval
 	}
-	return false
+	return 0
 }
 
-func (x *Spawner) GetJob() *Job {
+func (x *Entwine) GetCondition() *Condition {
 	if x != nil {
-		return x.Job
+		return x.Condition
 	}
 	return nil
 }
 
-// Exec must take map[string]interface{} (JSON) as input
-type Runnable struct {
+type Annotator struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PathToExec string   `protobuf:"bytes,1,opt,name=pathToExec,proto3" json:"pathToExec,omitempty"`
-	CmdArgs    []string `protobuf:"bytes,2,rep,name=cmdArgs,proto3" json:"cmdArgs,omitempty"`
+	Name        string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Annotations []*Annotation `protobuf:"bytes,2,rep,name=annotations,proto3" json:"annotations,omitempty"`
 }
 
-func (x *Runnable) Reset() {
-	*x = Runnable{}
+func (x *Annotator) Reset() {
+	*x = Annotator{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[18]
+		mi := &file_pipeline_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Runnable) String() string {
+func (x *Annotator) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Runnable) ProtoMessage() {}
+func (*Annotator) ProtoMessage() {}
 
-func (x *Runnable) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[18]
+func (x *Annotator) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1971,50 +2294,52 @@ func (x *Runnable) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Runnable.ProtoReflect.Descriptor instead.
-func (*Runnable) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use Annotator.ProtoReflect.Descriptor instead.
+func (*Annotator) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *Runnable) GetPathToExec() string {
+func (x *Annotator) GetName() string {
 	if x != nil {
-		return x.PathToExec
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Runnable) GetCmdArgs() []string {
+func (x *Annotator) GetAnnotations() []*Annotation {
 	if x != nil {
-		return x.CmdArgs
+		return x.Annotations
 	}
 	return nil
 }
 
-type Job struct {
+type Annotation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Runnable *Runnable `protobuf:"bytes,2,opt,name=runnable,proto3" json:"runnable,omitempty"`
+	FieldKey  string     `protobuf:"bytes,1,opt,name=fieldKey,proto3" json:"fieldKey,omitempty"`
+	Value     string     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Condition *Condition `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
 }
 
-func (x *Job) Reset() {
-	*x = Job{}
+func (x *Annotation) Reset() {
+	*x = Annotation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[19]
+		mi := &file_pipeline_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Job) String() string {
+func (x *Annotation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Job) ProtoMessage() {}
+func (*Annotation) ProtoMessage() {}
 
-func (x *Job) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[19]
+func (x *Annotation) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2025,47 +2350,62 @@ func (x *Job) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Job.ProtoReflect.Descriptor instead.
-func (*Job) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use Annotation.ProtoReflect.Descriptor instead.
+func (*Annotation) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *Job) GetRunnable() *Runnable {
+func (x *Annotation) GetFieldKey() string {
 	if x != nil {
-		return x.Runnable
+		return x.FieldKey
+	}
+	return ""
+}
+
+func (x *Annotation) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Annotation) GetCondition() *Condition {
+	if x != nil {
+		return x.Condition
 	}
 	return nil
 }
 
-type Tee struct {
+type Aggregator struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name               string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Condition          *Condition      `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
-	AdditionalBody     *_struct.Struct `protobuf:"bytes,3,opt,name=additionalBody,proto3" json:"additionalBody,omitempty"`
-	TransformerRef     string          `protobuf:"bytes,4,opt,name=transformerRef,proto3" json:"transformerRef,omitempty"`
-	OutputConnectorRef string          `protobuf:"bytes,5,opt,name=outputConnectorRef,proto3" json:"outputConnectorRef,omitempty"`
+	Name            string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition       *Condition   `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	StateStore      string       `protobuf:"bytes,3,opt,name=stateStore,proto3" json:"stateStore,omitempty"`
+	Aggregation     *Aggregation `protobuf:"bytes,4,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
+	AsyncCheckpoint bool         `protobuf:"varint,5,opt,name=asyncCheckpoint,proto3" json:"asyncCheckpoint,omitempty"`
+	ForwardState    bool         `protobuf:"varint,6,opt,name=forwardState,proto3" json:"forwardState,omitempty"`
 }
 
-func (x *Tee) Reset() {
-	*x = Tee{}
+func (x *Aggregator) Reset() {
+	*x = Aggregator{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[20]
+		mi := &file_pipeline_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Tee) String() string {
+func (x *Aggregator) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tee) ProtoMessage() {}
+func (*Aggregator) ProtoMessage() {}
 
-func (x *Tee) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[20]
+func (x *Aggregator) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2076,72 +2416,80 @@ func (x *Tee) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tee.ProtoReflect.Descriptor instead.
-func (*Tee) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use Aggregator.ProtoReflect.Descriptor instead.
+func (*Aggregator) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *Tee) GetName() string {
+func (x *Aggregator) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Tee) GetCondition() *Condition {
+func (x *Aggregator) GetCondition() *Condition {
 	if x != nil {
 		return x.Condition
 	}
 	return nil
 }
 
-func (x *Tee) GetAdditionalBody() *_struct.Struct {
+func (x *Aggregator) GetStateStore() string {
 	if x != nil {
-		return x.AdditionalBody
+		return x.StateStore
+	}
+	return ""
+}
+
+func (x *Aggregator) GetAggregation() *Aggregation {
+	if x != nil {
+		return x.Aggregation
 	}
 	return nil
 }
 
-func (x *Tee) GetTransformerRef() string {
+func (x *Aggregator) GetAsyncCheckpoint() bool {
 	if x != nil {
-		return x.TransformerRef
+		return x.AsyncCheckpoint
 	}
-	return ""
+	return false
 }
 
-func (x *Tee) GetOutputConnectorRef() string {
+func (x *Aggregator) GetForwardState() bool {
 	if x != nil {
-		return x.OutputConnectorRef
+		return x.ForwardState
 	}
-	return ""
+	return false
 }
 
-type Continuation struct {
+type Aggregation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name      string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Condition *Condition `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	Key             string          `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	AggregationType AggregationType `protobuf:"varint,2,opt,name=aggregationType,proto3,enum=pipeline.AggregationType" json:"aggregationType,omitempty"`
+	GroupByKeys     []string        `protobuf:"bytes,3,rep,name=groupByKeys,proto3" json:"groupByKeys,omitempty"`
 }
 
-func (x *Continuation) Reset() {
-	*x = Continuation{}
+func (x *Aggregation) Reset() {
+	*x = Aggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[21]
+		mi := &file_pipeline_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Continuation) String() string {
+func (x *Aggregation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Continuation) ProtoMessage() {}
+func (*Aggregation) ProtoMessage() {}
 
-func (x *Continuation) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[21]
+func (x *Aggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2152,52 +2500,60 @@ func (x *Continuation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Continuation.ProtoReflect.Descriptor instead.
-func (*Continuation) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use Aggregation.ProtoReflect.Descriptor instead.
+func (*Aggregation) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Continuation) GetName() string {
+func (x *Aggregation) GetKey() string {
 	if x != nil {
-		return x.Name
+		return x.Key
 	}
 	return ""
 }
 
-func (x *Continuation) GetCondition() *Condition {
+func (x *Aggregation) GetAggregationType() AggregationType {
 	if x != nil {
-		return x.Condition
+		return x.AggregationType
+	}
+	return AggregationType_AggUnknown
+}
+
+func (x *Aggregation) GetGroupByKeys() []string {
+	if x != nil {
+		return x.GroupByKeys
 	}
 	return nil
 }
 
-type Transformer struct {
+type Completer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name               string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Specs              []*TransformerSpec `protobuf:"bytes,2,rep,name=specs,proto3" json:"specs,omitempty"`
-	ForwardInputFields bool               `protobuf:"varint,3,opt,name=forwardInputFields,proto3" json:"forwardInputFields,omitempty"`
+	Name       string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition  *Condition  `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	StateStore string      `protobuf:"bytes,3,opt,name=stateStore,proto3" json:"stateStore,omitempty"`
+	Completion *Completion `protobuf:"bytes,4,opt,name=completion,proto3" json:"completion,omitempty"`
 }
 
-func (x *Transformer) Reset() {
-	*x = Transformer{}
+func (x *Completer) Reset() {
+	*x = Completer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[22]
+		mi := &file_pipeline_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Transformer) String() string {
+func (x *Completer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Transformer) ProtoMessage() {}
+func (*Completer) ProtoMessage() {}
 
-func (x *Transformer) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[22]
+func (x *Completer) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2208,59 +2564,65 @@ func (x *Transformer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Transformer.ProtoReflect.Descriptor instead.
-func (*Transformer) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use Completer.ProtoReflect.Descriptor instead.
+func (*Completer) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Transformer) GetName() string {
+func (x *Completer) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Transformer) GetSpecs() []*TransformerSpec {
+func (x *Completer) GetCondition() *Condition {
 	if x != nil {
-		return x.Specs
+		return x.Condition
 	}
 	return nil
 }
 
-func (x *Transformer) GetForwardInputFields() bool {
+func (x *Completer) GetStateStore() string {
 	if x != nil {
-		return x.ForwardInputFields
+		return x.StateStore
 	}
-	return false
+	return ""
 }
 
-type TransformerSpec struct {
+func (x *Completer) GetCompletion() *Completion {
+	if x != nil {
+		return x.Completion
+	}
+	return nil
+}
+
+type Completion struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SourceField    string          `protobuf:"bytes,2,opt,name=sourceField,proto3" json:"sourceField,omitempty"`
-	TargetField    string          `protobuf:"bytes,3,opt,name=targetField,proto3" json:"targetField,omitempty"`
-	Transformation *Transformation `protobuf:"bytes,4,opt,name=transformation,proto3" json:"transformation,omitempty"`
+	JoinKeys  []string `protobuf:"bytes,1,rep,name=joinKeys,proto3" json:"joinKeys,omitempty"`
+	TimeoutMs int64    `protobuf:"varint,2,opt,name=timeoutMs,proto3" json:"timeoutMs,omitempty"`
 }
 
-func (x *TransformerSpec) Reset() {
-	*x = TransformerSpec{}
+func (x *Completion) Reset() {
+	*x = Completion{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[23]
+		mi := &file_pipeline_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TransformerSpec) String() string {
+func (x *Completion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransformerSpec) ProtoMessage() {}
+func (*Completion) ProtoMessage() {}
 
-func (x *TransformerSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[23]
+func (x *Completion) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2271,57 +2633,52 @@ func (x *TransformerSpec) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransformerSpec.ProtoReflect.Descriptor instead.
-func (*TransformerSpec) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *TransformerSpec) GetSourceField() string {
-	if x != nil {
-		return x.SourceField
-	}
-	return ""
+// Deprecated: Use Completion.ProtoReflect.Descriptor instead.
+func (*Completion) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *TransformerSpec) GetTargetField() string {
+func (x *Completion) GetJoinKeys() []string {
 	if x != nil {
-		return x.TargetField
+		return x.JoinKeys
 	}
-	return ""
+	return nil
 }
 
-func (x *TransformerSpec) GetTransformation() *Transformation {
+func (x *Completion) GetTimeoutMs() int64 {
 	if x != nil {
-		return x.Transformation
+		return x.TimeoutMs
 	}
-	return nil
+	return 0
 }
 
-type MapArgs struct {
+type Filter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Regex       string `protobuf:"bytes,2,opt,name=regex,proto3" json:"regex,omitempty"`
+	KeepMatched bool   `protobuf:"varint,3,opt,name=keepMatched,proto3" json:"keepMatched,omitempty"`
 }
 
-func (x *MapArgs) Reset() {
-	*x = MapArgs{}
+func (x *Filter) Reset() {
+	*x = Filter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[24]
+		mi := &file_pipeline_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MapArgs) String() string {
+func (x *Filter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MapArgs) ProtoMessage() {}
+func (*Filter) ProtoMessage() {}
 
-func (x *MapArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[24]
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2332,43 +2689,57 @@ func (x *MapArgs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MapArgs.ProtoReflect.Descriptor instead.
-func (*MapArgs) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *MapArgs) GetPath() string {
+func (x *Filter) GetName() string {
 	if x != nil {
-		return x.Path
+		return x.Name
 	}
 	return ""
 }
 
-type MapAddArgs struct {
+func (x *Filter) GetRegex() string {
+	if x != nil {
+		return x.Regex
+	}
+	return ""
+}
+
+func (x *Filter) GetKeepMatched() bool {
+	if x != nil {
+		return x.KeepMatched
+	}
+	return false
+}
+
+type Checkpoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	OutputConnectorRef string `protobuf:"bytes,1,opt,name=outputConnectorRef,proto3" json:"outputConnectorRef,omitempty"`
 }
 
-func (x *MapAddArgs) Reset() {
-	*x = MapAddArgs{}
+func (x *Checkpoint) Reset() {
+	*x = Checkpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[25]
+		mi := &file_pipeline_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MapAddArgs) String() string {
+func (x *Checkpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MapAddArgs) ProtoMessage() {}
+func (*Checkpoint) ProtoMessage() {}
 
-func (x *MapAddArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[25]
+func (x *Checkpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2379,43 +2750,55 @@ func (x *MapAddArgs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MapAddArgs.ProtoReflect.Descriptor instead.
-func (*MapAddArgs) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *MapAddArgs) GetValue() float64 {
+func (x *Checkpoint) GetOutputConnectorRef() string {
 	if x != nil {
-		return x.Value
+		return x.OutputConnectorRef
 	}
-	return 0
+	return ""
 }
 
-type MapMultArgs struct {
+type Spawner struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Name      string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition *Condition `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	DelayInMs int64      `protobuf:"varint,3,opt,name=delayInMs,proto3" json:"delayInMs,omitempty"`
+	DoSync    bool       `protobuf:"varint,4,opt,name=doSync,proto3" json:"doSync,omitempty"`
+	Job       *Job       `protobuf:"bytes,5,opt,name=job,proto3" json:"job,omitempty"`
+	// timeoutInMs bounds the run time of each job; commands are killed when they time out
+	TimeoutInMs int64 `protobuf:"varint,6,opt,name=timeoutInMs,proto3" json:"timeoutInMs,omitempty"`
+	// maxConcurrency limits the number of jobs that run at once
+	MaxConcurrency int32 `protobuf:"varint,7,opt,name=maxConcurrency,proto3" json:"maxConcurrency,omitempty"`
+	// If set, asynchronous jobs are persisted in a durable queue at queuePath until they have run
+	QueuePath string `protobuf:"bytes,8,opt,name=queuePath,proto3" json:"queuePath,omitempty"`
+	// maxOutputBytes limits the stderr recorded for a command (default 4096)
+	MaxOutputBytes int32 `protobuf:"varint,9,opt,name=maxOutputBytes,proto3" json:"maxOutputBytes,omitempty"`
 }
 
-func (x *MapMultArgs) Reset() {
-	*x = MapMultArgs{}
+func (x *Spawner) Reset() {
+	*x = Spawner{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[26]
+		mi := &file_pipeline_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MapMultArgs) String() string {
+func (x *Spawner) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MapMultArgs) ProtoMessage() {}
+func (*Spawner) ProtoMessage() {}
 
-func (x *MapMultArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[26]
+func (x *Spawner) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2426,90 +2809,103 @@ func (x *MapMultArgs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MapMultArgs.ProtoReflect.Descriptor instead.
-func (*MapMultArgs) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use Spawner.ProtoReflect.Descriptor instead.
+func (*Spawner) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *MapMultArgs) GetValue() float64 {
+func (x *Spawner) GetName() string {
 	if x != nil {
-		return x.Value
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-type LeftFoldArgs struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+func (x *Spawner) GetCondition() *Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
 }
 
-func (x *LeftFoldArgs) Reset() {
-	*x = LeftFoldArgs{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[27]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Spawner) GetDelayInMs() int64 {
+	if x != nil {
+		return x.DelayInMs
 	}
+	return 0
 }
 
-func (x *LeftFoldArgs) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Spawner) GetDoSync() bool {
+	if x != nil {
+		return x.DoSync
+	}
+	return false
 }
 
-func (*LeftFoldArgs) ProtoMessage() {}
+func (x *Spawner) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
 
-func (x *LeftFoldArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[27]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Spawner) GetTimeoutInMs() int64 {
+	if x != nil {
+		return x.TimeoutInMs
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use LeftFoldArgs.ProtoReflect.Descriptor instead.
-func (*LeftFoldArgs) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{27}
+func (x *Spawner) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
 }
 
-func (x *LeftFoldArgs) GetPath() string {
+func (x *Spawner) GetQueuePath() string {
 	if x != nil {
-		return x.Path
+		return x.QueuePath
 	}
 	return ""
 }
 
-type RightFoldArgs struct {
+func (x *Spawner) GetMaxOutputBytes() int32 {
+	if x != nil {
+		return x.MaxOutputBytes
+	}
+	return 0
+}
+
+// Exec must take map[string]interface{} (JSON) as input
+type Runnable struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	PathToExec string     `protobuf:"bytes,1,opt,name=pathToExec,proto3" json:"pathToExec,omitempty"`
+	CmdArgs    []string   `protobuf:"bytes,2,rep,name=cmdArgs,proto3" json:"cmdArgs,omitempty"`
+	CoProcess  *CoProcess `protobuf:"bytes,3,opt,name=coProcess,proto3" json:"coProcess,omitempty"`
+	Sandbox    *Sandbox   `protobuf:"bytes,4,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
 }
 
-func (x *RightFoldArgs) Reset() {
-	*x = RightFoldArgs{}
+func (x *Runnable) Reset() {
+	*x = Runnable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[28]
+		mi := &file_pipeline_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RightFoldArgs) String() string {
+func (x *Runnable) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RightFoldArgs) ProtoMessage() {}
+func (*Runnable) ProtoMessage() {}
 
-func (x *RightFoldArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[28]
+func (x *Runnable) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2520,107 +2916,76 @@ func (x *RightFoldArgs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RightFoldArgs.ProtoReflect.Descriptor instead.
-func (*RightFoldArgs) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use Runnable.ProtoReflect.Descriptor instead.
+func (*Runnable) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *RightFoldArgs) GetPath() string {
+func (x *Runnable) GetPathToExec() string {
 	if x != nil {
-		return x.Path
+		return x.PathToExec
 	}
 	return ""
 }
 
-type MapRegexArgs struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Regex   string `protobuf:"bytes,1,opt,name=regex,proto3" json:"regex,omitempty"`
-	Replace string `protobuf:"bytes,2,opt,name=replace,proto3" json:"replace,omitempty"`
-}
-
-func (x *MapRegexArgs) Reset() {
-	*x = MapRegexArgs{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[29]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *MapRegexArgs) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*MapRegexArgs) ProtoMessage() {}
-
-func (x *MapRegexArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[29]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Runnable) GetCmdArgs() []string {
+	if x != nil {
+		return x.CmdArgs
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use MapRegexArgs.ProtoReflect.Descriptor instead.
-func (*MapRegexArgs) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{29}
+	return nil
 }
 
-func (x *MapRegexArgs) GetRegex() string {
+func (x *Runnable) GetCoProcess() *CoProcess {
 	if x != nil {
-		return x.Regex
+		return x.CoProcess
 	}
-	return ""
+	return nil
 }
 
-func (x *MapRegexArgs) GetReplace() string {
+func (x *Runnable) GetSandbox() *Sandbox {
 	if x != nil {
-		return x.Replace
+		return x.Sandbox
 	}
-	return ""
+	return nil
 }
 
-type Transformation struct {
+// Restricts the environment and resources of an executable.  Limits of 0 are not applied.
+type Sandbox struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Condition          *Condition         `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
-	TransformationType TransformationType `protobuf:"varint,2,opt,name=transformationType,proto3,enum=pipeline.TransformationType" json:"transformationType,omitempty"`
-	// Types that are assignable to TransformArgs:
-	//	*Transformation_MapArgs
-	//	*Transformation_MapAddArgs
-	//	*Transformation_MapMultArgs
-	//	*Transformation_MapRegexArgs
-	//	*Transformation_LeftFoldArgs
-	//	*Transformation_RightFoldArgs
-	TransformArgs isTransformation_TransformArgs `protobuf_oneof:"transformArgs"`
-}
-
-func (x *Transformation) Reset() {
-	*x = Transformation{}
+	// Only these environment variables are passed to the executable
+	EnvAllowlist []string `protobuf:"bytes,1,rep,name=envAllowlist,proto3" json:"envAllowlist,omitempty"`
+	WorkingDir   string   `protobuf:"bytes,2,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	// The executable and its children are killed after timeoutInMs
+	TimeoutInMs int64  `protobuf:"varint,3,opt,name=timeoutInMs,proto3" json:"timeoutInMs,omitempty"`
+	CpuSeconds  uint64 `protobuf:"varint,4,opt,name=cpuSeconds,proto3" json:"cpuSeconds,omitempty"`
+	MemoryBytes uint64 `protobuf:"varint,5,opt,name=memoryBytes,proto3" json:"memoryBytes,omitempty"`
+	OpenFiles   uint64 `protobuf:"varint,6,opt,name=openFiles,proto3" json:"openFiles,omitempty"`
+	// The executable is killed if it writes more than maxOutputBytes to stdout or stderr
+	MaxOutputBytes int32 `protobuf:"varint,7,opt,name=maxOutputBytes,proto3" json:"maxOutputBytes,omitempty"`
+	// If set, the executable runs as credential.uid:credential.gid
+	Credential *Credential `protobuf:"bytes,8,opt,name=credential,proto3" json:"credential,omitempty"`
+}
+
+func (x *Sandbox) Reset() {
+	*x = Sandbox{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[30]
+		mi := &file_pipeline_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Transformation) String() string {
+func (x *Sandbox) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Transformation) ProtoMessage() {}
+func (*Sandbox) ProtoMessage() {}
 
-func (x *Transformation) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[30]
+func (x *Sandbox) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2631,140 +2996,94 @@ func (x *Transformation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Transformation.ProtoReflect.Descriptor instead.
-func (*Transformation) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use Sandbox.ProtoReflect.Descriptor instead.
+func (*Sandbox) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *Transformation) GetCondition() *Condition {
+func (x *Sandbox) GetEnvAllowlist() []string {
 	if x != nil {
-		return x.Condition
+		return x.EnvAllowlist
 	}
 	return nil
 }
 
-func (x *Transformation) GetTransformationType() TransformationType {
+func (x *Sandbox) GetWorkingDir() string {
 	if x != nil {
-		return x.TransformationType
+		return x.WorkingDir
 	}
-	return TransformationType_TransformUnknown
-}
-
-func (m *Transformation) GetTransformArgs() isTransformation_TransformArgs {
-	if m != nil {
-		return m.TransformArgs
-	}
-	return nil
+	return ""
 }
 
-func (x *Transformation) GetMapArgs() *MapArgs {
-	if x, ok := x.GetTransformArgs().(*Transformation_MapArgs); ok {
-		return x.MapArgs
+func (x *Sandbox) GetTimeoutInMs() int64 {
+	if x != nil {
+		return x.TimeoutInMs
 	}
-	return nil
+	return 0
 }
 
-func (x *Transformation) GetMapAddArgs() *MapAddArgs {
-	if x, ok := x.GetTransformArgs().(*Transformation_MapAddArgs); ok {
-		return x.MapAddArgs
+func (x *Sandbox) GetCpuSeconds() uint64 {
+	if x != nil {
+		return x.CpuSeconds
 	}
-	return nil
+	return 0
 }
 
-func (x *Transformation) GetMapMultArgs() *MapMultArgs {
-	if x, ok := x.GetTransformArgs().(*Transformation_MapMultArgs); ok {
-		return x.MapMultArgs
+func (x *Sandbox) GetMemoryBytes() uint64 {
+	if x != nil {
+		return x.MemoryBytes
 	}
-	return nil
+	return 0
 }
 
-func (x *Transformation) GetMapRegexArgs() *MapRegexArgs {
-	if x, ok := x.GetTransformArgs().(*Transformation_MapRegexArgs); ok {
-		return x.MapRegexArgs
+func (x *Sandbox) GetOpenFiles() uint64 {
+	if x != nil {
+		return x.OpenFiles
 	}
-	return nil
+	return 0
 }
 
-func (x *Transformation) GetLeftFoldArgs() *LeftFoldArgs {
-	if x, ok := x.GetTransformArgs().(*Transformation_LeftFoldArgs); ok {
-		return x.LeftFoldArgs
+func (x *Sandbox) GetMaxOutputBytes() int32 {
+	if x != nil {
+		return x.MaxOutputBytes
 	}
-	return nil
+	return 0
 }
 
-func (x *Transformation) GetRightFoldArgs() *RightFoldArgs {
-	if x, ok := x.GetTransformArgs().(*Transformation_RightFoldArgs); ok {
-		return x.RightFoldArgs
+func (x *Sandbox) GetCredential() *Credential {
+	if x != nil {
+		return x.Credential
 	}
 	return nil
 }
 
-type isTransformation_TransformArgs interface {
-	isTransformation_TransformArgs()
-}
-
-type Transformation_MapArgs struct {
-	MapArgs *MapArgs `protobuf:"bytes,3,opt,name=mapArgs,proto3,oneof"`
-}
-
-type Transformation_MapAddArgs struct {
-	MapAddArgs *MapAddArgs `protobuf:"bytes,4,opt,name=mapAddArgs,proto3,oneof"`
-}
-
-type Transformation_MapMultArgs struct {
-	MapMultArgs *MapMultArgs `protobuf:"bytes,5,opt,name=mapMultArgs,proto3,oneof"`
-}
-
-type Transformation_MapRegexArgs struct {
-	MapRegexArgs *MapRegexArgs `protobuf:"bytes,6,opt,name=mapRegexArgs,proto3,oneof"`
-}
-
-type Transformation_LeftFoldArgs struct {
-	LeftFoldArgs *LeftFoldArgs `protobuf:"bytes,7,opt,name=leftFoldArgs,proto3,oneof"`
-}
-
-type Transformation_RightFoldArgs struct {
-	RightFoldArgs *RightFoldArgs `protobuf:"bytes,8,opt,name=rightFoldArgs,proto3,oneof"`
-}
-
-func (*Transformation_MapArgs) isTransformation_TransformArgs() {}
-
-func (*Transformation_MapAddArgs) isTransformation_TransformArgs() {}
-
-func (*Transformation_MapMultArgs) isTransformation_TransformArgs() {}
-
-func (*Transformation_MapRegexArgs) isTransformation_TransformArgs() {}
-
-func (*Transformation_LeftFoldArgs) isTransformation_TransformArgs() {}
-
-func (*Transformation_RightFoldArgs) isTransformation_TransformArgs() {}
-
-type ExistsOperation struct {
+// Credential is a user and group; both are used, so 0 means root
+type Credential struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key string         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Op  ExistsOperator `protobuf:"varint,2,opt,name=op,proto3,enum=pipeline.ExistsOperator" json:"op,omitempty"`
+	Uid uint32 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid uint32 `protobuf:"varint,2,opt,name=gid,proto3" json:"gid,omitempty"`
 }
 
-func (x *ExistsOperation) Reset() {
-	*x = ExistsOperation{}
+func (x *Credential) Reset() {
+	*x = Credential{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[31]
+		mi := &file_pipeline_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExistsOperation) String() string {
+func (x *Credential) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExistsOperation) ProtoMessage() {}
+func (*Credential) ProtoMessage() {}
 
-func (x *ExistsOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[31]
+func (x *Credential) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2775,50 +3094,54 @@ func (x *ExistsOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExistsOperation.ProtoReflect.Descriptor instead.
-func (*ExistsOperation) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use Credential.ProtoReflect.Descriptor instead.
+func (*Credential) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *ExistsOperation) GetKey() string {
+func (x *Credential) GetUid() uint32 {
 	if x != nil {
-		return x.Key
+		return x.Uid
 	}
-	return ""
+	return 0
 }
 
-func (x *ExistsOperation) GetOp() ExistsOperator {
+func (x *Credential) GetGid() uint32 {
 	if x != nil {
-		return x.Op
+		return x.Gid
 	}
-	return ExistsOperator_UnknownExists
+	return 0
 }
 
-type ExistsExpression struct {
+// If set, the executable is started once per worker and exchanges newline-delimited
+// JSON over stdin/stdout: each request is {"id": ..., "data": ...} and each response
+// is {"id": ..., "data": ..., "error": ...} with the same id
+type CoProcess struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ops []*ExistsOperation `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+	NumWorkers  int32 `protobuf:"varint,1,opt,name=numWorkers,proto3" json:"numWorkers,omitempty"`
+	TimeoutInMs int64 `protobuf:"varint,2,opt,name=timeoutInMs,proto3" json:"timeoutInMs,omitempty"`
 }
 
-func (x *ExistsExpression) Reset() {
-	*x = ExistsExpression{}
+func (x *CoProcess) Reset() {
+	*x = CoProcess{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[32]
+		mi := &file_pipeline_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExistsExpression) String() string {
+func (x *CoProcess) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExistsExpression) ProtoMessage() {}
+func (*CoProcess) ProtoMessage() {}
 
-func (x *ExistsExpression) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[32]
+func (x *CoProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2829,43 +3152,55 @@ func (x *ExistsExpression) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExistsExpression.ProtoReflect.Descriptor instead.
-func (*ExistsExpression) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use CoProcess.ProtoReflect.Descriptor instead.
+func (*CoProcess) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ExistsExpression) GetOps() []*ExistsOperation {
+func (x *CoProcess) GetNumWorkers() int32 {
 	if x != nil {
-		return x.Ops
+		return x.NumWorkers
 	}
-	return nil
+	return 0
 }
 
-type BooleanExpression struct {
+func (x *CoProcess) GetTimeoutInMs() int64 {
+	if x != nil {
+		return x.TimeoutInMs
+	}
+	return 0
+}
+
+// A job must set exactly one of runnable, http or grpc
+type Job struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value bool `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Runnable *Runnable `protobuf:"bytes,2,opt,name=runnable,proto3" json:"runnable,omitempty"`
+	Http     *HttpJob  `protobuf:"bytes,3,opt,name=http,proto3" json:"http,omitempty"`
+	Grpc     *GrpcJob  `protobuf:"bytes,4,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	// Retry failed http and grpc jobs, backing off exponentially
+	Retry *JobRetry `protobuf:"bytes,5,opt,name=retry,proto3" json:"retry,omitempty"`
 }
 
-func (x *BooleanExpression) Reset() {
-	*x = BooleanExpression{}
+func (x *Job) Reset() {
+	*x = Job{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[33]
+		mi := &file_pipeline_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BooleanExpression) String() string {
+func (x *Job) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BooleanExpression) ProtoMessage() {}
+func (*Job) ProtoMessage() {}
 
-func (x *BooleanExpression) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[33]
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2876,43 +3211,65 @@ func (x *BooleanExpression) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BooleanExpression.ProtoReflect.Descriptor instead.
-func (*BooleanExpression) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *BooleanExpression) GetValue() bool {
+func (x *Job) GetRunnable() *Runnable {
 	if x != nil {
-		return x.Value
+		return x.Runnable
 	}
-	return false
+	return nil
 }
 
-type Variable struct {
+func (x *Job) GetHttp() *HttpJob {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *Job) GetGrpc() *GrpcJob {
+	if x != nil {
+		return x.Grpc
+	}
+	return nil
+}
+
+func (x *Job) GetRetry() *JobRetry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+type JobRetry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxRetries       int32 `protobuf:"varint,1,opt,name=maxRetries,proto3" json:"maxRetries,omitempty"`
+	InitialDelayInMs int64 `protobuf:"varint,2,opt,name=initialDelayInMs,proto3" json:"initialDelayInMs,omitempty"`
 }
 
-func (x *Variable) Reset() {
-	*x = Variable{}
+func (x *JobRetry) Reset() {
+	*x = JobRetry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[34]
+		mi := &file_pipeline_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Variable) String() string {
+func (x *JobRetry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Variable) ProtoMessage() {}
+func (*JobRetry) ProtoMessage() {}
 
-func (x *Variable) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[34]
+func (x *JobRetry) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2923,48 +3280,55 @@ func (x *Variable) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Variable.ProtoReflect.Descriptor instead.
-func (*Variable) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use JobRetry.ProtoReflect.Descriptor instead.
+func (*JobRetry) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *Variable) GetName() string {
+func (x *JobRetry) GetMaxRetries() int32 {
 	if x != nil {
-		return x.Name
+		return x.MaxRetries
 	}
-	return ""
+	return 0
 }
 
-type Operand struct {
+func (x *JobRetry) GetInitialDelayInMs() int64 {
+	if x != nil {
+		return x.InitialDelayInMs
+	}
+	return 0
+}
+
+// Sends the event to url.  Header values and bodyTemplate are templates over the event; the
+// default body is the JSON-encoded event.
+type HttpJob struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Operand:
-	//	*Operand_Expression
-	//	*Operand_Variable
-	//	*Operand_Literal
-	//	*Operand_Numeric
-	Operand isOperand_Operand `protobuf_oneof:"operand"`
+	Url          string            `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Method       string            `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Headers      map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BodyTemplate string            `protobuf:"bytes,4,opt,name=bodyTemplate,proto3" json:"bodyTemplate,omitempty"`
 }
 
-func (x *Operand) Reset() {
-	*x = Operand{}
+func (x *HttpJob) Reset() {
+	*x = HttpJob{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[35]
+		mi := &file_pipeline_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Operand) String() string {
+func (x *HttpJob) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Operand) ProtoMessage() {}
+func (*HttpJob) ProtoMessage() {}
 
-func (x *Operand) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[35]
+func (x *HttpJob) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2975,101 +3339,71 @@ func (x *Operand) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Operand.ProtoReflect.Descriptor instead.
-func (*Operand) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use HttpJob.ProtoReflect.Descriptor instead.
+func (*HttpJob) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{30}
 }
 
-func (m *Operand) GetOperand() isOperand_Operand {
-	if m != nil {
-		return m.Operand
+func (x *HttpJob) GetUrl() string {
+	if x != nil {
+		return x.Url
 	}
-	return nil
+	return ""
 }
 
-func (x *Operand) GetExpression() *Expression {
-	if x, ok := x.GetOperand().(*Operand_Expression); ok {
-		return x.Expression
+func (x *HttpJob) GetMethod() string {
+	if x != nil {
+		return x.Method
 	}
-	return nil
+	return ""
 }
 
-func (x *Operand) GetVariable() *Variable {
-	if x, ok := x.GetOperand().(*Operand_Variable); ok {
-		return x.Variable
+func (x *HttpJob) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
 	}
 	return nil
 }
 
-func (x *Operand) GetLiteral() string {
-	if x, ok := x.GetOperand().(*Operand_Literal); ok {
-		return x.Literal
+func (x *HttpJob) GetBodyTemplate() string {
+	if x != nil {
+		return x.BodyTemplate
 	}
 	return ""
 }
 
-func (x *Operand) GetNumeric() float64 {
-	if x, ok := x.GetOperand().(*Operand_Numeric); ok {
-		return x.Numeric
-	}
-	return 0
-}
-
-type isOperand_Operand interface {
-	isOperand_Operand()
-}
-
-type Operand_Expression struct {
-	Expression *Expression `protobuf:"bytes,1,opt,name=expression,proto3,oneof"`
-}
-
-type Operand_Variable struct {
-	Variable *Variable `protobuf:"bytes,2,opt,name=variable,proto3,oneof"`
-}
-
-type Operand_Literal struct {
-	Literal string `protobuf:"bytes,3,opt,name=literal,proto3,oneof"`
-}
-
-type Operand_Numeric struct {
-	Numeric float64 `protobuf:"fixed64,4,opt,name=numeric,proto3,oneof"`
-}
-
-func (*Operand_Expression) isOperand_Operand() {}
-
-func (*Operand_Variable) isOperand_Operand() {}
-
-func (*Operand_Literal) isOperand_Operand() {}
-
-func (*Operand_Numeric) isOperand_Operand() {}
-
-type ComparatorExpression struct {
+// Makes a unary call to method ("package.Service/Method").  The request is converted from JSON
+// using the descriptor set at descriptorSetPath (protoc --include_imports --descriptor_set_out).
+type GrpcJob struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lhs *Operand           `protobuf:"bytes,1,opt,name=lhs,proto3" json:"lhs,omitempty"`
-	Rhs *Operand           `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
-	Op  ComparatorOperator `protobuf:"varint,3,opt,name=op,proto3,enum=pipeline.ComparatorOperator" json:"op,omitempty"`
+	Endpoint          string            `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Method            string            `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	DescriptorSetPath string            `protobuf:"bytes,3,opt,name=descriptorSetPath,proto3" json:"descriptorSetPath,omitempty"`
+	RequestTemplate   string            `protobuf:"bytes,4,opt,name=requestTemplate,proto3" json:"requestTemplate,omitempty"`
+	Metadata          map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Insecure          bool              `protobuf:"varint,6,opt,name=insecure,proto3" json:"insecure,omitempty"`
 }
 
-func (x *ComparatorExpression) Reset() {
-	*x = ComparatorExpression{}
+func (x *GrpcJob) Reset() {
+	*x = GrpcJob{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[36]
+		mi := &file_pipeline_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ComparatorExpression) String() string {
+func (x *GrpcJob) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ComparatorExpression) ProtoMessage() {}
+func (*GrpcJob) ProtoMessage() {}
 
-func (x *ComparatorExpression) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[36]
+func (x *GrpcJob) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3080,59 +3414,93 @@ func (x *ComparatorExpression) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ComparatorExpression.ProtoReflect.Descriptor instead.
-func (*ComparatorExpression) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use GrpcJob.ProtoReflect.Descriptor instead.
+func (*GrpcJob) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *ComparatorExpression) GetLhs() *Operand {
+func (x *GrpcJob) GetEndpoint() string {
 	if x != nil {
-		return x.Lhs
+		return x.Endpoint
 	}
-	return nil
+	return ""
 }
 
-func (x *ComparatorExpression) GetRhs() *Operand {
+func (x *GrpcJob) GetMethod() string {
 	if x != nil {
-		return x.Rhs
+		return x.Method
+	}
+	return ""
+}
+
+func (x *GrpcJob) GetDescriptorSetPath() string {
+	if x != nil {
+		return x.DescriptorSetPath
+	}
+	return ""
+}
+
+func (x *GrpcJob) GetRequestTemplate() string {
+	if x != nil {
+		return x.RequestTemplate
+	}
+	return ""
+}
+
+func (x *GrpcJob) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *ComparatorExpression) GetOp() ComparatorOperator {
+func (x *GrpcJob) GetInsecure() bool {
 	if x != nil {
-		return x.Op
+		return x.Insecure
 	}
-	return ComparatorOperator_UnknownComparator
+	return false
 }
 
-type LogicalExpression struct {
+type Tee struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lhs *Operand        `protobuf:"bytes,1,opt,name=lhs,proto3" json:"lhs,omitempty"`
-	Rhs *Operand        `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
-	Op  LogicalOperator `protobuf:"varint,3,opt,name=op,proto3,enum=pipeline.LogicalOperator" json:"op,omitempty"`
+	Name               string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition          *Condition         `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	AdditionalBody     *_struct.Struct    `protobuf:"bytes,3,opt,name=additionalBody,proto3" json:"additionalBody,omitempty"`
+	TransformerRef     string             `protobuf:"bytes,4,opt,name=transformerRef,proto3" json:"transformerRef,omitempty"`
+	OutputConnectorRef string             `protobuf:"bytes,5,opt,name=outputConnectorRef,proto3" json:"outputConnectorRef,omitempty"`
+	Delivery           *TeeDelivery       `protobuf:"bytes,6,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	Format             *TeeFormat         `protobuf:"bytes,7,opt,name=format,proto3" json:"format,omitempty"`
+	ObjectOutput       *ObjectOutput      `protobuf:"bytes,8,opt,name=objectOutput,proto3" json:"objectOutput,omitempty"`
+	RollingFile        *RollingFileOutput `protobuf:"bytes,9,opt,name=rollingFile,proto3" json:"rollingFile,omitempty"`
+	HttpOutput         *HttpOutput        `protobuf:"bytes,10,opt,name=httpOutput,proto3" json:"httpOutput,omitempty"`
+	// Write to several destinations in parallel, instead of outputConnectorRef.  The connector options
+	// (e.g. objectOutput) apply to the destinations of that type.
+	OutputConnectorRefs []string `protobuf:"bytes,11,rep,name=outputConnectorRefs,proto3" json:"outputConnectorRefs,omitempty"`
+	// allOrNothing (the default), atLeastOne or bestEffort
+	Mode         string        `protobuf:"bytes,12,opt,name=mode,proto3" json:"mode,omitempty"`
+	PubSubOutput *PubSubOutput `protobuf:"bytes,13,opt,name=pubSubOutput,proto3" json:"pubSubOutput,omitempty"`
 }
 
-func (x *LogicalExpression) Reset() {
-	*x = LogicalExpression{}
+func (x *Tee) Reset() {
+	*x = Tee{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[37]
+		mi := &file_pipeline_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LogicalExpression) String() string {
+func (x *Tee) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogicalExpression) ProtoMessage() {}
+func (*Tee) ProtoMessage() {}
 
-func (x *LogicalExpression) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[37]
+func (x *Tee) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3143,121 +3511,130 @@ func (x *LogicalExpression) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogicalExpression.ProtoReflect.Descriptor instead.
-func (*LogicalExpression) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use Tee.ProtoReflect.Descriptor instead.
+func (*Tee) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *LogicalExpression) GetLhs() *Operand {
+func (x *Tee) GetName() string {
 	if x != nil {
-		return x.Lhs
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *LogicalExpression) GetRhs() *Operand {
+func (x *Tee) GetCondition() *Condition {
 	if x != nil {
-		return x.Rhs
+		return x.Condition
 	}
 	return nil
 }
 
-func (x *LogicalExpression) GetOp() LogicalOperator {
+func (x *Tee) GetAdditionalBody() *_struct.Struct {
 	if x != nil {
-		return x.Op
+		return x.AdditionalBody
 	}
-	return LogicalOperator_UnknownLogical
+	return nil
 }
 
-type BinaryExpression struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Lhs *Operand       `protobuf:"bytes,1,opt,name=lhs,proto3" json:"lhs,omitempty"`
-	Rhs *Operand       `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
-	Op  BinaryOperator `protobuf:"varint,3,opt,name=op,proto3,enum=pipeline.BinaryOperator" json:"op,omitempty"`
+func (x *Tee) GetTransformerRef() string {
+	if x != nil {
+		return x.TransformerRef
+	}
+	return ""
 }
 
-func (x *BinaryExpression) Reset() {
-	*x = BinaryExpression{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[38]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Tee) GetOutputConnectorRef() string {
+	if x != nil {
+		return x.OutputConnectorRef
 	}
+	return ""
 }
 
-func (x *BinaryExpression) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Tee) GetDelivery() *TeeDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
 }
 
-func (*BinaryExpression) ProtoMessage() {}
-
-func (x *BinaryExpression) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[38]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Tee) GetFormat() *TeeFormat {
+	if x != nil {
+		return x.Format
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use BinaryExpression.ProtoReflect.Descriptor instead.
-func (*BinaryExpression) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{38}
+func (x *Tee) GetObjectOutput() *ObjectOutput {
+	if x != nil {
+		return x.ObjectOutput
+	}
+	return nil
 }
 
-func (x *BinaryExpression) GetLhs() *Operand {
+func (x *Tee) GetRollingFile() *RollingFileOutput {
 	if x != nil {
-		return x.Lhs
+		return x.RollingFile
 	}
 	return nil
 }
 
-func (x *BinaryExpression) GetRhs() *Operand {
+func (x *Tee) GetHttpOutput() *HttpOutput {
 	if x != nil {
-		return x.Rhs
+		return x.HttpOutput
 	}
 	return nil
 }
 
-func (x *BinaryExpression) GetOp() BinaryOperator {
+func (x *Tee) GetOutputConnectorRefs() []string {
 	if x != nil {
-		return x.Op
+		return x.OutputConnectorRefs
 	}
-	return BinaryOperator_UnknownBinary
+	return nil
 }
 
-type UnaryExpression struct {
-	state         protoimpl.MessageState
+func (x *Tee) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Tee) GetPubSubOutput() *PubSubOutput {
+	if x != nil {
+		return x.PubSubOutput
+	}
+	return nil
+}
+
+// Options for tees whose output connector is a pubsub
+type PubSubOutput struct {
+	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Rhs *Operand      `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
-	Op  UnaryOperator `protobuf:"varint,3,opt,name=op,proto3,enum=pipeline.UnaryOperator" json:"op,omitempty"`
+	// Rendered over the output to key each message, e.g. "{{.deviceId}}", so related outputs are
+	// published to the same partition
+	KeyTemplate string `protobuf:"bytes,1,opt,name=keyTemplate,proto3" json:"keyTemplate,omitempty"`
 }
 
-func (x *UnaryExpression) Reset() {
-	*x = UnaryExpression{}
+func (x *PubSubOutput) Reset() {
+	*x = PubSubOutput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[39]
+		mi := &file_pipeline_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UnaryExpression) String() string {
+func (x *PubSubOutput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnaryExpression) ProtoMessage() {}
+func (*PubSubOutput) ProtoMessage() {}
 
-func (x *UnaryExpression) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[39]
+func (x *PubSubOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3268,56 +3645,56 @@ func (x *UnaryExpression) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnaryExpression.ProtoReflect.Descriptor instead.
-func (*UnaryExpression) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{39}
-}
-
-func (x *UnaryExpression) GetRhs() *Operand {
-	if x != nil {
-		return x.Rhs
-	}
-	return nil
+// Deprecated: Use PubSubOutput.ProtoReflect.Descriptor instead.
+func (*PubSubOutput) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *UnaryExpression) GetOp() UnaryOperator {
+func (x *PubSubOutput) GetKeyTemplate() string {
 	if x != nil {
-		return x.Op
+		return x.KeyTemplate
 	}
-	return UnaryOperator_UnknownUnary
+	return ""
 }
 
-type Expression struct {
+// Options for tees whose output connector is an HTTP endpoint
+type HttpOutput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Expression:
-	//	*Expression_Boolean
-	//	*Expression_Comparator
-	//	*Expression_Logical
-	//	*Expression_Binary
-	//	*Expression_Unary
-	Expression isExpression_Expression `protobuf_oneof:"expression"`
-}
-
-func (x *Expression) Reset() {
-	*x = Expression{}
+	// Default is POST
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// Rendered over the output, e.g. "https://hooks.example.com/{{.team}}" (default is the connector's URL)
+	UrlTemplate string `protobuf:"bytes,2,opt,name=urlTemplate,proto3" json:"urlTemplate,omitempty"`
+	// Header values are templates over the output
+	Headers     map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Auth        *HttpAuth         `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
+	Tls         *HttpTls          `protobuf:"bytes,5,opt,name=tls,proto3" json:"tls,omitempty"`
+	TimeoutInMs int64             `protobuf:"varint,6,opt,name=timeoutInMs,proto3" json:"timeoutInMs,omitempty"`
+	// Default is any status below 300
+	AcceptedStatusCodes []int32 `protobuf:"varint,7,rep,packed,name=acceptedStatusCodes,proto3" json:"acceptedStatusCodes,omitempty"`
+	// Return the statusCode and body of the response, rather than only a JSON object body
+	CaptureResponse bool `protobuf:"varint,8,opt,name=captureResponse,proto3" json:"captureResponse,omitempty"`
+}
+
+func (x *HttpOutput) Reset() {
+	*x = HttpOutput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[40]
+		mi := &file_pipeline_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Expression) String() string {
+func (x *HttpOutput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Expression) ProtoMessage() {}
+func (*HttpOutput) ProtoMessage() {}
 
-func (x *Expression) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[40]
+func (x *HttpOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3328,115 +3705,158 @@ func (x *Expression) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Expression.ProtoReflect.Descriptor instead.
-func (*Expression) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use HttpOutput.ProtoReflect.Descriptor instead.
+func (*HttpOutput) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{34}
 }
 
-func (m *Expression) GetExpression() isExpression_Expression {
-	if m != nil {
-		return m.Expression
+func (x *HttpOutput) GetMethod() string {
+	if x != nil {
+		return x.Method
 	}
-	return nil
+	return ""
 }
 
-func (x *Expression) GetBoolean() *BooleanExpression {
-	if x, ok := x.GetExpression().(*Expression_Boolean); ok {
-		return x.Boolean
+func (x *HttpOutput) GetUrlTemplate() string {
+	if x != nil {
+		return x.UrlTemplate
 	}
-	return nil
+	return ""
 }
 
-func (x *Expression) GetComparator() *ComparatorExpression {
-	if x, ok := x.GetExpression().(*Expression_Comparator); ok {
-		return x.Comparator
+func (x *HttpOutput) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
 	}
 	return nil
 }
 
-func (x *Expression) GetLogical() *LogicalExpression {
-	if x, ok := x.GetExpression().(*Expression_Logical); ok {
-		return x.Logical
+func (x *HttpOutput) GetAuth() *HttpAuth {
+	if x != nil {
+		return x.Auth
 	}
 	return nil
 }
 
-func (x *Expression) GetBinary() *BinaryExpression {
-	if x, ok := x.GetExpression().(*Expression_Binary); ok {
-		return x.Binary
+func (x *HttpOutput) GetTls() *HttpTls {
+	if x != nil {
+		return x.Tls
 	}
 	return nil
 }
 
-func (x *Expression) GetUnary() *UnaryExpression {
-	if x, ok := x.GetExpression().(*Expression_Unary); ok {
-		return x.Unary
+func (x *HttpOutput) GetTimeoutInMs() int64 {
+	if x != nil {
+		return x.TimeoutInMs
 	}
-	return nil
+	return 0
 }
 
-type isExpression_Expression interface {
-	isExpression_Expression()
+func (x *HttpOutput) GetAcceptedStatusCodes() []int32 {
+	if x != nil {
+		return x.AcceptedStatusCodes
+	}
+	return nil
 }
 
-type Expression_Boolean struct {
-	Boolean *BooleanExpression `protobuf:"bytes,3,opt,name=boolean,proto3,oneof"`
+func (x *HttpOutput) GetCaptureResponse() bool {
+	if x != nil {
+		return x.CaptureResponse
+	}
+	return false
 }
 
-type Expression_Comparator struct {
-	Comparator *ComparatorExpression `protobuf:"bytes,4,opt,name=comparator,proto3,oneof"`
-}
+// Secrets are read from a file or an environment variable when each request is sent
+type HttpAuth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-type Expression_Logical struct {
-	Logical *LogicalExpression `protobuf:"bytes,5,opt,name=logical,proto3,oneof"`
+	Basic  *HttpBasicAuth  `protobuf:"bytes,1,opt,name=basic,proto3" json:"basic,omitempty"`
+	Bearer *HttpBearerAuth `protobuf:"bytes,2,opt,name=bearer,proto3" json:"bearer,omitempty"`
+	Hmac   *HttpHmacAuth   `protobuf:"bytes,3,opt,name=hmac,proto3" json:"hmac,omitempty"`
 }
 
-type Expression_Binary struct {
-	Binary *BinaryExpression `protobuf:"bytes,6,opt,name=binary,proto3,oneof"`
+func (x *HttpAuth) Reset() {
+	*x = HttpAuth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type Expression_Unary struct {
-	Unary *UnaryExpression `protobuf:"bytes,7,opt,name=unary,proto3,oneof"`
+func (x *HttpAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Expression_Boolean) isExpression_Expression() {}
+func (*HttpAuth) ProtoMessage() {}
 
-func (*Expression_Comparator) isExpression_Expression() {}
+func (x *HttpAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Expression_Logical) isExpression_Expression() {}
+// Deprecated: Use HttpAuth.ProtoReflect.Descriptor instead.
+func (*HttpAuth) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{35}
+}
 
-func (*Expression_Binary) isExpression_Expression() {}
+func (x *HttpAuth) GetBasic() *HttpBasicAuth {
+	if x != nil {
+		return x.Basic
+	}
+	return nil
+}
 
-func (*Expression_Unary) isExpression_Expression() {}
+func (x *HttpAuth) GetBearer() *HttpBearerAuth {
+	if x != nil {
+		return x.Bearer
+	}
+	return nil
+}
 
-type Condition struct {
+func (x *HttpAuth) GetHmac() *HttpHmacAuth {
+	if x != nil {
+		return x.Hmac
+	}
+	return nil
+}
+
+type HttpBasicAuth struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Condition:
-	//	*Condition_Expression
-	//	*Condition_Exists
-	Condition isCondition_Condition `protobuf_oneof:"condition"`
+	Username     string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	PasswordFile string `protobuf:"bytes,2,opt,name=passwordFile,proto3" json:"passwordFile,omitempty"`
+	PasswordEnv  string `protobuf:"bytes,3,opt,name=passwordEnv,proto3" json:"passwordEnv,omitempty"`
 }
 
-func (x *Condition) Reset() {
-	*x = Condition{}
+func (x *HttpBasicAuth) Reset() {
+	*x = HttpBasicAuth{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[41]
+		mi := &file_pipeline_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Condition) String() string {
+func (x *HttpBasicAuth) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Condition) ProtoMessage() {}
+func (*HttpBasicAuth) ProtoMessage() {}
 
-func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[41]
+func (x *HttpBasicAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3447,75 +3867,117 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
-func (*Condition) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use HttpBasicAuth.ProtoReflect.Descriptor instead.
+func (*HttpBasicAuth) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{36}
 }
 
-func (m *Condition) GetCondition() isCondition_Condition {
-	if m != nil {
-		return m.Condition
+func (x *HttpBasicAuth) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
-	return nil
+	return ""
 }
 
-func (x *Condition) GetExpression() *Expression {
-	if x, ok := x.GetCondition().(*Condition_Expression); ok {
-		return x.Expression
+func (x *HttpBasicAuth) GetPasswordFile() string {
+	if x != nil {
+		return x.PasswordFile
 	}
-	return nil
+	return ""
 }
 
-func (x *Condition) GetExists() *ExistsExpression {
-	if x, ok := x.GetCondition().(*Condition_Exists); ok {
-		return x.Exists
+func (x *HttpBasicAuth) GetPasswordEnv() string {
+	if x != nil {
+		return x.PasswordEnv
 	}
-	return nil
+	return ""
 }
 
-type isCondition_Condition interface {
-	isCondition_Condition()
+type HttpBearerAuth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenFile string `protobuf:"bytes,1,opt,name=tokenFile,proto3" json:"tokenFile,omitempty"`
+	TokenEnv  string `protobuf:"bytes,2,opt,name=tokenEnv,proto3" json:"tokenEnv,omitempty"`
 }
 
-type Condition_Expression struct {
-	Expression *Expression `protobuf:"bytes,1,opt,name=expression,proto3,oneof"`
+func (x *HttpBearerAuth) Reset() {
+	*x = HttpBearerAuth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type Condition_Exists struct {
-	Exists *ExistsExpression `protobuf:"bytes,2,opt,name=exists,proto3,oneof"`
+func (x *HttpBearerAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Condition_Expression) isCondition_Condition() {}
+func (*HttpBearerAuth) ProtoMessage() {}
 
-func (*Condition_Exists) isCondition_Condition() {}
+func (x *HttpBearerAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-type External struct {
+// Deprecated: Use HttpBearerAuth.ProtoReflect.Descriptor instead.
+func (*HttpBearerAuth) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *HttpBearerAuth) GetTokenFile() string {
+	if x != nil {
+		return x.TokenFile
+	}
+	return ""
+}
+
+func (x *HttpBearerAuth) GetTokenEnv() string {
+	if x != nil {
+		return x.TokenEnv
+	}
+	return ""
+}
+
+// Signs the request body, setting header (default X-Signature) to "<algorithm>=<hex signature>"
+type HttpHmacAuth struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ExternalType     ExternalType `protobuf:"varint,1,opt,name=externalType,proto3,enum=pipeline.ExternalType" json:"externalType,omitempty"`
-	Name             string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ConnectionString string       `protobuf:"bytes,3,opt,name=connectionString,proto3" json:"connectionString,omitempty"`
+	SecretFile string `protobuf:"bytes,1,opt,name=secretFile,proto3" json:"secretFile,omitempty"`
+	SecretEnv  string `protobuf:"bytes,2,opt,name=secretEnv,proto3" json:"secretEnv,omitempty"`
+	Header     string `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
+	// sha1, sha256 (the default) or sha512
+	Algorithm string `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
 }
 
-func (x *External) Reset() {
-	*x = External{}
+func (x *HttpHmacAuth) Reset() {
+	*x = HttpHmacAuth{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pipeline_proto_msgTypes[42]
+		mi := &file_pipeline_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *External) String() string {
+func (x *HttpHmacAuth) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*External) ProtoMessage() {}
+func (*HttpHmacAuth) ProtoMessage() {}
 
-func (x *External) ProtoReflect() protoreflect.Message {
-	mi := &file_pipeline_proto_msgTypes[42]
+func (x *HttpHmacAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3526,35 +3988,2946 @@ func (x *External) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use External.ProtoReflect.Descriptor instead.
-func (*External) Descriptor() ([]byte, []int) {
-	return file_pipeline_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use HttpHmacAuth.ProtoReflect.Descriptor instead.
+func (*HttpHmacAuth) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *External) GetExternalType() ExternalType {
+func (x *HttpHmacAuth) GetSecretFile() string {
 	if x != nil {
-		return x.ExternalType
+		return x.SecretFile
 	}
-	return ExternalType_ExternalUnknown
+	return ""
 }
 
-func (x *External) GetName() string {
+func (x *HttpHmacAuth) GetSecretEnv() string {
 	if x != nil {
-		return x.Name
+		return x.SecretEnv
 	}
 	return ""
 }
 
-func (x *External) GetConnectionString() string {
+func (x *HttpHmacAuth) GetHeader() string {
 	if x != nil {
-		return x.ConnectionString
+		return x.Header
 	}
 	return ""
 }
 
-var File_pipeline_proto protoreflect.FileDescriptor
+func (x *HttpHmacAuth) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
 
-var file_pipeline_proto_rawDesc = []byte{
+type HttpTls struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CertFile           string `protobuf:"bytes,1,opt,name=certFile,proto3" json:"certFile,omitempty"`
+	KeyFile            string `protobuf:"bytes,2,opt,name=keyFile,proto3" json:"keyFile,omitempty"`
+	CaFile             string `protobuf:"bytes,3,opt,name=caFile,proto3" json:"caFile,omitempty"`
+	InsecureSkipVerify bool   `protobuf:"varint,4,opt,name=insecureSkipVerify,proto3" json:"insecureSkipVerify,omitempty"`
+}
+
+func (x *HttpTls) Reset() {
+	*x = HttpTls{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HttpTls) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpTls) ProtoMessage() {}
+
+func (x *HttpTls) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpTls.ProtoReflect.Descriptor instead.
+func (*HttpTls) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *HttpTls) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *HttpTls) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *HttpTls) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *HttpTls) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+// Options for tees whose output connector is a local file: outputs are appended to <name>.<ext>,
+// which is rotated to <name>-<UTC time>.<ext>
+type RollingFileOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Defaults to the tee's name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Rotate once either is reached (0 disables each)
+	MaxBytes   int64 `protobuf:"varint,2,opt,name=maxBytes,proto3" json:"maxBytes,omitempty"`
+	MaxAgeInMs int64 `protobuf:"varint,3,opt,name=maxAgeInMs,proto3" json:"maxAgeInMs,omitempty"`
+	// Number of rotated files to keep (0 keeps all)
+	MaxFiles int32 `protobuf:"varint,4,opt,name=maxFiles,proto3" json:"maxFiles,omitempty"`
+	// never (the default), interval or always
+	Fsync             string `protobuf:"bytes,5,opt,name=fsync,proto3" json:"fsync,omitempty"`
+	FsyncIntervalInMs int64  `protobuf:"varint,6,opt,name=fsyncIntervalInMs,proto3" json:"fsyncIntervalInMs,omitempty"`
+	// Compression of rotated files: none, gzip or zstd
+	Compression string `protobuf:"bytes,7,opt,name=compression,proto3" json:"compression,omitempty"`
+}
+
+func (x *RollingFileOutput) Reset() {
+	*x = RollingFileOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RollingFileOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollingFileOutput) ProtoMessage() {}
+
+func (x *RollingFileOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollingFileOutput.ProtoReflect.Descriptor instead.
+func (*RollingFileOutput) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RollingFileOutput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RollingFileOutput) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *RollingFileOutput) GetMaxAgeInMs() int64 {
+	if x != nil {
+		return x.MaxAgeInMs
+	}
+	return 0
+}
+
+func (x *RollingFileOutput) GetMaxFiles() int32 {
+	if x != nil {
+		return x.MaxFiles
+	}
+	return 0
+}
+
+func (x *RollingFileOutput) GetFsync() string {
+	if x != nil {
+		return x.Fsync
+	}
+	return ""
+}
+
+func (x *RollingFileOutput) GetFsyncIntervalInMs() int64 {
+	if x != nil {
+		return x.FsyncIntervalInMs
+	}
+	return 0
+}
+
+func (x *RollingFileOutput) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+// Options for tees whose output connector is an object store
+type ObjectOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// e.g. "events/dt={{date}}/hour={{hour}}/{{pipeline}}-{{uuid}}{{ext}}"
+	KeyTemplate string `protobuf:"bytes,1,opt,name=keyTemplate,proto3" json:"keyTemplate,omitempty"`
+	// none, gzip or zstd
+	Compression string `protobuf:"bytes,2,opt,name=compression,proto3" json:"compression,omitempty"`
+	// If either is set, outputs are batched into one object per partition
+	BatchMaxBytes   int64 `protobuf:"varint,3,opt,name=batchMaxBytes,proto3" json:"batchMaxBytes,omitempty"`
+	BatchMaxAgeInMs int64 `protobuf:"varint,4,opt,name=batchMaxAgeInMs,proto3" json:"batchMaxAgeInMs,omitempty"`
+}
+
+func (x *ObjectOutput) Reset() {
+	*x = ObjectOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ObjectOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectOutput) ProtoMessage() {}
+
+func (x *ObjectOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectOutput.ProtoReflect.Descriptor instead.
+func (*ObjectOutput) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ObjectOutput) GetKeyTemplate() string {
+	if x != nil {
+		return x.KeyTemplate
+	}
+	return ""
+}
+
+func (x *ObjectOutput) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *ObjectOutput) GetBatchMaxBytes() int64 {
+	if x != nil {
+		return x.BatchMaxBytes
+	}
+	return 0
+}
+
+func (x *ObjectOutput) GetBatchMaxAgeInMs() int64 {
+	if x != nil {
+		return x.BatchMaxAgeInMs
+	}
+	return 0
+}
+
+// The encoding of the tee's output (default is JSON).  Search index tees ignore the format.
+type TeeFormat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// json, ndjson, csv, msgpack, protobuf, avro or a registered format
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// csv: the field paths of the columns, in order, and whether to write a header row at the start of
+	// each file, object or message
+	Columns []string `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	Header  bool     `protobuf:"varint,3,opt,name=header,proto3" json:"header,omitempty"`
+	// protobuf: a serialized FileDescriptorSet and the full name of the message
+	DescriptorSetPath string `protobuf:"bytes,4,opt,name=descriptorSetPath,proto3" json:"descriptorSetPath,omitempty"`
+	MessageName       string `protobuf:"bytes,5,opt,name=messageName,proto3" json:"messageName,omitempty"`
+	// avro: the schema, or a file containing it
+	Schema     string `protobuf:"bytes,6,opt,name=schema,proto3" json:"schema,omitempty"`
+	SchemaPath string `protobuf:"bytes,7,opt,name=schemaPath,proto3" json:"schemaPath,omitempty"`
+}
+
+func (x *TeeFormat) Reset() {
+	*x = TeeFormat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TeeFormat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeeFormat) ProtoMessage() {}
+
+func (x *TeeFormat) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeeFormat.ProtoReflect.Descriptor instead.
+func (*TeeFormat) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TeeFormat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TeeFormat) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *TeeFormat) GetHeader() bool {
+	if x != nil {
+		return x.Header
+	}
+	return false
+}
+
+func (x *TeeFormat) GetDescriptorSetPath() string {
+	if x != nil {
+		return x.DescriptorSetPath
+	}
+	return ""
+}
+
+func (x *TeeFormat) GetMessageName() string {
+	if x != nil {
+		return x.MessageName
+	}
+	return ""
+}
+
+func (x *TeeFormat) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *TeeFormat) GetSchemaPath() string {
+	if x != nil {
+		return x.SchemaPath
+	}
+	return ""
+}
+
+// By default, outputs are delivered inline and a failed output fails the pipeline
+type TeeDelivery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Retry          *TeeRetry          `protobuf:"bytes,1,opt,name=retry,proto3" json:"retry,omitempty"`
+	CircuitBreaker *TeeCircuitBreaker `protobuf:"bytes,2,opt,name=circuitBreaker,proto3" json:"circuitBreaker,omitempty"`
+	// If set, outputs are buffered and delivered in the background
+	Async bool `protobuf:"varint,3,opt,name=async,proto3" json:"async,omitempty"`
+	// Requires async or a spooling circuit breaker
+	Buffer *TeeBuffer `protobuf:"bytes,4,opt,name=buffer,proto3" json:"buffer,omitempty"`
+}
+
+func (x *TeeDelivery) Reset() {
+	*x = TeeDelivery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TeeDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeeDelivery) ProtoMessage() {}
+
+func (x *TeeDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeeDelivery.ProtoReflect.Descriptor instead.
+func (*TeeDelivery) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TeeDelivery) GetRetry() *TeeRetry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *TeeDelivery) GetCircuitBreaker() *TeeCircuitBreaker {
+	if x != nil {
+		return x.CircuitBreaker
+	}
+	return nil
+}
+
+func (x *TeeDelivery) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *TeeDelivery) GetBuffer() *TeeBuffer {
+	if x != nil {
+		return x.Buffer
+	}
+	return nil
+}
+
+type TeeRetry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxRetries       int32 `protobuf:"varint,1,opt,name=maxRetries,proto3" json:"maxRetries,omitempty"`
+	InitialDelayInMs int64 `protobuf:"varint,2,opt,name=initialDelayInMs,proto3" json:"initialDelayInMs,omitempty"`
+	MaxDelayInMs     int64 `protobuf:"varint,3,opt,name=maxDelayInMs,proto3" json:"maxDelayInMs,omitempty"`
+}
+
+func (x *TeeRetry) Reset() {
+	*x = TeeRetry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TeeRetry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeeRetry) ProtoMessage() {}
+
+func (x *TeeRetry) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeeRetry.ProtoReflect.Descriptor instead.
+func (*TeeRetry) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TeeRetry) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *TeeRetry) GetInitialDelayInMs() int64 {
+	if x != nil {
+		return x.InitialDelayInMs
+	}
+	return 0
+}
+
+func (x *TeeRetry) GetMaxDelayInMs() int64 {
+	if x != nil {
+		return x.MaxDelayInMs
+	}
+	return 0
+}
+
+type TeeCircuitBreaker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FailureThreshold int32 `protobuf:"varint,1,opt,name=failureThreshold,proto3" json:"failureThreshold,omitempty"`
+	ResetTimeoutInMs int64 `protobuf:"varint,2,opt,name=resetTimeoutInMs,proto3" json:"resetTimeoutInMs,omitempty"`
+	// If set, outputs are spooled to the buffer while the breaker is open; otherwise, they are skipped
+	Spool bool `protobuf:"varint,3,opt,name=spool,proto3" json:"spool,omitempty"`
+}
+
+func (x *TeeCircuitBreaker) Reset() {
+	*x = TeeCircuitBreaker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TeeCircuitBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeeCircuitBreaker) ProtoMessage() {}
+
+func (x *TeeCircuitBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeeCircuitBreaker.ProtoReflect.Descriptor instead.
+func (*TeeCircuitBreaker) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *TeeCircuitBreaker) GetFailureThreshold() int32 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+func (x *TeeCircuitBreaker) GetResetTimeoutInMs() int64 {
+	if x != nil {
+		return x.ResetTimeoutInMs
+	}
+	return 0
+}
+
+func (x *TeeCircuitBreaker) GetSpool() bool {
+	if x != nil {
+		return x.Spool
+	}
+	return false
+}
+
+// The buffer used by async and spooling tees.  Outputs are dropped when it is full.
+type TeeBuffer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capacity int32 `protobuf:"varint,1,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// If set, the buffer is a durable queue at queuePath.  Each destination of a
+	// tee with outputConnectorRefs has its own buffer, and queuePath is a
+	// directory with a queue for each connector.
+	QueuePath string `protobuf:"bytes,2,opt,name=queuePath,proto3" json:"queuePath,omitempty"`
+}
+
+func (x *TeeBuffer) Reset() {
+	*x = TeeBuffer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TeeBuffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeeBuffer) ProtoMessage() {}
+
+func (x *TeeBuffer) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeeBuffer.ProtoReflect.Descriptor instead.
+func (*TeeBuffer) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TeeBuffer) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *TeeBuffer) GetQueuePath() string {
+	if x != nil {
+		return x.QueuePath
+	}
+	return ""
+}
+
+type Continuation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Condition *Condition `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+}
+
+func (x *Continuation) Reset() {
+	*x = Continuation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Continuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Continuation) ProtoMessage() {}
+
+func (x *Continuation) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Continuation.ProtoReflect.Descriptor instead.
+func (*Continuation) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Continuation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Continuation) GetCondition() *Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type Transformer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name               string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Specs              []*TransformerSpec `protobuf:"bytes,2,rep,name=specs,proto3" json:"specs,omitempty"`
+	ForwardInputFields bool               `protobuf:"varint,3,opt,name=forwardInputFields,proto3" json:"forwardInputFields,omitempty"`
+}
+
+func (x *Transformer) Reset() {
+	*x = Transformer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transformer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transformer) ProtoMessage() {}
+
+func (x *Transformer) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transformer.ProtoReflect.Descriptor instead.
+func (*Transformer) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Transformer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Transformer) GetSpecs() []*TransformerSpec {
+	if x != nil {
+		return x.Specs
+	}
+	return nil
+}
+
+func (x *Transformer) GetForwardInputFields() bool {
+	if x != nil {
+		return x.ForwardInputFields
+	}
+	return false
+}
+
+type TransformerSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceField    string          `protobuf:"bytes,2,opt,name=sourceField,proto3" json:"sourceField,omitempty"`
+	TargetField    string          `protobuf:"bytes,3,opt,name=targetField,proto3" json:"targetField,omitempty"`
+	Transformation *Transformation `protobuf:"bytes,4,opt,name=transformation,proto3" json:"transformation,omitempty"`
+}
+
+func (x *TransformerSpec) Reset() {
+	*x = TransformerSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransformerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransformerSpec) ProtoMessage() {}
+
+func (x *TransformerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransformerSpec.ProtoReflect.Descriptor instead.
+func (*TransformerSpec) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TransformerSpec) GetSourceField() string {
+	if x != nil {
+		return x.SourceField
+	}
+	return ""
+}
+
+func (x *TransformerSpec) GetTargetField() string {
+	if x != nil {
+		return x.TargetField
+	}
+	return ""
+}
+
+func (x *TransformerSpec) GetTransformation() *Transformation {
+	if x != nil {
+		return x.Transformation
+	}
+	return nil
+}
+
+type MapArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path      string     `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	CoProcess *CoProcess `protobuf:"bytes,2,opt,name=coProcess,proto3" json:"coProcess,omitempty"`
+	Sandbox   *Sandbox   `protobuf:"bytes,3,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
+}
+
+func (x *MapArgs) Reset() {
+	*x = MapArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapArgs) ProtoMessage() {}
+
+func (x *MapArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapArgs.ProtoReflect.Descriptor instead.
+func (*MapArgs) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *MapArgs) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *MapArgs) GetCoProcess() *CoProcess {
+	if x != nil {
+		return x.CoProcess
+	}
+	return nil
+}
+
+func (x *MapArgs) GetSandbox() *Sandbox {
+	if x != nil {
+		return x.Sandbox
+	}
+	return nil
+}
+
+type MapAddArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *MapAddArgs) Reset() {
+	*x = MapAddArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapAddArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapAddArgs) ProtoMessage() {}
+
+func (x *MapAddArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapAddArgs.ProtoReflect.Descriptor instead.
+func (*MapAddArgs) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *MapAddArgs) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type MapMultArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *MapMultArgs) Reset() {
+	*x = MapMultArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapMultArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapMultArgs) ProtoMessage() {}
+
+func (x *MapMultArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapMultArgs.ProtoReflect.Descriptor instead.
+func (*MapMultArgs) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MapMultArgs) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// condition selects the elements used by FoldCount, FoldFirst and FoldLast.
+// separator is used by FoldConcat when joining non-list elements.
+type LeftFoldArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path      string     `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	FoldType  FoldType   `protobuf:"varint,2,opt,name=foldType,proto3,enum=pipeline.FoldType" json:"foldType,omitempty"`
+	Condition *Condition `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	Separator string     `protobuf:"bytes,4,opt,name=separator,proto3" json:"separator,omitempty"`
+	CoProcess *CoProcess `protobuf:"bytes,5,opt,name=coProcess,proto3" json:"coProcess,omitempty"`
+	Sandbox   *Sandbox   `protobuf:"bytes,6,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
+}
+
+func (x *LeftFoldArgs) Reset() {
+	*x = LeftFoldArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeftFoldArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeftFoldArgs) ProtoMessage() {}
+
+func (x *LeftFoldArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeftFoldArgs.ProtoReflect.Descriptor instead.
+func (*LeftFoldArgs) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *LeftFoldArgs) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *LeftFoldArgs) GetFoldType() FoldType {
+	if x != nil {
+		return x.FoldType
+	}
+	return FoldType_FoldExec
+}
+
+func (x *LeftFoldArgs) GetCondition() *Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *LeftFoldArgs) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *LeftFoldArgs) GetCoProcess() *CoProcess {
+	if x != nil {
+		return x.CoProcess
+	}
+	return nil
+}
+
+func (x *LeftFoldArgs) GetSandbox() *Sandbox {
+	if x != nil {
+		return x.Sandbox
+	}
+	return nil
+}
+
+type RightFoldArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path      string     `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	FoldType  FoldType   `protobuf:"varint,2,opt,name=foldType,proto3,enum=pipeline.FoldType" json:"foldType,omitempty"`
+	Condition *Condition `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	Separator string     `protobuf:"bytes,4,opt,name=separator,proto3" json:"separator,omitempty"`
+	CoProcess *CoProcess `protobuf:"bytes,5,opt,name=coProcess,proto3" json:"coProcess,omitempty"`
+	Sandbox   *Sandbox   `protobuf:"bytes,6,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
+}
+
+func (x *RightFoldArgs) Reset() {
+	*x = RightFoldArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RightFoldArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RightFoldArgs) ProtoMessage() {}
+
+func (x *RightFoldArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RightFoldArgs.ProtoReflect.Descriptor instead.
+func (*RightFoldArgs) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RightFoldArgs) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RightFoldArgs) GetFoldType() FoldType {
+	if x != nil {
+		return x.FoldType
+	}
+	return FoldType_FoldExec
+}
+
+func (x *RightFoldArgs) GetCondition() *Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *RightFoldArgs) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *RightFoldArgs) GetCoProcess() *CoProcess {
+	if x != nil {
+		return x.CoProcess
+	}
+	return nil
+}
+
+func (x *RightFoldArgs) GetSandbox() *Sandbox {
+	if x != nil {
+		return x.Sandbox
+	}
+	return nil
+}
+
+type MapRegexArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regex   string `protobuf:"bytes,1,opt,name=regex,proto3" json:"regex,omitempty"`
+	Replace string `protobuf:"bytes,2,opt,name=replace,proto3" json:"replace,omitempty"`
+}
+
+func (x *MapRegexArgs) Reset() {
+	*x = MapRegexArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapRegexArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapRegexArgs) ProtoMessage() {}
+
+func (x *MapRegexArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapRegexArgs.ProtoReflect.Descriptor instead.
+func (*MapRegexArgs) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *MapRegexArgs) GetRegex() string {
+	if x != nil {
+		return x.Regex
+	}
+	return ""
+}
+
+func (x *MapRegexArgs) GetReplace() string {
+	if x != nil {
+		return x.Replace
+	}
+	return ""
+}
+
+type Transformation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Condition          *Condition         `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+	TransformationType TransformationType `protobuf:"varint,2,opt,name=transformationType,proto3,enum=pipeline.TransformationType" json:"transformationType,omitempty"`
+	// Types that are assignable to TransformArgs:
+	//	*Transformation_MapArgs
+	//	*Transformation_MapAddArgs
+	//	*Transformation_MapMultArgs
+	//	*Transformation_MapRegexArgs
+	//	*Transformation_LeftFoldArgs
+	//	*Transformation_RightFoldArgs
+	TransformArgs isTransformation_TransformArgs `protobuf_oneof:"transformArgs"`
+}
+
+func (x *Transformation) Reset() {
+	*x = Transformation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transformation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transformation) ProtoMessage() {}
+
+func (x *Transformation) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transformation.ProtoReflect.Descriptor instead.
+func (*Transformation) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *Transformation) GetCondition() *Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *Transformation) GetTransformationType() TransformationType {
+	if x != nil {
+		return x.TransformationType
+	}
+	return TransformationType_TransformUnknown
+}
+
+func (m *Transformation) GetTransformArgs() isTransformation_TransformArgs {
+	if m != nil {
+		return m.TransformArgs
+	}
+	return nil
+}
+
+func (x *Transformation) GetMapArgs() *MapArgs {
+	if x, ok := x.GetTransformArgs().(*Transformation_MapArgs); ok {
+		return x.MapArgs
+	}
+	return nil
+}
+
+func (x *Transformation) GetMapAddArgs() *MapAddArgs {
+	if x, ok := x.GetTransformArgs().(*Transformation_MapAddArgs); ok {
+		return x.MapAddArgs
+	}
+	return nil
+}
+
+func (x *Transformation) GetMapMultArgs() *MapMultArgs {
+	if x, ok := x.GetTransformArgs().(*Transformation_MapMultArgs); ok {
+		return x.MapMultArgs
+	}
+	return nil
+}
+
+func (x *Transformation) GetMapRegexArgs() *MapRegexArgs {
+	if x, ok := x.GetTransformArgs().(*Transformation_MapRegexArgs); ok {
+		return x.MapRegexArgs
+	}
+	return nil
+}
+
+func (x *Transformation) GetLeftFoldArgs() *LeftFoldArgs {
+	if x, ok := x.GetTransformArgs().(*Transformation_LeftFoldArgs); ok {
+		return x.LeftFoldArgs
+	}
+	return nil
+}
+
+func (x *Transformation) GetRightFoldArgs() *RightFoldArgs {
+	if x, ok := x.GetTransformArgs().(*Transformation_RightFoldArgs); ok {
+		return x.RightFoldArgs
+	}
+	return nil
+}
+
+type isTransformation_TransformArgs interface {
+	isTransformation_TransformArgs()
+}
+
+type Transformation_MapArgs struct {
+	MapArgs *MapArgs `protobuf:"bytes,3,opt,name=mapArgs,proto3,oneof"`
+}
+
+type Transformation_MapAddArgs struct {
+	MapAddArgs *MapAddArgs `protobuf:"bytes,4,opt,name=mapAddArgs,proto3,oneof"`
+}
+
+type Transformation_MapMultArgs struct {
+	MapMultArgs *MapMultArgs `protobuf:"bytes,5,opt,name=mapMultArgs,proto3,oneof"`
+}
+
+type Transformation_MapRegexArgs struct {
+	MapRegexArgs *MapRegexArgs `protobuf:"bytes,6,opt,name=mapRegexArgs,proto3,oneof"`
+}
+
+type Transformation_LeftFoldArgs struct {
+	LeftFoldArgs *LeftFoldArgs `protobuf:"bytes,7,opt,name=leftFoldArgs,proto3,oneof"`
+}
+
+type Transformation_RightFoldArgs struct {
+	RightFoldArgs *RightFoldArgs `protobuf:"bytes,8,opt,name=rightFoldArgs,proto3,oneof"`
+}
+
+func (*Transformation_MapArgs) isTransformation_TransformArgs() {}
+
+func (*Transformation_MapAddArgs) isTransformation_TransformArgs() {}
+
+func (*Transformation_MapMultArgs) isTransformation_TransformArgs() {}
+
+func (*Transformation_MapRegexArgs) isTransformation_TransformArgs() {}
+
+func (*Transformation_LeftFoldArgs) isTransformation_TransformArgs() {}
+
+func (*Transformation_RightFoldArgs) isTransformation_TransformArgs() {}
+
+type ExistsOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Op  ExistsOperator `protobuf:"varint,2,opt,name=op,proto3,enum=pipeline.ExistsOperator" json:"op,omitempty"`
+}
+
+func (x *ExistsOperation) Reset() {
+	*x = ExistsOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExistsOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExistsOperation) ProtoMessage() {}
+
+func (x *ExistsOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExistsOperation.ProtoReflect.Descriptor instead.
+func (*ExistsOperation) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ExistsOperation) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ExistsOperation) GetOp() ExistsOperator {
+	if x != nil {
+		return x.Op
+	}
+	return ExistsOperator_UnknownExists
+}
+
+type ExistsExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ops []*ExistsOperation `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+}
+
+func (x *ExistsExpression) Reset() {
+	*x = ExistsExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExistsExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExistsExpression) ProtoMessage() {}
+
+func (x *ExistsExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExistsExpression.ProtoReflect.Descriptor instead.
+func (*ExistsExpression) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ExistsExpression) GetOps() []*ExistsOperation {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+type BooleanExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value bool `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *BooleanExpression) Reset() {
+	*x = BooleanExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BooleanExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BooleanExpression) ProtoMessage() {}
+
+func (x *BooleanExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BooleanExpression.ProtoReflect.Descriptor instead.
+func (*BooleanExpression) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *BooleanExpression) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+type Variable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Variable) Reset() {
+	*x = Variable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Variable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variable) ProtoMessage() {}
+
+func (x *Variable) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variable.ProtoReflect.Descriptor instead.
+func (*Variable) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *Variable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Operand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Operand:
+	//	*Operand_Expression
+	//	*Operand_Variable
+	//	*Operand_Literal
+	//	*Operand_Numeric
+	Operand isOperand_Operand `protobuf_oneof:"operand"`
+}
+
+func (x *Operand) Reset() {
+	*x = Operand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Operand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operand) ProtoMessage() {}
+
+func (x *Operand) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operand.ProtoReflect.Descriptor instead.
+func (*Operand) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{61}
+}
+
+func (m *Operand) GetOperand() isOperand_Operand {
+	if m != nil {
+		return m.Operand
+	}
+	return nil
+}
+
+func (x *Operand) GetExpression() *Expression {
+	if x, ok := x.GetOperand().(*Operand_Expression); ok {
+		return x.Expression
+	}
+	return nil
+}
+
+func (x *Operand) GetVariable() *Variable {
+	if x, ok := x.GetOperand().(*Operand_Variable); ok {
+		return x.Variable
+	}
+	return nil
+}
+
+func (x *Operand) GetLiteral() string {
+	if x, ok := x.GetOperand().(*Operand_Literal); ok {
+		return x.Literal
+	}
+	return ""
+}
+
+func (x *Operand) GetNumeric() float64 {
+	if x, ok := x.GetOperand().(*Operand_Numeric); ok {
+		return x.Numeric
+	}
+	return 0
+}
+
+type isOperand_Operand interface {
+	isOperand_Operand()
+}
+
+type Operand_Expression struct {
+	Expression *Expression `protobuf:"bytes,1,opt,name=expression,proto3,oneof"`
+}
+
+type Operand_Variable struct {
+	Variable *Variable `protobuf:"bytes,2,opt,name=variable,proto3,oneof"`
+}
+
+type Operand_Literal struct {
+	Literal string `protobuf:"bytes,3,opt,name=literal,proto3,oneof"`
+}
+
+type Operand_Numeric struct {
+	Numeric float64 `protobuf:"fixed64,4,opt,name=numeric,proto3,oneof"`
+}
+
+func (*Operand_Expression) isOperand_Operand() {}
+
+func (*Operand_Variable) isOperand_Operand() {}
+
+func (*Operand_Literal) isOperand_Operand() {}
+
+func (*Operand_Numeric) isOperand_Operand() {}
+
+type ComparatorExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lhs *Operand           `protobuf:"bytes,1,opt,name=lhs,proto3" json:"lhs,omitempty"`
+	Rhs *Operand           `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
+	Op  ComparatorOperator `protobuf:"varint,3,opt,name=op,proto3,enum=pipeline.ComparatorOperator" json:"op,omitempty"`
+}
+
+func (x *ComparatorExpression) Reset() {
+	*x = ComparatorExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComparatorExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparatorExpression) ProtoMessage() {}
+
+func (x *ComparatorExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparatorExpression.ProtoReflect.Descriptor instead.
+func (*ComparatorExpression) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ComparatorExpression) GetLhs() *Operand {
+	if x != nil {
+		return x.Lhs
+	}
+	return nil
+}
+
+func (x *ComparatorExpression) GetRhs() *Operand {
+	if x != nil {
+		return x.Rhs
+	}
+	return nil
+}
+
+func (x *ComparatorExpression) GetOp() ComparatorOperator {
+	if x != nil {
+		return x.Op
+	}
+	return ComparatorOperator_UnknownComparator
+}
+
+type LogicalExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lhs *Operand        `protobuf:"bytes,1,opt,name=lhs,proto3" json:"lhs,omitempty"`
+	Rhs *Operand        `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
+	Op  LogicalOperator `protobuf:"varint,3,opt,name=op,proto3,enum=pipeline.LogicalOperator" json:"op,omitempty"`
+}
+
+func (x *LogicalExpression) Reset() {
+	*x = LogicalExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogicalExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogicalExpression) ProtoMessage() {}
+
+func (x *LogicalExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogicalExpression.ProtoReflect.Descriptor instead.
+func (*LogicalExpression) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *LogicalExpression) GetLhs() *Operand {
+	if x != nil {
+		return x.Lhs
+	}
+	return nil
+}
+
+func (x *LogicalExpression) GetRhs() *Operand {
+	if x != nil {
+		return x.Rhs
+	}
+	return nil
+}
+
+func (x *LogicalExpression) GetOp() LogicalOperator {
+	if x != nil {
+		return x.Op
+	}
+	return LogicalOperator_UnknownLogical
+}
+
+type BinaryExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lhs *Operand       `protobuf:"bytes,1,opt,name=lhs,proto3" json:"lhs,omitempty"`
+	Rhs *Operand       `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
+	Op  BinaryOperator `protobuf:"varint,3,opt,name=op,proto3,enum=pipeline.BinaryOperator" json:"op,omitempty"`
+}
+
+func (x *BinaryExpression) Reset() {
+	*x = BinaryExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BinaryExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BinaryExpression) ProtoMessage() {}
+
+func (x *BinaryExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BinaryExpression.ProtoReflect.Descriptor instead.
+func (*BinaryExpression) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *BinaryExpression) GetLhs() *Operand {
+	if x != nil {
+		return x.Lhs
+	}
+	return nil
+}
+
+func (x *BinaryExpression) GetRhs() *Operand {
+	if x != nil {
+		return x.Rhs
+	}
+	return nil
+}
+
+func (x *BinaryExpression) GetOp() BinaryOperator {
+	if x != nil {
+		return x.Op
+	}
+	return BinaryOperator_UnknownBinary
+}
+
+type UnaryExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rhs *Operand      `protobuf:"bytes,2,opt,name=rhs,proto3" json:"rhs,omitempty"`
+	Op  UnaryOperator `protobuf:"varint,3,opt,name=op,proto3,enum=pipeline.UnaryOperator" json:"op,omitempty"`
+}
+
+func (x *UnaryExpression) Reset() {
+	*x = UnaryExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnaryExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnaryExpression) ProtoMessage() {}
+
+func (x *UnaryExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnaryExpression.ProtoReflect.Descriptor instead.
+func (*UnaryExpression) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UnaryExpression) GetRhs() *Operand {
+	if x != nil {
+		return x.Rhs
+	}
+	return nil
+}
+
+func (x *UnaryExpression) GetOp() UnaryOperator {
+	if x != nil {
+		return x.Op
+	}
+	return UnaryOperator_UnknownUnary
+}
+
+type Expression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Expression:
+	//	*Expression_Boolean
+	//	*Expression_Comparator
+	//	*Expression_Logical
+	//	*Expression_Binary
+	//	*Expression_Unary
+	Expression isExpression_Expression `protobuf_oneof:"expression"`
+}
+
+func (x *Expression) Reset() {
+	*x = Expression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Expression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression) ProtoMessage() {}
+
+func (x *Expression) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression.ProtoReflect.Descriptor instead.
+func (*Expression) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{66}
+}
+
+func (m *Expression) GetExpression() isExpression_Expression {
+	if m != nil {
+		return m.Expression
+	}
+	return nil
+}
+
+func (x *Expression) GetBoolean() *BooleanExpression {
+	if x, ok := x.GetExpression().(*Expression_Boolean); ok {
+		return x.Boolean
+	}
+	return nil
+}
+
+func (x *Expression) GetComparator() *ComparatorExpression {
+	if x, ok := x.GetExpression().(*Expression_Comparator); ok {
+		return x.Comparator
+	}
+	return nil
+}
+
+func (x *Expression) GetLogical() *LogicalExpression {
+	if x, ok := x.GetExpression().(*Expression_Logical); ok {
+		return x.Logical
+	}
+	return nil
+}
+
+func (x *Expression) GetBinary() *BinaryExpression {
+	if x, ok := x.GetExpression().(*Expression_Binary); ok {
+		return x.Binary
+	}
+	return nil
+}
+
+func (x *Expression) GetUnary() *UnaryExpression {
+	if x, ok := x.GetExpression().(*Expression_Unary); ok {
+		return x.Unary
+	}
+	return nil
+}
+
+type isExpression_Expression interface {
+	isExpression_Expression()
+}
+
+type Expression_Boolean struct {
+	Boolean *BooleanExpression `protobuf:"bytes,3,opt,name=boolean,proto3,oneof"`
+}
+
+type Expression_Comparator struct {
+	Comparator *ComparatorExpression `protobuf:"bytes,4,opt,name=comparator,proto3,oneof"`
+}
+
+type Expression_Logical struct {
+	Logical *LogicalExpression `protobuf:"bytes,5,opt,name=logical,proto3,oneof"`
+}
+
+type Expression_Binary struct {
+	Binary *BinaryExpression `protobuf:"bytes,6,opt,name=binary,proto3,oneof"`
+}
+
+type Expression_Unary struct {
+	Unary *UnaryExpression `protobuf:"bytes,7,opt,name=unary,proto3,oneof"`
+}
+
+func (*Expression_Boolean) isExpression_Expression() {}
+
+func (*Expression_Comparator) isExpression_Expression() {}
+
+func (*Expression_Logical) isExpression_Expression() {}
+
+func (*Expression_Binary) isExpression_Expression() {}
+
+func (*Expression_Unary) isExpression_Expression() {}
+
+type Condition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Condition:
+	//	*Condition_Expression
+	//	*Condition_Exists
+	Condition isCondition_Condition `protobuf_oneof:"condition"`
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{67}
+}
+
+func (m *Condition) GetCondition() isCondition_Condition {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
+func (x *Condition) GetExpression() *Expression {
+	if x, ok := x.GetCondition().(*Condition_Expression); ok {
+		return x.Expression
+	}
+	return nil
+}
+
+func (x *Condition) GetExists() *ExistsExpression {
+	if x, ok := x.GetCondition().(*Condition_Exists); ok {
+		return x.Exists
+	}
+	return nil
+}
+
+type isCondition_Condition interface {
+	isCondition_Condition()
+}
+
+type Condition_Expression struct {
+	Expression *Expression `protobuf:"bytes,1,opt,name=expression,proto3,oneof"`
+}
+
+type Condition_Exists struct {
+	Exists *ExistsExpression `protobuf:"bytes,2,opt,name=exists,proto3,oneof"`
+}
+
+func (*Condition_Expression) isCondition_Condition() {}
+
+func (*Condition_Exists) isCondition_Condition() {}
+
+type External struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExternalType     ExternalType `protobuf:"varint,1,opt,name=externalType,proto3,enum=pipeline.ExternalType" json:"externalType,omitempty"`
+	Name             string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ConnectionString string       `protobuf:"bytes,3,opt,name=connectionString,proto3" json:"connectionString,omitempty"`
+}
+
+func (x *External) Reset() {
+	*x = External{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *External) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*External) ProtoMessage() {}
+
+func (x *External) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use External.ProtoReflect.Descriptor instead.
+func (*External) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *External) GetExternalType() ExternalType {
+	if x != nil {
+		return x.ExternalType
+	}
+	return ExternalType_ExternalUnknown
+}
+
+func (x *External) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *External) GetConnectionString() string {
+	if x != nil {
+		return x.ConnectionString
+	}
+	return ""
+}
+
+// Source reads events into the pipelines of a daemon
+type Source struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The pipelines that the source's events are run through (default is every pipeline)
+	Pipelines []string `protobuf:"bytes,2,rep,name=pipelines,proto3" json:"pipelines,omitempty"`
+	// Types that are assignable to SourceSpec:
+	//	*Source_Http
+	//	*Source_Subscriber
+	//	*Source_FileTail
+	//	*Source_Syslog
+	//	*Source_JsonLines
+	//	*Source_ObjectStore
+	//	*Source_Schedule
+	SourceSpec isSource_SourceSpec `protobuf_oneof:"sourceSpec"`
+}
+
+func (x *Source) Reset() {
+	*x = Source{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Source) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Source) ProtoMessage() {}
+
+func (x *Source) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Source.ProtoReflect.Descriptor instead.
+func (*Source) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *Source) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Source) GetPipelines() []string {
+	if x != nil {
+		return x.Pipelines
+	}
+	return nil
+}
+
+func (m *Source) GetSourceSpec() isSource_SourceSpec {
+	if m != nil {
+		return m.SourceSpec
+	}
+	return nil
+}
+
+func (x *Source) GetHttp() *HttpSource {
+	if x, ok := x.GetSourceSpec().(*Source_Http); ok {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *Source) GetSubscriber() *SubscriberSource {
+	if x, ok := x.GetSourceSpec().(*Source_Subscriber); ok {
+		return x.Subscriber
+	}
+	return nil
+}
+
+func (x *Source) GetFileTail() *FileTailSource {
+	if x, ok := x.GetSourceSpec().(*Source_FileTail); ok {
+		return x.FileTail
+	}
+	return nil
+}
+
+func (x *Source) GetSyslog() *ListenerSource {
+	if x, ok := x.GetSourceSpec().(*Source_Syslog); ok {
+		return x.Syslog
+	}
+	return nil
+}
+
+func (x *Source) GetJsonLines() *ListenerSource {
+	if x, ok := x.GetSourceSpec().(*Source_JsonLines); ok {
+		return x.JsonLines
+	}
+	return nil
+}
+
+func (x *Source) GetObjectStore() *ObjectStoreSource {
+	if x, ok := x.GetSourceSpec().(*Source_ObjectStore); ok {
+		return x.ObjectStore
+	}
+	return nil
+}
+
+func (x *Source) GetSchedule() *ScheduleSource {
+	if x, ok := x.GetSourceSpec().(*Source_Schedule); ok {
+		return x.Schedule
+	}
+	return nil
+}
+
+type isSource_SourceSpec interface {
+	isSource_SourceSpec()
+}
+
+type Source_Http struct {
+	Http *HttpSource `protobuf:"bytes,3,opt,name=http,proto3,oneof"`
+}
+
+type Source_Subscriber struct {
+	Subscriber *SubscriberSource `protobuf:"bytes,4,opt,name=subscriber,proto3,oneof"`
+}
+
+type Source_FileTail struct {
+	FileTail *FileTailSource `protobuf:"bytes,5,opt,name=fileTail,proto3,oneof"`
+}
+
+type Source_Syslog struct {
+	// RFC5424 and RFC3164 syslog messages
+	Syslog *ListenerSource `protobuf:"bytes,6,opt,name=syslog,proto3,oneof"`
+}
+
+type Source_JsonLines struct {
+	// Newline-delimited JSON objects
+	JsonLines *ListenerSource `protobuf:"bytes,7,opt,name=jsonLines,proto3,oneof"`
+}
+
+type Source_ObjectStore struct {
+	ObjectStore *ObjectStoreSource `protobuf:"bytes,8,opt,name=objectStore,proto3,oneof"`
+}
+
+type Source_Schedule struct {
+	Schedule *ScheduleSource `protobuf:"bytes,9,opt,name=schedule,proto3,oneof"`
+}
+
+func (*Source_Http) isSource_SourceSpec() {}
+
+func (*Source_Subscriber) isSource_SourceSpec() {}
+
+func (*Source_FileTail) isSource_SourceSpec() {}
+
+func (*Source_Syslog) isSource_SourceSpec() {}
+
+func (*Source_JsonLines) isSource_SourceSpec() {}
+
+func (*Source_ObjectStore) isSource_SourceSpec() {}
+
+func (*Source_Schedule) isSource_SourceSpec() {}
+
+// Events are POSTed to the path as JSON objects
+type HttpSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Port int32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// Default is /binge
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Default is 64
+	MaxConnections int32 `protobuf:"varint,3,opt,name=maxConnections,proto3" json:"maxConnections,omitempty"`
+}
+
+func (x *HttpSource) Reset() {
+	*x = HttpSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HttpSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpSource) ProtoMessage() {}
+
+func (x *HttpSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpSource.ProtoReflect.Descriptor instead.
+func (*HttpSource) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *HttpSource) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *HttpSource) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HttpSource) GetMaxConnections() int32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+// Events are consumed from a topic, and each message is committed after it is processed
+type SubscriberSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// mem:<topic> or kafka:servers=<host:port>,topicName=<topic>,group=<group>
+	ConnectionString string `protobuf:"bytes,1,opt,name=connectionString,proto3" json:"connectionString,omitempty"`
+	// Publisher for messages that are not JSON objects or still fail after the retries (default stops
+	// the source)
+	DeadLetterConnectionString string `protobuf:"bytes,2,opt,name=deadLetterConnectionString,proto3" json:"deadLetterConnectionString,omitempty"`
+	MaxRetries                 int32  `protobuf:"varint,3,opt,name=maxRetries,proto3" json:"maxRetries,omitempty"`
+}
+
+func (x *SubscriberSource) Reset() {
+	*x = SubscriberSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriberSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberSource) ProtoMessage() {}
+
+func (x *SubscriberSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberSource.ProtoReflect.Descriptor instead.
+func (*SubscriberSource) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *SubscriberSource) GetConnectionString() string {
+	if x != nil {
+		return x.ConnectionString
+	}
+	return ""
+}
+
+func (x *SubscriberSource) GetDeadLetterConnectionString() string {
+	if x != nil {
+		return x.DeadLetterConnectionString
+	}
+	return ""
+}
+
+func (x *SubscriberSource) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+// Messages are read from TCP connections or UDP datagrams
+type ListenerSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tcp or udp (the default)
+	Network string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	// e.g. :514
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Default is 64
+	MaxConnections int32 `protobuf:"varint,3,opt,name=maxConnections,proto3" json:"maxConnections,omitempty"`
+	// Default is 65536
+	MaxMessageSize int32 `protobuf:"varint,4,opt,name=maxMessageSize,proto3" json:"maxMessageSize,omitempty"`
+}
+
+func (x *ListenerSource) Reset() {
+	*x = ListenerSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListenerSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerSource) ProtoMessage() {}
+
+func (x *ListenerSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerSource.ProtoReflect.Descriptor instead.
+func (*ListenerSource) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListenerSource) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *ListenerSource) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListenerSource) GetMaxConnections() int32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+func (x *ListenerSource) GetMaxMessageSize() int32 {
+	if x != nil {
+		return x.MaxMessageSize
+	}
+	return 0
+}
+
+// A synthetic event is sent to the source's pipelines, which must be named, on a schedule
+type ScheduleSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cron expression ("minute hour day-of-month month day-of-week"), descriptor (e.g. @hourly) or
+	// "@every <duration>"
+	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// IANA time zone of the cron expression (default is UTC)
+	Timezone string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Fields added to each event
+	Payload *_struct.Struct `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	// KV store shared by replicas, so only one replica fires each tick (default fires on every replica)
+	LeaderStoreRef string `protobuf:"bytes,4,opt,name=leaderStoreRef,proto3" json:"leaderStoreRef,omitempty"`
+	// Default is 5000
+	LockTimeoutInMs int64 `protobuf:"varint,5,opt,name=lockTimeoutInMs,proto3" json:"lockTimeoutInMs,omitempty"`
+}
+
+func (x *ScheduleSource) Reset() {
+	*x = ScheduleSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleSource) ProtoMessage() {}
+
+func (x *ScheduleSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleSource.ProtoReflect.Descriptor instead.
+func (*ScheduleSource) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ScheduleSource) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *ScheduleSource) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ScheduleSource) GetPayload() *_struct.Struct {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ScheduleSource) GetLeaderStoreRef() string {
+	if x != nil {
+		return x.LeaderStoreRef
+	}
+	return ""
+}
+
+func (x *ScheduleSource) GetLockTimeoutInMs() int64 {
+	if x != nil {
+		return x.LockTimeoutInMs
+	}
+	return 0
+}
+
+// Records are read from the objects under a prefix, e.g. CSV or NDJSON files dropped by partners
+type ObjectStoreSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ObjectStoreRef string `protobuf:"bytes,1,opt,name=objectStoreRef,proto3" json:"objectStoreRef,omitempty"`
+	Prefix         string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// KV store that holds the claims of the objects, shared by replicas (default is in memory)
+	ClaimStoreRef string `protobuf:"bytes,3,opt,name=claimStoreRef,proto3" json:"claimStoreRef,omitempty"`
+	// auto (by extension, the default), csv, ndjson or json
+	Format string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	// Keys of the columns of CSV objects without a header row
+	CsvColumns []string `protobuf:"bytes,5,rep,name=csvColumns,proto3" json:"csvColumns,omitempty"`
+	// Renames CSV columns to keys
+	CsvHeaderMapping map[string]string `protobuf:"bytes,6,rep,name=csvHeaderMapping,proto3" json:"csvHeaderMapping,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Completed and failed objects are moved under these prefixes (default only marks them)
+	DonePrefix   string `protobuf:"bytes,7,opt,name=donePrefix,proto3" json:"donePrefix,omitempty"`
+	FailedPrefix string `protobuf:"bytes,8,opt,name=failedPrefix,proto3" json:"failedPrefix,omitempty"`
+	// Default is 10000
+	PollIntervalInMs int64 `protobuf:"varint,9,opt,name=pollIntervalInMs,proto3" json:"pollIntervalInMs,omitempty"`
+	// Default is 300000
+	ClaimTimeoutInMs int64 `protobuf:"varint,10,opt,name=claimTimeoutInMs,proto3" json:"claimTimeoutInMs,omitempty"`
+	// Default is 3
+	MaxRetries int32 `protobuf:"varint,11,opt,name=maxRetries,proto3" json:"maxRetries,omitempty"`
+}
+
+func (x *ObjectStoreSource) Reset() {
+	*x = ObjectStoreSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ObjectStoreSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectStoreSource) ProtoMessage() {}
+
+func (x *ObjectStoreSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectStoreSource.ProtoReflect.Descriptor instead.
+func (*ObjectStoreSource) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ObjectStoreSource) GetObjectStoreRef() string {
+	if x != nil {
+		return x.ObjectStoreRef
+	}
+	return ""
+}
+
+func (x *ObjectStoreSource) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ObjectStoreSource) GetClaimStoreRef() string {
+	if x != nil {
+		return x.ClaimStoreRef
+	}
+	return ""
+}
+
+func (x *ObjectStoreSource) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ObjectStoreSource) GetCsvColumns() []string {
+	if x != nil {
+		return x.CsvColumns
+	}
+	return nil
+}
+
+func (x *ObjectStoreSource) GetCsvHeaderMapping() map[string]string {
+	if x != nil {
+		return x.CsvHeaderMapping
+	}
+	return nil
+}
+
+func (x *ObjectStoreSource) GetDonePrefix() string {
+	if x != nil {
+		return x.DonePrefix
+	}
+	return ""
+}
+
+func (x *ObjectStoreSource) GetFailedPrefix() string {
+	if x != nil {
+		return x.FailedPrefix
+	}
+	return ""
+}
+
+func (x *ObjectStoreSource) GetPollIntervalInMs() int64 {
+	if x != nil {
+		return x.PollIntervalInMs
+	}
+	return 0
+}
+
+func (x *ObjectStoreSource) GetClaimTimeoutInMs() int64 {
+	if x != nil {
+		return x.ClaimTimeoutInMs
+	}
+	return 0
+}
+
+func (x *ObjectStoreSource) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+// Lines appended to the files matching the glob patterns are events, following rotation and truncation
+type FileTailSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Patterns []string `protobuf:"bytes,1,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	// json (the default), where lines that are not JSON objects are wrapped like text, or text, where each
+	// line is wrapped in {"message": <line>}
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	// KV store or local file (a directory) that persists the offsets (default does not persist them)
+	OffsetConnectorRef string `protobuf:"bytes,3,opt,name=offsetConnectorRef,proto3" json:"offsetConnectorRef,omitempty"`
+	// Default is 1000
+	PollIntervalInMs int64          `protobuf:"varint,4,opt,name=pollIntervalInMs,proto3" json:"pollIntervalInMs,omitempty"`
+	Multiline        *TailMultiline `protobuf:"bytes,5,opt,name=multiline,proto3" json:"multiline,omitempty"`
+}
+
+func (x *FileTailSource) Reset() {
+	*x = FileTailSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileTailSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileTailSource) ProtoMessage() {}
+
+func (x *FileTailSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileTailSource.ProtoReflect.Descriptor instead.
+func (*FileTailSource) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *FileTailSource) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *FileTailSource) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *FileTailSource) GetOffsetConnectorRef() string {
+	if x != nil {
+		return x.OffsetConnectorRef
+	}
+	return ""
+}
+
+func (x *FileTailSource) GetPollIntervalInMs() int64 {
+	if x != nil {
+		return x.PollIntervalInMs
+	}
+	return 0
+}
+
+func (x *FileTailSource) GetMultiline() *TailMultiline {
+	if x != nil {
+		return x.Multiline
+	}
+	return nil
+}
+
+// Joins lines into one event, e.g. a stack trace.  Set one of the patterns.
+type TailMultiline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A line matching startPattern begins a new event
+	StartPattern string `protobuf:"bytes,1,opt,name=startPattern,proto3" json:"startPattern,omitempty"`
+	// A line matching continuationPattern is appended to the current event
+	ContinuationPattern string `protobuf:"bytes,2,opt,name=continuationPattern,proto3" json:"continuationPattern,omitempty"`
+	// Default is 500
+	MaxLines int32 `protobuf:"varint,3,opt,name=maxLines,proto3" json:"maxLines,omitempty"`
+	// An event is emitted after no lines are appended for timeoutInMs (default is 1000)
+	TimeoutInMs int64 `protobuf:"varint,4,opt,name=timeoutInMs,proto3" json:"timeoutInMs,omitempty"`
+}
+
+func (x *TailMultiline) Reset() {
+	*x = TailMultiline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pipeline_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TailMultiline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailMultiline) ProtoMessage() {}
+
+func (x *TailMultiline) ProtoReflect() protoreflect.Message {
+	mi := &file_pipeline_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailMultiline.ProtoReflect.Descriptor instead.
+func (*TailMultiline) Descriptor() ([]byte, []int) {
+	return file_pipeline_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *TailMultiline) GetStartPattern() string {
+	if x != nil {
+		return x.StartPattern
+	}
+	return ""
+}
+
+func (x *TailMultiline) GetContinuationPattern() string {
+	if x != nil {
+		return x.ContinuationPattern
+	}
+	return ""
+}
+
+func (x *TailMultiline) GetMaxLines() int32 {
+	if x != nil {
+		return x.MaxLines
+	}
+	return 0
+}
+
+func (x *TailMultiline) GetTimeoutInMs() int64 {
+	if x != nil {
+		return x.TimeoutInMs
+	}
+	return 0
+}
+
+var File_pipeline_proto protoreflect.FileDescriptor
+
+var file_pipeline_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x12, 0x08, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
@@ -3574,7 +6947,7 @@ var file_pipeline_proto_rawDesc = []byte{
 	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6c,
 	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
 	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
-	0x22, 0xee, 0x01, 0x0a, 0x09, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x24,
+	0x22, 0x86, 0x03, 0x0a, 0x09, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x24,
 	0x0a, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x75, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
 	0x55, 0x75, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
@@ -3589,447 +6962,1015 @@ var file_pipeline_proto_rawDesc = []byte{
 	0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
 	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
 	0x52, 0x0f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x73, 0x22, 0xd9, 0x01, 0x0a, 0x08, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
-	0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
-	0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x63,
-	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x12, 0x24, 0x0a, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x63, 0x69,
-	0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x22, 0x5b, 0x0a,
-	0x0d, 0x52, 0x65, 0x74, 0x72, 0x79, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x1e,
-	0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2a,
-	0x0a, 0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66,
-	0x4d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
-	0x6c, 0x42, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x4d, 0x73, 0x22, 0xb0, 0x01, 0x0a, 0x0f, 0x50,
-	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x0d, 0x72, 0x65, 0x74, 0x72, 0x79, 0x53, 0x74, 0x72, 0x61, 0x74,
-	0x65, 0x67, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x70, 0x65,
-	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65,
-	0x67, 0x79, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x72, 0x79, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
-	0x79, 0x12, 0x4a, 0x0a, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x69, 0x70,
-	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x73,
-	0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x69, 0x6e,
-	0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xf0, 0x03,
-	0x0a, 0x11, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
-	0x65, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x09, 0x61,
-	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x36, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70,
-	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x6f, 0x72, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72,
-	0x12, 0x33, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43,
-	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70,
-	0x6c, 0x65, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
-	0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x12, 0x2d, 0x0a, 0x07, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x70,
-	0x61, 0x77, 0x6e, 0x65, 0x72, 0x48, 0x00, 0x52, 0x07, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x65, 0x72,
-	0x12, 0x21, 0x0a, 0x03, 0x74, 0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x65, 0x65, 0x48, 0x00, 0x52, 0x03,
-	0x74, 0x65, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
-	0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
-	0x69, 0x6e, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x48,
-	0x00, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x12, 0x3c,
-	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
-	0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0c,
-	0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x07,
-	0x65, 0x6e, 0x74, 0x77, 0x69, 0x6e, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x77, 0x69, 0x6e, 0x65,
-	0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x77, 0x69, 0x6e, 0x65, 0x42, 0x13, 0x0a, 0x11, 0x70,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0xaa, 0x02, 0x0a, 0x07, 0x45, 0x6e, 0x74, 0x77, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x2a, 0x0a, 0x10, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53,
-	0x74, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x20, 0x0a, 0x0b,
-	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x70, 0x65, 0x6d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x70, 0x65, 0x6d, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73,
-	0x75, 0x62, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x69,
-	0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x69, 0x63, 0x6b,
-	0x65, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x14, 0x6e, 0x74, 0x70,
+	0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x53, 0x65,
+	0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x6e, 0x74, 0x70, 0x53, 0x79, 0x6e, 0x63,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x53, 0x65, 0x63, 0x12, 0x2a, 0x0a,
+	0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0xd9, 0x01, 0x0a, 0x08, 0x50, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0a, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12,
+	0x24, 0x0a, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x22, 0x5b, 0x0a, 0x0d, 0x52, 0x65, 0x74, 0x72, 0x79, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x52,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x6c, 0x42, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66,
+	0x4d, 0x73, 0x22, 0xb0, 0x01, 0x0a, 0x0f, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x0d, 0x72, 0x65,
+	0x74, 0x72, 0x79, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65, 0x74,
+	0x72, 0x79, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x4a, 0x0a, 0x0f, 0x69, 0x6e, 0x73,
+	0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x82, 0x06, 0x0a, 0x11, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x09, 0x61,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x36, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48,
+	0x00, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x07, 0x73, 0x70, 0x61,
+	0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x65, 0x72, 0x48, 0x00, 0x52,
+	0x07, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x03, 0x74, 0x65, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x2e, 0x54, 0x65, 0x65, 0x48, 0x00, 0x52, 0x03, 0x74, 0x65, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x77, 0x69, 0x6e, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x2e, 0x45, 0x6e, 0x74, 0x77, 0x69, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x77,
+	0x69, 0x6e, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x48, 0x00, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12,
+	0x2a, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65,
+	0x72, 0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x07, 0x61,
+	0x62, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x41, 0x62, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x48,
+	0x00, 0x52, 0x07, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x0e,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x45,
+	0x0a, 0x0f, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x48, 0x00, 0x52, 0x0f, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x44, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x42, 0x13, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa8, 0x02, 0x0a, 0x07, 0x41,
+	0x62, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
 	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x57, 0x0a,
-	0x09, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36,
-	0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x41,
-	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x71, 0x0a, 0x0a, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65,
-	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09,
-	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xfa, 0x01, 0x0a, 0x0a, 0x41, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x09, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x6e, 0x4d,
+	0x73, 0x12, 0x2c, 0x0a, 0x11, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x4d, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x65, 0x65, 0x12, 0x26, 0x0a,
+	0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x50, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xc4, 0x01, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09,
 	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69,
 	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12,
-	0x37, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
-	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x67, 0x67,
-	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x61, 0x73, 0x79, 0x6e,
-	0x63, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0f, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72,
-	0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x0b, 0x41, 0x67, 0x67, 0x72, 0x65,
-	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x43, 0x0a, 0x0f, 0x61, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x41, 0x67, 0x67,
-	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0f, 0x61, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a,
-	0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x22,
-	0xa8, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x2d, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x22, 0x79, 0x0a, 0x0b,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x2a, 0x0a, 0x10, 0x61, 0x62, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x65, 0x44, 0x65, 0x61, 0x64, 0x62,
+	0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x61, 0x62, 0x73, 0x6f, 0x6c,
+	0x75, 0x74, 0x65, 0x44, 0x65, 0x61, 0x64, 0x62, 0x61, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x44, 0x65, 0x61, 0x64, 0x62, 0x61, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x44,
+	0x65, 0x61, 0x64, 0x62, 0x61, 0x6e, 0x64, 0x22, 0xca, 0x03, 0x0a, 0x0f, 0x41, 0x6e, 0x6f, 0x6d,
+	0x61, 0x6c, 0x79, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x4b,
+	0x65, 0x79, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x42, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x61, 0x79, 0x46,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x63,
+	0x61, 0x79, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x61, 0x72, 0x6d,
+	0x55, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x77, 0x61, 0x72, 0x6d, 0x55, 0x70,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x32,
+	0x0a, 0x07, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x41, 0x6e, 0x6f, 0x6d, 0x61,
+	0x6c, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x63, 0x6f, 0x72, 0x69,
+	0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x73, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x4b, 0x65, 0x79, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x24,
+	0x0a, 0x0d, 0x6f, 0x6e, 0x6c, 0x79, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6f, 0x6e, 0x6c, 0x79, 0x41, 0x6e, 0x6f, 0x6d, 0x61,
+	0x6c, 0x69, 0x65, 0x73, 0x22, 0x94, 0x04, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x0a, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x20,
+	0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x58, 0x0a, 0x12,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x12, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x73, 0x50, 0x61, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x50, 0x61, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x61,
+	0x69, 0x72, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x70, 0x61, 0x69, 0x72, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x20, 0x0a, 0x0b, 0x6b, 0x76, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6b, 0x76, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x4f, 0x6e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x4f, 0x6e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x1a, 0x45, 0x0a, 0x17, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc7, 0x01, 0x0a, 0x06,
+	0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x65, 0x70, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xaa, 0x02, 0x0a, 0x07, 0x45, 0x6e, 0x74, 0x77, 0x69, 0x6e,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x6d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x65, 0x6d, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a,
+	0x0b, 0x73, 0x75, 0x62, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x12,
+	0x26, 0x0a, 0x0e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x45,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x57, 0x0a, 0x09, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b,
+	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x71, 0x0a, 0x0a, 0x41,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xfa,
+	0x01, 0x0a, 0x0a, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
 	0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
 	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69,
 	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f,
 	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53,
-	0x74, 0x6f, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
-	0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
-	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x46, 0x0a, 0x0a, 0x43, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6a, 0x6f, 0x69, 0x6e,
-	0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e,
-	0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
-	0x4d, 0x73, 0x22, 0x54, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x6b, 0x65, 0x65, 0x70, 0x4d, 0x61,
-	0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6b, 0x65, 0x65,
-	0x70, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x22, 0x3c, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x52, 0x65, 0x66, 0x22, 0xa7, 0x01, 0x0a, 0x07, 0x53, 0x70, 0x61, 0x77, 0x6e,
+	0x74, 0x6f, 0x72, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a,
+	0x0f, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x0b,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x43, 0x0a,
+	0x0f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x0f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x4b, 0x65, 0x79,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79,
+	0x4b, 0x65, 0x79, 0x73, 0x22, 0xa8, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
 	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
 	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65,
 	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09,
-	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c,
-	0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65,
-	0x6c, 0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x53, 0x79, 0x6e,
-	0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12,
-	0x1f, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
-	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62,
-	0x22, 0x44, 0x0a, 0x08, 0x52, 0x75, 0x6e, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
-	0x70, 0x61, 0x74, 0x68, 0x54, 0x6f, 0x45, 0x78, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x54, 0x6f, 0x45, 0x78, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07,
-	0x63, 0x6d, 0x64, 0x41, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63,
-	0x6d, 0x64, 0x41, 0x72, 0x67, 0x73, 0x22, 0x35, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x2e, 0x0a,
-	0x08, 0x72, 0x75, 0x6e, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x12, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x75, 0x6e, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x52, 0x08, 0x72, 0x75, 0x6e, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xe5, 0x01,
-	0x0a, 0x03, 0x54, 0x65, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
-	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0e,
-	0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x42, 0x6f, 0x64, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0e, 0x61,
-	0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x26, 0x0a,
-	0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x66, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
-	0x65, 0x72, 0x52, 0x65, 0x66, 0x12, 0x2e, 0x0a, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x52, 0x65, 0x66, 0x22, 0x55, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
-	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x82, 0x01, 0x0a,
-	0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x2f, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x05, 0x73, 0x70, 0x65, 0x63,
-	0x73, 0x12, 0x2e, 0x0a, 0x12, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x70, 0x75,
-	0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x66,
-	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
-	0x73, 0x22, 0x97, 0x01, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65,
-	0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x40, 0x0a, 0x0e, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1d, 0x0a, 0x07, 0x4d,
-	0x61, 0x70, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x22, 0x0a, 0x0a, 0x4d, 0x61,
-	0x70, 0x41, 0x64, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x23,
-	0x0a, 0x0b, 0x4d, 0x61, 0x70, 0x4d, 0x75, 0x6c, 0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x22, 0x0a, 0x0c, 0x4c, 0x65, 0x66, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x41,
-	0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x23, 0x0a, 0x0d, 0x52, 0x69, 0x67, 0x68, 0x74,
-	0x46, 0x6f, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x3e, 0x0a, 0x0c,
-	0x4d, 0x61, 0x70, 0x52, 0x65, 0x67, 0x65, 0x78, 0x41, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x67,
-	0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x22, 0x81, 0x04, 0x0a,
-	0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x46, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x22, 0x54, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x6b,
+	0x65, 0x65, 0x70, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x6b, 0x65, 0x65, 0x70, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x22, 0x3c, 0x0a,
+	0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65,
+	0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x66, 0x22, 0xb7, 0x02, 0x0a, 0x07,
+	0x53, 0x70, 0x61, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x6f,
+	0x53, 0x79, 0x6e, 0x63, 0x12, 0x1f, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4a, 0x6f, 0x62,
+	0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x49, 0x6e, 0x4d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f,
+	0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x26, 0x0a,
+	0x0e, 0x6d, 0x61, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x08, 0x52, 0x75, 0x6e, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x54, 0x6f, 0x45, 0x78, 0x65, 0x63,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x54, 0x6f, 0x45, 0x78,
+	0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6d, 0x64, 0x41, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6d, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x09,
+	0x63, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x52, 0x09, 0x63, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12,
+	0x2b, 0x0a, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x52, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x22, 0xad, 0x02, 0x0a,
+	0x07, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e, 0x76, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x65, 0x6e, 0x76, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x26,
+	0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x52, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x30, 0x0a, 0x0a,
+	0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x67, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x67, 0x69, 0x64, 0x22, 0x4d,
+	0x0a, 0x09, 0x43, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6e,
+	0x75, 0x6d, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x6e, 0x75, 0x6d, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x22, 0xad, 0x01,
+	0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x2e, 0x0a, 0x08, 0x72, 0x75, 0x6e, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x52, 0x75, 0x6e, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x08, 0x72, 0x75, 0x6e,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x48,
+	0x74, 0x74, 0x70, 0x4a, 0x6f, 0x62, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x25, 0x0a, 0x04,
+	0x67, 0x72, 0x70, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x72, 0x70, 0x63, 0x4a, 0x6f, 0x62, 0x52, 0x04, 0x67,
+	0x72, 0x70, 0x63, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x22, 0x56, 0x0a,
+	0x08, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d,
+	0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61,
+	0x79, 0x49, 0x6e, 0x4d, 0x73, 0x22, 0xcd, 0x01, 0x0a, 0x07, 0x48, 0x74, 0x74, 0x70, 0x4a, 0x6f,
+	0x62, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x38, 0x0a, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x4a, 0x6f, 0x62, 0x2e,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x6f, 0x64, 0x79, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x62, 0x6f, 0x64,
+	0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xab, 0x02, 0x0a, 0x07, 0x47, 0x72, 0x70, 0x63, 0x4a, 0x6f,
+	0x62, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x3b, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x72, 0x70, 0x63, 0x4a,
+	0x6f, 0x62, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x6e,
+	0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xf8, 0x04, 0x0a, 0x03, 0x54, 0x65, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f,
 	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c,
-	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x12, 0x74, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x2d, 0x0a, 0x07, 0x6d, 0x61, 0x70, 0x41, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4d, 0x61, 0x70,
-	0x41, 0x72, 0x67, 0x73, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x61, 0x70, 0x41, 0x72, 0x67, 0x73, 0x12,
-	0x36, 0x0a, 0x0a, 0x6d, 0x61, 0x70, 0x41, 0x64, 0x64, 0x41, 0x72, 0x67, 0x73, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4d,
-	0x61, 0x70, 0x41, 0x64, 0x64, 0x41, 0x72, 0x67, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x70,
-	0x41, 0x64, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x39, 0x0a, 0x0b, 0x6d, 0x61, 0x70, 0x4d, 0x75,
-	0x6c, 0x74, 0x41, 0x72, 0x67, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70,
-	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x4d, 0x75, 0x6c, 0x74, 0x41,
-	0x72, 0x67, 0x73, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x70, 0x4d, 0x75, 0x6c, 0x74, 0x41, 0x72,
-	0x67, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x67, 0x65, 0x78, 0x41, 0x72,
-	0x67, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
-	0x69, 0x6e, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x67, 0x65, 0x78, 0x41, 0x72, 0x67, 0x73,
-	0x48, 0x00, 0x52, 0x0c, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x67, 0x65, 0x78, 0x41, 0x72, 0x67, 0x73,
-	0x12, 0x3c, 0x0a, 0x0c, 0x6c, 0x65, 0x66, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
-	0x65, 0x2e, 0x4c, 0x65, 0x66, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x48, 0x00,
-	0x52, 0x0c, 0x6c, 0x65, 0x66, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x3f,
-	0x0a, 0x0d, 0x72, 0x69, 0x67, 0x68, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
-	0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x48, 0x00,
-	0x52, 0x0d, 0x72, 0x69, 0x67, 0x68, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x42,
-	0x0f, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x41, 0x72, 0x67, 0x73,
-	0x22, 0x4d, 0x0a, 0x0f, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x69,
-	0x73, 0x74, 0x73, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70, 0x22,
-	0x3f, 0x0a, 0x10, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x03, 0x6f, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x69, 0x73,
-	0x74, 0x73, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x6f, 0x70, 0x73,
-	0x22, 0x29, 0x0a, 0x11, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x45, 0x78, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1e, 0x0a, 0x08, 0x56,
-	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xb6, 0x01, 0x0a, 0x07,
-	0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x12, 0x36, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69,
-	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x30, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x56, 0x61, 0x72,
-	0x69, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x12, 0x1a, 0x0a, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x12, 0x1a, 0x0a,
-	0x07, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00,
-	0x52, 0x07, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x42, 0x09, 0x0a, 0x07, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x6e, 0x64, 0x22, 0x8e, 0x01, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a,
-	0x03, 0x6c, 0x68, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70,
-	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x03, 0x6c,
-	0x68, 0x73, 0x12, 0x23, 0x0a, 0x03, 0x72, 0x68, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61,
-	0x6e, 0x64, 0x52, 0x03, 0x72, 0x68, 0x73, 0x12, 0x2c, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43,
-	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
-	0x72, 0x52, 0x02, 0x6f, 0x70, 0x22, 0x88, 0x01, 0x0a, 0x11, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61,
-	0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x03, 0x6c,
+	0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0e, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x42, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x52, 0x0e, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x42,
+	0x6f, 0x64, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x65, 0x72, 0x52, 0x65, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x66, 0x12, 0x2e, 0x0a, 0x12, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65,
+	0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x66, 0x12, 0x31, 0x0a, 0x08, 0x64,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x65, 0x65, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x2b,
+	0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x65, 0x65, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x3a, 0x0a, 0x0c, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x0c, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x72, 0x6f, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x46,
+	0x69, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x0b, 0x72, 0x6f, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x52, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x30, 0x0a, 0x13,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52,
+	0x65, 0x66, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x66, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x12, 0x3a, 0x0a, 0x0c, 0x70, 0x75, 0x62, 0x53, 0x75, 0x62, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x2e, 0x50, 0x75, 0x62, 0x53, 0x75, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x52, 0x0c, 0x70, 0x75, 0x62, 0x53, 0x75, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x30,
+	0x0a, 0x0c, 0x50, 0x75, 0x62, 0x53, 0x75, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x20,
+	0x0a, 0x0b, 0x6b, 0x65, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6b, 0x65, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x22, 0x8a, 0x03, 0x0a, 0x0a, 0x48, 0x74, 0x74, 0x70, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x72, 0x6c, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x75, 0x72,
+	0x6c, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
+	0x48, 0x74, 0x74, 0x70, 0x41, 0x75, 0x74, 0x68, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x23,
+	0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x54, 0x6c, 0x73, 0x52, 0x03,
+	0x74, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e,
+	0x4d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x13, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0f, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x97, 0x01,
+	0x0a, 0x08, 0x48, 0x74, 0x74, 0x70, 0x41, 0x75, 0x74, 0x68, 0x12, 0x2d, 0x0a, 0x05, 0x62, 0x61,
+	0x73, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x42, 0x61, 0x73, 0x69, 0x63, 0x41, 0x75,
+	0x74, 0x68, 0x52, 0x05, 0x62, 0x61, 0x73, 0x69, 0x63, 0x12, 0x30, 0x0a, 0x06, 0x62, 0x65, 0x61,
+	0x72, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x41,
+	0x75, 0x74, 0x68, 0x52, 0x06, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x04, 0x68,
+	0x6d, 0x61, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x48, 0x6d, 0x61, 0x63, 0x41, 0x75, 0x74,
+	0x68, 0x52, 0x04, 0x68, 0x6d, 0x61, 0x63, 0x22, 0x71, 0x0a, 0x0d, 0x48, 0x74, 0x74, 0x70, 0x42,
+	0x61, 0x73, 0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x45, 0x6e, 0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x45, 0x6e, 0x76, 0x22, 0x4a, 0x0a, 0x0e, 0x48, 0x74,
+	0x74, 0x70, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x41, 0x75, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x45, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x45, 0x6e, 0x76, 0x22, 0x82, 0x01, 0x0a, 0x0c, 0x48, 0x74, 0x74, 0x70, 0x48,
+	0x6d, 0x61, 0x63, 0x41, 0x75, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x45, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x45, 0x6e, 0x76, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0x87, 0x01, 0x0a, 0x07,
+	0x48, 0x74, 0x74, 0x70, 0x54, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x65, 0x72, 0x74, 0x46,
+	0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x65, 0x72, 0x74, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x61, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72,
+	0x65, 0x53, 0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x12, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x22, 0xe5, 0x01, 0x0a, 0x11, 0x52, 0x6f, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d,
+	0x61, 0x78, 0x41, 0x67, 0x65, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d,
+	0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x73, 0x79, 0x6e, 0x63,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x73, 0x79, 0x6e, 0x63, 0x12, 0x2c, 0x0a,
+	0x11, 0x66, 0x73, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e,
+	0x4d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x66, 0x73, 0x79, 0x6e, 0x63, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa2, 0x01,
+	0x0a, 0x0c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x20,
+	0x0a, 0x0b, 0x6b, 0x65, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6b, 0x65, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x78, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x4d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x4d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x49, 0x6e,
+	0x4d, 0x73, 0x22, 0xd9, 0x01, 0x0a, 0x09, 0x54, 0x65, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x1e,
+	0x0a, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x50, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x50, 0x61, 0x74, 0x68, 0x22, 0xbf,
+	0x01, 0x0a, 0x0b, 0x54, 0x65, 0x65, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x28,
+	0x0a, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x65, 0x65, 0x52, 0x65, 0x74, 0x72,
+	0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x0e, 0x63, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x65, 0x65, 0x43,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x52, 0x0e, 0x63,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x73,
+	0x79, 0x6e, 0x63, 0x12, 0x2b, 0x0a, 0x06, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54,
+	0x65, 0x65, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x52, 0x06, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72,
+	0x22, 0x7a, 0x0a, 0x08, 0x54, 0x65, 0x65, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44,
+	0x65, 0x6c, 0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x44,
+	0x65, 0x6c, 0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x49, 0x6e, 0x4d, 0x73, 0x22, 0x81, 0x01, 0x0a,
+	0x11, 0x54, 0x65, 0x65, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b,
+	0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2a,
+	0x0a, 0x10, 0x72, 0x65, 0x73, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e,
+	0x4d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x72, 0x65, 0x73, 0x65, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70,
+	0x6f, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x70, 0x6f, 0x6f, 0x6c,
+	0x22, 0x45, 0x0a, 0x09, 0x54, 0x65, 0x65, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0x55, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x74, 0x69,
+	0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x82,
+	0x01, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x05, 0x73, 0x70,
+	0x65, 0x63, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x12, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
+	0x6d, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x40, 0x0a, 0x0e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7d, 0x0a,
+	0x07, 0x4d, 0x61, 0x70, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x31, 0x0a, 0x09,
+	0x63, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x52, 0x09, 0x63, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12,
+	0x2b, 0x0a, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x52, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x22, 0x22, 0x0a, 0x0a,
+	0x4d, 0x61, 0x70, 0x41, 0x64, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x23, 0x0a, 0x0b, 0x4d, 0x61, 0x70, 0x4d, 0x75, 0x6c, 0x74, 0x41, 0x72, 0x67, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x83, 0x02, 0x0a, 0x0c, 0x4c, 0x65, 0x66, 0x74, 0x46, 0x6f,
+	0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x2e, 0x0a, 0x08, 0x66, 0x6f,
+	0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x46, 0x6f, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x08, 0x66, 0x6f, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x09, 0x63,
+	0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x09, 0x63, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2b,
+	0x0a, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62,
+	0x6f, 0x78, 0x52, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x22, 0x84, 0x02, 0x0a, 0x0d,
+	0x52, 0x69, 0x67, 0x68, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x2e, 0x0a, 0x08, 0x66, 0x6f, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x46,
+	0x6f, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x64, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x2e, 0x43, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x09, 0x63, 0x6f, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62,
+	0x6f, 0x78, 0x22, 0x3e, 0x0a, 0x0c, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x67, 0x65, 0x78, 0x41, 0x72,
+	0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x22, 0x81, 0x04, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x6d, 0x61, 0x70, 0x41, 0x72, 0x67,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x41, 0x72, 0x67, 0x73, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x61,
+	0x70, 0x41, 0x72, 0x67, 0x73, 0x12, 0x36, 0x0a, 0x0a, 0x6d, 0x61, 0x70, 0x41, 0x64, 0x64, 0x41,
+	0x72, 0x67, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x41, 0x64, 0x64, 0x41, 0x72, 0x67, 0x73, 0x48,
+	0x00, 0x52, 0x0a, 0x6d, 0x61, 0x70, 0x41, 0x64, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x39, 0x0a,
+	0x0b, 0x6d, 0x61, 0x70, 0x4d, 0x75, 0x6c, 0x74, 0x41, 0x72, 0x67, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4d, 0x61,
+	0x70, 0x4d, 0x75, 0x6c, 0x74, 0x41, 0x72, 0x67, 0x73, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x70,
+	0x4d, 0x75, 0x6c, 0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x6d, 0x61, 0x70, 0x52,
+	0x65, 0x67, 0x65, 0x78, 0x41, 0x72, 0x67, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x67,
+	0x65, 0x78, 0x41, 0x72, 0x67, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x67,
+	0x65, 0x78, 0x41, 0x72, 0x67, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x6c, 0x65, 0x66, 0x74, 0x46, 0x6f,
+	0x6c, 0x64, 0x41, 0x72, 0x67, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4c, 0x65, 0x66, 0x74, 0x46, 0x6f, 0x6c, 0x64,
+	0x41, 0x72, 0x67, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x65, 0x66, 0x74, 0x46, 0x6f, 0x6c, 0x64,
+	0x41, 0x72, 0x67, 0x73, 0x12, 0x3f, 0x0a, 0x0d, 0x72, 0x69, 0x67, 0x68, 0x74, 0x46, 0x6f, 0x6c,
+	0x64, 0x41, 0x72, 0x67, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x46, 0x6f, 0x6c, 0x64,
+	0x41, 0x72, 0x67, 0x73, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x69, 0x67, 0x68, 0x74, 0x46, 0x6f, 0x6c,
+	0x64, 0x41, 0x72, 0x67, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x41, 0x72, 0x67, 0x73, 0x22, 0x4d, 0x0a, 0x0f, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x02, 0x6f,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x02, 0x6f, 0x70, 0x22, 0x3f, 0x0a, 0x10, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x45,
+	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x03, 0x6f, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x03, 0x6f, 0x70, 0x73, 0x22, 0x29, 0x0a, 0x11, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61,
+	0x6e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x1e, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0xb6, 0x01, 0x0a, 0x07, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x12, 0x36, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x08, 0x76,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72,
+	0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x69, 0x74, 0x65,
+	0x72, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x42,
+	0x09, 0x0a, 0x07, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x22, 0x8e, 0x01, 0x0a, 0x14, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x03, 0x6c, 0x68, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x6e, 0x64, 0x52, 0x03, 0x6c, 0x68, 0x73, 0x12, 0x23, 0x0a, 0x03, 0x72, 0x68, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x03, 0x72, 0x68, 0x73, 0x12, 0x2c, 0x0a,
+	0x02, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70, 0x22, 0x88, 0x01, 0x0a, 0x11,
+	0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x23, 0x0a, 0x03, 0x6c, 0x68, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e,
+	0x64, 0x52, 0x03, 0x6c, 0x68, 0x73, 0x12, 0x23, 0x0a, 0x03, 0x72, 0x68, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x03, 0x72, 0x68, 0x73, 0x12, 0x29, 0x0a, 0x02, 0x6f,
+	0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70, 0x22, 0x86, 0x01, 0x0a, 0x10, 0x42, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x03, 0x6c,
 	0x68, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
 	0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x03, 0x6c, 0x68, 0x73,
 	0x12, 0x23, 0x0a, 0x03, 0x72, 0x68, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
 	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64,
-	0x52, 0x03, 0x72, 0x68, 0x73, 0x12, 0x29, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4c, 0x6f, 0x67,
-	0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70,
-	0x22, 0x86, 0x01, 0x0a, 0x10, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x03, 0x6c, 0x68, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70,
-	0x65, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x03, 0x6c, 0x68, 0x73, 0x12, 0x23, 0x0a, 0x03, 0x72, 0x68,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
-	0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x03, 0x72, 0x68, 0x73, 0x12,
-	0x28, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x69,
-	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70, 0x22, 0x5f, 0x0a, 0x0f, 0x55, 0x6e, 0x61,
-	0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x03,
-	0x72, 0x68, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x69, 0x70, 0x65,
-	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x03, 0x72, 0x68,
-	0x73, 0x12, 0x27, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
-	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x4f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70, 0x22, 0xb7, 0x02, 0x0a, 0x0a, 0x45,
-	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x07, 0x62, 0x6f, 0x6f,
-	0x6c, 0x65, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x69, 0x70,
-	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x45, 0x78, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65,
-	0x61, 0x6e, 0x12, 0x40, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
-	0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72,
-	0x61, 0x74, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x07, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
-	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x12, 0x34, 0x0a,
-	0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x45,
-	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x06, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x55, 0x6e,
-	0x61, 0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
-	0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x42, 0x0c, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x86, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
-	0x65, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a,
-	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x06, 0x65, 0x78,
-	0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x69, 0x70,
-	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x45, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73,
-	0x42, 0x0b, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x86, 0x01,
-	0x0a, 0x08, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x3a, 0x0a, 0x0c, 0x65, 0x78,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x16, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x2a, 0xe0, 0x01, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
-	0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x6e,
-	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x01,
-	0x12, 0x15, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
-	0x65, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x03, 0x12, 0x11, 0x0a,
-	0x0d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x04,
-	0x12, 0x12, 0x0a, 0x0e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x65, 0x65, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
-	0x6d, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13,
-	0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x6e, 0x74, 0x77, 0x69, 0x6e, 0x65,
-	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x09, 0x2a, 0xa7, 0x01, 0x0a, 0x0c, 0x45, 0x78,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x78,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12,
-	0x13, 0x0a, 0x0f, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4b, 0x56, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x10, 0x02, 0x12, 0x12, 0x0a,
-	0x0e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50, 0x75, 0x62, 0x53, 0x75, 0x62, 0x10,
-	0x03, 0x12, 0x10, 0x0a, 0x0c, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x48, 0x74, 0x74,
-	0x70, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4c,
-	0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x78,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x10, 0x06, 0x2a, 0x79, 0x0a, 0x0f, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x67, 0x67, 0x55, 0x6e, 0x6b,
-	0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x67, 0x67, 0x53, 0x75, 0x6d,
-	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x67, 0x67, 0x4d, 0x61, 0x78, 0x10, 0x02, 0x12, 0x0a,
-	0x0a, 0x06, 0x41, 0x67, 0x67, 0x4d, 0x69, 0x6e, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x67,
-	0x67, 0x41, 0x76, 0x67, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x67, 0x67, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x67, 0x67, 0x44, 0x69, 0x73, 0x63, 0x72,
-	0x65, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x10, 0x06, 0x2a, 0x92,
-	0x02, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
-	0x72, 0x6d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x53, 0x75, 0x6d, 0x10, 0x01, 0x12, 0x11, 0x0a,
-	0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x43, 0x6f, 0x70, 0x79, 0x10, 0x02,
-	0x12, 0x15, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x4d, 0x61, 0x70,
-	0x52, 0x65, 0x67, 0x65, 0x78, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x6f, 0x72, 0x6d, 0x4d, 0x61, 0x70, 0x41, 0x64, 0x64, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10,
-	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x4d, 0x61, 0x70, 0x4d, 0x75, 0x6c, 0x74,
-	0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
-	0x6f, 0x72, 0x6d, 0x4c, 0x65, 0x66, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x10, 0x07, 0x12, 0x16, 0x0a,
-	0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x69, 0x67, 0x68, 0x74, 0x46,
-	0x6f, 0x6c, 0x64, 0x10, 0x08, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
-	0x72, 0x6d, 0x4d, 0x61, 0x70, 0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x6f, 0x72, 0x6d, 0x50, 0x6f, 0x70, 0x48, 0x65, 0x61, 0x64, 0x10, 0x0a, 0x12, 0x14, 0x0a,
-	0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x50, 0x6f, 0x70, 0x54, 0x61, 0x69,
-	0x6c, 0x10, 0x0b, 0x2a, 0x73, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x54, 0x79,
-	0x70, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x54, 0x79, 0x70,
-	0x65, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x54, 0x79, 0x70,
-	0x65, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x54, 0x79,
-	0x70, 0x65, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x78, 0x69, 0x73,
-	0x74, 0x73, 0x54, 0x79, 0x70, 0x65, 0x10, 0x05, 0x2a, 0x3e, 0x0a, 0x0e, 0x45, 0x78, 0x69, 0x73,
-	0x74, 0x73, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x6e,
-	0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x00, 0x12, 0x0a, 0x0a,
-	0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x6f, 0x74,
-	0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x02, 0x2a, 0x4e, 0x0a, 0x0d, 0x55, 0x6e, 0x61, 0x72,
-	0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x6e, 0x6b,
-	0x6e, 0x6f, 0x77, 0x6e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4e,
-	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x6f, 0x67, 0x69,
-	0x63, 0x61, 0x6c, 0x4e, 0x6f, 0x74, 0x10, 0x05, 0x2a, 0xaa, 0x01, 0x0a, 0x0e, 0x42, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x11, 0x0a, 0x0d, 0x55,
-	0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x10, 0x00, 0x12, 0x0c,
-	0x0a, 0x08, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08,
-	0x53, 0x75, 0x62, 0x74, 0x72, 0x61, 0x63, 0x74, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x75,
-	0x6c, 0x74, 0x69, 0x70, 0x6c, 0x79, 0x10, 0x08, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x69, 0x76, 0x69,
-	0x64, 0x65, 0x10, 0x09, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x10, 0x0a, 0x12,
-	0x0b, 0x0a, 0x07, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x75, 0x73, 0x10, 0x0b, 0x12, 0x0e, 0x0a, 0x0a,
-	0x52, 0x69, 0x67, 0x68, 0x74, 0x53, 0x68, 0x69, 0x66, 0x74, 0x10, 0x0c, 0x12, 0x0d, 0x0a, 0x09,
-	0x4c, 0x65, 0x66, 0x74, 0x53, 0x68, 0x69, 0x66, 0x74, 0x10, 0x0d, 0x12, 0x06, 0x0a, 0x02, 0x4f,
-	0x72, 0x10, 0x0e, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x6e, 0x64, 0x10, 0x0f, 0x12, 0x07, 0x0a, 0x03,
-	0x58, 0x6f, 0x72, 0x10, 0x10, 0x2a, 0x44, 0x0a, 0x0f, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
-	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x6e, 0x6b, 0x6e,
-	0x6f, 0x77, 0x6e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x41, 0x6e, 0x64, 0x10, 0x11, 0x12, 0x0d, 0x0a, 0x09,
-	0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x72, 0x10, 0x12, 0x2a, 0xb3, 0x01, 0x0a, 0x12,
-	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x47, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x10, 0x13, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x65,
-	0x73, 0x73, 0x54, 0x68, 0x61, 0x6e, 0x10, 0x14, 0x12, 0x16, 0x0a, 0x12, 0x47, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x4f, 0x72, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x10, 0x15,
-	0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x65, 0x73, 0x73, 0x54, 0x68, 0x61, 0x6e, 0x4f, 0x72, 0x45, 0x71,
-	0x75, 0x61, 0x6c, 0x10, 0x16, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x10, 0x17,
-	0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x10, 0x18, 0x12, 0x0e,
-	0x0a, 0x0a, 0x52, 0x65, 0x67, 0x65, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x10, 0x19, 0x12, 0x11,
-	0x0a, 0x0d, 0x52, 0x65, 0x67, 0x65, 0x78, 0x4e, 0x6f, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x10,
-	0x1a, 0x32, 0x60, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x65, 0x72, 0x12, 0x4f, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x70,
-	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
-	0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x52, 0x03, 0x72, 0x68, 0x73, 0x12, 0x28, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x42, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70, 0x22,
+	0x5f, 0x0a, 0x0f, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x03, 0x72, 0x68, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x6e, 0x64, 0x52, 0x03, 0x72, 0x68, 0x73, 0x12, 0x27, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x55,
+	0x6e, 0x61, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70,
+	0x22, 0xb7, 0x02, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x37, 0x0a, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x42, 0x6f, 0x6f, 0x6c,
+	0x65, 0x61, 0x6e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x40, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x07, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x45, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x69,
+	0x63, 0x61, 0x6c, 0x12, 0x34, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x42,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x75, 0x6e, 0x61,
+	0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x42, 0x0c, 0x0a, 0x0a,
+	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x86, 0x01, 0x0a, 0x09, 0x43,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x34, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x69, 0x73,
+	0x74, 0x73, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x06,
+	0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x86, 0x01, 0x0a, 0x08, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x12, 0x3a, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c,
+	0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x22, 0xd1, 0x03, 0x0a,
+	0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x04, 0x68, 0x74, 0x74,
+	0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52,
+	0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x3c, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x61, 0x69, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x61, 0x69, 0x6c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48,
+	0x00, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x61, 0x69, 0x6c, 0x12, 0x32, 0x0a, 0x06, 0x73,
+	0x79, 0x73, 0x6c, 0x6f, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x06, 0x73, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x12,
+	0x38, 0x0a, 0x09, 0x6a, 0x73, 0x6f, 0x6e, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x09,
+	0x6a, 0x73, 0x6f, 0x6e, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0b, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x70, 0x65, 0x63,
+	0x22, 0x5c, 0x0a, 0x0a, 0x48, 0x74, 0x74, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x9e,
+	0x01, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x3e, 0x0a, 0x1a, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x1a, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22,
+	0x94, 0x01, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26,
+	0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xd1, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65,
+	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d,
+	0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d,
+	0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x66,
+	0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49,
+	0x6e, 0x4d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x6b, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x22, 0x91, 0x04, 0x0a, 0x11, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x26, 0x0a, 0x0e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52,
+	0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x73, 0x76, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x73, 0x76, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x5d,
+	0x0a, 0x10, 0x63, 0x73, 0x76, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x70, 0x69,
+	0x6e, 0x67, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x43, 0x73, 0x76, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4d,
+	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x63, 0x73, 0x76,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a,
+	0x0a, 0x64, 0x6f, 0x6e, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x64, 0x6f, 0x6e, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x22, 0x0a,
+	0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x6f, 0x6c, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x70, 0x6f, 0x6c,
+	0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x2a, 0x0a,
+	0x10, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d,
+	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d,
+	0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x43, 0x0a, 0x15, 0x43, 0x73, 0x76,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd7,
+	0x01, 0x0a, 0x0e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x61, 0x69, 0x6c, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x65, 0x66, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x6f, 0x6c, 0x6c, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x10, 0x70, 0x6f, 0x6c, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x4d,
+	0x73, 0x12, 0x35, 0x0a, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
+	0x54, 0x61, 0x69, 0x6c, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x09, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x0d, 0x54, 0x61, 0x69,
+	0x6c, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x30,
+	0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x63, 0x6f, 0x6e,
+	0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x4d, 0x73, 0x2a, 0xe0,
+	0x01, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12,
+	0x0a, 0x0e, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x02, 0x12,
+	0x14, 0x0a, 0x10, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x70, 0x61, 0x77,
+	0x6e, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a,
+	0x54, 0x65, 0x65, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x08, 0x12, 0x12, 0x0a,
+	0x0e, 0x45, 0x6e, 0x74, 0x77, 0x69, 0x6e, 0x65, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10,
+	0x09, 0x2a, 0xa7, 0x01, 0x0a, 0x0c, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x55, 0x6e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x4b, 0x56, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13,
+	0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x50, 0x75, 0x62, 0x53, 0x75, 0x62, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x45, 0x78, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x48, 0x74, 0x74, 0x70, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x45,
+	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65,
+	0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x10, 0x06, 0x2a, 0x79, 0x0a, 0x0f, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e,
+	0x0a, 0x0a, 0x41, 0x67, 0x67, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x41, 0x67, 0x67, 0x53, 0x75, 0x6d, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x67,
+	0x67, 0x4d, 0x61, 0x78, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x67, 0x67, 0x4d, 0x69, 0x6e,
+	0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x67, 0x67, 0x41, 0x76, 0x67, 0x10, 0x04, 0x12, 0x0c,
+	0x0a, 0x08, 0x41, 0x67, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14,
+	0x41, 0x67, 0x67, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x10, 0x06, 0x2a, 0x92, 0x02, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a,
+	0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x53, 0x75, 0x6d, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x43, 0x6f, 0x70, 0x79, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x67, 0x65, 0x78, 0x10, 0x03, 0x12,
+	0x13, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x4d, 0x61, 0x70, 0x41,
+	0x64, 0x64, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
+	0x6d, 0x4d, 0x61, 0x70, 0x4d, 0x75, 0x6c, 0x74, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x10, 0x06, 0x12, 0x15,
+	0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x4c, 0x65, 0x66, 0x74, 0x46,
+	0x6f, 0x6c, 0x64, 0x10, 0x07, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x52, 0x69, 0x67, 0x68, 0x74, 0x46, 0x6f, 0x6c, 0x64, 0x10, 0x08, 0x12, 0x10, 0x0a,
+	0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x4d, 0x61, 0x70, 0x10, 0x09, 0x12,
+	0x14, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x50, 0x6f, 0x70, 0x48,
+	0x65, 0x61, 0x64, 0x10, 0x0a, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x50, 0x6f, 0x70, 0x54, 0x61, 0x69, 0x6c, 0x10, 0x0b, 0x2a, 0x9a, 0x01, 0x0a, 0x08,
+	0x46, 0x6f, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x6f, 0x6c, 0x64,
+	0x45, 0x78, 0x65, 0x63, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x6f, 0x6c, 0x64, 0x4d, 0x69,
+	0x6e, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x6f, 0x6c, 0x64, 0x4d, 0x61, 0x78, 0x10, 0x02,
+	0x12, 0x0d, 0x0a, 0x09, 0x46, 0x6f, 0x6c, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x10, 0x03, 0x12,
+	0x0b, 0x0a, 0x07, 0x46, 0x6f, 0x6c, 0x64, 0x53, 0x75, 0x6d, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07,
+	0x46, 0x6f, 0x6c, 0x64, 0x41, 0x76, 0x67, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x46, 0x6f, 0x6c,
+	0x64, 0x43, 0x6f, 0x6e, 0x63, 0x61, 0x74, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x6f, 0x6c,
+	0x64, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x10, 0x07, 0x12, 0x0d, 0x0a, 0x09, 0x46,
+	0x6f, 0x6c, 0x64, 0x46, 0x69, 0x72, 0x73, 0x74, 0x10, 0x08, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x6f,
+	0x6c, 0x64, 0x4c, 0x61, 0x73, 0x74, 0x10, 0x09, 0x2a, 0x73, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x6e, 0x61,
+	0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x69, 0x6e, 0x61,
+	0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x69,
+	0x63, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x10, 0x04, 0x12, 0x0e, 0x0a,
+	0x0a, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x54, 0x79, 0x70, 0x65, 0x10, 0x05, 0x2a, 0x3e, 0x0a,
+	0x0e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x11, 0x0a, 0x0d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x01, 0x12, 0x0d,
+	0x0a, 0x09, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x02, 0x2a, 0x4e, 0x0a,
+	0x0d, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10,
+	0x0a, 0x0c, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x10, 0x00,
+	0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x03, 0x12, 0x0d,
+	0x0a, 0x09, 0x49, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x04, 0x12, 0x0e, 0x0a,
+	0x0a, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4e, 0x6f, 0x74, 0x10, 0x05, 0x2a, 0xaa, 0x01,
+	0x0a, 0x0e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x11, 0x0a, 0x0d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x42, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x10,
+	0x06, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x75, 0x62, 0x74, 0x72, 0x61, 0x63, 0x74, 0x10, 0x07, 0x12,
+	0x0c, 0x0a, 0x08, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x79, 0x10, 0x08, 0x12, 0x0a, 0x0a,
+	0x06, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x10, 0x09, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x6f, 0x77,
+	0x65, 0x72, 0x10, 0x0a, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x75, 0x73, 0x10,
+	0x0b, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x69, 0x67, 0x68, 0x74, 0x53, 0x68, 0x69, 0x66, 0x74, 0x10,
+	0x0c, 0x12, 0x0d, 0x0a, 0x09, 0x4c, 0x65, 0x66, 0x74, 0x53, 0x68, 0x69, 0x66, 0x74, 0x10, 0x0d,
+	0x12, 0x06, 0x0a, 0x02, 0x4f, 0x72, 0x10, 0x0e, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x6e, 0x64, 0x10,
+	0x0f, 0x12, 0x07, 0x0a, 0x03, 0x58, 0x6f, 0x72, 0x10, 0x10, 0x2a, 0x44, 0x0a, 0x0f, 0x4c, 0x6f,
+	0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a,
+	0x0e, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x10,
+	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x41, 0x6e, 0x64, 0x10,
+	0x11, 0x12, 0x0d, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x72, 0x10, 0x12,
+	0x2a, 0xb3, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x6e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x10, 0x00, 0x12, 0x0f,
+	0x0a, 0x0b, 0x47, 0x72, 0x65, 0x61, 0x74, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x10, 0x13, 0x12,
+	0x0c, 0x0a, 0x08, 0x4c, 0x65, 0x73, 0x73, 0x54, 0x68, 0x61, 0x6e, 0x10, 0x14, 0x12, 0x16, 0x0a,
+	0x12, 0x47, 0x72, 0x65, 0x61, 0x74, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x4f, 0x72, 0x45, 0x71,
+	0x75, 0x61, 0x6c, 0x10, 0x15, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x65, 0x73, 0x73, 0x54, 0x68, 0x61,
+	0x6e, 0x4f, 0x72, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x10, 0x16, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x71,
+	0x75, 0x61, 0x6c, 0x10, 0x17, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x45, 0x71, 0x75, 0x61,
+	0x6c, 0x10, 0x18, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x65, 0x67, 0x65, 0x78, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x10, 0x19, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x65, 0x67, 0x65, 0x78, 0x4e, 0x6f, 0x74, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x10, 0x1a, 0x2a, 0x2a, 0x0a, 0x0e, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c,
+	0x79, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x5a, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x41, 0x44,
+	0x10, 0x01, 0x2a, 0x5f, 0x0a, 0x0a, 0x50, 0x61, 0x72, 0x73, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0d, 0x0a, 0x09, 0x50, 0x61, 0x72, 0x73, 0x65, 0x47, 0x72, 0x6f, 0x6b, 0x10, 0x00, 0x12,
+	0x0f, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x73, 0x65, 0x4c, 0x6f, 0x67, 0x66, 0x6d, 0x74, 0x10, 0x01,
+	0x12, 0x11, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x73, 0x65, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x46, 0x43, 0x35,
+	0x34, 0x32, 0x34, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x61, 0x72, 0x73, 0x65, 0x43, 0x45,
+	0x46, 0x10, 0x04, 0x32, 0x60, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x65, 0x72, 0x12, 0x4f, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x20,
 	0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69,
-	0x6e, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -4044,136 +7985,236 @@ func file_pipeline_proto_rawDescGZIP() []byte {
 	return file_pipeline_proto_rawDescData
 }
 
-var file_pipeline_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
-var file_pipeline_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_pipeline_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_pipeline_proto_msgTypes = make([]protoimpl.MessageInfo, 82)
 var file_pipeline_proto_goTypes = []interface{}{
 	(ProcessType)(0),                // 0: pipeline.ProcessType
 	(ExternalType)(0),               // 1: pipeline.ExternalType
 	(AggregationType)(0),            // 2: pipeline.AggregationType
 	(TransformationType)(0),         // 3: pipeline.TransformationType
-	(OperatorType)(0),               // 4: pipeline.OperatorType
-	(ExistsOperator)(0),             // 5: pipeline.ExistsOperator
-	(UnaryOperator)(0),              // 6: pipeline.UnaryOperator
-	(BinaryOperator)(0),             // 7: pipeline.BinaryOperator
-	(LogicalOperator)(0),            // 8: pipeline.LogicalOperator
-	(ComparatorOperator)(0),         // 9: pipeline.ComparatorOperator
-	(*PipelinesCreateRequest)(nil),  // 10: pipeline.PipelinesCreateRequest
-	(*PipelinesCreateResponse)(nil), // 11: pipeline.PipelinesCreateResponse
-	(*ProcessInstrumentation)(nil),  // 12: pipeline.ProcessInstrumentation
-	(*Pipelines)(nil),               // 13: pipeline.Pipelines
-	(*Pipeline)(nil),                // 14: pipeline.Pipeline
-	(*RetryStrategy)(nil),           // 15: pipeline.RetryStrategy
-	(*PipelineProcess)(nil),         // 16: pipeline.PipelineProcess
-	(*ProcessDefinition)(nil),       // 17: pipeline.ProcessDefinition
-	(*Entwine)(nil),                 // 18: pipeline.Entwine
-	(*Annotator)(nil),               // 19: pipeline.Annotator
-	(*Annotation)(nil),              // 20: pipeline.Annotation
-	(*Aggregator)(nil),              // 21: pipeline.Aggregator
-	(*Aggregation)(nil),             // 22: pipeline.Aggregation
-	(*Completer)(nil),               // 23: pipeline.Completer
-	(*Completion)(nil),              // 24: pipeline.Completion
-	(*Filter)(nil),                  // 25: pipeline.Filter
-	(*Checkpoint)(nil),              // 26: pipeline.Checkpoint
-	(*Spawner)(nil),                 // 27: pipeline.Spawner
-	(*Runnable)(nil),                // 28: pipeline.Runnable
-	(*Job)(nil),                     // 29: pipeline.Job
-	(*Tee)(nil),                     // 30: pipeline.Tee
-	(*Continuation)(nil),            // 31: pipeline.Continuation
-	(*Transformer)(nil),             // 32: pipeline.Transformer
-	(*TransformerSpec)(nil),         // 33: pipeline.TransformerSpec
-	(*MapArgs)(nil),                 // 34: pipeline.MapArgs
-	(*MapAddArgs)(nil),              // 35: pipeline.MapAddArgs
-	(*MapMultArgs)(nil),             // 36: pipeline.MapMultArgs
-	(*LeftFoldArgs)(nil),            // 37: pipeline.LeftFoldArgs
-	(*RightFoldArgs)(nil),           // 38: pipeline.RightFoldArgs
-	(*MapRegexArgs)(nil),            // 39: pipeline.MapRegexArgs
-	(*Transformation)(nil),          // 40: pipeline.Transformation
-	(*ExistsOperation)(nil),         // 41: pipeline.ExistsOperation
-	(*ExistsExpression)(nil),        // 42: pipeline.ExistsExpression
-	(*BooleanExpression)(nil),       // 43: pipeline.BooleanExpression
-	(*Variable)(nil),                // 44: pipeline.Variable
-	(*Operand)(nil),                 // 45: pipeline.Operand
-	(*ComparatorExpression)(nil),    // 46: pipeline.ComparatorExpression
-	(*LogicalExpression)(nil),       // 47: pipeline.LogicalExpression
-	(*BinaryExpression)(nil),        // 48: pipeline.BinaryExpression
-	(*UnaryExpression)(nil),         // 49: pipeline.UnaryExpression
-	(*Expression)(nil),              // 50: pipeline.Expression
-	(*Condition)(nil),               // 51: pipeline.Condition
-	(*External)(nil),                // 52: pipeline.External
-	(*_struct.Struct)(nil),          // 53: google.protobuf.Struct
+	(FoldType)(0),                   // 4: pipeline.FoldType
+	(OperatorType)(0),               // 5: pipeline.OperatorType
+	(ExistsOperator)(0),             // 6: pipeline.ExistsOperator
+	(UnaryOperator)(0),              // 7: pipeline.UnaryOperator
+	(BinaryOperator)(0),             // 8: pipeline.BinaryOperator
+	(LogicalOperator)(0),            // 9: pipeline.LogicalOperator
+	(ComparatorOperator)(0),         // 10: pipeline.ComparatorOperator
+	(AnomalyScoring)(0),             // 11: pipeline.AnomalyScoring
+	(ParserType)(0),                 // 12: pipeline.ParserType
+	(*PipelinesCreateRequest)(nil),  // 13: pipeline.PipelinesCreateRequest
+	(*PipelinesCreateResponse)(nil), // 14: pipeline.PipelinesCreateResponse
+	(*ProcessInstrumentation)(nil),  // 15: pipeline.ProcessInstrumentation
+	(*Pipelines)(nil),               // 16: pipeline.Pipelines
+	(*Pipeline)(nil),                // 17: pipeline.Pipeline
+	(*RetryStrategy)(nil),           // 18: pipeline.RetryStrategy
+	(*PipelineProcess)(nil),         // 19: pipeline.PipelineProcess
+	(*ProcessDefinition)(nil),       // 20: pipeline.ProcessDefinition
+	(*Absence)(nil),                 // 21: pipeline.Absence
+	(*ChangeDetector)(nil),          // 22: pipeline.ChangeDetector
+	(*ChangeField)(nil),             // 23: pipeline.ChangeField
+	(*AnomalyDetector)(nil),         // 24: pipeline.AnomalyDetector
+	(*Parser)(nil),                  // 25: pipeline.Parser
+	(*Script)(nil),                  // 26: pipeline.Script
+	(*Entwine)(nil),                 // 27: pipeline.Entwine
+	(*Annotator)(nil),               // 28: pipeline.Annotator
+	(*Annotation)(nil),              // 29: pipeline.Annotation
+	(*Aggregator)(nil),              // 30: pipeline.Aggregator
+	(*Aggregation)(nil),             // 31: pipeline.Aggregation
+	(*Completer)(nil),               // 32: pipeline.Completer
+	(*Completion)(nil),              // 33: pipeline.Completion
+	(*Filter)(nil),                  // 34: pipeline.Filter
+	(*Checkpoint)(nil),              // 35: pipeline.Checkpoint
+	(*Spawner)(nil),                 // 36: pipeline.Spawner
+	(*Runnable)(nil),                // 37: pipeline.Runnable
+	(*Sandbox)(nil),                 // 38: pipeline.Sandbox
+	(*Credential)(nil),              // 39: pipeline.Credential
+	(*CoProcess)(nil),               // 40: pipeline.CoProcess
+	(*Job)(nil),                     // 41: pipeline.Job
+	(*JobRetry)(nil),                // 42: pipeline.JobRetry
+	(*HttpJob)(nil),                 // 43: pipeline.HttpJob
+	(*GrpcJob)(nil),                 // 44: pipeline.GrpcJob
+	(*Tee)(nil),                     // 45: pipeline.Tee
+	(*PubSubOutput)(nil),            // 46: pipeline.PubSubOutput
+	(*HttpOutput)(nil),              // 47: pipeline.HttpOutput
+	(*HttpAuth)(nil),                // 48: pipeline.HttpAuth
+	(*HttpBasicAuth)(nil),           // 49: pipeline.HttpBasicAuth
+	(*HttpBearerAuth)(nil),          // 50: pipeline.HttpBearerAuth
+	(*HttpHmacAuth)(nil),            // 51: pipeline.HttpHmacAuth
+	(*HttpTls)(nil),                 // 52: pipeline.HttpTls
+	(*RollingFileOutput)(nil),       // 53: pipeline.RollingFileOutput
+	(*ObjectOutput)(nil),            // 54: pipeline.ObjectOutput
+	(*TeeFormat)(nil),               // 55: pipeline.TeeFormat
+	(*TeeDelivery)(nil),             // 56: pipeline.TeeDelivery
+	(*TeeRetry)(nil),                // 57: pipeline.TeeRetry
+	(*TeeCircuitBreaker)(nil),       // 58: pipeline.TeeCircuitBreaker
+	(*TeeBuffer)(nil),               // 59: pipeline.TeeBuffer
+	(*Continuation)(nil),            // 60: pipeline.Continuation
+	(*Transformer)(nil),             // 61: pipeline.Transformer
+	(*TransformerSpec)(nil),         // 62: pipeline.TransformerSpec
+	(*MapArgs)(nil),                 // 63: pipeline.MapArgs
+	(*MapAddArgs)(nil),              // 64: pipeline.MapAddArgs
+	(*MapMultArgs)(nil),             // 65: pipeline.MapMultArgs
+	(*LeftFoldArgs)(nil),            // 66: pipeline.LeftFoldArgs
+	(*RightFoldArgs)(nil),           // 67: pipeline.RightFoldArgs
+	(*MapRegexArgs)(nil),            // 68: pipeline.MapRegexArgs
+	(*Transformation)(nil),          // 69: pipeline.Transformation
+	(*ExistsOperation)(nil),         // 70: pipeline.ExistsOperation
+	(*ExistsExpression)(nil),        // 71: pipeline.ExistsExpression
+	(*BooleanExpression)(nil),       // 72: pipeline.BooleanExpression
+	(*Variable)(nil),                // 73: pipeline.Variable
+	(*Operand)(nil),                 // 74: pipeline.Operand
+	(*ComparatorExpression)(nil),    // 75: pipeline.ComparatorExpression
+	(*LogicalExpression)(nil),       // 76: pipeline.LogicalExpression
+	(*BinaryExpression)(nil),        // 77: pipeline.BinaryExpression
+	(*UnaryExpression)(nil),         // 78: pipeline.UnaryExpression
+	(*Expression)(nil),              // 79: pipeline.Expression
+	(*Condition)(nil),               // 80: pipeline.Condition
+	(*External)(nil),                // 81: pipeline.External
+	(*Source)(nil),                  // 82: pipeline.Source
+	(*HttpSource)(nil),              // 83: pipeline.HttpSource
+	(*SubscriberSource)(nil),        // 84: pipeline.SubscriberSource
+	(*ListenerSource)(nil),          // 85: pipeline.ListenerSource
+	(*ScheduleSource)(nil),          // 86: pipeline.ScheduleSource
+	(*ObjectStoreSource)(nil),       // 87: pipeline.ObjectStoreSource
+	(*FileTailSource)(nil),          // 88: pipeline.FileTailSource
+	(*TailMultiline)(nil),           // 89: pipeline.TailMultiline
+	nil,                             // 90: pipeline.Parser.PatternDefinitionsEntry
+	nil,                             // 91: pipeline.HttpJob.HeadersEntry
+	nil,                             // 92: pipeline.GrpcJob.MetadataEntry
+	nil,                             // 93: pipeline.HttpOutput.HeadersEntry
+	nil,                             // 94: pipeline.ObjectStoreSource.CsvHeaderMappingEntry
+	(*_struct.Struct)(nil),          // 95: google.protobuf.Struct
 }
 var file_pipeline_proto_depIdxs = []int32{
-	13, // 0: pipeline.PipelinesCreateRequest.pipelines:type_name -> pipeline.Pipelines
-	14, // 1: pipeline.Pipelines.pipelines:type_name -> pipeline.Pipeline
-	17, // 2: pipeline.Pipelines.processDefinitions:type_name -> pipeline.ProcessDefinition
-	52, // 3: pipeline.Pipelines.externalSystems:type_name -> pipeline.External
-	16, // 4: pipeline.Pipeline.processes:type_name -> pipeline.PipelineProcess
-	26, // 5: pipeline.Pipeline.checkpoint:type_name -> pipeline.Checkpoint
-	15, // 6: pipeline.PipelineProcess.retryStrategy:type_name -> pipeline.RetryStrategy
-	12, // 7: pipeline.PipelineProcess.instrumentation:type_name -> pipeline.ProcessInstrumentation
-	19, // 8: pipeline.ProcessDefinition.annotator:type_name -> pipeline.Annotator
-	21, // 9: pipeline.ProcessDefinition.aggregator:type_name -> pipeline.Aggregator
-	23, // 10: pipeline.ProcessDefinition.completer:type_name -> pipeline.Completer
-	25, // 11: pipeline.ProcessDefinition.filter:type_name -> pipeline.Filter
-	27, // 12: pipeline.ProcessDefinition.spawner:type_name -> pipeline.Spawner
-	30, // 13: pipeline.ProcessDefinition.tee:type_name -> pipeline.Tee
-	32, // 14: pipeline.ProcessDefinition.transformer:type_name -> pipeline.Transformer
-	31, // 15: pipeline.ProcessDefinition.continuation:type_name -> pipeline.Continuation
-	18, // 16: pipeline.ProcessDefinition.entwine:type_name -> pipeline.Entwine
-	51, // 17: pipeline.Entwine.condition:type_name -> pipeline.Condition
-	20, // 18: pipeline.Annotator.annotations:type_name -> pipeline.Annotation
-	51, // 19: pipeline.Annotation.condition:type_name -> pipeline.Condition
-	51, // 20: pipeline.Aggregator.condition:type_name -> pipeline.Condition
-	22, // 21: pipeline.Aggregator.aggregation:type_name -> pipeline.Aggregation
-	2,  // 22: pipeline.Aggregation.aggregationType:type_name -> pipeline.AggregationType
-	51, // 23: pipeline.Completer.condition:type_name -> pipeline.Condition
-	24, // 24: pipeline.Completer.completion:type_name -> pipeline.Completion
-	51, // 25: pipeline.Spawner.condition:type_name -> pipeline.Condition
-	29, // 26: pipeline.Spawner.job:type_name -> pipeline.Job
-	28, // 27: pipeline.Job.runnable:type_name -> pipeline.Runnable
-	51, // 28: pipeline.Tee.condition:type_name -> pipeline.Condition
-	53, // 29: pipeline.Tee.additionalBody:type_name -> google.protobuf.Struct
-	51, // 30: pipeline.Continuation.condition:type_name -> pipeline.Condition
-	33, // 31: pipeline.Transformer.specs:type_name -> pipeline.TransformerSpec
-	40, // 32: pipeline.TransformerSpec.transformation:type_name -> pipeline.Transformation
-	51, // 33: pipeline.Transformation.condition:type_name -> pipeline.Condition
-	3,  // 34: pipeline.Transformation.transformationType:type_name -> pipeline.TransformationType
-	34, // 35: pipeline.Transformation.mapArgs:type_name -> pipeline.MapArgs
-	35, // 36: pipeline.Transformation.mapAddArgs:type_name -> pipeline.MapAddArgs
-	36, // 37: pipeline.Transformation.mapMultArgs:type_name -> pipeline.MapMultArgs
-	39, // 38: pipeline.Transformation.mapRegexArgs:type_name -> pipeline.MapRegexArgs
-	37, // 39: pipeline.Transformation.leftFoldArgs:type_name -> pipeline.LeftFoldArgs
-	38, // 40: pipeline.Transformation.rightFoldArgs:type_name -> pipeline.RightFoldArgs
-	5,  // 41: pipeline.ExistsOperation.op:type_name -> pipeline.ExistsOperator
-	41, // 42: pipeline.ExistsExpression.ops:type_name -> pipeline.ExistsOperation
-	50, // 43: pipeline.Operand.expression:type_name -> pipeline.Expression
-	44, // 44: pipeline.Operand.variable:type_name -> pipeline.Variable
-	45, // 45: pipeline.ComparatorExpression.lhs:type_name -> pipeline.Operand
-	45, // 46: pipeline.ComparatorExpression.rhs:type_name -> pipeline.Operand
-	9,  // 47: pipeline.ComparatorExpression.op:type_name -> pipeline.ComparatorOperator
-	45, // 48: pipeline.LogicalExpression.lhs:type_name -> pipeline.Operand
-	45, // 49: pipeline.LogicalExpression.rhs:type_name -> pipeline.Operand
-	8,  // 50: pipeline.LogicalExpression.op:type_name -> pipeline.LogicalOperator
-	45, // 51: pipeline.BinaryExpression.lhs:type_name -> pipeline.Operand
-	45, // 52: pipeline.BinaryExpression.rhs:type_name -> pipeline.Operand
-	7,  // 53: pipeline.BinaryExpression.op:type_name -> pipeline.BinaryOperator
-	45, // 54: pipeline.UnaryExpression.rhs:type_name -> pipeline.Operand
-	6,  // 55: pipeline.UnaryExpression.op:type_name -> pipeline.UnaryOperator
-	43, // 56: pipeline.Expression.boolean:type_name -> pipeline.BooleanExpression
-	46, // 57: pipeline.Expression.comparator:type_name -> pipeline.ComparatorExpression
-	47, // 58: pipeline.Expression.logical:type_name -> pipeline.LogicalExpression
-	48, // 59: pipeline.Expression.binary:type_name -> pipeline.BinaryExpression
-	49, // 60: pipeline.Expression.unary:type_name -> pipeline.UnaryExpression
-	50, // 61: pipeline.Condition.expression:type_name -> pipeline.Expression
-	42, // 62: pipeline.Condition.exists:type_name -> pipeline.ExistsExpression
-	1,  // 63: pipeline.External.externalType:type_name -> pipeline.ExternalType
-	10, // 64: pipeline.ConfigBuilder.Create:input_type -> pipeline.PipelinesCreateRequest
-	11, // 65: pipeline.ConfigBuilder.Create:output_type -> pipeline.PipelinesCreateResponse
-	65, // [65:66] is the sub-list for method output_type
-	64, // [64:65] is the sub-list for method input_type
-	64, // [64:64] is the sub-list for extension type_name
-	64, // [64:64] is the sub-list for extension extendee
-	0,  // [0:64] is the sub-list for field type_name
+	16,  // 0: pipeline.PipelinesCreateRequest.pipelines:type_name -> pipeline.Pipelines
+	17,  // 1: pipeline.Pipelines.pipelines:type_name -> pipeline.Pipeline
+	20,  // 2: pipeline.Pipelines.processDefinitions:type_name -> pipeline.ProcessDefinition
+	81,  // 3: pipeline.Pipelines.externalSystems:type_name -> pipeline.External
+	82,  // 4: pipeline.Pipelines.sources:type_name -> pipeline.Source
+	19,  // 5: pipeline.Pipeline.processes:type_name -> pipeline.PipelineProcess
+	35,  // 6: pipeline.Pipeline.checkpoint:type_name -> pipeline.Checkpoint
+	18,  // 7: pipeline.PipelineProcess.retryStrategy:type_name -> pipeline.RetryStrategy
+	15,  // 8: pipeline.PipelineProcess.instrumentation:type_name -> pipeline.ProcessInstrumentation
+	28,  // 9: pipeline.ProcessDefinition.annotator:type_name -> pipeline.Annotator
+	30,  // 10: pipeline.ProcessDefinition.aggregator:type_name -> pipeline.Aggregator
+	32,  // 11: pipeline.ProcessDefinition.completer:type_name -> pipeline.Completer
+	34,  // 12: pipeline.ProcessDefinition.filter:type_name -> pipeline.Filter
+	36,  // 13: pipeline.ProcessDefinition.spawner:type_name -> pipeline.Spawner
+	45,  // 14: pipeline.ProcessDefinition.tee:type_name -> pipeline.Tee
+	61,  // 15: pipeline.ProcessDefinition.transformer:type_name -> pipeline.Transformer
+	60,  // 16: pipeline.ProcessDefinition.continuation:type_name -> pipeline.Continuation
+	27,  // 17: pipeline.ProcessDefinition.entwine:type_name -> pipeline.Entwine
+	26,  // 18: pipeline.ProcessDefinition.script:type_name -> pipeline.Script
+	25,  // 19: pipeline.ProcessDefinition.parser:type_name -> pipeline.Parser
+	21,  // 20: pipeline.ProcessDefinition.absence:type_name -> pipeline.Absence
+	22,  // 21: pipeline.ProcessDefinition.changeDetector:type_name -> pipeline.ChangeDetector
+	24,  // 22: pipeline.ProcessDefinition.anomalyDetector:type_name -> pipeline.AnomalyDetector
+	80,  // 23: pipeline.Absence.condition:type_name -> pipeline.Condition
+	80,  // 24: pipeline.ChangeDetector.condition:type_name -> pipeline.Condition
+	23,  // 25: pipeline.ChangeDetector.fields:type_name -> pipeline.ChangeField
+	80,  // 26: pipeline.AnomalyDetector.condition:type_name -> pipeline.Condition
+	11,  // 27: pipeline.AnomalyDetector.scoring:type_name -> pipeline.AnomalyScoring
+	80,  // 28: pipeline.Parser.condition:type_name -> pipeline.Condition
+	12,  // 29: pipeline.Parser.parserType:type_name -> pipeline.ParserType
+	90,  // 30: pipeline.Parser.patternDefinitions:type_name -> pipeline.Parser.PatternDefinitionsEntry
+	80,  // 31: pipeline.Script.condition:type_name -> pipeline.Condition
+	80,  // 32: pipeline.Entwine.condition:type_name -> pipeline.Condition
+	29,  // 33: pipeline.Annotator.annotations:type_name -> pipeline.Annotation
+	80,  // 34: pipeline.Annotation.condition:type_name -> pipeline.Condition
+	80,  // 35: pipeline.Aggregator.condition:type_name -> pipeline.Condition
+	31,  // 36: pipeline.Aggregator.aggregation:type_name -> pipeline.Aggregation
+	2,   // 37: pipeline.Aggregation.aggregationType:type_name -> pipeline.AggregationType
+	80,  // 38: pipeline.Completer.condition:type_name -> pipeline.Condition
+	33,  // 39: pipeline.Completer.completion:type_name -> pipeline.Completion
+	80,  // 40: pipeline.Spawner.condition:type_name -> pipeline.Condition
+	41,  // 41: pipeline.Spawner.job:type_name -> pipeline.Job
+	40,  // 42: pipeline.Runnable.coProcess:type_name -> pipeline.CoProcess
+	38,  // 43: pipeline.Runnable.sandbox:type_name -> pipeline.Sandbox
+	39,  // 44: pipeline.Sandbox.credential:type_name -> pipeline.Credential
+	37,  // 45: pipeline.Job.runnable:type_name -> pipeline.Runnable
+	43,  // 46: pipeline.Job.http:type_name -> pipeline.HttpJob
+	44,  // 47: pipeline.Job.grpc:type_name -> pipeline.GrpcJob
+	42,  // 48: pipeline.Job.retry:type_name -> pipeline.JobRetry
+	91,  // 49: pipeline.HttpJob.headers:type_name -> pipeline.HttpJob.HeadersEntry
+	92,  // 50: pipeline.GrpcJob.metadata:type_name -> pipeline.GrpcJob.MetadataEntry
+	80,  // 51: pipeline.Tee.condition:type_name -> pipeline.Condition
+	95,  // 52: pipeline.Tee.additionalBody:type_name -> google.protobuf.Struct
+	56,  // 53: pipeline.Tee.delivery:type_name -> pipeline.TeeDelivery
+	55,  // 54: pipeline.Tee.format:type_name -> pipeline.TeeFormat
+	54,  // 55: pipeline.Tee.objectOutput:type_name -> pipeline.ObjectOutput
+	53,  // 56: pipeline.Tee.rollingFile:type_name -> pipeline.RollingFileOutput
+	47,  // 57: pipeline.Tee.httpOutput:type_name -> pipeline.HttpOutput
+	46,  // 58: pipeline.Tee.pubSubOutput:type_name -> pipeline.PubSubOutput
+	93,  // 59: pipeline.HttpOutput.headers:type_name -> pipeline.HttpOutput.HeadersEntry
+	48,  // 60: pipeline.HttpOutput.auth:type_name -> pipeline.HttpAuth
+	52,  // 61: pipeline.HttpOutput.tls:type_name -> pipeline.HttpTls
+	49,  // 62: pipeline.HttpAuth.basic:type_name -> pipeline.HttpBasicAuth
+	50,  // 63: pipeline.HttpAuth.bearer:type_name -> pipeline.HttpBearerAuth
+	51,  // 64: pipeline.HttpAuth.hmac:type_name -> pipeline.HttpHmacAuth
+	57,  // 65: pipeline.TeeDelivery.retry:type_name -> pipeline.TeeRetry
+	58,  // 66: pipeline.TeeDelivery.circuitBreaker:type_name -> pipeline.TeeCircuitBreaker
+	59,  // 67: pipeline.TeeDelivery.buffer:type_name -> pipeline.TeeBuffer
+	80,  // 68: pipeline.Continuation.condition:type_name -> pipeline.Condition
+	62,  // 69: pipeline.Transformer.specs:type_name -> pipeline.TransformerSpec
+	69,  // 70: pipeline.TransformerSpec.transformation:type_name -> pipeline.Transformation
+	40,  // 71: pipeline.MapArgs.coProcess:type_name -> pipeline.CoProcess
+	38,  // 72: pipeline.MapArgs.sandbox:type_name -> pipeline.Sandbox
+	4,   // 73: pipeline.LeftFoldArgs.foldType:type_name -> pipeline.FoldType
+	80,  // 74: pipeline.LeftFoldArgs.condition:type_name -> pipeline.Condition
+	40,  // 75: pipeline.LeftFoldArgs.coProcess:type_name -> pipeline.CoProcess
+	38,  // 76: pipeline.LeftFoldArgs.sandbox:type_name -> pipeline.Sandbox
+	4,   // 77: pipeline.RightFoldArgs.foldType:type_name -> pipeline.FoldType
+	80,  // 78: pipeline.RightFoldArgs.condition:type_name -> pipeline.Condition
+	40,  // 79: pipeline.RightFoldArgs.coProcess:type_name -> pipeline.CoProcess
+	38,  // 80: pipeline.RightFoldArgs.sandbox:type_name -> pipeline.Sandbox
+	80,  // 81: pipeline.Transformation.condition:type_name -> pipeline.Condition
+	3,   // 82: pipeline.Transformation.transformationType:type_name -> pipeline.TransformationType
+	63,  // 83: pipeline.Transformation.mapArgs:type_name -> pipeline.MapArgs
+	64,  // 84: pipeline.Transformation.mapAddArgs:type_name -> pipeline.MapAddArgs
+	65,  // 85: pipeline.Transformation.mapMultArgs:type_name -> pipeline.MapMultArgs
+	68,  // 86: pipeline.Transformation.mapRegexArgs:type_name -> pipeline.MapRegexArgs
+	66,  // 87: pipeline.Transformation.leftFoldArgs:type_name -> pipeline.LeftFoldArgs
+	67,  // 88: pipeline.Transformation.rightFoldArgs:type_name -> pipeline.RightFoldArgs
+	6,   // 89: pipeline.ExistsOperation.op:type_name -> pipeline.ExistsOperator
+	70,  // 90: pipeline.ExistsExpression.ops:type_name -> pipeline.ExistsOperation
+	79,  // 91: pipeline.Operand.expression:type_name -> pipeline.Expression
+	73,  // 92: pipeline.Operand.variable:type_name -> pipeline.Variable
+	74,  // 93: pipeline.ComparatorExpression.lhs:type_name -> pipeline.Operand
+	74,  // 94: pipeline.ComparatorExpression.rhs:type_name -> pipeline.Operand
+	10,  // 95: pipeline.ComparatorExpression.op:type_name -> pipeline.ComparatorOperator
+	74,  // 96: pipeline.LogicalExpression.lhs:type_name -> pipeline.Operand
+	74,  // 97: pipeline.LogicalExpression.rhs:type_name -> pipeline.Operand
+	9,   // 98: pipeline.LogicalExpression.op:type_name -> pipeline.LogicalOperator
+	74,  // 99: pipeline.BinaryExpression.lhs:type_name -> pipeline.Operand
+	74,  // 100: pipeline.BinaryExpression.rhs:type_name -> pipeline.Operand
+	8,   // 101: pipeline.BinaryExpression.op:type_name -> pipeline.BinaryOperator
+	74,  // 102: pipeline.UnaryExpression.rhs:type_name -> pipeline.Operand
+	7,   // 103: pipeline.UnaryExpression.op:type_name -> pipeline.UnaryOperator
+	72,  // 104: pipeline.Expression.boolean:type_name -> pipeline.BooleanExpression
+	75,  // 105: pipeline.Expression.comparator:type_name -> pipeline.ComparatorExpression
+	76,  // 106: pipeline.Expression.logical:type_name -> pipeline.LogicalExpression
+	77,  // 107: pipeline.Expression.binary:type_name -> pipeline.BinaryExpression
+	78,  // 108: pipeline.Expression.unary:type_name -> pipeline.UnaryExpression
+	79,  // 109: pipeline.Condition.expression:type_name -> pipeline.Expression
+	71,  // 110: pipeline.Condition.exists:type_name -> pipeline.ExistsExpression
+	1,   // 111: pipeline.External.externalType:type_name -> pipeline.ExternalType
+	83,  // 112: pipeline.Source.http:type_name -> pipeline.HttpSource
+	84,  // 113: pipeline.Source.subscriber:type_name -> pipeline.SubscriberSource
+	88,  // 114: pipeline.Source.fileTail:type_name -> pipeline.FileTailSource
+	85,  // 115: pipeline.Source.syslog:type_name -> pipeline.ListenerSource
+	85,  // 116: pipeline.Source.jsonLines:type_name -> pipeline.ListenerSource
+	87,  // 117: pipeline.Source.objectStore:type_name -> pipeline.ObjectStoreSource
+	86,  // 118: pipeline.Source.schedule:type_name -> pipeline.ScheduleSource
+	95,  // 119: pipeline.ScheduleSource.payload:type_name -> google.protobuf.Struct
+	94,  // 120: pipeline.ObjectStoreSource.csvHeaderMapping:type_name -> pipeline.ObjectStoreSource.CsvHeaderMappingEntry
+	89,  // 121: pipeline.FileTailSource.multiline:type_name -> pipeline.TailMultiline
+	13,  // 122: pipeline.ConfigBuilder.Create:input_type -> pipeline.PipelinesCreateRequest
+	14,  // 123: pipeline.ConfigBuilder.Create:output_type -> pipeline.PipelinesCreateResponse
+	123, // [123:124] is the sub-list for method output_type
+	122, // [122:123] is the sub-list for method input_type
+	122, // [122:122] is the sub-list for extension type_name
+	122, // [122:122] is the sub-list for extension extendee
+	0,   // [0:122] is the sub-list for field type_name
 }
 
 func init() { file_pipeline_proto_init() }
@@ -4194,8 +8235,224 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PipelinesCreateResponse); i {
+		file_pipeline_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PipelinesCreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessInstrumentation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pipelines); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pipeline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryStrategy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PipelineProcess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessDefinition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Absence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeDetector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnomalyDetector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Parser); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Script); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Entwine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Annotator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Annotation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Aggregator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Aggregation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Completer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4206,8 +8463,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessInstrumentation); i {
+		file_pipeline_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Completion); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4218,8 +8475,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pipelines); i {
+		file_pipeline_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Filter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4230,8 +8487,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pipeline); i {
+		file_pipeline_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Checkpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4242,8 +8499,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetryStrategy); i {
+		file_pipeline_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Spawner); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4254,8 +8511,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PipelineProcess); i {
+		file_pipeline_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Runnable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4266,8 +8523,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessDefinition); i {
+		file_pipeline_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sandbox); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4278,8 +8535,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Entwine); i {
+		file_pipeline_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Credential); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4290,8 +8547,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Annotator); i {
+		file_pipeline_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CoProcess); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4302,8 +8559,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Annotation); i {
+		file_pipeline_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Job); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4314,8 +8571,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Aggregator); i {
+		file_pipeline_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobRetry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4326,8 +8583,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Aggregation); i {
+		file_pipeline_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpJob); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4338,8 +8595,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Completer); i {
+		file_pipeline_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GrpcJob); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4350,8 +8607,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Completion); i {
+		file_pipeline_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tee); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4362,8 +8619,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Filter); i {
+		file_pipeline_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PubSubOutput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4374,8 +8631,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Checkpoint); i {
+		file_pipeline_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpOutput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4386,8 +8643,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Spawner); i {
+		file_pipeline_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpAuth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4398,8 +8655,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Runnable); i {
+		file_pipeline_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpBasicAuth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4410,8 +8667,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Job); i {
+		file_pipeline_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpBearerAuth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4422,8 +8679,8 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tee); i {
+		file_pipeline_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpHmacAuth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4434,7 +8691,103 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpTls); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollingFileOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ObjectOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TeeFormat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TeeDelivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TeeRetry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TeeCircuitBreaker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TeeBuffer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Continuation); i {
 			case 0:
 				return &v.state
@@ -4446,7 +8799,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Transformer); i {
 			case 0:
 				return &v.state
@@ -4458,7 +8811,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TransformerSpec); i {
 			case 0:
 				return &v.state
@@ -4470,7 +8823,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MapArgs); i {
 			case 0:
 				return &v.state
@@ -4482,7 +8835,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MapAddArgs); i {
 			case 0:
 				return &v.state
@@ -4494,7 +8847,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MapMultArgs); i {
 			case 0:
 				return &v.state
@@ -4506,7 +8859,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LeftFoldArgs); i {
 			case 0:
 				return &v.state
@@ -4518,7 +8871,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RightFoldArgs); i {
 			case 0:
 				return &v.state
@@ -4530,7 +8883,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MapRegexArgs); i {
 			case 0:
 				return &v.state
@@ -4542,7 +8895,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Transformation); i {
 			case 0:
 				return &v.state
@@ -4554,7 +8907,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ExistsOperation); i {
 			case 0:
 				return &v.state
@@ -4566,7 +8919,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ExistsExpression); i {
 			case 0:
 				return &v.state
@@ -4578,7 +8931,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BooleanExpression); i {
 			case 0:
 				return &v.state
@@ -4590,7 +8943,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Variable); i {
 			case 0:
 				return &v.state
@@ -4602,7 +8955,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Operand); i {
 			case 0:
 				return &v.state
@@ -4614,7 +8967,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ComparatorExpression); i {
 			case 0:
 				return &v.state
@@ -4626,7 +8979,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LogicalExpression); i {
 			case 0:
 				return &v.state
@@ -4638,7 +8991,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BinaryExpression); i {
 			case 0:
 				return &v.state
@@ -4650,7 +9003,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UnaryExpression); i {
 			case 0:
 				return &v.state
@@ -4662,7 +9015,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Expression); i {
 			case 0:
 				return &v.state
@@ -4674,7 +9027,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Condition); i {
 			case 0:
 				return &v.state
@@ -4686,7 +9039,7 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
-		file_pipeline_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+		file_pipeline_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*External); i {
 			case 0:
 				return &v.state
@@ -4698,6 +9051,102 @@ func file_pipeline_proto_init() {
 				return nil
 			}
 		}
+		file_pipeline_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Source); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListenerSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ObjectStoreSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileTailSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pipeline_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TailMultiline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_pipeline_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*ProcessDefinition_Annotator)(nil),
@@ -4709,8 +9158,13 @@ func file_pipeline_proto_init() {
 		(*ProcessDefinition_Transformer)(nil),
 		(*ProcessDefinition_Continuation)(nil),
 		(*ProcessDefinition_Entwine)(nil),
+		(*ProcessDefinition_Script)(nil),
+		(*ProcessDefinition_Parser)(nil),
+		(*ProcessDefinition_Absence)(nil),
+		(*ProcessDefinition_ChangeDetector)(nil),
+		(*ProcessDefinition_AnomalyDetector)(nil),
 	}
-	file_pipeline_proto_msgTypes[30].OneofWrappers = []interface{}{
+	file_pipeline_proto_msgTypes[56].OneofWrappers = []interface{}{
 		(*Transformation_MapArgs)(nil),
 		(*Transformation_MapAddArgs)(nil),
 		(*Transformation_MapMultArgs)(nil),
@@ -4718,30 +9172,39 @@ func file_pipeline_proto_init() {
 		(*Transformation_LeftFoldArgs)(nil),
 		(*Transformation_RightFoldArgs)(nil),
 	}
-	file_pipeline_proto_msgTypes[35].OneofWrappers = []interface{}{
+	file_pipeline_proto_msgTypes[61].OneofWrappers = []interface{}{
 		(*Operand_Expression)(nil),
 		(*Operand_Variable)(nil),
 		(*Operand_Literal)(nil),
 		(*Operand_Numeric)(nil),
 	}
-	file_pipeline_proto_msgTypes[40].OneofWrappers = []interface{}{
+	file_pipeline_proto_msgTypes[66].OneofWrappers = []interface{}{
 		(*Expression_Boolean)(nil),
 		(*Expression_Comparator)(nil),
 		(*Expression_Logical)(nil),
 		(*Expression_Binary)(nil),
 		(*Expression_Unary)(nil),
 	}
-	file_pipeline_proto_msgTypes[41].OneofWrappers = []interface{}{
+	file_pipeline_proto_msgTypes[67].OneofWrappers = []interface{}{
 		(*Condition_Expression)(nil),
 		(*Condition_Exists)(nil),
 	}
+	file_pipeline_proto_msgTypes[69].OneofWrappers = []interface{}{
+		(*Source_Http)(nil),
+		(*Source_Subscriber)(nil),
+		(*Source_FileTail)(nil),
+		(*Source_Syslog)(nil),
+		(*Source_JsonLines)(nil),
+		(*Source_ObjectStore)(nil),
+		(*Source_Schedule)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pipeline_proto_rawDesc,
-			NumEnums:      10,
-			NumMessages:   43,
+			NumEnums:      13,
+			NumMessages:   82,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -18,15 +18,20 @@ require (
 	github.com/google/uuid v1.1.2
 	github.com/grpc-ecosystem/go-grpc-middleware v1.0.0
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.2.0
+	github.com/klauspost/compress v1.13.6
+	github.com/linkedin/goavro/v2 v2.10.0
 	github.com/minio/minio-go/v7 v7.0.9
+	github.com/oklog/ulid/v2 v2.0.2
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/viper v1.4.0
 	github.com/stretchr/testify v1.6.1
+	github.com/vmihailenco/msgpack/v5 v5.3.5
 	go.etcd.io/bbolt v1.3.5
 	go.opentelemetry.io/otel v0.15.0
 	go.opentelemetry.io/otel/exporters/stdout v0.15.0
 	go.opentelemetry.io/otel/exporters/trace/zipkin v0.15.0
 	go.opentelemetry.io/otel/sdk v0.15.0
+	go.starlark.net v0.0.0-20210223155950-e043a3d3c984
 	go.uber.org/zap v1.10.0
 	golang.org/x/net v0.0.0-20201224014010-6772e930b67b
 	google.golang.org/api v0.40.0
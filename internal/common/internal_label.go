@@ -20,6 +20,7 @@ const (
 	EntwineMetadataKey InternalKey = "internal:entwine:output"
 	MessageIDKey InternalKey = "internal:messageID"
 	NTPTimeKey InternalKey = "internal:ntp:time"
+	SourceKey InternalKey = "internal:source"
 )
 
 func (k InternalKey) String()string {
@@ -30,13 +31,17 @@ const (
 	AggregationDataPrefix  InternalKeyPrefix = "internal:aggregation"
 	CompletionStatusPrefix InternalKeyPrefix = "internal:completion"
 	CompletionStatePrefix  InternalKeyPrefix = "internal:completion:state"
+	ParserErrorPrefix      InternalKeyPrefix = "internal:parser:error"
+	ChangeDiffPrefix       InternalKeyPrefix = "internal:change"
+	AnomalyPrefix          InternalKeyPrefix = "internal:anomaly"
 )
 
 func (k InternalKeyPrefix) String()string {
 	return string(k)
 }
 var reservedKeys = []fmt.Stringer{PartitionIDKey, ResourceNameKey, AccumulatorKey, CheckpointIndexKey,
-	CheckpointDataKey, TeeMetadataKey, AggregationDataPrefix, CompletionStatusPrefix, MessageIDKey}
+	CheckpointDataKey, TeeMetadataKey, AggregationDataPrefix, CompletionStatusPrefix, MessageIDKey,
+	ParserErrorPrefix, SourceKey, ChangeDiffPrefix, AnomalyPrefix}
 
 func GetReservedKeys()[]fmt.Stringer {
 	return reservedKeys
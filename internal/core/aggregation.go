@@ -314,7 +314,7 @@ func NewFieldAggregation(path string, aggType AggregationType, groupByKeys []str
 func (fieldAggregation *FieldAggregation) getGroupByPath(in map[string]interface{}) []string {
 	groupByPath := []string {fmt.Sprintf("%s:%s", fieldAggregation.Key, fieldAggregation.Type.String())}
 	for _, groupByKey := range fieldAggregation.GroupByKeys {
-		if v, ok := in[groupByKey]; ok {
+		if v, err := util.LookupVariable(in, groupByKey); err == nil {
 			groupByPath = append(groupByPath, fmt.Sprintf("%v", v))
 		} else {
 			groupByPath = append(groupByPath, "nil")
@@ -323,6 +323,26 @@ func (fieldAggregation *FieldAggregation) getGroupByPath(in map[string]interface
 	return groupByPath
 }
 
+// getValues will return the values to aggregate.  Keys are paths (see util.Path); a key with a
+// wildcard aggregates every matching value.
+func (fieldAggregation *FieldAggregation) getValues(in map[string]interface{}) ([]interface{}, error) {
+	if v, ok := in[fieldAggregation.Key]; ok {
+		return []interface{}{v}, nil
+	}
+	path, err := util.NewPath(fieldAggregation.Key)
+	if err != nil {
+		return nil, err
+	}
+	val, err := path.Get(in)
+	if err != nil {
+		return nil, err
+	}
+	if path.HasWildcard() {
+		return val.([]interface{}), nil
+	}
+	return []interface{}{val}, nil
+}
+
 type Aggregation struct {
 	FieldAggregation *FieldAggregation `json:"fieldAggregation"`
 }
@@ -338,9 +358,13 @@ func (a Aggregation) Update(in map[string]interface{}, state *AggregationState)
 	var err error
 	var updatedPaths []string
 	var updatedValues []interface{}
-	flattened := util.Flatten(in)
 
-	if val, ok := flattened[a.FieldAggregation.Key]; ok {
+	vals, err := a.FieldAggregation.getValues(in)
+	if err != nil && !errors.Is(err, &util.NotFoundError{}) {
+		return nil, nil, err
+	}
+
+	if len(vals) > 0 {
 		var currVal map[string]interface{}
 		groupByPath := a.FieldAggregation.getGroupByPath(in)
 		currVal, err = state.Get(groupByPath)
@@ -375,9 +399,11 @@ func (a Aggregation) Update(in map[string]interface{}, state *AggregationState)
 		if err != nil {
 			return nil, nil, err
 		}
-		err = fieldAggregationState.Update(val)
-		if err != nil {
-			return nil, nil, err
+		for _, val := range vals {
+			err = fieldAggregationState.Update(val)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 		err = state.Update(groupByPath, fieldAggregationState)
 		if err != nil {
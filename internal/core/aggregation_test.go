@@ -116,3 +116,36 @@ func TestBasicAggregationGroupBy(t *testing.T) {
 		"bob": 6,
 	})
 }
+
+func TestAggregationWildcard(t *testing.T) {
+	in := map[string]interface{}{
+		"store": map[string]interface{}{"id": "a"},
+		"items": []interface{}{
+			map[string]interface{}{"price": float64(1)},
+			map[string]interface{}{"price": float64(2)},
+			map[string]interface{}{"name": "noprice"},
+			map[string]interface{}{"price": float64(4)},
+		},
+	}
+	aggregation := core.NewAggregation(core.NewFieldAggregation("items.*.price", core.AggSum,
+		[]string{"store.id"}))
+	aggState := core.NewAggregationState(make(map[string]interface{}))
+
+	paths, values, err := aggregation.Update(in, aggState)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"items.*.price:AggSum.a"}, paths)
+	assert.Equal(t, []interface{}{float64(7)}, values)
+
+	aggregation = core.NewAggregation(core.NewFieldAggregation("items.-1.price", core.AggMax,
+		[]string{"store.id"}))
+	paths, values, err = aggregation.Update(in, aggState)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"items.-1.price:AggMax.a"}, paths)
+	assert.Equal(t, []interface{}{float64(4)}, values)
+
+	aggregation = core.NewAggregation(core.NewFieldAggregation("items.9.price", core.AggMax, nil))
+	paths, values, err = aggregation.Update(in, aggState)
+	assert.Nil(t, err)
+	assert.Empty(t, paths)
+	assert.Empty(t, values)
+}
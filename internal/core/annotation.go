@@ -1,7 +1,19 @@
 package core
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
 	"fmt"
+	"github.com/beevik/ntp"
+	gUuid "github.com/google/uuid"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/oklog/ulid/v2"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 )
 
@@ -9,16 +21,207 @@ type DerivedValue string
 
 const (
 	CurrentTime DerivedValue = "derived:currentTime"
+	NTPTime DerivedValue = "derived:ntpTime"
+	UUID DerivedValue = "derived:uuid"
+	ULID DerivedValue = "derived:ulid"
+	Hostname DerivedValue = "derived:hostname"
+	InstanceID DerivedValue = "derived:instanceID"
+	Sequence DerivedValue = "derived:sequence"
+	ConfigVersion DerivedValue = "derived:configVersion"
 )
 
-func deriveCurrentTime(inValue string) string {
+// derivedState is the per-instance state used by the derived values
+var derivedState = struct {
+	sync.RWMutex
+	instanceID string
+	configVersion string
+	clockOffset time.Duration
+	sequence uint64
+	ulidEntropy *ulid.MonotonicEntropy
+	ulidLock sync.Mutex
+}{
+	instanceID: gUuid.New().String(),
+	ulidEntropy: ulid.Monotonic(rand.Reader, 0),
+}
+
+var hostname = func() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return name
+}()
+
+// SetInstanceID will set the value of derived:instanceID.  It defaults to a random UUID per process.
+func SetInstanceID(instanceID string) {
+	derivedState.Lock()
+	defer derivedState.Unlock()
+	derivedState.instanceID = instanceID
+}
+
+// SetConfigVersion will set the value of derived:configVersion
+func SetConfigVersion(version string) {
+	derivedState.Lock()
+	defer derivedState.Unlock()
+	derivedState.configVersion = version
+}
+
+// SetClockOffset will set the offset added to the local clock by derived:ntpTime
+func SetClockOffset(offset time.Duration) {
+	derivedState.Lock()
+	defer derivedState.Unlock()
+	derivedState.clockOffset = offset
+}
+
+// DefaultClockSyncRetryInterval is how often the clock is synced when the first sync fails and no sync
+// interval is configured
+const DefaultClockSyncRetryInterval = time.Minute
+
+// SyncClock will query the NTP server and set the clock offset used by derived:ntpTime
+func SyncClock(server string) error {
+	response, err := ntp.Query(server)
+	if err != nil {
+		return util.NewInternalError(fmt.Sprintf("NTP query to '%s' failed: %s", server, err.Error()))
+	}
+	if err = response.Validate(); err != nil {
+		return util.NewInvalidError(fmt.Sprintf("invalid NTP response from '%s': %s", server, err.Error()))
+	}
+	SetClockOffset(response.ClockOffset)
+	return nil
+}
+
+// StartClockSync will sync the clock with the NTP server every interval, until the returned function
+// is called.  Failed syncs keep the previous offset.
+func StartClockSync(server string, interval time.Duration) func() error {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = SyncClock(server)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() error {
+		close(done)
+		return nil
+	}
+}
+
+func deriveCurrentTime(inValue string) interface{} {
 	return time.Now().Format(time.RFC3339)
 }
 
-type DerivedValueGenerator func(string) string
+func deriveNTPTime(inValue string) interface{} {
+	derivedState.RLock()
+	defer derivedState.RUnlock()
+	return time.Now().Add(derivedState.clockOffset).Format(time.RFC3339)
+}
+
+func deriveUUID(inValue string) interface{} {
+	return gUuid.New().String()
+}
+
+// deriveULID returns ULIDs that are monotonically increasing within this process
+func deriveULID(inValue string) interface{} {
+	derivedState.ulidLock.Lock()
+	defer derivedState.ulidLock.Unlock()
+	return ulid.MustNew(ulid.Timestamp(time.Now()), derivedState.ulidEntropy).String()
+}
+
+func deriveHostname(inValue string) interface{} {
+	return hostname
+}
+
+func deriveInstanceID(inValue string) interface{} {
+	derivedState.RLock()
+	defer derivedState.RUnlock()
+	return derivedState.instanceID
+}
+
+// deriveSequence returns a number that is monotonically increasing within this process
+func deriveSequence(inValue string) interface{} {
+	return float64(atomic.AddUint64(&derivedState.sequence, 1))
+}
+
+func deriveConfigVersion(inValue string) interface{} {
+	derivedState.RLock()
+	defer derivedState.RUnlock()
+	return derivedState.configVersion
+}
+
+type DerivedValueGenerator func(string) interface{}
 
 var DerivedValues = map[DerivedValue]DerivedValueGenerator {
 	CurrentTime: deriveCurrentTime,
+	NTPTime: deriveNTPTime,
+	UUID: deriveUUID,
+	ULID: deriveULID,
+	Hostname: deriveHostname,
+	InstanceID: deriveInstanceID,
+	Sequence: deriveSequence,
+	ConfigVersion: deriveConfigVersion,
+}
+
+// templateFuncs exposes the derived values to templates, e.g. "{{instanceID}}-{{sequence}}", and
+// json, which encodes a value as JSON, e.g. `{"event": {{json .}}}`
+var templateFuncs = func() template.FuncMap {
+	funcs := make(template.FuncMap)
+	for value, generator := range DerivedValues {
+		generator := generator
+		funcs[strings.TrimPrefix(string(value), "derived:")] = func() interface{} {
+			return generator("")
+		}
+	}
+	funcs["json"] = func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+	return funcs
+}()
+
+// newEventTemplate will parse a template that is executed over a map.  Referencing a missing field is
+// an error.
+func newEventTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid template for '%s': %s", name, err.Error()))
+	}
+	return tmpl, nil
+}
+
+// executeEventTemplate will execute the template over the map
+func executeEventTemplate(tmpl *template.Template, in map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, in); err != nil {
+		return "", util.NewInvalidError(fmt.Sprintf("cannot render '%s': %s", tmpl.Name(), err.Error()))
+	}
+	return buf.String(), nil
+}
+
+// EventTemplate is a template that is rendered over a map, with the same functions as annotation
+// templates
+type EventTemplate struct {
+	tmpl *template.Template
+}
+
+// NewEventTemplate will parse a template, e.g. "https://example.com/{{.team}}".  Referencing a missing
+// field is an error when the template is rendered.
+func NewEventTemplate(name, text string) (*EventTemplate, error) {
+	tmpl, err := newEventTemplate(name, text)
+	if err != nil {
+		return nil, err
+	}
+	return &EventTemplate{tmpl}, nil
+}
+
+// Render will execute the template over the map
+func (t *EventTemplate) Render(in map[string]interface{}) (string, error) {
+	return executeEventTemplate(t.tmpl, in)
 }
 
 // Annotation is a conditional annotation for a map
@@ -26,9 +229,10 @@ type Annotation struct {
 	fieldKey string
 	value string
 	condition *Condition
+	template *template.Template
 }
 
-func getValue(value string) string {
+func getValue(value string) interface{} {
 	if vFunc, ok := DerivedValues[DerivedValue(value)]; ok {
 		return vFunc(value)
 	}
@@ -40,12 +244,30 @@ func getValue(value string) string {
 // added to the provided map in the Process function
 func NewAnnotation(key string, value string, condition *Condition) *Annotation {
 	return &Annotation{
-		key,
-		value,
-		condition,
+		fieldKey: key,
+		value: value,
+		condition: condition,
 	}
 }
 
+// NewTemplatedAnnotation will create a conditional Annotation whose value is a template over the
+// annotated map, e.g. "{{.device.site}}-{{.device.id}}".  Derived values are available as functions
+// (e.g. "{{hostname}}") and keys that are not identifiers can be referenced with index
+// (e.g. `{{index . "internal:name"}}`).  Referencing a missing field is an error.  Values without
+// template actions are handled in the same way as NewAnnotation.
+func NewTemplatedAnnotation(key string, value string, condition *Condition) (*Annotation, error) {
+	annotation := NewAnnotation(key, value, condition)
+	if !strings.Contains(value, "{{") {
+		return annotation, nil
+	}
+	tmpl, err := newEventTemplate(key, value)
+	if err != nil {
+		return nil, err
+	}
+	annotation.template = tmpl
+	return annotation, nil
+}
+
 // ShouldAnnotate will return true if the underlying condition is satisfied in the
 // provided map; otherwise, return false
 func (a Annotation) ShouldAnnotate(in map[string]interface{}) (bool, error) {
@@ -57,6 +279,14 @@ func (a Annotation) Annotate(in map[string]interface{}) error {
 	if _, ok := in[a.fieldKey]; ok {
 		return fmt.Errorf("field exists: cannot annotate with field '%s'", a.fieldKey)
 	}
-	in[a.fieldKey] = getValue(a.value)
+	if a.template == nil {
+		in[a.fieldKey] = getValue(a.value)
+		return nil
+	}
+	value, err := executeEventTemplate(a.template, in)
+	if err != nil {
+		return err
+	}
+	in[a.fieldKey] = value
 	return nil
-}
\ No newline at end of file
+}
@@ -1 +1,98 @@
 package core_test
+
+import (
+	"errors"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTemplatedAnnotation(t *testing.T) {
+	in := map[string]interface{}{
+		"device": map[string]interface{}{
+			"site": "sfo",
+			"id": float64(12),
+		},
+		"internal:name": "foo",
+	}
+
+	annotation, err := core.NewTemplatedAnnotation("deviceKey", "{{.device.site}}-{{.device.id}}",
+		core.TrueCondition)
+	assert.Nil(t, err)
+	assert.Nil(t, annotation.Annotate(in))
+	assert.Equal(t, "sfo-12", in["deviceKey"])
+
+	annotation, err = core.NewTemplatedAnnotation("name", `{{index . "internal:name"}}`, core.TrueCondition)
+	assert.Nil(t, err)
+	assert.Nil(t, annotation.Annotate(in))
+	assert.Equal(t, "foo", in["name"])
+
+	annotation, err = core.NewTemplatedAnnotation("literal", "bar", core.TrueCondition)
+	assert.Nil(t, err)
+	assert.Nil(t, annotation.Annotate(in))
+	assert.Equal(t, "bar", in["literal"])
+
+	annotation, err = core.NewTemplatedAnnotation("missing", "{{.device.rack}}", core.TrueCondition)
+	assert.Nil(t, err)
+	assert.True(t, errors.Is(annotation.Annotate(in), &util.InvalidError{}))
+	_, ok := in["missing"]
+	assert.False(t, ok)
+
+	_, err = core.NewTemplatedAnnotation("bad", "{{.device.site", core.TrueCondition)
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+}
+
+func TestDerivedValues(t *testing.T) {
+	core.SetInstanceID("edge-1")
+	core.SetConfigVersion("v2")
+	core.SetClockOffset(time.Hour)
+	defer core.SetClockOffset(0)
+
+	in := make(map[string]interface{})
+	for key, value := range map[string]core.DerivedValue{
+		"instance": core.InstanceID,
+		"version": core.ConfigVersion,
+		"host": core.Hostname,
+		"uuid": core.UUID,
+		"ulid0": core.ULID,
+		"ulid1": core.ULID,
+		"seq0": core.Sequence,
+		"seq1": core.Sequence,
+		"ntpTime": core.NTPTime,
+	} {
+		assert.Nil(t, core.NewAnnotation(key, string(value), core.TrueCondition).Annotate(in))
+	}
+
+	hostname, _ := os.Hostname()
+	assert.Equal(t, "edge-1", in["instance"])
+	assert.Equal(t, "v2", in["version"])
+	assert.Equal(t, hostname, in["host"])
+	assert.Len(t, in["uuid"], 36)
+	assert.Len(t, in["ulid0"], 26)
+	assert.NotEqual(t, in["ulid0"], in["ulid1"])
+	assert.NotEqual(t, in["seq0"], in["seq1"])
+	assert.IsType(t, float64(0), in["seq0"])
+
+	ntpTime, err := time.Parse(time.RFC3339, in["ntpTime"].(string))
+	assert.Nil(t, err)
+	assert.InDelta(t, time.Hour.Seconds(), ntpTime.Sub(time.Now()).Seconds(), 5)
+
+	annotation, err := core.NewTemplatedAnnotation("provenance", "{{instanceID}}/{{configVersion}}/{{sequence}}",
+		core.TrueCondition)
+	assert.Nil(t, err)
+	assert.Nil(t, annotation.Annotate(in))
+	assert.Regexp(t, `^edge-1/v2/[0-9]+$`, in["provenance"])
+}
+
+func TestDerivedULIDMonotonic(t *testing.T) {
+	prev := ""
+	for i := 0; i < 100; i++ {
+		in := make(map[string]interface{})
+		assert.Nil(t, core.NewAnnotation("id", string(core.ULID), core.TrueCondition).Annotate(in))
+		assert.True(t, in["id"].(string) > prev)
+		prev = in["id"].(string)
+	}
+}
@@ -0,0 +1,298 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	gUuid "github.com/google/uuid"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultAnomalyDecayFactor = 0.05
+	DefaultAnomalyWarmUp = 30
+	DefaultAnomalyThreshold = 3.0
+)
+
+// AnomalyScoring is how the distance of a value from its baseline is scored
+type AnomalyScoring int
+
+const (
+	// ScoreZ divides the distance by the standard deviation
+	ScoreZ AnomalyScoring = iota
+	// ScoreMAD divides the distance by the mean absolute deviation, scaled to be comparable to a z-score.
+	// It is less sensitive than the variance to earlier outliers.
+	ScoreMAD
+)
+
+// madScale makes the mean absolute deviation of a normal distribution equal to its standard deviation
+var madScale = math.Sqrt(math.Pi / 2)
+
+type AnomalyOption func(detection *AnomalyDetection)
+
+// WithAnomalyDecayFactor sets the weight of each new value in the baseline, in (0, 1]
+// (default DefaultAnomalyDecayFactor)
+func WithAnomalyDecayFactor(decayFactor float64) AnomalyOption {
+	return func(detection *AnomalyDetection) {
+		detection.DecayFactor = decayFactor
+	}
+}
+
+// WithAnomalyWarmUp sets the number of values in a baseline before values are scored
+// (default DefaultAnomalyWarmUp)
+func WithAnomalyWarmUp(warmUp int64) AnomalyOption {
+	return func(detection *AnomalyDetection) {
+		detection.WarmUp = warmUp
+	}
+}
+
+// WithAnomalyThreshold sets the absolute score above which a value is anomalous
+// (default DefaultAnomalyThreshold)
+func WithAnomalyThreshold(threshold float64) AnomalyOption {
+	return func(detection *AnomalyDetection) {
+		detection.Threshold = threshold
+	}
+}
+
+// WithAnomalyScoring sets the scoring method (default ScoreZ)
+func WithAnomalyScoring(scoring AnomalyScoring) AnomalyOption {
+	return func(detection *AnomalyDetection) {
+		detection.Scoring = scoring
+	}
+}
+
+// WithAnomalySeason keeps a baseline for each of the buckets of a period, e.g. 24 buckets of a 24 hour
+// period for the hours of the day (in UTC).  The time of an event is the RFC3339 string or Unix seconds
+// at timeKey, or the current time if timeKey is empty or not found.
+func WithAnomalySeason(period time.Duration, buckets int, timeKey string) AnomalyOption {
+	return func(detection *AnomalyDetection) {
+		detection.SeasonPeriod = period
+		detection.SeasonBuckets = buckets
+		detection.TimeKey = timeKey
+	}
+}
+
+// AnomalyDetection keeps an exponentially weighted baseline (mean, variance and mean absolute deviation)
+// of a numeric field for each group, and scores values against it
+type AnomalyDetection struct {
+	Key string `json:"key"`
+	GroupByKeys []string `json:"groupByKeys"`
+	DecayFactor float64 `json:"decayFactor"`
+	WarmUp int64 `json:"warmUp"`
+	Threshold float64 `json:"threshold"`
+	Scoring AnomalyScoring `json:"scoring"`
+	SeasonPeriod time.Duration `json:"seasonPeriod"`
+	SeasonBuckets int `json:"seasonBuckets"`
+	TimeKey string `json:"timeKey"`
+}
+
+func NewAnomalyDetection(key string, groupByKeys []string, options ...AnomalyOption) (*AnomalyDetection, error) {
+	detection := &AnomalyDetection{
+		Key: key,
+		GroupByKeys: groupByKeys,
+		DecayFactor: DefaultAnomalyDecayFactor,
+		WarmUp: DefaultAnomalyWarmUp,
+		Threshold: DefaultAnomalyThreshold,
+	}
+	for _, option := range options {
+		option(detection)
+	}
+	if detection.DecayFactor <= 0 || detection.DecayFactor > 1 {
+		return nil, util.NewInvalidError(fmt.Sprintf("decay factor must be in (0, 1], got %v",
+			detection.DecayFactor))
+	}
+	if detection.WarmUp < 0 || detection.Threshold <= 0 {
+		return nil, util.NewInvalidError("warm-up must not be negative and threshold must be positive")
+	}
+	if detection.Scoring != ScoreZ && detection.Scoring != ScoreMAD {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid anomaly scoring: %v", detection.Scoring))
+	}
+	if (detection.SeasonPeriod != 0 || detection.SeasonBuckets != 0) && (detection.SeasonBuckets <= 0 ||
+		detection.SeasonPeriod < time.Duration(detection.SeasonBuckets)) {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid season: %d buckets of %v",
+			detection.SeasonBuckets, detection.SeasonPeriod))
+	}
+	return detection, nil
+}
+
+// AnomalyObservation is a value and the baseline it belongs to, which is appended to the state store
+type AnomalyObservation struct {
+	Baseline string `json:"baseline"`
+	Value float64 `json:"value"`
+}
+
+// Observation returns the value in the map and its baseline, or nil if the value is not found
+func (detection *AnomalyDetection) Observation(in map[string]interface{}) (*AnomalyObservation, error) {
+	raw, err := util.LookupVariable(in, detection.Key)
+	if err != nil {
+		return nil, nil
+	}
+	value, err := util.GetNumeric(raw)
+	if err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("value of %s is not numeric: %v", detection.Key, raw))
+	}
+
+	baseline := []string{detection.Key}
+	for _, groupByKey := range detection.GroupByKeys {
+		if v, err := util.LookupVariable(in, groupByKey); err == nil {
+			baseline = append(baseline, fmt.Sprintf("%v", v))
+		} else {
+			baseline = append(baseline, "nil")
+		}
+	}
+	if detection.SeasonPeriod != 0 {
+		t, err := detection.eventTime(in)
+		if err != nil {
+			return nil, err
+		}
+		offset := t.UnixNano() % int64(detection.SeasonPeriod)
+		if offset < 0 {
+			offset += int64(detection.SeasonPeriod)
+		}
+		bucket := offset / (int64(detection.SeasonPeriod) / int64(detection.SeasonBuckets))
+		if bucket >= int64(detection.SeasonBuckets) {
+			bucket = int64(detection.SeasonBuckets) - 1
+		}
+		baseline = append(baseline, fmt.Sprintf("@%d", bucket))
+	}
+	return &AnomalyObservation{
+		Baseline: strings.Join(baseline, "."),
+		Value: value,
+	}, nil
+}
+
+func (detection *AnomalyDetection) eventTime(in map[string]interface{}) (time.Time, error) {
+	if len(detection.TimeKey) == 0 {
+		return time.Now(), nil
+	}
+	raw, err := util.LookupVariable(in, detection.TimeKey)
+	if err != nil {
+		return time.Now(), nil
+	}
+	if s, ok := raw.(string); ok {
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	seconds, err := util.GetNumeric(raw)
+	if err != nil {
+		return time.Time{}, util.NewInvalidError(fmt.Sprintf("invalid time in %s: %v", detection.TimeKey, raw))
+	}
+	return time.Unix(0, int64(seconds * float64(time.Second))), nil
+}
+
+// AnomalyScore is the score of a value against its baseline, before the value is added to it
+type AnomalyScore struct {
+	Score float64
+	Anomalous bool
+	WarmingUp bool
+	Mean float64
+	Deviation float64
+	Count int64
+}
+
+// ToMap returns the score as a map, without the score while the baseline is warming up
+func (score AnomalyScore) ToMap() map[string]interface{} {
+	m := map[string]interface{}{
+		"anomalous": score.Anomalous,
+		"warmingUp": score.WarmingUp,
+		"mean": score.Mean,
+		"deviation": score.Deviation,
+		"count": score.Count,
+	}
+	if !score.WarmingUp {
+		m["score"] = score.Score
+	}
+	return m
+}
+
+// Score returns the score of the value.  Values are not anomalous while the baseline has fewer than WarmUp
+// values.  A value that differs from a baseline without deviation has a score of +/- math.MaxFloat64.
+func (detection *AnomalyDetection) Score(baseline *AnomalyBaseline, value float64) AnomalyScore {
+	if baseline == nil {
+		baseline = &AnomalyBaseline{}
+	}
+	score := AnomalyScore{
+		Mean: baseline.Mean,
+		Count: baseline.Count,
+		Deviation: math.Sqrt(baseline.Variance),
+	}
+	if detection.Scoring == ScoreMAD {
+		score.Deviation = baseline.AbsDeviation * madScale
+	}
+	if baseline.Count == 0 || baseline.Count < detection.WarmUp {
+		score.WarmingUp = true
+		return score
+	}
+	distance := value - baseline.Mean
+	if score.Deviation > 0 {
+		score.Score = distance / score.Deviation
+	} else if distance != 0 {
+		score.Score = math.Copysign(math.MaxFloat64, distance)
+	}
+	score.Anomalous = math.Abs(score.Score) > detection.Threshold
+	return score
+}
+
+// AnomalyBaseline is an exponentially weighted mean, variance and mean absolute deviation.  Until a
+// baseline has 1 / DecayFactor values, each value has the weight 1 / count, so the first values are not
+// discounted.
+type AnomalyBaseline struct {
+	Count int64 `json:"count"`
+	Mean float64 `json:"mean"`
+	Variance float64 `json:"variance"`
+	AbsDeviation float64 `json:"absDeviation"`
+}
+
+func (baseline *AnomalyBaseline) Update(value, decayFactor float64) {
+	baseline.Count++
+	weight := math.Max(decayFactor, 1 / float64(baseline.Count))
+	distance := value - baseline.Mean
+	baseline.Mean += weight * distance
+	baseline.Variance = (1 - weight) * (baseline.Variance + weight * distance * distance)
+	baseline.AbsDeviation += weight * (math.Abs(distance) - baseline.AbsDeviation)
+}
+
+// AnomalyState is the baselines of an anomaly detection, keyed by the field, the group-by values and the
+// season bucket
+type AnomalyState struct {
+	Baselines map[string]*AnomalyBaseline `json:"baselines"`
+}
+
+func NewAnomalyState() *AnomalyState {
+	return &AnomalyState{
+		Baselines: make(map[string]*AnomalyBaseline),
+	}
+}
+
+func NewAnomalyStateFromBytes(stateBytes []byte) (*AnomalyState, error) {
+	anomalyState := NewAnomalyState()
+	if err := json.NewDecoder(bytes.NewBuffer(stateBytes)).Decode(anomalyState); err != nil {
+		return nil, err
+	}
+	if anomalyState.Baselines == nil {
+		anomalyState.Baselines = make(map[string]*AnomalyBaseline)
+	}
+	return anomalyState, nil
+}
+
+func (s AnomalyState) Bytes() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// Update will add the observation to its baseline
+func (s *AnomalyState) Update(observation *AnomalyObservation, decayFactor float64) {
+	baseline, ok := s.Baselines[observation.Baseline]
+	if !ok {
+		baseline = &AnomalyBaseline{}
+		s.Baselines[observation.Baseline] = baseline
+	}
+	baseline.Update(observation.Value, decayFactor)
+}
+
+// <UUID>:<pipeline>:<name>:an
+var anomalyStateKeyFormat string = "%s:%s:%s:an"
+func AnomalyStateKey(partitionID gUuid.UUID, pipelineName, name string) string {
+	return fmt.Sprintf(anomalyStateKeyFormat, partitionID.String(), pipelineName, name)
+}
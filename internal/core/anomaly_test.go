@@ -0,0 +1,110 @@
+package core_test
+
+import (
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAnomalyBaseline(t *testing.T) {
+	baseline := &core.AnomalyBaseline{}
+	for _, v := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		baseline.Update(v, 0.01)
+	}
+	// Until 1 / decayFactor values, the baseline is the population mean and variance
+	assert.Equal(t, int64(8), baseline.Count)
+	assert.InDelta(t, 5.0, baseline.Mean, 0.0001)
+	assert.InDelta(t, 4.0, baseline.Variance, 0.0001)
+
+	// Later values decay
+	for i := 0; i < 1000; i++ {
+		baseline.Update(100, 0.1)
+	}
+	assert.InDelta(t, 100.0, baseline.Mean, 0.0001)
+	assert.InDelta(t, 0.0, baseline.Variance, 0.0001)
+}
+
+func TestAnomalyScore(t *testing.T) {
+	for _, scoring := range []core.AnomalyScoring{core.ScoreZ, core.ScoreMAD} {
+		detection, err := core.NewAnomalyDetection("latency", nil, core.WithAnomalyWarmUp(4),
+			core.WithAnomalyScoring(scoring))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		baseline := &core.AnomalyBaseline{}
+		for i, v := range []float64{9, 11, 9, 11} {
+			score := detection.Score(baseline, 1000)
+			assert.True(t, score.WarmingUp)
+			assert.False(t, score.Anomalous)
+			assert.Equal(t, int64(i), score.Count)
+			assert.NotContains(t, score.ToMap(), "score")
+			baseline.Update(v, detection.DecayFactor)
+		}
+
+		score := detection.Score(baseline, 10.5)
+		assert.False(t, score.WarmingUp)
+		assert.False(t, score.Anomalous)
+		assert.InDelta(t, 10.0, score.Mean, 0.0001)
+		score = detection.Score(baseline, 30)
+		assert.True(t, score.Anomalous)
+		assert.Greater(t, score.Score, 3.0)
+		score = detection.Score(baseline, -10)
+		assert.True(t, score.Anomalous)
+		assert.Less(t, score.Score, -3.0)
+	}
+
+	detection, err := core.NewAnomalyDetection("latency", nil, core.WithAnomalyWarmUp(1))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	baseline := &core.AnomalyBaseline{}
+	baseline.Update(5, detection.DecayFactor)
+	assert.Equal(t, 0.0, detection.Score(baseline, 5).Score)
+	assert.Equal(t, math.MaxFloat64, detection.Score(baseline, 6).Score)
+}
+
+func TestAnomalyObservation(t *testing.T) {
+	detection, err := core.NewAnomalyDetection("latency", []string{"host", "region"},
+		core.WithAnomalySeason(24 * time.Hour, 24, "time"))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	observation, err := detection.Observation(map[string]interface{}{
+		"latency": 12, "host": "a", "time": "2021-03-01T13:30:00Z",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &core.AnomalyObservation{Baseline: "latency.a.nil.@13", Value: 12}, observation)
+
+	observation, err = detection.Observation(map[string]interface{}{
+		"latency": 12.5, "host": "a", "region": "us", "time": 1614556800,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &core.AnomalyObservation{Baseline: "latency.a.us.@0", Value: 12.5}, observation)
+
+	observation, err = detection.Observation(map[string]interface{}{"host": "a"})
+	assert.Nil(t, err)
+	assert.Nil(t, observation)
+
+	_, err = detection.Observation(map[string]interface{}{"latency": "slow"})
+	assert.Error(t, err)
+	_, err = detection.Observation(map[string]interface{}{"latency": 1, "time": "yesterday"})
+	assert.Error(t, err)
+}
+
+func TestAnomalyDetectionInvalid(t *testing.T) {
+	for _, option := range []core.AnomalyOption{
+		core.WithAnomalyDecayFactor(0),
+		core.WithAnomalyDecayFactor(1.5),
+		core.WithAnomalyWarmUp(-1),
+		core.WithAnomalyThreshold(0),
+		core.WithAnomalyScoring(core.AnomalyScoring(-1)),
+		core.WithAnomalySeason(time.Hour, 0, ""),
+		core.WithAnomalySeason(0, 24, ""),
+	} {
+		_, err := core.NewAnomalyDetection("latency", nil, option)
+		assert.Error(t, err)
+	}
+}
@@ -16,6 +16,20 @@ func Variable(name string) variable {
 	return variable{name}
 }
 
+// exists will return true if the variable resolves to a value in the provided map.  Variable names
+// are paths (see util.Path), so "items.0.price", "items.-1.price" and "items.*.price" are all valid.
+func (v variable) exists(in map[string]interface{}) bool {
+	_, err := util.LookupVariable(in, v.name)
+	return err == nil
+}
+
+// variableParameters resolves govaluate parameters using variable paths
+type variableParameters map[string]interface{}
+
+func (p variableParameters) Get(name string) (interface{}, error) {
+	return util.LookupVariable(p, name)
+}
+
 type OperatorType int
 const (
 	UnknownType OperatorType = iota
@@ -122,8 +136,6 @@ func (expr *ExistsExpression) String() string {
 }
 
 func (expr *ExistsExpression) VariablesExist(in map[string]interface{}) bool {
-	flattened := util.Flatten(in)
-
 	components := strings.Split(expr.String(), ",")
 	for _, component := range components {
 		notExistOp := false
@@ -132,20 +144,7 @@ func (expr *ExistsExpression) VariablesExist(in map[string]interface{}) bool {
 			notExistOp = true
 		}
 
-		/*
-		 * ToDo(KMG): This will only resolve to true if the key is equal to a flattened key.  This
-		 * means that it currently does not work for paths in the map.  For example:
-		 *
-		 * - map[string]interface{} {
-		 *     "foo" : { "bar": { "baz": 1 } }
-		 *   }
-		 *
-		 * - Exists("foo.bar") and Exists("foo") would be false, while Exists("foo.bar.baz") would be true
-		 *
-		 * This can be fixed by doing a tree-based search.  We cannot rely on simple prefix matching, because
-		 * flattened keys, such as "foo.ba" would evaluate to true
-		 */
-		if _, ok := flattened[component]; ok {
+		if Variable(component).exists(in) {
 			if notExistOp {
 				return false
 			}
@@ -177,10 +176,9 @@ func NewUnaryExpression(rhs interface{}, operator UnaryOperator) *UnaryExpressio
 }
 
 func (expr *UnaryExpression) VariablesExist(in map[string]interface{}) bool {
-	flattened := util.Flatten(in)
 	switch rhs := expr.rhs.(type) {
 	case variable:
-		if _, ok := flattened[rhs.name]; !ok {
+		if !rhs.exists(in) {
 			return false
 		}
 	}
@@ -285,16 +283,15 @@ func (expr *BinaryExpression) String() string {
 }
 
 func (expr *BinaryExpression) VariablesExist(in map[string]interface{}) bool {
-	flattened := util.Flatten(in)
 	switch rhs := expr.rhs.(type) {
 	case variable:
-		if _, ok := flattened[rhs.name]; !ok {
+		if !rhs.exists(in) {
 			return false
 		}
 	}
 	switch lhs := expr.lhs.(type) {
 	case variable:
-		if _, ok := flattened[lhs.name]; !ok {
+		if !lhs.exists(in) {
 			return false
 		}
 	}
@@ -425,10 +422,9 @@ func (expr *ComparatorExpression) String() string {
 }
 
 func (expr *ComparatorExpression) VariablesExist(in map[string]interface{}) bool {
-	flattened := util.Flatten(in)
 	switch rhs := expr.rhs.(type) {
 	case variable:
-		if _, ok := flattened[rhs.name]; !ok {
+		if !rhs.exists(in) {
 			return false
 		}
 	case Expression:
@@ -438,7 +434,7 @@ func (expr *ComparatorExpression) VariablesExist(in map[string]interface{}) bool
 	}
 	switch lhs := expr.lhs.(type) {
 	case variable:
-		if _, ok := flattened[lhs.name]; !ok {
+		if !lhs.exists(in) {
 			return false
 		}
 	case Expression:
@@ -549,7 +545,7 @@ func (c *Condition) Evaluate(in map[string]interface{}) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	result, err := expression.Evaluate(in)
+	result, err := expression.Eval(variableParameters(in))
 	if err != nil {
 		return false, err
 	}
@@ -123,3 +123,43 @@ func TestExistsEvaluation(t *testing.T) {
 	assert.True(t, result)
 }
 
+
+func TestPathVariableEvaluation(t *testing.T) {
+	testMap := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"price": float64(1)},
+			map[string]interface{}{"price": float64(20)},
+		},
+	}
+
+	expr := core.NewComparatorExpression(core.Variable("items.1.price"), 10, core.GreaterThan)
+	cond, err := core.NewCondition(expr)
+	assert.Nil(t, err)
+	result, err := cond.Evaluate(testMap)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	expr = core.NewComparatorExpression(core.Variable("items.-2.price"), 10, core.GreaterThan)
+	cond, err = core.NewCondition(expr)
+	assert.Nil(t, err)
+	result, err = cond.Evaluate(testMap)
+	assert.Nil(t, err)
+	assert.False(t, result)
+
+	expr = core.NewComparatorExpression(core.Variable("items.2.price"), 10, core.GreaterThan)
+	cond, err = core.NewCondition(expr)
+	assert.Nil(t, err)
+	result, err = cond.Evaluate(testMap)
+	assert.Nil(t, err)
+	assert.False(t, result)
+
+	builder := core.NewExistsExpressionBuilder()
+	builder.Add("items.*.price", core.Exists)
+	builder.Add("items.0", core.Exists)
+	builder.Add("items.2", core.NotExists)
+	cond, err = core.NewCondition(builder.Get())
+	assert.Nil(t, err)
+	result, err = cond.Evaluate(testMap)
+	assert.Nil(t, err)
+	assert.True(t, result)
+}
@@ -0,0 +1,246 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultGrokPatterns is the base pattern library.  Patterns are RE2 expressions that may reference
+// other patterns as %{NAME}.
+var DefaultGrokPatterns = map[string]string{
+	"USERNAME": `[a-zA-Z0-9._-]+`,
+	"USER": `%{USERNAME}`,
+	"INT": `(?:[+-]?(?:[0-9]+))`,
+	"BASE10NUM": `(?:[+-]?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+))`,
+	"NUMBER": `(?:%{BASE10NUM})`,
+	"POSINT": `\b(?:[1-9][0-9]*)\b`,
+	"NONNEGINT": `\b(?:[0-9]+)\b`,
+	"WORD": `\b\w+\b`,
+	"NOTSPACE": `\S+`,
+	"SPACE": `\s*`,
+	"DATA": `.*?`,
+	"GREEDYDATA": `.*`,
+	"QUOTEDSTRING": `"(?:[^"\\]|\\.)*"`,
+	"QS": `%{QUOTEDSTRING}`,
+	"UUID": `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+	"MAC": `(?:[A-Fa-f0-9]{2}[:-]){5}[A-Fa-f0-9]{2}`,
+	"IPV4": `(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)`,
+	"IPV6": `(?:[0-9A-Fa-f]{0,4}:){2,7}(?:[0-9A-Fa-f]{1,4}|%{IPV4})?`,
+	"IP": `(?:%{IPV6}|%{IPV4})`,
+	"HOSTNAME": `\b(?:[0-9A-Za-z][0-9A-Za-z-]{0,62})(?:\.(?:[0-9A-Za-z][0-9A-Za-z-]{0,62}))*\b`,
+	"IPORHOST": `(?:%{IP}|%{HOSTNAME})`,
+	"HOSTPORT": `%{IPORHOST}:%{POSINT}`,
+	"PATH": `(?:/[^\s]*)+`,
+	"URIPATH": `(?:/[A-Za-z0-9$.+!*'(){},~:;=@#%&_\-]*)+`,
+	"URIPARAM": `\?[A-Za-z0-9$.+!*'|(){},~@#%&/=:;_?\-\[\]<>]*`,
+	"URIPATHPARAM": `%{URIPATH}(?:%{URIPARAM})?`,
+	"MONTH": `\b(?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)[a-z]*\b`,
+	"MONTHNUM": `(?:0?[1-9]|1[0-2])`,
+	"MONTHDAY": `(?:0[1-9]|[12][0-9]|3[01]|[1-9])`,
+	"YEAR": `[0-9]{4}`,
+	"HOUR": `(?:2[0123]|[01]?[0-9])`,
+	"MINUTE": `(?:[0-5][0-9])`,
+	"SECOND": `(?:(?:[0-5]?[0-9]|60)(?:[:.,][0-9]+)?)`,
+	"TIME": `%{HOUR}:%{MINUTE}(?::%{SECOND})?`,
+	"ISO8601_TIMEZONE": `(?:Z|[+-]%{HOUR}(?::?%{MINUTE}))`,
+	"TIMESTAMP_ISO8601": `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?%{ISO8601_TIMEZONE}?`,
+	"HTTPDATE": `%{MONTHDAY}/%{MONTH}/%{YEAR}:%{TIME} %{INT}`,
+	"SYSLOGTIMESTAMP": `%{MONTH} +%{MONTHDAY} %{TIME}`,
+	"LOGLEVEL": `(?:[Aa]lert|ALERT|[Tt]race|TRACE|[Dd]ebug|DEBUG|[Nn]otice|NOTICE|[Ii]nfo|INFO|[Ww]arn(?:ing)?|` +
+		`WARN(?:ING)?|[Ee]rr(?:or)?|ERR(?:OR)?|[Cc]rit(?:ical)?|CRIT(?:ICAL)?|[Ff]atal|FATAL|[Ss]evere|SEVERE|` +
+		`EMERG(?:ENCY)?|[Ee]merg(?:ency)?)`,
+	"COMMONAPACHELOG": `%{IPORHOST:clientip} %{USER:ident} %{USER:auth} \[%{HTTPDATE:timestamp}\] ` +
+		`"(?:%{WORD:verb} %{NOTSPACE:request}(?: HTTP/%{NUMBER:httpversion})?|%{DATA:rawrequest})" ` +
+		`%{NUMBER:response:int} (?:%{NUMBER:bytes:int}|-)`,
+}
+
+var grokReferenceRegex = regexp.MustCompile(`%\{(\w+)(?::([^:}]+))?(?::(\w+))?\}`)
+var grokNameRegex = regexp.MustCompile(`^\w+$`)
+
+const maxGrokDepth = 32
+
+// GrokLibrary is a set of named patterns that can be composed into grok expressions
+type GrokLibrary struct {
+	patterns map[string]string
+}
+
+// NewGrokLibrary will create a library containing the default patterns
+func NewGrokLibrary() *GrokLibrary {
+	library := &GrokLibrary{
+		patterns: make(map[string]string),
+	}
+	for name, pattern := range DefaultGrokPatterns {
+		library.patterns[name] = pattern
+	}
+	return library
+}
+
+// Add will add (or replace) a named pattern
+func (library *GrokLibrary) Add(name, pattern string) error {
+	if !grokNameRegex.MatchString(name) {
+		return util.NewInvalidError(fmt.Sprintf("invalid grok pattern name: '%s'", name))
+	}
+	library.patterns[name] = pattern
+	return nil
+}
+
+// Load will add patterns from a reader, where each line is a name followed by whitespace and the
+// pattern.  Blank lines and lines starting with '#' are ignored.
+func (library *GrokLibrary) Load(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 {
+			return util.NewInvalidError(fmt.Sprintf("invalid grok pattern definition: '%s'", line))
+		}
+		if err := library.Add(fields[0], strings.TrimSpace(fields[1])); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+type grokCapture struct {
+	field *util.Path
+	fieldType string
+}
+
+// GrokParser extracts fields from a line using one or more grok expressions.  The first expression
+// that matches is used.
+type GrokParser struct {
+	regexes []*regexp.Regexp
+	captures []map[string]grokCapture
+}
+
+// NewGrokParser will compile the grok expressions against the library.  Each %{NAME:field} or
+// %{NAME:field:type} reference captures a field, where field may be a path and type is int, float or
+// string.  RE2 named groups, (?P<field>...), also capture fields.
+func (library *GrokLibrary) NewGrokParser(expressions ...string) (*GrokParser, error) {
+	if len(expressions) == 0 {
+		return nil, util.NewInvalidError("at least one grok expression is required")
+	}
+	parser := &GrokParser{}
+	for _, expression := range expressions {
+		captures := make(map[string]grokCapture)
+		expanded, err := library.expand(expression, captures, 0)
+		if err != nil {
+			return nil, err
+		}
+		regex, err := regexp.Compile(expanded)
+		if err != nil {
+			msg := fmt.Sprintf("invalid grok expression '%s': %s", expression, err.Error())
+			return nil, util.NewInvalidError(msg)
+		}
+		for _, name := range regex.SubexpNames() {
+			if _, ok := captures[name]; len(name) > 0 && !ok {
+				captures[name] = grokCapture{field: util.NewPathFromComponents([]string{name})}
+			}
+		}
+		parser.regexes = append(parser.regexes, regex)
+		parser.captures = append(parser.captures, captures)
+	}
+	return parser, nil
+}
+
+func (library *GrokLibrary) expand(expression string, captures map[string]grokCapture, depth int) (string,
+	error) {
+	if depth > maxGrokDepth {
+		msg := fmt.Sprintf("grok expression is too deeply nested (or recursive): '%s'", expression)
+		return "", util.NewInvalidError(msg)
+	}
+
+	var expandErr error
+	expanded := grokReferenceRegex.ReplaceAllStringFunc(expression, func(reference string) string {
+		if expandErr != nil {
+			return ""
+		}
+		groups := grokReferenceRegex.FindStringSubmatch(reference)
+		name, field, fieldType := groups[1], groups[2], groups[3]
+
+		pattern, ok := library.patterns[name]
+		if !ok {
+			expandErr = util.NewNotFoundError(fmt.Sprintf("grok pattern not found: '%s'", name))
+			return ""
+		}
+		inner, err := library.expand(pattern, captures, depth+1)
+		if err != nil {
+			expandErr = err
+			return ""
+		}
+		if len(field) == 0 {
+			return "(?:" + inner + ")"
+		}
+
+		switch fieldType {
+		case "", "string", "int", "float":
+		default:
+			expandErr = util.NewInvalidError(fmt.Sprintf("invalid grok field type '%s' in '%s'", fieldType,
+				reference))
+			return ""
+		}
+		fieldPath, err := util.NewPath(field)
+		if err != nil {
+			expandErr = err
+			return ""
+		}
+		// Fields may be paths, which are not valid group names, so groups are numbered
+		groupName := "grok" + strconv.Itoa(len(captures))
+		captures[groupName] = grokCapture{field: fieldPath, fieldType: fieldType}
+		return "(?P<" + groupName + ">" + inner + ")"
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
+
+// Parse will return the captured fields of the first matching expression
+func (parser *GrokParser) Parse(line string) (map[string]interface{}, error) {
+	for i, regex := range parser.regexes {
+		match := regex.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+		out := make(map[string]interface{})
+		for j, name := range regex.SubexpNames() {
+			// Skip unnamed groups and optional groups that did not participate in the match
+			if len(name) == 0 || match[2*j] < 0 {
+				continue
+			}
+			capture := parser.captures[i][name]
+			value, err := convertGrokValue(line[match[2*j]:match[2*j+1]], capture.fieldType)
+			if err != nil {
+				return nil, err
+			}
+			if err = capture.field.Set(out, value); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return nil, util.NewNotFoundError("line did not match any grok expression")
+}
+
+func convertGrokValue(value, fieldType string) (interface{}, error) {
+	switch fieldType {
+	case "int", "float":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, util.NewInvalidError(fmt.Sprintf("cannot convert '%s' to %s", value, fieldType))
+		}
+		if fieldType == "int" {
+			return float64(int64(f)), nil
+		}
+		return f, nil
+	}
+	return value, nil
+}
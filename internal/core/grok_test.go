@@ -0,0 +1,85 @@
+package core_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGrokParser(t *testing.T) {
+	library := core.NewGrokLibrary()
+	parser, err := library.NewGrokParser(
+		`%{IP:client} %{WORD:http.method} %{URIPATHPARAM:http.path} %{NUMBER:http.status:int} %{NUMBER:duration:float}`)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := parser.Parse("10.1.2.3 GET /index.html?a=b 200 0.043")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"client": "10.1.2.3",
+		"http": map[string]interface{}{
+			"method": "GET",
+			"path": "/index.html?a=b",
+			"status": float64(200),
+		},
+		"duration": 0.043,
+	}, out)
+
+	_, err = parser.Parse("not a log line")
+	assert.True(t, errors.Is(err, &util.NotFoundError{}))
+}
+
+func TestGrokParserLibrary(t *testing.T) {
+	library := core.NewGrokLibrary()
+	err := library.Load(bytes.NewBufferString("# comment\nSTATUS (?:OK|FAIL)\nCHECK %{WORD:check}=%{STATUS:status}\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, library.Add("GATEWAY", `gw-%{INT:gateway:int}`))
+
+	// The first matching expression wins
+	parser, err := library.NewGrokParser(`%{GATEWAY} %{CHECK}`, `%{GREEDYDATA:raw}`)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := parser.Parse("gw-12 disk=FAIL")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"gateway": float64(12), "check": "disk", "status": "FAIL"}, out)
+
+	out, err = parser.Parse("something else")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"raw": "something else"}, out)
+
+	parser, err = library.NewGrokParser(`%{COMMONAPACHELOG}`)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	out, err = parser.Parse(`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`)
+	assert.Nil(t, err)
+	assert.Equal(t, "127.0.0.1", out["clientip"])
+	assert.Equal(t, "frank", out["auth"])
+	assert.Equal(t, "GET", out["verb"])
+	assert.Equal(t, float64(200), out["response"])
+	assert.Equal(t, float64(2326), out["bytes"])
+}
+
+func TestGrokParserInvalid(t *testing.T) {
+	library := core.NewGrokLibrary()
+
+	_, err := library.NewGrokParser(`%{DOESNOTEXIST:foo}`)
+	assert.True(t, errors.Is(err, &util.NotFoundError{}))
+
+	_, err = library.NewGrokParser(`%{INT:foo:bool}`)
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+
+	assert.Nil(t, library.Add("LOOP", `%{LOOP}`))
+	_, err = library.NewGrokParser(`%{LOOP}`)
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+
+	assert.Error(t, library.Add("bad name", `x`))
+	_, err = library.NewGrokParser()
+	assert.Error(t, err)
+}
@@ -7,8 +7,10 @@ import (
 	"time"
 )
 
+// Job runs a runnable, optionally after a delay.  The context is passed to the runnable, so a job
+// can be cancelled or given a deadline.
 type Job interface {
-	Run(delay time.Duration, sync bool, inData ...interface{}) util.Future
+	Run(ctx context.Context, delay time.Duration, sync bool, inData ...interface{}) util.Future
 }
 
 type LocalJob struct {
@@ -22,7 +24,8 @@ func NewLocalJob(runnable util.PartialRunnable) *LocalJob {
 	}
 }
 
-func run(runnable util.PartialRunnable, delay time.Duration, sync bool, inData interface{}) util.Future {
+func run(ctx context.Context, runnable util.PartialRunnable, delay time.Duration, sync bool,
+	inData interface{}, options ...util.FutureOption) util.Future {
 	completable := util.NewCompletable()
 	var future util.Future
 	if inData != nil {
@@ -35,11 +38,11 @@ func run(runnable util.PartialRunnable, delay time.Duration, sync bool, inData i
 		}
 	}
 
+	options = append(options, util.SetContext(ctx))
 	if delay > 0 {
-		future = util.CreateFuture(runnable, util.WithDelay(delay))
-	} else {
-		future = util.CreateFuture(runnable)
+		options = append(options, util.WithDelay(delay))
 	}
+	future = util.CreateFuture(runnable, options...)
 
 	if sync {
 		util.WaitAll([]util.Future{future}, -1)
@@ -47,37 +50,124 @@ func run(runnable util.PartialRunnable, delay time.Duration, sync bool, inData i
 	return future
 }
 
-func (j LocalJob) Run(delay time.Duration, sync bool, inData ...interface{}) util.Future {
+func (j LocalJob) Run(ctx context.Context, delay time.Duration, sync bool, inData ...interface{}) util.Future {
 	if len(inData) > 1 {
 		completable := util.NewCompletable()
 		msg := fmt.Sprintf("expected 1 inData varadic arg to Run, got %d", len(inData))
 		_ = completable.Fail(context.Background(), util.NewInvalidError(msg))
 		return completable.Future()
 	} else if len(inData) == 1 {
-		return run(j.runnable, delay, sync, inData[0])
+		return run(ctx, j.runnable, delay, sync, inData[0])
 	}
-	return run(j.runnable, delay, sync, nil)
+	return run(ctx, j.runnable, delay, sync, nil)
 }
 
+// CmdResult is the value of a completed CmdJob
+type CmdResult struct {
+	Output interface{}
+	Status *util.ExecStatus
+}
+
+// CmdError is the error of a CmdJob whose command was started, and carries the command's result, so
+// the status of a failed command can be recorded
+type CmdError struct {
+	err error
+	Result *CmdResult
+}
+
+func (e *CmdError) Error() string {
+	return e.err.Error()
+}
+
+func (e *CmdError) Unwrap() error {
+	return e.err
+}
+
+// cmdRunnable wraps an ExecRunnable, so the command status is returned with the output
+type cmdRunnable struct {
+	*util.ExecRunnable
+}
+
+func (runnable cmdRunnable) Run(ctx context.Context) (interface{}, error) {
+	out, status, err := runnable.RunWithStatus(ctx)
+	if err != nil {
+		if status == nil {
+			return nil, err
+		}
+		if status.ExitCode > 0 {
+			msg := fmt.Sprintf("exit status %d: %s", status.ExitCode, string(status.Stderr))
+			err = util.NewInternalError(msg)
+		}
+		return nil, &CmdError{
+			err: err,
+			Result: &CmdResult{Status: status},
+		}
+	}
+	return &CmdResult{
+		Output: out,
+		Status: status,
+	}, nil
+}
+
+// CmdJob runs a command, which is killed if the context is done.  The value of the future is a
+// *CmdResult.  If the command was started but failed, the future fails with a *CmdError.
 type CmdJob struct {
-	runnable util.PartialRunnable
+	cmdPath string
+	cmdArgs []string
+	sandbox *util.Sandbox
 }
 
 func NewCmdJob(cmdPath string, cmdArgs ...string) *CmdJob {
-	runnable := util.NewExecRunnable(util.WithCmdArgs(cmdArgs...), util.WithPath(cmdPath))
+	return NewSandboxedCmdJob(nil, cmdPath, cmdArgs...)
+}
+
+// NewSandboxedCmdJob will create a CmdJob whose command runs in the sandbox
+func NewSandboxedCmdJob(sandbox *util.Sandbox, cmdPath string, cmdArgs ...string) *CmdJob {
 	return &CmdJob{
-		runnable: runnable,
+		cmdPath: cmdPath,
+		cmdArgs: cmdArgs,
+		sandbox: sandbox,
 	}
 }
 
-func (j CmdJob) Run(delay time.Duration, sync bool, inData ...interface{}) util.Future {
+func (j CmdJob) Run(ctx context.Context, delay time.Duration, sync bool, inData ...interface{}) util.Future {
 	if len(inData) > 1 {
 		completable := util.NewCompletable()
 		msg := fmt.Sprintf("expected 1 inData varadic arg to Run, got %d", len(inData))
 		_ = completable.Fail(context.Background(), util.NewInvalidError(msg))
 		return completable.Future()
-	} else if len(inData) == 1 {
-		return run(j.runnable, delay, sync, inData[0])
 	}
-	return run(j.runnable, delay, sync, nil)
+	// Each run gets its own runnable, since runs may be concurrent
+	runnable := cmdRunnable{util.NewExecRunnable(util.WithCmdArgs(j.cmdArgs...), util.WithPath(j.cmdPath),
+		util.WithSandbox(j.sandbox))}
+	if len(inData) == 1 {
+		return run(ctx, runnable, delay, sync, inData[0])
+	}
+	return run(ctx, runnable, delay, sync, nil)
+}
+
+// CoProcessJob runs each job on a pool of long-lived workers
+type CoProcessJob struct {
+	pool *util.CoProcessPool
+}
+
+func NewCoProcessJob(pool *util.CoProcessPool) *CoProcessJob {
+	return &CoProcessJob{
+		pool: pool,
+	}
+}
+
+func (j CoProcessJob) Run(ctx context.Context, delay time.Duration, sync bool, inData ...interface{}) util.Future {
+	if len(inData) > 1 {
+		completable := util.NewCompletable()
+		msg := fmt.Sprintf("expected 1 inData varadic arg to Run, got %d", len(inData))
+		_ = completable.Fail(context.Background(), util.NewInvalidError(msg))
+		return completable.Future()
+	}
+	// Each run gets its own runnable, since calls to the pool may be concurrent
+	runnable := util.NewCoProcessRunnable(j.pool, nil)
+	if len(inData) == 1 {
+		return run(ctx, runnable, delay, sync, inData[0])
+	}
+	return run(ctx, runnable, delay, sync, nil)
 }
@@ -1,7 +1,9 @@
 package core_test
 
 import (
+	"context"
 	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/util"
 	"github.com/kmgreen2/agglo/test"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -12,7 +14,7 @@ func TestLocalJob(t *testing.T) {
 	runnable := test.NewSleepRunnable(1)
 	job := core.NewLocalJob(runnable)
 
-	f := job.Run(-1, false)
+	f := job.Run(context.Background(), -1, false)
 
 	result := f.Get()
 	assert.Nil(t, result.Error())
@@ -26,7 +28,7 @@ func TestLocalJobSync(t *testing.T) {
 	job := core.NewLocalJob(runnable)
 
 	start := time.Now()
-	f := job.Run(-1, true)
+	f := job.Run(context.Background(), -1, true)
 	end := time.Now()
 
 	assert.True(t, end.Sub(start) > 1 * time.Second)
@@ -42,7 +44,7 @@ func TestLocalJobFailed(t *testing.T) {
 	runnable := test.NewFailRunnable()
 	job := core.NewLocalJob(runnable)
 
-	f := job.Run(-1, false)
+	f := job.Run(context.Background(), -1, false)
 
 	result := f.Get()
 	assert.Error(t, result.Error())
@@ -53,7 +55,7 @@ func TestLocalJobDeferred(t *testing.T) {
 	job := core.NewLocalJob(runnable)
 
 	start := time.Now()
-	f := job.Run(1*time.Second, true)
+	f := job.Run(context.Background(), 1*time.Second, true)
 	end := time.Now()
 
 	assert.True(t, end.Sub(start) > 2 * time.Second)
@@ -64,3 +66,22 @@ func TestLocalJobDeferred(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, 1, intResult)
 }
+
+func TestCoProcessJob(t *testing.T) {
+	pool := util.NewCoProcessPool("cat", util.WithNumWorkers(2))
+	defer func() {
+		_ = pool.Close()
+	}()
+	job := core.NewCoProcessJob(pool)
+
+	futures := make([]util.Future, 8)
+	for i := range futures {
+		futures[i] = job.Run(context.Background(), -1, false, map[string]interface{}{"i": float64(i)})
+	}
+
+	for i, f := range futures {
+		result := f.Get()
+		assert.Nil(t, result.Error())
+		assert.Equal(t, map[string]interface{}{"i": float64(i)}, result.Value())
+	}
+}
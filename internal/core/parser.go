@@ -0,0 +1,399 @@
+package core
+
+import (
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// LineParser extracts structured fields from a single line of text
+type LineParser interface {
+	Parse(line string) (map[string]interface{}, error)
+}
+
+// KeyValueParser parses lines of key/value pairs, such as "a=1, b=2".  Values may be double-quoted.
+type KeyValueParser struct {
+	pairSeparator string
+	kvSeparator string
+	bareKeys bool
+}
+
+// NewKeyValueParser will create a parser that splits pairs on pairSeparator and keys from values on
+// kvSeparator.  An empty pairSeparator splits on whitespace.  Pairs without a kvSeparator are ignored.
+func NewKeyValueParser(pairSeparator, kvSeparator string) (*KeyValueParser, error) {
+	if len(kvSeparator) == 0 {
+		kvSeparator = "="
+	}
+	if pairSeparator == kvSeparator {
+		msg := fmt.Sprintf("pair and key/value separators must be different, got '%s'", pairSeparator)
+		return nil, util.NewInvalidError(msg)
+	}
+	return &KeyValueParser{
+		pairSeparator: pairSeparator,
+		kvSeparator: kvSeparator,
+	}, nil
+}
+
+// NewLogfmtParser will create a parser for logfmt lines (e.g. `level=info msg="hello world" debug`).
+// Keys without a value are set to true.
+func NewLogfmtParser() *KeyValueParser {
+	return &KeyValueParser{
+		kvSeparator: "=",
+		bareKeys: true,
+	}
+}
+
+func (parser *KeyValueParser) atPairSeparator(line string, i int) (bool, int) {
+	if len(parser.pairSeparator) == 0 {
+		if unicode.IsSpace(rune(line[i])) {
+			return true, 1
+		}
+		return false, 0
+	}
+	if strings.HasPrefix(line[i:], parser.pairSeparator) {
+		return true, len(parser.pairSeparator)
+	}
+	return false, 0
+}
+
+// splitPairs will split the line on the pair separator, ignoring separators in quoted values
+func (parser *KeyValueParser) splitPairs(line string) ([]string, error) {
+	var pairs []string
+	start := 0
+	inQuotes := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && inQuotes:
+			i++
+		case line[i] == '"':
+			inQuotes = !inQuotes
+		case !inQuotes:
+			if ok, width := parser.atPairSeparator(line, i); ok {
+				pairs = append(pairs, line[start:i])
+				start = i + width
+				i += width - 1
+			}
+		}
+	}
+	if inQuotes {
+		return nil, util.NewInvalidError(fmt.Sprintf("unterminated quote in '%s'", line))
+	}
+	return append(pairs, line[start:]), nil
+}
+
+func (parser *KeyValueParser) Parse(line string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	pairs, err := parser.splitPairs(line)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range pairs {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		idx := strings.Index(pair, parser.kvSeparator)
+		if idx < 0 {
+			if parser.bareKeys {
+				out[pair] = true
+			}
+			continue
+		}
+		key := strings.TrimSpace(pair[:idx])
+		value := strings.TrimSpace(pair[idx+len(parser.kvSeparator):])
+		if len(key) == 0 {
+			return nil, util.NewInvalidError(fmt.Sprintf("missing key in '%s'", pair))
+		}
+		if strings.HasPrefix(value, "\"") {
+			if value, err = strconv.Unquote(value); err != nil {
+				return nil, util.NewInvalidError(fmt.Sprintf("invalid quoted value in '%s'", pair))
+			}
+		}
+		out[key] = value
+	}
+	if len(out) == 0 {
+		return nil, util.NewNotFoundError("no key/value pairs found")
+	}
+	return out, nil
+}
+
+// SyslogParser parses RFC5424 syslog messages:
+//
+// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+//
+// Nil values ("-") are omitted.  Structured data is returned as a map of SD-ID to parameters.
+type SyslogParser struct {}
+
+func NewSyslogParser() *SyslogParser {
+	return &SyslogParser{}
+}
+
+func (parser *SyslogParser) Parse(line string) (map[string]interface{}, error) {
+	invalid := func(reason string) error {
+		return util.NewInvalidError(fmt.Sprintf("invalid RFC5424 message (%s): '%s'", reason, line))
+	}
+
+	out, rest, reason := parsePriority(line)
+	if len(reason) > 0 {
+		return nil, invalid(reason)
+	}
+
+	fields := []string{"version", "timestamp", "hostname", "appName", "procID", "msgID"}
+	for _, field := range fields {
+		var token string
+		idx := strings.IndexByte(rest, ' ')
+		if idx < 0 {
+			return nil, invalid("missing " + field)
+		}
+		token, rest = rest[:idx], rest[idx+1:]
+		if field == "version" {
+			version, err := strconv.Atoi(token)
+			if err != nil {
+				return nil, invalid("bad version")
+			}
+			out[field] = float64(version)
+		} else if token != "-" {
+			out[field] = token
+		}
+	}
+
+	structuredData, rest, err := parseStructuredData(rest)
+	if err != nil {
+		return nil, invalid(err.Error())
+	}
+	if len(structuredData) > 0 {
+		out["structuredData"] = structuredData
+	}
+
+	if strings.HasPrefix(rest, " ") {
+		message := strings.TrimPrefix(rest[1:], "\ufeff")
+		if len(message) > 0 {
+			out["message"] = message
+		}
+	}
+	return out, nil
+}
+
+// parsePriority parses the <PRI> prefix of a syslog message and returns the remainder, or the reason the
+// prefix is invalid
+func parsePriority(line string) (map[string]interface{}, string, string) {
+	if !strings.HasPrefix(line, "<") {
+		return nil, "", "missing priority"
+	}
+	end := strings.Index(line, ">")
+	if end < 2 || end > 4 {
+		return nil, "", "missing priority"
+	}
+	priority, err := strconv.Atoi(line[1:end])
+	if err != nil || priority > 191 {
+		return nil, "", "bad priority"
+	}
+	return map[string]interface{}{
+		"priority": float64(priority),
+		"facility": float64(priority / 8),
+		"severity": float64(priority % 8),
+	}, line[end+1:], ""
+}
+
+// BSDSyslogParser parses RFC3164 (BSD) syslog messages:
+//
+// <PRI>TIMESTAMP HOSTNAME TAG[PID]: MSG
+//
+// The timestamp is "Mmm dd hh:mm:ss" or, as many devices send, RFC3339.  Parts of the header that are
+// missing are omitted, and anything that cannot be parsed is left in the message.
+type BSDSyslogParser struct {}
+
+func NewBSDSyslogParser() *BSDSyslogParser {
+	return &BSDSyslogParser{}
+}
+
+var bsdSyslogTag = regexp.MustCompile(`^([^\s:\[\]]+)(?:\[([^\]]+)\])?: ?`)
+
+func (parser *BSDSyslogParser) Parse(line string) (map[string]interface{}, error) {
+	out, rest, reason := parsePriority(line)
+	if len(reason) > 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid RFC3164 message (%s): '%s'", reason, line))
+	}
+
+	hasTimestamp := false
+	if len(rest) > 15 && rest[15] == ' ' {
+		if _, err := time.Parse(time.Stamp, rest[:15]); err == nil {
+			out["timestamp"], rest, hasTimestamp = rest[:15], rest[16:], true
+		}
+	}
+	if idx := strings.IndexByte(rest, ' '); !hasTimestamp && idx > 0 {
+		if _, err := time.Parse(time.RFC3339Nano, rest[:idx]); err == nil {
+			out["timestamp"], rest, hasTimestamp = rest[:idx], rest[idx+1:], true
+		}
+	}
+	// The hostname follows the timestamp, unless the next token is the tag
+	if idx := strings.IndexByte(rest, ' '); hasTimestamp && idx > 0 && !bsdSyslogTag.MatchString(rest[:idx+1]) {
+		out["hostname"], rest = rest[:idx], rest[idx+1:]
+	}
+	if match := bsdSyslogTag.FindStringSubmatch(rest); match != nil {
+		out["appName"] = match[1]
+		if len(match[2]) > 0 {
+			out["procID"] = match[2]
+		}
+		rest = rest[len(match[0]):]
+	}
+	if len(rest) > 0 {
+		out["message"] = rest
+	}
+	return out, nil
+}
+
+// parseStructuredData parses the STRUCTURED-DATA part of a RFC5424 message and returns the remainder
+func parseStructuredData(in string) (map[string]interface{}, string, error) {
+	if strings.HasPrefix(in, "-") {
+		return nil, in[1:], nil
+	}
+	out := make(map[string]interface{})
+	for strings.HasPrefix(in, "[") {
+		in = in[1:]
+		idx := strings.IndexAny(in, " ]")
+		if idx <= 0 {
+			return nil, "", fmt.Errorf("bad structured data id")
+		}
+		params := make(map[string]interface{})
+		out[in[:idx]] = params
+		in = in[idx:]
+		for strings.HasPrefix(in, " ") {
+			in = in[1:]
+			eq := strings.Index(in, "=\"")
+			if eq <= 0 {
+				return nil, "", fmt.Errorf("bad structured data param")
+			}
+			name := in[:eq]
+			in = in[eq+2:]
+			var value strings.Builder
+			closed := false
+			for i := 0; i < len(in); i++ {
+				if in[i] == '\\' && i+1 < len(in) && strings.IndexByte(`"\]`, in[i+1]) >= 0 {
+					value.WriteByte(in[i+1])
+					i++
+				} else if in[i] == '"' {
+					in = in[i+1:]
+					closed = true
+					break
+				} else {
+					value.WriteByte(in[i])
+				}
+			}
+			if !closed {
+				return nil, "", fmt.Errorf("unterminated structured data param")
+			}
+			params[name] = value.String()
+		}
+		if !strings.HasPrefix(in, "]") {
+			return nil, "", fmt.Errorf("unterminated structured data element")
+		}
+		in = in[1:]
+	}
+	if len(out) == 0 {
+		return nil, "", fmt.Errorf("missing structured data")
+	}
+	return out, in, nil
+}
+
+// CEFParser parses ArcSight Common Event Format messages.  Any prefix before "CEF:" (e.g. a syslog
+// header) is ignored.
+//
+// CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+type CEFParser struct {}
+
+func NewCEFParser() *CEFParser {
+	return &CEFParser{}
+}
+
+var cefHeaderFields = []string{"version", "deviceVendor", "deviceProduct", "deviceVersion", "signatureID",
+	"name", "severity"}
+
+func (parser *CEFParser) Parse(line string) (map[string]interface{}, error) {
+	start := strings.Index(line, "CEF:")
+	if start < 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid CEF message (missing 'CEF:'): '%s'", line))
+	}
+	rest := line[start+4:]
+
+	out := make(map[string]interface{})
+	for _, field := range cefHeaderFields {
+		var value strings.Builder
+		end := -1
+		for i := 0; i < len(rest); i++ {
+			if rest[i] == '\\' && i+1 < len(rest) {
+				value.WriteByte(rest[i+1])
+				i++
+			} else if rest[i] == '|' {
+				end = i
+				break
+			} else {
+				value.WriteByte(rest[i])
+			}
+		}
+		if end < 0 {
+			msg := fmt.Sprintf("invalid CEF message (missing %s): '%s'", field, line)
+			return nil, util.NewInvalidError(msg)
+		}
+		out[field] = value.String()
+		rest = rest[end+1:]
+	}
+
+	extensions, err := parseCEFExtensions(rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(extensions) > 0 {
+		out["extensions"] = extensions
+	}
+	return out, nil
+}
+
+// parseCEFExtensions parses space-separated key=value pairs, where values may contain unescaped
+// spaces.  A value ends where the next key begins.
+func parseCEFExtensions(in string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	in = strings.TrimSpace(in)
+
+	// Find the start of every key: a run of word characters followed by an unescaped '='
+	type keyPos struct {
+		key string
+		start, valueStart int
+	}
+	var keys []keyPos
+	for i := 0; i < len(in); i++ {
+		if in[i] == '\\' {
+			i++
+			continue
+		}
+		if in[i] != '=' {
+			continue
+		}
+		j := i
+		for j > 0 && (unicode.IsLetter(rune(in[j-1])) || unicode.IsDigit(rune(in[j-1])) || in[j-1] == '_' ||
+			in[j-1] == '.') {
+			j--
+		}
+		if j == i || (j > 0 && in[j-1] != ' ') {
+			continue
+		}
+		keys = append(keys, keyPos{key: in[j:i], start: j, valueStart: i + 1})
+	}
+	if len(in) > 0 && (len(keys) == 0 || keys[0].start != 0) {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid CEF extension: '%s'", in))
+	}
+
+	replacer := strings.NewReplacer(`\=`, `=`, `\\`, `\`, `\n`, "\n", `\r`, "\r")
+	for i, k := range keys {
+		end := len(in)
+		if i+1 < len(keys) {
+			end = keys[i+1].start
+		}
+		out[k.key] = replacer.Replace(strings.TrimRight(in[k.valueStart:end], " "))
+	}
+	return out, nil
+}
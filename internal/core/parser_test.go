@@ -0,0 +1,130 @@
+package core_test
+
+import (
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLogfmtParser(t *testing.T) {
+	out, err := core.NewLogfmtParser().Parse(`level=info msg="hello \"world\"" duration=12ms debug`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"level": "info",
+		"msg": `hello "world"`,
+		"duration": "12ms",
+		"debug": true,
+	}, out)
+
+	_, err = core.NewLogfmtParser().Parse(`msg="unterminated`)
+	assert.Error(t, err)
+}
+
+func TestKeyValueParser(t *testing.T) {
+	parser, err := core.NewKeyValueParser(",", ":")
+	assert.Nil(t, err)
+	out, err := parser.Parse(`user: frank, action: "login, remote", ignored`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"user": "frank", "action": "login, remote"}, out)
+
+	_, err = parser.Parse("no pairs here")
+	assert.Error(t, err)
+
+	_, err = core.NewKeyValueParser("=", "=")
+	assert.Error(t, err)
+}
+
+func TestSyslogParser(t *testing.T) {
+	parser := core.NewSyslogParser()
+	out, err := parser.Parse(`<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 ` +
+		`[exampleSDID@32473 iut="3" eventSource="Application"][examplePriority@32473 class="high"] An application event`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"priority": float64(165),
+		"facility": float64(20),
+		"severity": float64(5),
+		"version": float64(1),
+		"timestamp": "2003-10-11T22:14:15.003Z",
+		"hostname": "mymachine.example.com",
+		"appName": "evntslog",
+		"msgID": "ID47",
+		"structuredData": map[string]interface{}{
+			"exampleSDID@32473": map[string]interface{}{"iut": "3", "eventSource": "Application"},
+			"examplePriority@32473": map[string]interface{}{"class": "high"},
+		},
+		"message": "An application event",
+	}, out)
+
+	out, err = parser.Parse(`<34>1 2003-10-11T22:14:15.003Z host su - - - 'su root' failed`)
+	assert.Nil(t, err)
+	assert.Equal(t, "'su root' failed", out["message"])
+	assert.Equal(t, "su", out["appName"])
+	_, ok := out["procID"]
+	assert.False(t, ok)
+
+	for _, line := range []string{"no priority", "<999>1 - - - - - -", "<34>1 - - - - - [unterminated"} {
+		_, err = parser.Parse(line)
+		assert.Error(t, err, line)
+	}
+}
+
+func TestBSDSyslogParser(t *testing.T) {
+	parser := core.NewBSDSyslogParser()
+	out, err := parser.Parse(`<34>Oct 11 22:14:15 mymachine su[1234]: 'su root' failed for lonvick on /dev/pts/8`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"priority": float64(34),
+		"facility": float64(4),
+		"severity": float64(2),
+		"timestamp": "Oct 11 22:14:15",
+		"hostname": "mymachine",
+		"appName": "su",
+		"procID": "1234",
+		"message": "'su root' failed for lonvick on /dev/pts/8",
+	}, out)
+
+	// Without a hostname
+	out, err = parser.Parse(`<13>2021-03-01T10:00:00Z sshd: session opened`)
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-03-01T10:00:00Z", out["timestamp"])
+	assert.Equal(t, "sshd", out["appName"])
+	assert.Equal(t, "session opened", out["message"])
+	_, ok := out["hostname"]
+	assert.False(t, ok)
+
+	// Without a header
+	out, err = parser.Parse(`<13>link down on port 4`)
+	assert.Nil(t, err)
+	assert.Equal(t, "link down on port 4", out["message"])
+	_, ok = out["timestamp"]
+	assert.False(t, ok)
+
+	_, err = parser.Parse("Oct 11 22:14:15 mymachine su: no priority")
+	assert.Error(t, err)
+}
+
+func TestCEFParser(t *testing.T) {
+	parser := core.NewCEFParser()
+	out, err := parser.Parse(`Sep 19 08:26:10 host CEF:0|Security|threatmanager|1.0|100|detected a \| in message|10|` +
+		`src=10.0.0.1 act=blocked a \= sign msg=multiple words here`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"version": "0",
+		"deviceVendor": "Security",
+		"deviceProduct": "threatmanager",
+		"deviceVersion": "1.0",
+		"signatureID": "100",
+		"name": "detected a | in message",
+		"severity": "10",
+		"extensions": map[string]interface{}{
+			"src": "10.0.0.1",
+			"act": "blocked a = sign",
+			"msg": "multiple words here",
+		},
+	}, out)
+
+	_, err = parser.Parse("CEF:0|missing|fields")
+	assert.Error(t, err)
+	_, err = parser.Parse("not cef")
+	assert.Error(t, err)
+}
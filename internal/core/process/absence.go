@@ -0,0 +1,303 @@
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/kmgreen2/agglo/pkg/observability"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AbsenceKey is the key of the absence metadata in the events of an Absence process
+	AbsenceKey = "absence"
+	AbsenceStatusAbsent = "absent"
+	AbsenceStatusRecovered = "recovered"
+	// DefaultAbsenceCheckInterval is the longest interval between scans for silent keys
+	DefaultAbsenceCheckInterval = 10 * time.Second
+	// maxUpdateConflicts is the number of times an update is retried when another writer changes a key
+	maxUpdateConflicts = 8
+)
+
+// AbsenceOutput receives the absent and recovered events of an Absence process
+type AbsenceOutput func(ctx context.Context, in map[string]interface{}) error
+
+// ProcessAbsenceOutput returns an output that runs the events through a process, e.g. a Tee
+func ProcessAbsenceOutput(process PipelineProcess) AbsenceOutput {
+	return func(ctx context.Context, in map[string]interface{}) error {
+		_, err := process.Process(ctx, in)
+		return err
+	}
+}
+
+// PipelineAbsenceOutput returns an output that runs the events through a pipeline
+func PipelineAbsenceOutput(pipeline *Pipeline) AbsenceOutput {
+	return func(ctx context.Context, in map[string]interface{}) error {
+		_, err := pipeline.RunSync(in)
+		return err
+	}
+}
+
+// AbsenceOption configures an Absence process
+type AbsenceOption func(*Absence)
+
+// WithAbsenceCheckInterval sets how often the state store is scanned for silent keys (default: the smaller
+// of the threshold and DefaultAbsenceCheckInterval)
+func WithAbsenceCheckInterval(checkInterval time.Duration) AbsenceOption {
+	return func(a *Absence) {
+		if checkInterval > 0 {
+			a.checkInterval = checkInterval
+		}
+	}
+}
+
+// absenceState is the state of a key, which is stored as JSON
+type absenceState struct {
+	Key map[string]interface{} `json:"key"`
+	LastSeen time.Time `json:"lastSeen"`
+	Absent bool `json:"absent"`
+	AbsentSince time.Time `json:"absentSince"`
+}
+
+// Absence records when each key, i.e. the values of the key fields, was last seen, and detects keys that
+// have been silent for longer than the threshold, e.g. a device that stops sending heartbeats.  Events are
+// passed through unchanged.
+//
+// A checker scans the state store every check interval, and sends an "absent" event to the output for each
+// key that became silent.  Each silence episode is reported once, and the next event with the key sends a
+// "recovered" event.  State changes are atomic, so replicas that share the state store report each episode
+// once.  The events have the metadata in AbsenceKey:
+//
+// {"name": <process>, "status": "absent"|"recovered", "key": {<field>: <value>, ...}, "lastSeen": <RFC3339>,
+//  "absentSince": <RFC3339>, "detectedTime": <RFC3339>}
+//
+// The following metrics are emitted: <name>.absent, <name>.recovered and <name>.failed (events the output
+// could not handle, which are dropped).
+type Absence struct {
+	name string
+	keyFields []string
+	condition *core.Condition
+	kvStore kvs.KVStore
+	threshold time.Duration
+	checkInterval time.Duration
+	output AbsenceOutput
+	emitter *observability.Emitter
+	stopCh chan struct{}
+	done chan struct{}
+	closeOnce sync.Once
+}
+
+// NewAbsence returns an Absence process, whose checker runs until Close is called
+func NewAbsence(name string, keyFields []string, condition *core.Condition, kvStore kvs.KVStore,
+	threshold time.Duration, output AbsenceOutput, options ...AbsenceOption) (*Absence, error) {
+	if len(keyFields) == 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("absence %s: at least one key field is required", name))
+	}
+	if threshold <= 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("absence %s: threshold must be positive", name))
+	}
+	a := &Absence{
+		name: name,
+		keyFields: keyFields,
+		condition: condition,
+		kvStore: kvStore,
+		threshold: threshold,
+		checkInterval: DefaultAbsenceCheckInterval,
+		output: output,
+		emitter: observability.NewEmitter("agglo/absence"),
+		stopCh: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if threshold < a.checkInterval {
+		a.checkInterval = threshold
+	}
+	for _, option := range options {
+		option(a)
+	}
+	for _, metric := range []string{".absent", ".recovered", ".failed"} {
+		a.emitter.AddMetric(name + metric, observability.Int64Counter)
+	}
+	go a.run()
+	return a, nil
+}
+
+func (a *Absence) Name() string {
+	return a.name
+}
+
+func (a *Absence) prefix() string {
+	return fmt.Sprintf("absence:%s:", a.name)
+}
+
+// stateKey returns the values of the key fields and their state store key, or nil if a field is missing
+func (a *Absence) stateKey(in map[string]interface{}) (map[string]interface{}, string, error) {
+	key, keyString, err := fieldKey(a.keyFields, in)
+	if key == nil || err != nil {
+		return nil, "", err
+	}
+	return key, a.prefix() + keyString, nil
+}
+
+// fieldKey returns the values of the key fields and the values joined by ':', or nil if a field is missing
+func fieldKey(keyFields []string, in map[string]interface{}) (map[string]interface{}, string, error) {
+	key := make(map[string]interface{})
+	values := make([]string, len(keyFields))
+	for i, field := range keyFields {
+		path, err := util.NewPath(field)
+		if err != nil {
+			return nil, "", err
+		}
+		value, err := path.Get(in)
+		if err != nil {
+			if errors.Is(err, &util.NotFoundError{}) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+		key[field] = value
+		values[i] = fmt.Sprintf("%v", value)
+	}
+	return key, strings.Join(values, ":"), nil
+}
+
+// Process records that the event's key was seen and, if the key was absent, sends a recovered event
+func (a *Absence) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+	if shouldProcess, err := a.condition.Evaluate(in); !shouldProcess || err != nil {
+		return in, PipelineProcessError(a, err, "evaluating condition")
+	}
+	key, stateKey, err := a.stateKey(in)
+	if err != nil {
+		return nil, PipelineProcessError(a, err, "getting key")
+	} else if key == nil {
+		return in, nil
+	}
+
+	now := time.Now()
+	var prev *absenceState
+	err = a.update(ctx, stateKey, func(curr *absenceState) *absenceState {
+		prev = curr
+		return &absenceState{Key: key, LastSeen: now}
+	})
+	if err != nil {
+		return nil, PipelineProcessError(a, err, "updating state")
+	}
+	if prev != nil && prev.Absent {
+		a.emit(ctx, AbsenceStatusRecovered, prev, now)
+	}
+	return in, nil
+}
+
+// update will atomically replace the key's state (nil if the key is new) with the result of updateFn,
+// unless updateFn returns nil
+func (a *Absence) update(ctx context.Context, stateKey string, updateFn func(curr *absenceState) *absenceState) error {
+	return atomicUpdate(ctx, a.kvStore, stateKey, func(currBytes []byte) ([]byte, error) {
+		var curr *absenceState
+		if currBytes != nil {
+			curr = &absenceState{}
+			if err := json.Unmarshal(currBytes, curr); err != nil {
+				return nil, err
+			}
+		}
+		next := updateFn(curr)
+		if next == nil {
+			return nil, nil
+		}
+		return json.Marshal(next)
+	})
+}
+
+// atomicUpdate will replace the value of the key (nil if the key is new) with the result of updateFn, unless
+// updateFn returns nil.  If another writer changes the key, updateFn is called again with the new value.
+func atomicUpdate(ctx context.Context, kvStore kvs.KVStore, key string,
+	updateFn func(curr []byte) ([]byte, error)) error {
+	for i := 0; i < maxUpdateConflicts; i++ {
+		curr, err := kvStore.Get(ctx, key)
+		if err != nil && !errors.Is(err, &util.NotFoundError{}) {
+			return err
+		} else if err != nil {
+			curr = nil
+		}
+		next, err := updateFn(curr)
+		if next == nil || err != nil {
+			return err
+		}
+		err = kvStore.AtomicPut(ctx, key, curr, next)
+		if err == nil || !errors.Is(err, &util.ConflictError{}) {
+			return err
+		}
+	}
+	return util.NewConflictError(fmt.Sprintf("too many conflicts updating %s", key))
+}
+
+func (a *Absence) run() {
+	defer close(a.done)
+	ticker := time.NewTicker(a.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stopCh:
+			return
+		case <-ticker.C:
+			a.check(context.Background())
+		}
+	}
+}
+
+// check sends an absent event for each key that became silent since the last check
+func (a *Absence) check(ctx context.Context) {
+	stateKeys, err := a.kvStore.List(ctx, a.prefix())
+	if err != nil {
+		a.emitter.AddInt64(a.name + ".failed", 1)
+		return
+	}
+	for _, stateKey := range stateKeys {
+		now := time.Now()
+		var absent *absenceState
+		err = a.update(ctx, stateKey, func(curr *absenceState) *absenceState {
+			absent = nil
+			if curr == nil || curr.Absent || now.Sub(curr.LastSeen) <= a.threshold {
+				return nil
+			}
+			absent = &absenceState{Key: curr.Key, LastSeen: curr.LastSeen, Absent: true, AbsentSince: now}
+			return absent
+		})
+		if err != nil {
+			a.emitter.AddInt64(a.name + ".failed", 1)
+		} else if absent != nil {
+			a.emit(ctx, AbsenceStatusAbsent, absent, now)
+		}
+	}
+}
+
+func (a *Absence) emit(ctx context.Context, status string, state *absenceState, detected time.Time) {
+	metadata := map[string]interface{}{
+		"name": a.name,
+		"status": status,
+		"key": util.CopyableMap(state.Key).DeepCopy(),
+		"lastSeen": state.LastSeen.UTC().Format(time.RFC3339Nano),
+		"detectedTime": detected.UTC().Format(time.RFC3339Nano),
+	}
+	if !state.AbsentSince.IsZero() {
+		metadata["absentSince"] = state.AbsentSince.UTC().Format(time.RFC3339Nano)
+	}
+	if err := a.output(ctx, map[string]interface{}{AbsenceKey: metadata}); err != nil {
+		a.emitter.AddInt64(a.name + ".failed", 1)
+		return
+	}
+	a.emitter.AddInt64(a.name + "." + status, 1)
+}
+
+// Close will stop the checker
+func (a *Absence) Close() error {
+	a.closeOnce.Do(func() {
+		close(a.stopCh)
+	})
+	<-a.done
+	return nil
+}
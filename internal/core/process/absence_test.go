@@ -0,0 +1,114 @@
+package process_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type absenceEvents struct {
+	lock sync.Mutex
+	events []map[string]interface{}
+}
+
+func (e *absenceEvents) output(ctx context.Context, in map[string]interface{}) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.events = append(e.events, in[process.AbsenceKey].(map[string]interface{}))
+	return nil
+}
+
+func (e *absenceEvents) get() []map[string]interface{} {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]map[string]interface{}{}, e.events...)
+}
+
+func newTestAbsence(t *testing.T, kvStore kvs.KVStore, events *absenceEvents) *process.Absence {
+	absence, err := process.NewAbsence("heartbeats", []string{"device", "site"}, core.TrueCondition, kvStore,
+		100 * time.Millisecond, events.output, process.WithAbsenceCheckInterval(10 * time.Millisecond))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return absence
+}
+
+func TestAbsence(t *testing.T) {
+	events := &absenceEvents{}
+	absence := newTestAbsence(t, kvs.NewMemKVStore(), events)
+	defer func() {
+		assert.Nil(t, absence.Close())
+	}()
+
+	heartbeat := map[string]interface{}{"device": "d1", "site": 3}
+	out, err := absence.Process(context.Background(), heartbeat)
+	assert.Nil(t, err)
+	assert.Equal(t, heartbeat, out)
+
+	// Events without the key fields are passed through
+	out, err = absence.Process(context.Background(), map[string]interface{}{"device": "d2"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"device": "d2"}, out)
+
+	// Each silence episode is reported once
+	time.Sleep(300 * time.Millisecond)
+	received := events.get()
+	assert.Equal(t, 1, len(received))
+	assert.Equal(t, process.AbsenceStatusAbsent, received[0]["status"])
+	assert.Equal(t, "heartbeats", received[0]["name"])
+	assert.Equal(t, map[string]interface{}{"device": "d1", "site": float64(3)}, received[0]["key"])
+	assert.Contains(t, received[0], "absentSince")
+
+	_, err = absence.Process(context.Background(), heartbeat)
+	assert.Nil(t, err)
+	received = events.get()
+	assert.Equal(t, 2, len(received))
+	assert.Equal(t, process.AbsenceStatusRecovered, received[1]["status"])
+	assert.Equal(t, received[0]["lastSeen"], received[1]["lastSeen"])
+	assert.Equal(t, received[0]["absentSince"], received[1]["absentSince"])
+
+	// Regular heartbeats are not reported
+	for i := 0; i < 5; i++ {
+		time.Sleep(50 * time.Millisecond)
+		_, err = absence.Process(context.Background(), heartbeat)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 2, len(events.get()))
+}
+
+func TestAbsenceReplicas(t *testing.T) {
+	kvStore := kvs.NewMemKVStore()
+	events := &absenceEvents{}
+	replicas := []*process.Absence{newTestAbsence(t, kvStore, events), newTestAbsence(t, kvStore, events)}
+
+	for i := 0; i < 4; i++ {
+		_, err := replicas[i % 2].Process(context.Background(), map[string]interface{}{"device": i, "site": 1})
+		assert.Nil(t, err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	for _, replica := range replicas {
+		assert.Nil(t, replica.Close())
+	}
+
+	devices := make(map[interface{}]int)
+	for _, event := range events.get() {
+		assert.Equal(t, process.AbsenceStatusAbsent, event["status"])
+		devices[event["key"].(map[string]interface{})["device"]]++
+	}
+	assert.Equal(t, map[interface{}]int{float64(0): 1, float64(1): 1, float64(2): 1, float64(3): 1}, devices)
+}
+
+func TestAbsenceInvalid(t *testing.T) {
+	events := &absenceEvents{}
+	_, err := process.NewAbsence("heartbeats", nil, core.TrueCondition, kvs.NewMemKVStore(), time.Second,
+		events.output)
+	assert.Error(t, err)
+	_, err = process.NewAbsence("heartbeats", []string{"device"}, core.TrueCondition, kvs.NewMemKVStore(), 0,
+		events.output)
+	assert.Error(t, err)
+}
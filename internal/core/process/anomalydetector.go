@@ -0,0 +1,121 @@
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/state"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// AnomalyDetector scores a numeric field against a baseline for its group (see core.AnomalyDetection),
+// and adds the score to internal:anomaly:<name>:
+//
+// {"score": <score>, "anomalous": <bool>, "warmingUp": <bool>, "mean": <mean>, "deviation": <deviation>,
+//  "count": <values in the baseline>}
+//
+// Each value is then appended to the state store and checkpointed into the baselines, like an
+// Aggregator, so the baselines survive restarts.  If onlyAnomalies is set, processing stops for values
+// that are not anomalous, like a Continuation.  Events without the field are passed through.
+type AnomalyDetector struct {
+	name string
+	detection *core.AnomalyDetection
+	condition *core.Condition
+	stateStore state.StateStore
+	onlyAnomalies bool
+}
+
+func NewAnomalyDetector(name string, detection *core.AnomalyDetection, condition *core.Condition,
+	stateStore state.StateStore, onlyAnomalies bool) *AnomalyDetector {
+	return &AnomalyDetector{
+		name: name,
+		detection: detection,
+		condition: condition,
+		stateStore: stateStore,
+		onlyAnomalies: onlyAnomalies,
+	}
+}
+
+func (a AnomalyDetector) Name() string {
+	return a.name
+}
+
+func (a AnomalyDetector) getState(ctx context.Context, stateKey string) (*core.AnomalyState, error) {
+	stateBytes, err := a.stateStore.Get(ctx, stateKey)
+	if err != nil {
+		if errors.Is(err, &util.NotFoundError{}) {
+			return core.NewAnomalyState(), nil
+		}
+		return nil, err
+	}
+	return core.NewAnomalyStateFromBytes(stateBytes)
+}
+
+func (a AnomalyDetector) checkpointMapFunc() func(curr, val []byte) ([]byte, error) {
+	return func(curr, val []byte) ([]byte, error) {
+		anomalyState := core.NewAnomalyState()
+		if curr != nil {
+			var err error
+			if anomalyState, err = core.NewAnomalyStateFromBytes(curr); err != nil {
+				return nil, err
+			}
+		}
+		observation := &core.AnomalyObservation{}
+		if err := json.Unmarshal(val, observation); err != nil {
+			return nil, err
+		}
+		anomalyState.Update(observation, a.detection.DecayFactor)
+		return anomalyState.Bytes()
+	}
+}
+
+func (a AnomalyDetector) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+	if shouldProcess, err := a.condition.Evaluate(in); !shouldProcess || err != nil {
+		return in, PipelineProcessError(a, err, "evaluating condition")
+	}
+
+	observation, err := a.detection.Observation(in)
+	if err != nil {
+		return nil, PipelineProcessError(a, err, "getting value")
+	} else if observation == nil {
+		return in, nil
+	}
+
+	partitionID, err := core.GetPartitionID(in)
+	if err != nil {
+		return nil, PipelineProcessError(a, err, "get partition id")
+	}
+	pipelineName, err := core.GetName(in)
+	if err != nil {
+		return nil, PipelineProcessError(a, err, "get pipeline name")
+	}
+	stateKey := core.AnomalyStateKey(partitionID, pipelineName, a.name)
+
+	anomalyState, err := a.getState(ctx, stateKey)
+	if err != nil {
+		return nil, PipelineProcessError(a, err, "get anomaly state")
+	}
+	score := a.detection.Score(anomalyState.Baselines[observation.Baseline], observation.Value)
+
+	observationBytes, err := json.Marshal(observation)
+	if err != nil {
+		return nil, PipelineProcessError(a, err, "converting observation to JSON")
+	}
+	if err = a.stateStore.Append(ctx, stateKey, observationBytes); err != nil {
+		return nil, PipelineProcessError(a, err, "appending to state store")
+	}
+	if err = a.stateStore.Checkpoint(ctx, stateKey, a.checkpointMapFunc()); err != nil {
+		return nil, PipelineProcessError(a, err, "checkpoint")
+	}
+
+	if a.onlyAnomalies && !score.Anomalous {
+		return nil, PipelineProcessError(a, util.NewContinuationNotSatisfied("not anomalous"), "")
+	}
+	out := util.CopyableMap(in).DeepCopy()
+	if err = common.SetUsingInternalPrefix(common.AnomalyPrefix, a.name, score.ToMap(), out, true); err != nil {
+		return nil, PipelineProcessError(a, err, "set anomaly score")
+	}
+	return out, nil
+}
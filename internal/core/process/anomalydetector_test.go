@@ -0,0 +1,83 @@
+package process_test
+
+import (
+	"context"
+	gUuid "github.com/google/uuid"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/kmgreen2/agglo/pkg/state"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func anomalyEvent(partitionID gUuid.UUID, host string, latency float64) map[string]interface{} {
+	m := map[string]interface{}{"host": host, "latency": latency}
+	common.MustSetUsingInternalKey(common.PartitionIDKey, partitionID.String(), m)
+	common.MustSetUsingInternalKey(common.ResourceNameKey, "requests", m)
+	return m
+}
+
+func newTestAnomalyDetector(t *testing.T, kvStore kvs.KVStore, onlyAnomalies bool) *process.AnomalyDetector {
+	detection, err := core.NewAnomalyDetection("latency", []string{"host"}, core.WithAnomalyWarmUp(10),
+		core.WithAnomalyDecayFactor(0.1))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return process.NewAnomalyDetector("latencyAnomalies", detection, core.TrueCondition,
+		state.NewKvStateStore(kvStore), onlyAnomalies)
+}
+
+func TestAnomalyDetector(t *testing.T) {
+	partitionID := gUuid.New()
+	kvStore := kvs.NewMemKVStore()
+	detector := newTestAnomalyDetector(t, kvStore, false)
+	scoreKey := common.InternalKeyFromPrefix(common.AnomalyPrefix, "latencyAnomalies")
+
+	for i := 0; i < 10; i++ {
+		out, err := detector.Process(context.Background(), anomalyEvent(partitionID, "a", float64(100 + i % 2)))
+		assert.Nil(t, err)
+		score := out[scoreKey].(map[string]interface{})
+		assert.Equal(t, true, score["warmingUp"])
+		assert.Equal(t, false, score["anomalous"])
+		assert.Equal(t, int64(i), score["count"])
+	}
+
+	out, err := detector.Process(context.Background(), anomalyEvent(partitionID, "a", 101))
+	assert.Nil(t, err)
+	assert.Equal(t, false, out[scoreKey].(map[string]interface{})["anomalous"])
+	assert.Contains(t, out[scoreKey], "score")
+
+	// Groups have separate baselines
+	out, err = detector.Process(context.Background(), anomalyEvent(partitionID, "b", 500))
+	assert.Nil(t, err)
+	assert.Equal(t, true, out[scoreKey].(map[string]interface{})["warmingUp"])
+
+	// The baselines are checkpointed, so they survive a restart
+	detector = newTestAnomalyDetector(t, kvStore, true)
+	out, err = detector.Process(context.Background(), anomalyEvent(partitionID, "a", 500))
+	assert.Nil(t, err)
+	score := out[scoreKey].(map[string]interface{})
+	assert.Equal(t, true, score["anomalous"])
+	assert.Equal(t, int64(11), score["count"])
+	assert.Greater(t, score["score"], 3.0)
+
+	// Only anomalies continue
+	out, err = detector.Process(context.Background(), anomalyEvent(partitionID, "a", 100))
+	assert.Nil(t, out)
+	assert.True(t, errors.Is(err, &util.ContinuationNotSatisfied{}))
+
+	// Events without the field are passed through
+	in := anomalyEvent(partitionID, "a", 0)
+	delete(in, "latency")
+	out, err = detector.Process(context.Background(), in)
+	assert.Nil(t, err)
+	assert.Equal(t, in, out)
+
+	in["latency"] = "slow"
+	_, err = detector.Process(context.Background(), in)
+	assert.Error(t, err)
+}
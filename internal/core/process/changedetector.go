@@ -0,0 +1,188 @@
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+	"math"
+	"reflect"
+)
+
+// ChangeField is a field whose changes are detected.  A numeric value has changed when it differs from the
+// last reported value by more than AbsoluteDeadband and by more than RelativeDeadband times the last
+// reported value (a deadband of 0 is not applied).  Any other difference is a change.
+type ChangeField struct {
+	Path string
+	AbsoluteDeadband float64
+	RelativeDeadband float64
+}
+
+// ChangeDetector continues the pipeline only when the value of a field changes, e.g. to drop the repeated
+// readings of a sensor.  The last reported values are kept in a KV store for each key, i.e. the values of
+// the key fields, so replicas that share the store agree on the changes.  A value that is within the
+// deadband is not reported, and is not recorded, so a slow drift is reported once it leaves the deadband.
+//
+// The changed fields are added to internal:change:<name>:
+//
+// {<path>: {"old": <value>, "new": <value>, "delta": <new - old, if numeric>}, ...}
+//
+// The first value of a field is a change, whose old value is nil.  Events without a key field or any of the
+// fields are passed through.
+type ChangeDetector struct {
+	name string
+	keyFields []string
+	fields []ChangeField
+	paths []*util.Path
+	condition *core.Condition
+	kvStore kvs.KVStore
+}
+
+func NewChangeDetector(name string, keyFields []string, fields []ChangeField, condition *core.Condition,
+	kvStore kvs.KVStore) (*ChangeDetector, error) {
+	if len(fields) == 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("change detector %s: at least one field is required", name))
+	}
+	c := &ChangeDetector{
+		name: name,
+		keyFields: keyFields,
+		fields: fields,
+		condition: condition,
+		kvStore: kvStore,
+	}
+	for _, field := range fields {
+		if field.AbsoluteDeadband < 0 || field.RelativeDeadband < 0 {
+			msg := fmt.Sprintf("change detector %s: deadbands of %s must not be negative", name, field.Path)
+			return nil, util.NewInvalidError(msg)
+		}
+		path, err := util.NewPath(field.Path)
+		if err != nil {
+			return nil, err
+		}
+		c.paths = append(c.paths, path)
+	}
+	return c, nil
+}
+
+func (c ChangeDetector) Name() string {
+	return c.name
+}
+
+func (c ChangeDetector) stateKey(keyString string) string {
+	return fmt.Sprintf("change:%s:%s", c.name, keyString)
+}
+
+// changed returns true if the value is outside of the field's deadband around the previous value
+func (c ChangeDetector) changed(field ChangeField, prev, value interface{}) bool {
+	prevNum, prevErr := util.GetNumeric(prev)
+	valueNum, valueErr := util.GetNumeric(value)
+	if prevErr != nil || valueErr != nil {
+		return !reflect.DeepEqual(prev, value)
+	}
+	delta := math.Abs(valueNum - prevNum)
+	if field.AbsoluteDeadband > 0 && delta <= field.AbsoluteDeadband {
+		return false
+	}
+	if field.RelativeDeadband > 0 && delta <= field.RelativeDeadband * math.Abs(prevNum) {
+		return false
+	}
+	return delta > 0
+}
+
+// values returns the values of the fields in the event, and their JSON representation, which is how they
+// are compared with the stored values
+func (c ChangeDetector) values(in map[string]interface{}) (map[string]interface{}, map[string]interface{},
+	error) {
+	values := make(map[string]interface{})
+	normalized := make(map[string]interface{})
+	for i, path := range c.paths {
+		value, err := path.Get(in)
+		if err != nil {
+			if errors.Is(err, &util.NotFoundError{}) {
+				continue
+			}
+			return nil, nil, err
+		}
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		var normalizedValue interface{}
+		if err = json.Unmarshal(valueBytes, &normalizedValue); err != nil {
+			return nil, nil, err
+		}
+		values[c.fields[i].Path] = value
+		normalized[c.fields[i].Path] = normalizedValue
+	}
+	return values, normalized, nil
+}
+
+func (c ChangeDetector) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+	if shouldProcess, err := c.condition.Evaluate(in); !shouldProcess || err != nil {
+		return in, PipelineProcessError(c, err, "evaluating condition")
+	}
+	key, keyString, err := fieldKey(c.keyFields, in)
+	if err != nil {
+		return nil, PipelineProcessError(c, err, "getting key")
+	} else if key == nil {
+		return in, nil
+	}
+	values, normalized, err := c.values(in)
+	if err != nil {
+		return nil, PipelineProcessError(c, err, "getting fields")
+	} else if len(values) == 0 {
+		return in, nil
+	}
+
+	var diff map[string]interface{}
+	err = atomicUpdate(ctx, c.kvStore, c.stateKey(keyString), func(curr []byte) ([]byte, error) {
+		last := make(map[string]interface{})
+		if curr != nil {
+			if err := json.Unmarshal(curr, &last); err != nil {
+				return nil, err
+			}
+		}
+		diff = make(map[string]interface{})
+		for _, field := range c.fields {
+			value, ok := normalized[field.Path]
+			if !ok {
+				continue
+			}
+			prev, seen := last[field.Path]
+			if seen && !c.changed(field, prev, value) {
+				continue
+			}
+			change := map[string]interface{}{
+				"old": prev,
+				"new": values[field.Path],
+			}
+			prevNum, prevErr := util.GetNumeric(prev)
+			valueNum, valueErr := util.GetNumeric(value)
+			if seen && prevErr == nil && valueErr == nil {
+				change["delta"] = valueNum - prevNum
+			}
+			diff[field.Path] = change
+			last[field.Path] = value
+		}
+		if len(diff) == 0 {
+			return nil, nil
+		}
+		return json.Marshal(last)
+	})
+	if err != nil {
+		return nil, PipelineProcessError(c, err, "updating state")
+	}
+	if len(diff) == 0 {
+		return nil, PipelineProcessError(c, util.NewContinuationNotSatisfied("no change"), "")
+	}
+
+	out := util.CopyableMap(in).DeepCopy()
+	if err = common.SetUsingInternalPrefix(common.ChangeDiffPrefix, c.name, diff, out, true); err != nil {
+		return nil, PipelineProcessError(c, err, "setting diff")
+	}
+	return out, nil
+}
@@ -0,0 +1,113 @@
+package process_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func newTestChangeDetector(t *testing.T, kvStore kvs.KVStore) *process.ChangeDetector {
+	detector, err := process.NewChangeDetector("sensors", []string{"sensor"}, []process.ChangeField{
+		{Path: "temperature", AbsoluteDeadband: 0.5},
+		{Path: "humidity", RelativeDeadband: 0.1},
+		{Path: "state"},
+	}, core.TrueCondition, kvStore)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return detector
+}
+
+func TestChangeDetector(t *testing.T) {
+	detector := newTestChangeDetector(t, kvs.NewMemKVStore())
+	diffKey := common.InternalKeyFromPrefix(common.ChangeDiffPrefix, "sensors")
+
+	out, err := detector.Process(context.Background(), map[string]interface{}{
+		"sensor": "s1", "temperature": 20.0, "humidity": 50, "state": "ok",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"temperature": map[string]interface{}{"old": nil, "new": 20.0},
+		"humidity": map[string]interface{}{"old": nil, "new": 50},
+		"state": map[string]interface{}{"old": nil, "new": "ok"},
+	}, out[diffKey])
+
+	// Values within the deadbands stop the pipeline
+	out, err = detector.Process(context.Background(), map[string]interface{}{
+		"sensor": "s1", "temperature": 20.4, "humidity": 54, "state": "ok",
+	})
+	assert.Nil(t, out)
+	assert.True(t, errors.Is(err, &util.ContinuationNotSatisfied{}))
+
+	// Drift is measured from the last reported value
+	out, err = detector.Process(context.Background(), map[string]interface{}{
+		"sensor": "s1", "temperature": 20.6, "humidity": 54, "state": "ok",
+	})
+	assert.Nil(t, err)
+	diff := out[diffKey].(map[string]interface{})
+	assert.Equal(t, 1, len(diff))
+	assert.Equal(t, 20.0, diff["temperature"].(map[string]interface{})["old"])
+	assert.InDelta(t, 0.6, diff["temperature"].(map[string]interface{})["delta"], 0.0001)
+
+	out, err = detector.Process(context.Background(), map[string]interface{}{
+		"sensor": "s1", "temperature": 20.6, "humidity": 56, "state": "alarm",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"humidity": map[string]interface{}{"old": float64(50), "new": 56, "delta": float64(6)},
+		"state": map[string]interface{}{"old": "ok", "new": "alarm"},
+	}, out[diffKey])
+
+	// Keys are independent, and events without a key are passed through
+	out, err = detector.Process(context.Background(), map[string]interface{}{"sensor": "s2", "state": "ok"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"state": map[string]interface{}{"old": nil, "new": "ok"}},
+		out[diffKey])
+	in := map[string]interface{}{"temperature": 30.0}
+	out, err = detector.Process(context.Background(), in)
+	assert.Nil(t, err)
+	assert.Equal(t, in, out)
+}
+
+func TestChangeDetectorConcurrent(t *testing.T) {
+	kvStore := kvs.NewMemKVStore()
+	replicas := []*process.ChangeDetector{newTestChangeDetector(t, kvStore), newTestChangeDetector(t, kvStore)}
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	changes := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := replicas[i % 2].Process(context.Background(), map[string]interface{}{
+				"sensor": "s1", "state": "ok",
+			})
+			if err == nil {
+				lock.Lock()
+				changes++
+				lock.Unlock()
+			} else {
+				assert.True(t, errors.Is(err, &util.ContinuationNotSatisfied{}))
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, changes)
+}
+
+func TestChangeDetectorInvalid(t *testing.T) {
+	_, err := process.NewChangeDetector("sensors", nil, nil, core.TrueCondition, kvs.NewMemKVStore())
+	assert.Error(t, err)
+	_, err = process.NewChangeDetector("sensors", nil, []process.ChangeField{
+		{Path: "temperature", AbsoluteDeadband: -1},
+	}, core.TrueCondition, kvs.NewMemKVStore())
+	assert.Error(t, err)
+}
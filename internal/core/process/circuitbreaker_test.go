@@ -0,0 +1,24 @@
+package process
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerUntilReset(t *testing.T) {
+	breaker := &circuitBreaker{threshold: 1, resetTimeout: time.Second}
+	breaker.failure()
+	assert.False(t, breaker.allow())
+	assert.InDelta(t, time.Second, breaker.untilReset(), float64(100*time.Millisecond))
+
+	// Another goroutine holds the attempt, so waiters must still sleep
+	breaker.openedAt = time.Now().Add(-2 * time.Second)
+	assert.True(t, breaker.allow())
+	assert.False(t, breaker.allow())
+	assert.Equal(t, 100*time.Millisecond, breaker.untilReset())
+
+	breaker = &circuitBreaker{threshold: 1}
+	breaker.failure()
+	assert.Equal(t, time.Millisecond, breaker.untilReset())
+}
@@ -0,0 +1,339 @@
+package process
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultHmacHeader is the header of HMAC request signatures, when one is not set
+const DefaultHmacHeader = "X-Signature"
+
+// HttpSecret returns a secret when a request is sent, so rotated secrets are picked up
+type HttpSecret func() (string, error)
+
+// StaticSecret returns the value
+func StaticSecret(value string) HttpSecret {
+	return func() (string, error) {
+		return value, nil
+	}
+}
+
+// SecretFromFile reads the secret from a file, without surrounding whitespace
+func SecretFromFile(path string) HttpSecret {
+	return func() (string, error) {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+}
+
+// SecretFromEnv reads the secret from an environment variable, which must be set
+func SecretFromEnv(name string) HttpSecret {
+	return func() (string, error) {
+		value, ok := os.LookupEnv(name)
+		if !ok || len(value) == 0 {
+			return "", util.NewNotFoundError(fmt.Sprintf("environment variable '%s' is not set", name))
+		}
+		return value, nil
+	}
+}
+
+// HttpAuth authenticates the requests of an HTTP tee.  body is the request's payload.
+type HttpAuth interface {
+	Authenticate(req *http.Request, body []byte) error
+}
+
+type basicAuth struct {
+	username string
+	password HttpSecret
+}
+
+// NewHttpBasicAuth will set the Authorization header with basic auth
+func NewHttpBasicAuth(username string, password HttpSecret) HttpAuth {
+	return basicAuth{username, password}
+}
+
+func (auth basicAuth) Authenticate(req *http.Request, body []byte) error {
+	password, err := auth.password()
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(auth.username, password)
+	return nil
+}
+
+type bearerAuth struct {
+	token HttpSecret
+}
+
+// NewHttpBearerAuth will set the Authorization header with a bearer token
+func NewHttpBearerAuth(token HttpSecret) HttpAuth {
+	return bearerAuth{token}
+}
+
+func (auth bearerAuth) Authenticate(req *http.Request, body []byte) error {
+	token, err := auth.token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer " + token)
+	return nil
+}
+
+type hmacAuth struct {
+	secret HttpSecret
+	header string
+	algorithm string
+	newHash func() hash.Hash
+}
+
+// NewHttpHmacAuth will sign the body of each request, setting header (default DefaultHmacHeader) to
+// "<algorithm>=<hex signature>", e.g. "sha256=6f1c...".  algorithm is sha1, sha256 (the default) or
+// sha512.
+func NewHttpHmacAuth(secret HttpSecret, header, algorithm string) (HttpAuth, error) {
+	auth := hmacAuth{secret: secret, header: header, algorithm: algorithm}
+	if len(auth.header) == 0 {
+		auth.header = DefaultHmacHeader
+	}
+	switch algorithm {
+	case "sha1":
+		auth.newHash = sha1.New
+	case "", "sha256":
+		auth.algorithm = "sha256"
+		auth.newHash = sha256.New
+	case "sha512":
+		auth.newHash = sha512.New
+	default:
+		return nil, util.NewInvalidError(fmt.Sprintf("unknown hmac algorithm '%s'", algorithm))
+	}
+	return auth, nil
+}
+
+func (auth hmacAuth) Authenticate(req *http.Request, body []byte) error {
+	secret, err := auth.secret()
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(auth.newHash, []byte(secret))
+	mac.Write(body)
+	req.Header.Set(auth.header, auth.algorithm + "=" + hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+// NewHttpTLSConfig returns the TLS config of an HTTP client.  certFile and keyFile are the client
+// certificate, and caFile replaces the system's root CAs; each is optional.
+func NewHttpTLSConfig(certFile, keyFile, caFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	if len(certFile) > 0 || len(keyFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, util.NewInvalidError(fmt.Sprintf("cannot load client certificate: %s", err.Error()))
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if len(caFile) > 0 {
+		caBytes, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, util.NewInvalidError(fmt.Sprintf("no certificates found in '%s'", caFile))
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// NewHttpClient returns a client with a timeout (0 is no timeout) and, optionally, a TLS config
+func NewHttpClient(timeout time.Duration, tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// httpTeeSettings are set by the HTTP tee options
+type httpTeeSettings struct {
+	method string
+	url *core.EventTemplate
+	headers map[string]string
+	headerTemplates map[string]*core.EventTemplate
+	auth []HttpAuth
+	acceptedStatusCodes map[int]bool
+	captureResponse bool
+}
+
+// WithHttpMethod sets the request method of an HTTP tee (default POST)
+func WithHttpMethod(method string) TeeOption {
+	return func(settings *teeSettings) {
+		settings.http.method = method
+	}
+}
+
+// WithHttpURLTemplate renders the URL of each request over the output, rather than using the tee's URL
+func WithHttpURLTemplate(url *core.EventTemplate) TeeOption {
+	return func(settings *teeSettings) {
+		settings.http.url = url
+	}
+}
+
+// WithHttpHeaders adds static request headers to an HTTP tee
+func WithHttpHeaders(headers map[string]string) TeeOption {
+	return func(settings *teeSettings) {
+		if settings.http.headers == nil {
+			settings.http.headers = make(map[string]string)
+		}
+		for k, v := range headers {
+			settings.http.headers[k] = v
+		}
+	}
+}
+
+// WithHttpHeaderTemplate adds a request header, whose value is rendered over the output
+func WithHttpHeaderTemplate(name string, value *core.EventTemplate) TeeOption {
+	return func(settings *teeSettings) {
+		if settings.http.headerTemplates == nil {
+			settings.http.headerTemplates = make(map[string]*core.EventTemplate)
+		}
+		settings.http.headerTemplates[name] = value
+	}
+}
+
+// WithHttpAuth adds an authenticator to an HTTP tee.  Authenticators are applied in order, after the
+// headers are set.
+func WithHttpAuth(auth HttpAuth) TeeOption {
+	return func(settings *teeSettings) {
+		settings.http.auth = append(settings.http.auth, auth)
+	}
+}
+
+// WithHttpAcceptedStatusCodes sets the response status codes that are successful (default is any status
+// below 300)
+func WithHttpAcceptedStatusCodes(codes ...int) TeeOption {
+	return func(settings *teeSettings) {
+		settings.http.acceptedStatusCodes = make(map[int]bool)
+		for _, code := range codes {
+			settings.http.acceptedStatusCodes[code] = true
+		}
+	}
+}
+
+// WithHttpCaptureResponse will return the statusCode and the body of each response, which is decoded if
+// it is JSON, as the tee's response.  Without it, the response is the body if it is a JSON object.
+func WithHttpCaptureResponse() TeeOption {
+	return func(settings *teeSettings) {
+		settings.http.captureResponse = true
+	}
+}
+
+func (settings httpTeeSettings) accepts(statusCode int) bool {
+	if len(settings.acceptedStatusCodes) > 0 {
+		return settings.acceptedStatusCodes[statusCode]
+	}
+	return statusCode < 300
+}
+
+func newHttpOutputFunc(client common.HTTPClient, url string, settings *teeSettings,
+	additionalBody map[string]interface{}) func(ctx context.Context, key string,
+	in map[string]interface{}) (map[string]interface{}, error) {
+	httpSettings := settings.http
+	method := httpSettings.method
+	if len(method) == 0 {
+		method = http.MethodPost
+	}
+
+	return func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
+		payload, err := serializeDocument(settings.serializer, in, additionalBody)
+		if err != nil {
+			return nil, err
+		}
+		requestURL := url
+		if httpSettings.url != nil {
+			if requestURL, err = httpSettings.url.Render(in); err != nil {
+				return nil, err
+			}
+		}
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", settings.serializer.ContentType())
+		for k, v := range httpSettings.headers {
+			req.Header.Set(k, v)
+		}
+		for k, tmpl := range httpSettings.headerTemplates {
+			v, err := tmpl.Render(in)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set(k, v)
+		}
+		for _, auth := range httpSettings.auth {
+			if err = auth.Authenticate(req, payload); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		var bodyBytes []byte
+		if resp.Body != nil {
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			if bodyBytes, err = ioutil.ReadAll(resp.Body); err != nil {
+				return nil, errors.Wrap(err, "NewHttpTee: error reading response body")
+			}
+		}
+		if !httpSettings.accepts(resp.StatusCode) {
+			msg := fmt.Sprintf("error status %d sending to url '%s'", resp.StatusCode, requestURL)
+			return nil, util.NewInternalError(msg)
+		}
+
+		if httpSettings.captureResponse {
+			out := map[string]interface{}{
+				"statusCode": float64(resp.StatusCode),
+			}
+			if len(bodyBytes) > 0 {
+				var respBody interface{}
+				if json.Unmarshal(bodyBytes, &respBody) == nil {
+					out["body"] = respBody
+				} else {
+					out["body"] = string(bodyBytes)
+				}
+			}
+			return out, nil
+		}
+		if len(bodyBytes) == 0 {
+			return nil, nil
+		}
+		respMap, err := util.JsonToMap(bodyBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "NewHttpTee: error converting response body to JSON")
+		}
+		return respMap, nil
+	}
+}
@@ -0,0 +1,169 @@
+package process_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type recordedRequest struct {
+	*http.Request
+	body []byte
+}
+
+// recordingServer records each request and responds with status and body
+func recordingServer(status int, body string) (*httptest.Server, chan recordedRequest) {
+	requests := make(chan recordedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		requests <- recordedRequest{r, b}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	return server, requests
+}
+
+func mustEventTemplate(t *testing.T, text string) *core.EventTemplate {
+	tmpl, err := core.NewEventTemplate("test", text)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return tmpl
+}
+
+func TestHttpTeeRequest(t *testing.T) {
+	server, requests := recordingServer(http.StatusAccepted, "ok")
+	defer server.Close()
+
+	assert.Nil(t, os.Setenv("TEST_HTTP_TEE_TOKEN", "secret-token"))
+	defer func() {
+		_ = os.Unsetenv("TEST_HTTP_TEE_TOKEN")
+	}()
+	hmacAuth, err := process.NewHttpHmacAuth(process.StaticSecret("key"), "", "")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	tee := process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil, nil,
+		process.WithHttpMethod(http.MethodPut),
+		process.WithHttpURLTemplate(mustEventTemplate(t, server.URL + "/teams/{{.team}}")),
+		process.WithHttpHeaders(map[string]string{"X-Source": "binge"}),
+		process.WithHttpHeaderTemplate("X-Severity", mustEventTemplate(t, "{{.severity}}")),
+		process.WithHttpAuth(process.NewHttpBearerAuth(process.SecretFromEnv("TEST_HTTP_TEE_TOKEN"))),
+		process.WithHttpAuth(hmacAuth),
+		process.WithHttpAcceptedStatusCodes(http.StatusAccepted),
+		process.WithHttpCaptureResponse())
+
+	out, err := tee.Process(context.Background(), map[string]interface{}{"team": "infra", "severity": "high"})
+	assert.Nil(t, err)
+	req := <-requests
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "/teams/infra", req.URL.Path)
+	assert.Equal(t, "binge", req.Header.Get("X-Source"))
+	assert.Equal(t, "high", req.Header.Get("X-Severity"))
+	assert.Equal(t, "Bearer secret-token", req.Header.Get("Authorization"))
+	mac := hmac.New(sha256.New, []byte("key"))
+	mac.Write(req.body)
+	assert.Equal(t, "sha256=" + hex.EncodeToString(mac.Sum(nil)), req.Header.Get(process.DefaultHmacHeader))
+
+	// The non-JSON body is captured with the status
+	teeOutput := out[process.TeeMetadataKey].([]map[string]interface{})[0]
+	assert.Equal(t, map[string]interface{}{"statusCode": float64(http.StatusAccepted), "body": "ok"},
+		teeOutput["response"])
+
+	// Missing template fields fail the output
+	_, err = tee.Process(context.Background(), map[string]interface{}{"team": "infra"})
+	assert.Error(t, err)
+
+	// A missing secret fails the output
+	_ = os.Unsetenv("TEST_HTTP_TEE_TOKEN")
+	_, err = tee.Process(context.Background(), map[string]interface{}{"team": "infra", "severity": "high"})
+	assert.Error(t, err)
+
+	_, err = process.NewHttpHmacAuth(process.StaticSecret("key"), "", "md5")
+	assert.Error(t, err)
+}
+
+func TestHttpTeeStatusCodes(t *testing.T) {
+	server, requests := recordingServer(http.StatusOK, `{"ok": true}`)
+	defer server.Close()
+
+	// 200 is not accepted
+	tee := process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil, nil,
+		process.WithHttpAcceptedStatusCodes(http.StatusAccepted))
+	_, err := tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+	<-requests
+
+	dir := newTempDir(t, "httptee")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	passwordFile := filepath.Join(dir, "password")
+	assert.Nil(t, ioutil.WriteFile(passwordFile, []byte("hunter2\n"), 0600))
+
+	tee = process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil, nil,
+		process.WithHttpAuth(process.NewHttpBasicAuth("binge", process.SecretFromFile(passwordFile))))
+	out, err := tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+	username, password, ok := (<-requests).BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "binge", username)
+	assert.Equal(t, "hunter2", password)
+	teeOutput := out[process.TeeMetadataKey].([]map[string]interface{})[0]
+	assert.Equal(t, map[string]interface{}{"ok": true}, teeOutput["response"])
+}
+
+func TestHttpTeeClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	dir := newTempDir(t, "httptee")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	caFile := filepath.Join(dir, "ca.pem")
+	assert.Nil(t, ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE",
+		Bytes: server.Certificate().Raw}), 0600))
+
+	tlsConfig, err := process.NewHttpTLSConfig("", "", caFile, false)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	client := process.NewHttpClient(50 * time.Millisecond, tlsConfig)
+	tee := process.NewHttpTee("tee", client, server.URL, core.TrueCondition, nil, nil)
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+
+	// The server's certificate is not trusted without the CA
+	tee = process.NewHttpTee("tee", process.NewHttpClient(0, nil), server.URL, core.TrueCondition, nil, nil)
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+
+	tee = process.NewHttpTee("tee", client, server.URL + "/slow", core.TrueCondition, nil, nil)
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+
+	_, err = process.NewHttpTLSConfig(filepath.Join(dir, "missing.pem"), filepath.Join(dir, "missing.key"),
+		"", false)
+	assert.Error(t, err)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "empty.pem"), []byte("not a certificate"), 0600))
+	_, err = process.NewHttpTLSConfig("", "", filepath.Join(dir, "empty.pem"), false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,186 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	gUuid "github.com/google/uuid"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"strings"
+	"sync"
+)
+
+// TeeMode determines when a multi-destination tee fails
+type TeeMode string
+
+const (
+	// TeeAllOrNothing fails if any destination fails, after deleting the output from the destinations
+	// that support it (KV stores and object stores without batching)
+	TeeAllOrNothing TeeMode = "allOrNothing"
+	// TeeAtLeastOne fails if every destination fails
+	TeeAtLeastOne TeeMode = "atLeastOne"
+	// TeeBestEffort never fails; failed destinations are recorded in TeeMetadataKey
+	TeeBestEffort TeeMode = "bestEffort"
+)
+
+// ParseTeeMode returns the mode with the name ("" is TeeAllOrNothing)
+func ParseTeeMode(name string) (TeeMode, error) {
+	switch TeeMode(name) {
+	case "":
+		return TeeAllOrNothing, nil
+	case TeeAllOrNothing, TeeAtLeastOne, TeeBestEffort:
+		return TeeMode(name), nil
+	}
+	return TeeAllOrNothing, util.NewInvalidError(fmt.Sprintf("unknown tee mode '%s'", name))
+}
+
+// MultiTee is a Tee that writes each output to several destinations, in parallel.  The destinations are
+// Tees, whose conditions and transformers are ignored, and every destination uses the same key.
+type MultiTee struct {
+	name string
+	destinations []*Tee
+	mode TeeMode
+	condition *core.Condition
+	transformer *Transformer
+}
+
+// NewMultiTee will create a Tee processor that writes to each of the destinations
+func NewMultiTee(name string, destinations []*Tee, mode TeeMode, condition *core.Condition,
+	transformer *Transformer) *MultiTee {
+	if transformer == nil {
+		transformation := core.NewTransformation(
+			[]core.FieldTransformation{&core.CopyTransformation{}},
+			core.TrueCondition)
+		transformer = DefaultTransformer()
+		transformer.AddSpec("", "", transformation)
+	}
+	return &MultiTee{
+		name: name,
+		destinations: destinations,
+		mode: mode,
+		condition: condition,
+		transformer: transformer,
+	}
+}
+
+func (t MultiTee) Name() string {
+	return t.name
+}
+
+// Close will close each destination
+func (t MultiTee) Close() error {
+	var err error
+	for _, destination := range t.destinations {
+		if closeErr := destination.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+type destinationResult struct {
+	response map[string]interface{}
+	deliveryStatus string
+	err error
+	compensated bool
+}
+
+// Process will write the output to each destination and, if the mode allows it, append an entry for each
+// destination to TeeMetadataKey.  Failed destinations have an "error".
+func (t MultiTee) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+	shouldTee, err := t.condition.Evaluate(in)
+	if err != nil {
+		return in, PipelineProcessError(t, err, "evaluating condition")
+	}
+
+	if !shouldTee {
+		return in, nil
+	}
+
+	uuid, err := gUuid.NewRandom()
+	if err != nil {
+		return nil, PipelineProcessError(t, err, "generating UUID")
+	}
+	key := uuid.String()
+
+	out := util.CopyableMap(in).DeepCopy()
+
+	teeOut, err := t.transformer.Process(ctx, in)
+	if err != nil {
+		return nil, PipelineProcessError(t, err, "transforming fields")
+	}
+
+	results := make([]destinationResult, len(t.destinations))
+	var wg sync.WaitGroup
+	for i, destination := range t.destinations {
+		wg.Add(1)
+		go func(i int, destination *Tee) {
+			defer wg.Done()
+			results[i].response, results[i].deliveryStatus, results[i].err = destination.deliver(ctx, key, teeOut)
+		}(i, destination)
+	}
+	wg.Wait()
+
+	numFailed := 0
+	for _, result := range results {
+		if result.err != nil {
+			numFailed++
+		}
+	}
+	if (t.mode == TeeAllOrNothing && numFailed > 0) || (t.mode == TeeAtLeastOne && numFailed == len(results)) {
+		if t.mode == TeeAllOrNothing {
+			t.compensate(ctx, key, results)
+		}
+		return nil, PipelineProcessError(t, util.NewInternalError(t.failureMessage(results)),
+			"running output functions")
+	}
+
+	teeOutputs := make([]map[string]interface{}, len(results))
+	for i, result := range results {
+		teeOutputs[i] = t.destinations[i].teeOutput(key, result.response, result.deliveryStatus)
+		teeOutputs[i]["destination"] = t.destinations[i].name
+		if result.err != nil {
+			teeOutputs[i]["error"] = result.err.Error()
+		}
+	}
+	if err = appendTeeOutputs(out, teeOutputs...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// compensate will delete the output from the destinations that wrote it inline and support deletes.
+// Buffered outputs cannot be compensated.
+func (t MultiTee) compensate(ctx context.Context, key string, results []destinationResult) {
+	var wg sync.WaitGroup
+	for i, destination := range t.destinations {
+		if results[i].err != nil || len(results[i].deliveryStatus) > 0 || destination.compensateFn == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, destination *Tee) {
+			defer wg.Done()
+			results[i].compensated = destination.compensateFn(ctx, key, results[i].response) == nil
+		}(i, destination)
+	}
+	wg.Wait()
+}
+
+func (t MultiTee) failureMessage(results []destinationResult) string {
+	var failed, written []string
+	for i, result := range results {
+		name := t.destinations[i].name
+		if result.err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", name, result.err.Error()))
+		} else if result.compensated {
+			written = append(written, name + " (deleted)")
+		} else {
+			written = append(written, name)
+		}
+	}
+	msg := fmt.Sprintf("%d of %d destinations failed: %s", len(failed), len(results), strings.Join(failed, "; "))
+	if len(written) > 0 {
+		msg += fmt.Sprintf("; written to: %s", strings.Join(written, ", "))
+	}
+	return msg
+}
@@ -0,0 +1,105 @@
+package process_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newStatusServer(status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+}
+
+func TestMultiTeeAllOrNothing(t *testing.T) {
+	failing := newStatusServer(http.StatusInternalServerError)
+	defer failing.Close()
+
+	kvStore := kvs.NewMemKVStore()
+	objectStore := newTestObjectStore(t, "multiTeeAllOrNothing")
+	keyTemplate, err := process.NewObjectKeyTemplate("events/{{uuid}}{{ext}}")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	destinations := []*process.Tee{
+		process.NewKVTee("kv", kvStore, core.TrueCondition, nil, nil),
+		process.NewObjectStoreTee("object", objectStore, core.TrueCondition, nil, nil,
+			process.WithObjectKeyTemplate(keyTemplate)),
+		process.NewHttpTee("http", http.DefaultClient, failing.URL, core.TrueCondition, nil, nil),
+	}
+	tee := process.NewMultiTee("tee", destinations, process.TeeAllOrNothing, core.TrueCondition, nil)
+
+	// The outputs written to the KV store and object store are deleted
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 destinations failed")
+	assert.Contains(t, err.Error(), "kv (deleted), object (deleted)")
+	keys, err := kvStore.List(context.Background(), "")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+	assert.Empty(t, listObjects(t, objectStore, "events/"))
+	assert.Nil(t, tee.Close())
+}
+
+func TestMultiTeeModes(t *testing.T) {
+	failing := newStatusServer(http.StatusInternalServerError)
+	defer failing.Close()
+	var calls int32
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		// Destinations are written in parallel, so both requests are in flight at once
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer healthy.Close()
+
+	newDestinations := func(urls ...string) []*process.Tee {
+		var destinations []*process.Tee
+		for i, url := range urls {
+			destinations = append(destinations, process.NewHttpTee([]string{"first", "second"}[i],
+				http.DefaultClient, url, core.TrueCondition, nil, nil))
+		}
+		return destinations
+	}
+
+	tee := process.NewMultiTee("tee", newDestinations(healthy.URL, failing.URL), process.TeeAtLeastOne,
+		core.TrueCondition, nil)
+	out, err := tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+	teeOutputs := out[process.TeeMetadataKey].([]map[string]interface{})
+	assert.Equal(t, 2, len(teeOutputs))
+	assert.Equal(t, "first", teeOutputs[0]["destination"])
+	assert.Nil(t, teeOutputs[0]["error"])
+	assert.Equal(t, "second", teeOutputs[1]["destination"])
+	assert.Contains(t, teeOutputs[1]["error"], "error status 500")
+	assert.Equal(t, teeOutputs[0]["uuid"], teeOutputs[1]["uuid"])
+
+	tee = process.NewMultiTee("tee", newDestinations(failing.URL, failing.URL), process.TeeAtLeastOne,
+		core.TrueCondition, nil)
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+
+	tee = process.NewMultiTee("tee", newDestinations(failing.URL, failing.URL), process.TeeBestEffort,
+		core.TrueCondition, nil)
+	out, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(out[process.TeeMetadataKey].([]map[string]interface{})))
+
+	start := time.Now()
+	tee = process.NewMultiTee("tee", newDestinations(healthy.URL, healthy.URL), process.TeeAllOrNothing,
+		core.TrueCondition, nil)
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < 190 * time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	_, err = process.ParseTeeMode("mostly")
+	assert.Error(t, err)
+}
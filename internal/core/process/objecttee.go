@@ -0,0 +1,422 @@
+package process
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	gUuid "github.com/google/uuid"
+	"github.com/klauspost/compress/zstd"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/pkg/observability"
+	"github.com/kmgreen2/agglo/pkg/storage"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// DefaultBatchKeyTemplate is the object key template of batching object store tees, which partitions
+// objects Hive-style by pipeline, date and hour
+const DefaultBatchKeyTemplate = "pipeline={{pipeline}}/dt={{date}}/hour={{hour}}/{{uuid}}{{ext}}"
+
+// MaxObjectBatchAttempts is the number of times a batch is written before it is dropped
+const MaxObjectBatchAttempts = 5
+
+// Compression is the compression of the objects written by an object store tee
+type Compression string
+
+const (
+	CompressionNone Compression = ""
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// ParseCompression returns the compression with the name ("", "none", "gzip" or "zstd")
+func ParseCompression(name string) (Compression, error) {
+	switch Compression(name) {
+	case CompressionNone, "none":
+		return CompressionNone, nil
+	case CompressionGzip, CompressionZstd:
+		return Compression(name), nil
+	}
+	return CompressionNone, util.NewInvalidError(fmt.Sprintf("unknown compression '%s'", name))
+}
+
+func (c Compression) extension() string {
+	switch c {
+	case CompressionGzip:
+		return ".gz"
+	case CompressionZstd:
+		return ".zst"
+	}
+	return ""
+}
+
+// newWriter returns a writer that compresses to w; closing it does not close w
+func (c Compression) newWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	}
+	return nopWriteCloser{w}, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (w nopWriteCloser) Close() error {
+	return nil
+}
+
+func (c Compression) compress(data []byte) ([]byte, error) {
+	if c == CompressionNone {
+		return data, nil
+	}
+	byteBuffer := bytes.NewBuffer([]byte{})
+	writer, err := c.newWriter(byteBuffer)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return byteBuffer.Bytes(), nil
+}
+
+// ObjectKeyTemplate renders the keys of the objects written by an object store tee
+type ObjectKeyTemplate struct {
+	tmpl *template.Template
+}
+
+// objectKeyFuncs are placeholders, which are replaced with the values for each key when it is rendered
+var objectKeyFuncs = template.FuncMap{
+	"uuid": func() string { return "" },
+	"pipeline": func() string { return "" },
+	"ext": func() string { return "" },
+	"date": func() string { return "" },
+	"year": func() string { return "" },
+	"month": func() string { return "" },
+	"day": func() string { return "" },
+	"hour": func() string { return "" },
+	"minute": func() string { return "" },
+	"timestamp": func() string { return "" },
+}
+
+// NewObjectKeyTemplate will parse an object key template, e.g.
+// "events/dt={{date}}/hour={{hour}}/{{pipeline}}-{{uuid}}.json.gz".  The functions are uuid (the tee's
+// key or, when batching, the batch's key), pipeline, ext (the serializer's file extension and the
+// compression extension, e.g. ".json.gz"), and date, year, month, day, hour, minute and timestamp,
+// which are UTC times.  Fields of the output can be referenced, e.g. "region={{.region}}", and
+// referencing a missing field is an error.
+func NewObjectKeyTemplate(text string) (*ObjectKeyTemplate, error) {
+	tmpl, err := template.New("objectKey").Funcs(objectKeyFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid object key template: %s", err.Error()))
+	}
+	return &ObjectKeyTemplate{tmpl}, nil
+}
+
+func (t *ObjectKeyTemplate) render(in map[string]interface{}, uuid, ext string, now time.Time) (string,
+	error) {
+	now = now.UTC()
+	pipeline := ""
+	if name, ok := common.GetFromInternalKey(common.ResourceNameKey, in); ok {
+		pipeline = fmt.Sprintf("%v", name)
+	}
+	values := map[string]string{
+		"uuid": uuid,
+		"pipeline": pipeline,
+		"ext": ext,
+		"date": now.Format("2006-01-02"),
+		"year": now.Format("2006"),
+		"month": now.Format("01"),
+		"day": now.Format("02"),
+		"hour": now.Format("15"),
+		"minute": now.Format("04"),
+		"timestamp": strconv.FormatInt(now.Unix(), 10),
+	}
+	funcs := make(template.FuncMap)
+	for name, value := range values {
+		value := value
+		funcs[name] = func() string { return value }
+	}
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Funcs(funcs).Execute(&buf, in); err != nil {
+		return "", util.NewInvalidError(fmt.Sprintf("cannot render object key: %s", err.Error()))
+	}
+	return buf.String(), nil
+}
+
+// objectTeeSettings are set by the object store tee options
+type objectTeeSettings struct {
+	keyTemplate *ObjectKeyTemplate
+	compression Compression
+	batchMaxBytes int
+	batchMaxAge time.Duration
+}
+
+// WithObjectKeyTemplate sets the key template of an object store tee (default is the tee's key or, when
+// batching, DefaultBatchKeyTemplate)
+func WithObjectKeyTemplate(keyTemplate *ObjectKeyTemplate) TeeOption {
+	return func(settings *teeSettings) {
+		settings.object.keyTemplate = keyTemplate
+	}
+}
+
+// WithObjectCompression sets the compression of the objects written by an object store tee
+func WithObjectCompression(compression Compression) TeeOption {
+	return func(settings *teeSettings) {
+		settings.object.compression = compression
+	}
+}
+
+// WithObjectBatching will batch the outputs of an object store tee, writing one object per partition
+// when the batch reaches maxBytes (before compression) or maxAge.  Outputs are partitioned by their
+// rendered key, so a batch only contains outputs whose keys only differ in {{uuid}}.
+func WithObjectBatching(maxBytes int, maxAge time.Duration) TeeOption {
+	return func(settings *teeSettings) {
+		settings.object.batchMaxBytes = maxBytes
+		settings.object.batchMaxAge = maxAge
+	}
+}
+
+type objectBatch struct {
+	key string
+	data bytes.Buffer
+	created time.Time
+	attempts int
+}
+
+// objectBatcher accumulates outputs in per-partition batches and writes each batch as an object
+type objectBatcher struct {
+	name string
+	lock sync.Mutex
+	objectStore storage.ObjectStore
+	compression Compression
+	maxBytes int
+	maxAge time.Duration
+	// header is written at the start of each batch
+	header []byte
+	batches map[string]*objectBatch
+	// failed batches are retried on the next flush
+	failed []*objectBatch
+	emitter *observability.Emitter
+	done chan struct{}
+	stopped chan struct{}
+	closeOnce sync.Once
+}
+
+func newObjectBatcher(name string, objectStore storage.ObjectStore, compression Compression, maxBytes int,
+	maxAge time.Duration, header []byte) *objectBatcher {
+	batcher := &objectBatcher{
+		name: name,
+		objectStore: objectStore,
+		compression: compression,
+		maxBytes: maxBytes,
+		maxAge: maxAge,
+		header: header,
+		batches: make(map[string]*objectBatch),
+		emitter: observability.NewEmitter("agglo/tee"),
+		done: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	batcher.emitter.AddMetric(name + ".droppedBatches", observability.Int64Counter)
+	go batcher.flushExpired()
+	return batcher
+}
+
+// add will append data to the partition's batch and return the key of the batch's object.  The batch is
+// written if it is full; if that fails, it is retried on the next flush.
+func (b *objectBatcher) add(ctx context.Context, partition string, newKey func() string, data []byte) string {
+	b.lock.Lock()
+	batch, ok := b.batches[partition]
+	if !ok {
+		batch = &objectBatch{key: newKey(), created: time.Now()}
+		batch.data.Write(b.header)
+		b.batches[partition] = batch
+	}
+	batch.data.Write(data)
+	full := b.maxBytes > 0 && batch.data.Len() >= b.maxBytes
+	if full {
+		delete(b.batches, partition)
+	}
+	b.lock.Unlock()
+
+	if full {
+		_ = b.write(ctx, batch)
+	}
+	return batch.key
+}
+
+// write will write the batch, or keep it to retry on the next flush.  The batch is dropped after
+// MaxObjectBatchAttempts failed writes.
+func (b *objectBatcher) write(ctx context.Context, batch *objectBatch) error {
+	data, err := b.compression.compress(batch.data.Bytes())
+	if err == nil {
+		err = b.objectStore.Put(ctx, batch.key, bytes.NewBuffer(data))
+	}
+	if err == nil {
+		return nil
+	}
+	batch.attempts++
+	if batch.attempts >= MaxObjectBatchAttempts {
+		b.emitter.AddInt64(b.name + ".droppedBatches", 1)
+		return errors.Wrap(err, fmt.Sprintf("dropped batch %s after %d attempts", batch.key, batch.attempts))
+	}
+	b.lock.Lock()
+	b.failed = append(b.failed, batch)
+	b.lock.Unlock()
+	return err
+}
+
+// flush will write the failed batches and the batches created before the cutoff
+func (b *objectBatcher) flush(cutoff time.Time) error {
+	b.lock.Lock()
+	batches := b.failed
+	b.failed = nil
+	for partition, batch := range b.batches {
+		if !batch.created.After(cutoff) {
+			batches = append(batches, batch)
+			delete(b.batches, partition)
+		}
+	}
+	b.lock.Unlock()
+
+	var lastErr error
+	numFailed := 0
+	for _, batch := range batches {
+		if err := b.write(context.Background(), batch); err != nil {
+			lastErr = err
+			numFailed++
+		}
+	}
+	return errors.Wrap(lastErr, fmt.Sprintf("%d of %d batches failed", numFailed, len(batches)))
+}
+
+func (b *objectBatcher) flushExpired() {
+	defer close(b.stopped)
+	interval := b.maxAge / 2
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Without a maxAge, only failed batches are written
+			cutoff := time.Time{}
+			if b.maxAge > 0 {
+				cutoff = time.Now().Add(-b.maxAge)
+			}
+			_ = b.flush(cutoff)
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// Close will write all of the batches.  Batches that cannot be written are dropped.
+func (b *objectBatcher) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		close(b.done)
+		<-b.stopped
+		err = b.flush(time.Now())
+
+		// There are no more flushes to retry the failed batches
+		b.lock.Lock()
+		numDropped := len(b.failed)
+		b.failed = nil
+		b.lock.Unlock()
+		if numDropped > 0 {
+			b.emitter.AddInt64(b.name + ".droppedBatches", int64(numDropped))
+			err = errors.Wrap(err, fmt.Sprintf("dropped %d batches on close", numDropped))
+		}
+	})
+	return err
+}
+
+// newObjectOutputFunc returns the output function, the function that writes the remaining batches (when
+// batching) and the function that deletes an output (when not batching, since a batch holds other
+// outputs)
+func newObjectOutputFunc(name string, objectStore storage.ObjectStore, settings *teeSettings,
+	additionalBody map[string]interface{}) (func(ctx context.Context, key string,
+	in map[string]interface{}) (map[string]interface{}, error), func() error,
+	func(ctx context.Context, key string, response map[string]interface{}) error) {
+	object := settings.object
+	ext := "." + settings.serializer.FileExtension() + object.compression.extension()
+
+	if object.batchMaxBytes <= 0 && object.batchMaxAge <= 0 {
+		return func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
+			payload, err := serializeDocument(settings.serializer, in, additionalBody)
+			if err != nil {
+				return nil, err
+			}
+			if object.keyTemplate != nil {
+				if key, err = object.keyTemplate.render(in, key, ext, time.Now()); err != nil {
+					return nil, err
+				}
+			}
+			if payload, err = object.compression.compress(payload); err != nil {
+				return nil, err
+			}
+			if err = objectStore.Put(ctx, key, bytes.NewBuffer(payload)); err != nil {
+				return nil, err
+			}
+			if object.keyTemplate == nil {
+				return nil, nil
+			}
+			return map[string]interface{}{"objectKey": key}, nil
+		}, nil, func(ctx context.Context, key string, response map[string]interface{}) error {
+			if objectKey, ok := response["objectKey"].(string); ok {
+				key = objectKey
+			}
+			return objectStore.Delete(ctx, key)
+		}
+	}
+
+	keyTemplate := object.keyTemplate
+	if keyTemplate == nil {
+		keyTemplate, _ = NewObjectKeyTemplate(DefaultBatchKeyTemplate)
+	}
+	// A header that cannot be serialized fails every output, like the payloads
+	header, headerErr := serializerHeader(settings.serializer)
+	batcher := newObjectBatcher(name, objectStore, object.compression, object.batchMaxBytes,
+		object.batchMaxAge, header)
+
+	// The partition is the key rendered with a placeholder for the batch's uuid
+	const uuidPlaceholder = "\x00"
+	return func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
+		if headerErr != nil {
+			return nil, headerErr
+		}
+		payload, err := serializePayload(settings.serializer, in, additionalBody)
+		if err != nil {
+			return nil, err
+		}
+		partition, err := keyTemplate.render(in, uuidPlaceholder, ext, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		newKey := func() string {
+			return strings.ReplaceAll(partition, uuidPlaceholder, gUuid.New().String())
+		}
+		return map[string]interface{}{"objectKey": batcher.add(ctx, partition, newKey, payload)}, nil
+	}, batcher.Close, nil
+}
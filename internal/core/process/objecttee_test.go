@@ -0,0 +1,243 @@
+package process_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/klauspost/compress/zstd"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestObjectStore(t *testing.T, name string) storage.ObjectStore {
+	params, err := storage.NewMemObjectStoreBackendParams(storage.MemObjectStoreBackend, name)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	objectStore, err := storage.NewMemObjectStore(params)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return objectStore
+}
+
+func listObjects(t *testing.T, objectStore storage.ObjectStore, prefix string) []string {
+	keys, err := objectStore.List(context.Background(), prefix)
+	assert.Nil(t, err)
+	sort.Strings(keys)
+	return keys
+}
+
+func readLines(t *testing.T, reader io.Reader) []map[string]interface{} {
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestObjectStoreTeeKeyTemplate(t *testing.T) {
+	objectStore := newTestObjectStore(t, "keyTemplate")
+	keyTemplate, err := process.NewObjectKeyTemplate("events/{{.region}}/dt={{date}}/{{pipeline}}-{{uuid}}{{ext}}")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	tee := process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithObjectKeyTemplate(keyTemplate), process.WithObjectCompression(process.CompressionGzip))
+
+	in := map[string]interface{}{"region": "us", "internal:name": "p1"}
+	out, err := tee.Process(context.Background(), in)
+	assert.Nil(t, err)
+	teeOutput := out[process.TeeMetadataKey].([]map[string]interface{})[0]
+	key := teeOutput["response"].(map[string]interface{})["objectKey"].(string)
+	assert.Regexp(t, regexp.MustCompile(`^events/us/dt=\d{4}-\d{2}-\d{2}/p1-`+teeOutput["uuid"].(string)+`\.json\.gz$`),
+		key)
+
+	reader, err := objectStore.Get(context.Background(), key)
+	assert.Nil(t, err)
+	gzipReader, err := gzip.NewReader(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{in}, readLines(t, gzipReader))
+
+	// Referencing a missing field fails the output
+	_, err = tee.Process(context.Background(), map[string]interface{}{"internal:name": "p1"})
+	assert.Error(t, err)
+
+	_, err = process.NewObjectKeyTemplate("{{.region")
+	assert.Error(t, err)
+}
+
+func TestObjectStoreTeeZstd(t *testing.T) {
+	objectStore := newTestObjectStore(t, "zstd")
+	tee := process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithObjectCompression(process.CompressionZstd))
+
+	in := map[string]interface{}{"region": "us"}
+	out, err := tee.Process(context.Background(), in)
+	assert.Nil(t, err)
+
+	// Without a key template, the key is the uuid
+	reader, err := objectStore.Get(context.Background(),
+		out[process.TeeMetadataKey].([]map[string]interface{})[0]["uuid"].(string))
+	assert.Nil(t, err)
+	zstdReader, err := zstd.NewReader(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{in}, readLines(t, zstdReader))
+
+	_, err = process.ParseCompression("lz4")
+	assert.Error(t, err)
+}
+
+func TestObjectStoreTeeBatching(t *testing.T) {
+	objectStore := newTestObjectStore(t, "batching")
+	keyTemplate, err := process.NewObjectKeyTemplate("region={{.region}}/{{uuid}}{{ext}}")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	tee := process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithObjectKeyTemplate(keyTemplate), process.WithObjectBatching(1<<20, time.Hour))
+
+	keys := make(map[string][]string)
+	for i, region := range []string{"us", "eu", "us", "us"} {
+		out, err := tee.Process(context.Background(), map[string]interface{}{"region": region, "i": float64(i)})
+		assert.Nil(t, err)
+		response := out[process.TeeMetadataKey].([]map[string]interface{})[0]["response"].(map[string]interface{})
+		keys[region] = append(keys[region], response["objectKey"].(string))
+	}
+	assert.Equal(t, keys["us"][0], keys["us"][1])
+	assert.Equal(t, keys["us"][0], keys["us"][2])
+	assert.Empty(t, listObjects(t, objectStore, "region="))
+
+	// Closing writes one object per partition
+	assert.Nil(t, tee.Close())
+	assert.Equal(t, 2, len(listObjects(t, objectStore, "region=")))
+	reader, err := objectStore.Get(context.Background(), keys["us"][0])
+	assert.Nil(t, err)
+	lines := readLines(t, reader)
+	assert.Equal(t, 3, len(lines))
+	assert.Equal(t, float64(3), lines[2]["i"])
+}
+
+func TestObjectStoreTeeBatchThresholds(t *testing.T) {
+	// Each output fills a batch
+	objectStore := newTestObjectStore(t, "batchSize")
+	tee := process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithObjectBatching(1, 0))
+	for i := 0; i < 2; i++ {
+		_, err := tee.Process(context.Background(), map[string]interface{}{"internal:name": "p1"})
+		assert.Nil(t, err)
+	}
+	keys := listObjects(t, objectStore, "pipeline=p1/dt=")
+	assert.Equal(t, 2, len(keys))
+	assert.Regexp(t, regexp.MustCompile(`^pipeline=p1/dt=\d{4}-\d{2}-\d{2}/hour=\d{2}/.+\.json$`), keys[0])
+	assert.Nil(t, tee.Close())
+
+	// Batches are written once they reach the max age
+	objectStore = newTestObjectStore(t, "batchAge")
+	tee = process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithObjectBatching(0, 50*time.Millisecond))
+	_, err := tee.Process(context.Background(), map[string]interface{}{"internal:name": "p1"})
+	assert.Nil(t, err)
+	assert.Empty(t, listObjects(t, objectStore, "pipeline=p1"))
+	time.Sleep(200 * time.Millisecond)
+	keys = listObjects(t, objectStore, "pipeline=p1")
+	assert.Equal(t, 1, len(keys))
+	assert.Nil(t, tee.Close())
+
+	reader, err := objectStore.Get(context.Background(), keys[0])
+	assert.Nil(t, err)
+	b, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"internal:name\":\"p1\"}\n", string(b))
+}
+
+// failingObjectStore fails every Put
+type failingObjectStore struct {
+	storage.ObjectStore
+	puts int32
+}
+
+func (s *failingObjectStore) Put(ctx context.Context, key string, reader io.Reader) error {
+	atomic.AddInt32(&s.puts, 1)
+	return fmt.Errorf("put failed")
+}
+
+func TestObjectStoreTeeBatchRetries(t *testing.T) {
+	// The batch is retried by each flush until it is dropped
+	objectStore := &failingObjectStore{ObjectStore: newTestObjectStore(t, "batchRetries")}
+	tee := process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithObjectBatching(1, 10*time.Millisecond))
+	_, err := tee.Process(context.Background(), map[string]interface{}{"internal:name": "p1"})
+	assert.Nil(t, err)
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, int32(process.MaxObjectBatchAttempts), atomic.LoadInt32(&objectStore.puts))
+	assert.Nil(t, tee.Close())
+
+	// Batches that still fail on close are dropped and reported
+	objectStore = &failingObjectStore{ObjectStore: newTestObjectStore(t, "batchRetriesClose")}
+	tee = process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithObjectBatching(1<<20, time.Hour))
+	for i := 0; i < 2; i++ {
+		_, err = tee.Process(context.Background(), map[string]interface{}{"internal:name": fmt.Sprintf("p%d", i)})
+		assert.Nil(t, err)
+	}
+	err = tee.Close()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "dropped 2 batches on close")
+	assert.Contains(t, err.Error(), "2 of 2 batches failed: put failed")
+}
+
+func TestObjectStoreTeeBatchingHeader(t *testing.T) {
+	objectStore := newTestObjectStore(t, "batchingHeader")
+	serializer, err := process.NewTeeSerializer(process.TeeFormatCsv, process.TeeSerializerOptions{
+		Columns: []string{"i"},
+		Header: true,
+	})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	tee := process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithTeeSerializer(serializer), process.WithObjectBatching(1<<20, time.Hour))
+	for i := 0; i < 3; i++ {
+		_, err := tee.Process(context.Background(), map[string]interface{}{"i": float64(i)})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tee.Close())
+
+	// The header is written once, at the start of the batch
+	keys := listObjects(t, objectStore, "")
+	assert.Equal(t, 1, len(keys))
+	reader, err := objectStore.Get(context.Background(), keys[0])
+	assert.Nil(t, err)
+	content, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "i\n0\n1\n2\n", string(content))
+
+	// Outputs written on their own start with the header
+	objectStore = newTestObjectStore(t, "objectHeader")
+	tee = process.NewObjectStoreTee("tee", objectStore, core.TrueCondition, nil, nil,
+		process.WithTeeSerializer(serializer))
+	_, err = tee.Process(context.Background(), map[string]interface{}{"i": float64(0)})
+	assert.Nil(t, err)
+	keys = listObjects(t, objectStore, "")
+	assert.Equal(t, 1, len(keys))
+	reader, err = objectStore.Get(context.Background(), keys[0])
+	assert.Nil(t, err)
+	content, err = ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "i\n0\n", string(content))
+}
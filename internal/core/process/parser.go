@@ -0,0 +1,93 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"reflect"
+)
+
+// Parser extracts structured fields from a string field (e.g. a raw log line).  The fields are
+// written under the target path, or merged into the top-level map if the target is empty.
+//
+// If failOnError is set, a line that cannot be parsed fails the pipeline.  Otherwise, the input is
+// passed through and the error is recorded under internal:parser:error:<name>.
+type Parser struct {
+	name string
+	parser core.LineParser
+	source *util.Path
+	target *util.Path
+	condition *core.Condition
+	failOnError bool
+}
+
+func NewParser(name string, parser core.LineParser, sourceField, targetField string, condition *core.Condition,
+	failOnError bool) (*Parser, error) {
+	source, err := util.NewPath(sourceField)
+	if err != nil {
+		return nil, err
+	}
+	target, err := util.NewPath(targetField)
+	if err != nil {
+		return nil, err
+	}
+	return &Parser{
+		name: name,
+		parser: parser,
+		source: source,
+		target: target,
+		condition: condition,
+		failOnError: failOnError,
+	}, nil
+}
+
+func (p Parser) Name() string {
+	return p.name
+}
+
+func (p *Parser) parse(in map[string]interface{}) (map[string]interface{}, error) {
+	value, err := p.source.Get(in)
+	if err != nil {
+		return nil, err
+	}
+	line, ok := value.(string)
+	if !ok {
+		msg := fmt.Sprintf("expected a string at '%s', got %v", p.source.String(), reflect.TypeOf(value))
+		return nil, util.NewInvalidError(msg)
+	}
+	return p.parser.Parse(line)
+}
+
+func (p *Parser) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+	shouldParse, err := p.condition.Evaluate(in)
+	if err != nil {
+		return in, PipelineProcessError(p, err, "evaluating condition")
+	}
+	if !shouldParse {
+		return in, nil
+	}
+
+	out := util.CopyableMap(in).DeepCopy()
+
+	fields, err := p.parse(in)
+	if err != nil {
+		if p.failOnError {
+			return nil, PipelineProcessError(p, err, "parsing "+p.source.String())
+		}
+		_ = common.SetUsingInternalPrefix(common.ParserErrorPrefix, p.name, err.Error(), out, true)
+		return out, nil
+	}
+
+	if p.target.IsRoot() {
+		for k, v := range fields {
+			out[k] = v
+		}
+		return out, nil
+	}
+	if err = p.target.Set(out, fields); err != nil {
+		return nil, PipelineProcessError(p, err, "setting "+p.target.String())
+	}
+	return out, nil
+}
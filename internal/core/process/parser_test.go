@@ -0,0 +1,62 @@
+package process_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	parser, err := process.NewParser("logfmt", core.NewLogfmtParser(), "message", "parsed", core.TrueCondition,
+		false)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	in := map[string]interface{}{"message": "level=warn msg=\"disk full\""}
+	out, err := parser.Process(context.Background(), in)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"level": "warn", "msg": "disk full"}, out["parsed"])
+	assert.Equal(t, "level=warn msg=\"disk full\"", out["message"])
+	_, ok := in["parsed"]
+	assert.False(t, ok)
+
+	parser, err = process.NewParser("logfmt", core.NewLogfmtParser(), "message", "", core.TrueCondition, false)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	out, err = parser.Process(context.Background(), in)
+	assert.Nil(t, err)
+	assert.Equal(t, "warn", out["level"])
+}
+
+func TestParserFailure(t *testing.T) {
+	in := map[string]interface{}{"message": "not cef"}
+
+	parser, err := process.NewParser("cef", core.NewCEFParser(), "message", "cef", core.TrueCondition, false)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	out, err := parser.Process(context.Background(), in)
+	assert.Nil(t, err)
+	_, ok := out["cef"]
+	assert.False(t, ok)
+	_, ok = common.GetFromInternalPrefix(common.ParserErrorPrefix, "cef", out)
+	assert.True(t, ok)
+
+	// A missing source field is also a parse failure
+	out, err = parser.Process(context.Background(), map[string]interface{}{})
+	assert.Nil(t, err)
+	_, ok = common.GetFromInternalPrefix(common.ParserErrorPrefix, "cef", out)
+	assert.True(t, ok)
+
+	parser, err = process.NewParser("cef", core.NewCEFParser(), "message", "cef", core.TrueCondition, true)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = parser.Process(context.Background(), in)
+	assert.Error(t, err)
+}
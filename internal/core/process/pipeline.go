@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	api "github.com/kmgreen2/agglo/generated/proto"
+	"github.com/kmgreen2/agglo/internal/source"
 	"github.com/kmgreen2/agglo/pkg/util"
 	"github.com/kmgreen2/agglo/pkg/observability"
 	"github.com/pkg/errors"
@@ -198,15 +199,24 @@ type Pipeline struct {
 	enableMetrics bool
 }
 
+// PipelineSource is a source of events for a daemon, with the names of the pipelines that its events are
+// run through (every pipeline, if empty)
+type PipelineSource struct {
+	source.Source
+	Pipelines []string
+}
+
 type Pipelines struct {
 	pipelines []*Pipeline
 	shutdownFns []func() error
+	sources []*PipelineSource
 }
 
-func NewPipelines(pipelines []*Pipeline, shutdownFns []func() error) *Pipelines {
+func NewPipelines(pipelines []*Pipeline, shutdownFns []func() error, sources ...*PipelineSource) *Pipelines {
 	return &Pipelines{
 		pipelines,
 		shutdownFns,
+		sources,
 	}
 }
 
@@ -214,10 +224,17 @@ func (pipelines Pipelines) Underlying() []*Pipeline {
 	return pipelines.pipelines
 }
 
+// Sources returns the configured sources, which are run and closed by daemons
+func (pipelines Pipelines) Sources() []*PipelineSource {
+	return pipelines.sources
+}
+
 func (pipelines Pipelines) Shutdown() error {
 	errStr := ""
-	for _, fn := range pipelines.shutdownFns {
-		err := fn()
+	// Shut down in reverse order, so processes (e.g. tees) flush their outputs before the external
+	// systems they write to are closed
+	for i := len(pipelines.shutdownFns) - 1; i >= 0; i-- {
+		err := pipelines.shutdownFns[i]()
 		if err != nil {
 			errStr += err.Error() + "\n"
 		}
@@ -0,0 +1,52 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/streaming"
+	"github.com/kmgreen2/agglo/pkg/util"
+)
+
+// pubSubTeeSettings are set by the pubsub tee options
+type pubSubTeeSettings struct {
+	keyTemplate *core.EventTemplate
+}
+
+// WithPubSubKeyTemplate renders the key of each message over the output, e.g. "{{.deviceId}}", so
+// related outputs are published to the same partition.  The publisher must be a
+// streaming.KeyedPublisher.
+func WithPubSubKeyTemplate(keyTemplate *core.EventTemplate) TeeOption {
+	return func(settings *teeSettings) {
+		settings.pubSub.keyTemplate = keyTemplate
+	}
+}
+
+func newPubSubOutputFunc(publisher streaming.Publisher, settings *teeSettings,
+	additionalBody map[string]interface{}) func(ctx context.Context, key string,
+	in map[string]interface{}) (map[string]interface{}, error) {
+	keyTemplate := settings.pubSub.keyTemplate
+	return func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
+		payload, err := serializeDocument(settings.serializer, in, additionalBody)
+		if err != nil {
+			return nil, err
+		}
+		if keyTemplate == nil {
+			return nil, publisher.Publish(ctx, payload)
+		}
+
+		keyedPublisher, ok := publisher.(streaming.KeyedPublisher)
+		if !ok {
+			return nil, util.NewInvalidError(fmt.Sprintf("publisher '%s' does not support message keys",
+				publisher.ConnectionString()))
+		}
+		messageKey, err := keyTemplate.Render(in)
+		if err != nil {
+			return nil, err
+		}
+		if err = keyedPublisher.PublishWithKey(ctx, []byte(messageKey), payload); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"messageKey": messageKey}, nil
+	}
+}
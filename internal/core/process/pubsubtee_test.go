@@ -0,0 +1,79 @@
+package process_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/pkg/streaming"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type keyedMessage struct {
+	key string
+	payload string
+}
+
+// recordingPublisher records the messages published with keys
+type recordingPublisher struct {
+	messages []keyedMessage
+}
+
+func (publisher *recordingPublisher) Publish(ctx context.Context, b []byte) error {
+	return publisher.PublishWithKey(ctx, nil, b)
+}
+
+func (publisher *recordingPublisher) PublishWithKey(ctx context.Context, key, b []byte) error {
+	publisher.messages = append(publisher.messages, keyedMessage{string(key), string(b)})
+	return nil
+}
+
+func (publisher *recordingPublisher) Flush(ctx context.Context, timeout time.Duration) error {
+	return nil
+}
+
+func (publisher *recordingPublisher) Close() error {
+	return nil
+}
+
+func (publisher *recordingPublisher) ConnectionString() string {
+	return "recording"
+}
+
+// unkeyedPublisher does not support message keys
+type unkeyedPublisher struct {
+	streaming.Publisher
+}
+
+func TestPubSubTeeKeyTemplate(t *testing.T) {
+	publisher := &recordingPublisher{}
+	tee := process.NewPubSubTee("tee", publisher, core.TrueCondition, nil, nil,
+		process.WithPubSubKeyTemplate(mustEventTemplate(t, "{{.device.id}}")))
+
+	out, err := tee.Process(context.Background(), map[string]interface{}{
+		"device": map[string]interface{}{"id": "sensor-1"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(publisher.messages))
+	assert.Equal(t, "sensor-1", publisher.messages[0].key)
+	assert.JSONEq(t, `{"device": {"id": "sensor-1"}}`, publisher.messages[0].payload)
+	teeOutput := out[process.TeeMetadataKey].([]map[string]interface{})[0]
+	assert.Equal(t, map[string]interface{}{"messageKey": "sensor-1"}, teeOutput["response"])
+
+	// Missing template fields fail the output
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(publisher.messages))
+
+	// Without a template, messages are not keyed
+	tee = process.NewPubSubTee("tee", publisher, core.TrueCondition, nil, nil)
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+	assert.Equal(t, "", publisher.messages[1].key)
+
+	tee = process.NewPubSubTee("tee", unkeyedPublisher{publisher}, core.TrueCondition, nil, nil,
+		process.WithPubSubKeyTemplate(mustEventTemplate(t, "{{.a}}")))
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+}
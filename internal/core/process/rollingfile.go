@@ -0,0 +1,391 @@
+package process
+
+import (
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FsyncPolicy determines when a rolling file is synced to disk
+type FsyncPolicy string
+
+const (
+	// FsyncNever leaves syncing to the OS, except on rotation and close
+	FsyncNever FsyncPolicy = "never"
+	// FsyncInterval syncs written data every FsyncInterval
+	FsyncInterval FsyncPolicy = "interval"
+	// FsyncAlways syncs after every write
+	FsyncAlways FsyncPolicy = "always"
+)
+
+// DefaultFsyncInterval is the interval of FsyncInterval, when one is not set
+const DefaultFsyncInterval = time.Second
+
+// ParseFsyncPolicy returns the policy with the name ("" is FsyncNever)
+func ParseFsyncPolicy(name string) (FsyncPolicy, error) {
+	switch FsyncPolicy(name) {
+	case "", FsyncNever:
+		return FsyncNever, nil
+	case FsyncInterval, FsyncAlways:
+		return FsyncPolicy(name), nil
+	}
+	return FsyncNever, util.NewInvalidError(fmt.Sprintf("unknown fsync policy '%s'", name))
+}
+
+// RollingFileOptions configures a rolling file
+type RollingFileOptions struct {
+	// Name is the base name of the files (local file tees default to the tee's name)
+	Name string
+	// Extension of the files, e.g. "ndjson"
+	Extension string
+	// MaxBytes and MaxAge rotate the active file once either is reached (0 disables each)
+	MaxBytes int64
+	MaxAge time.Duration
+	// MaxFiles is the number of rotated files that are kept (0 keeps all)
+	MaxFiles int
+	Fsync FsyncPolicy
+	FsyncInterval time.Duration
+	// Compression of the rotated files
+	Compression Compression
+	// Header is written at the start of each file, e.g. the column names of CSV
+	Header []byte
+}
+
+// WithRollingFile will append the outputs of a local file tee to a rolling file, rather than writing
+// each output to its own file.  The name defaults to the tee's name and the extension to the serializer's
+// ("ndjson" for JSON, since outputs are newline-delimited).
+func WithRollingFile(options RollingFileOptions) TeeOption {
+	return func(settings *teeSettings) {
+		settings.rolling = &options
+	}
+}
+
+// rotatedTimeLayout is the UTC time in the names of rotated files
+const rotatedTimeLayout = "20060102T150405.000000000Z"
+
+// RollingFile is an append-only file, which is rotated by size and age.  The active file is
+// <dir>/<name>.<ext> and rotated files are <dir>/<name>-<UTC time>.<ext>, followed by the compression's
+// extension.  Rotated files are compressed, and old ones removed, in the background.  A RollingFile is
+// safe for concurrent use.
+type RollingFile struct {
+	lock sync.Mutex
+	dir string
+	options RollingFileOptions
+	// rotatedName matches the names of rotated files, so files of other rolling files with the same
+	// prefix are ignored
+	rotatedName *regexp.Regexp
+	file *os.File
+	size int64
+	opened time.Time
+	dirty bool
+	// pending are rotated files that have not been compressed
+	pending []string
+	signal chan struct{}
+	done chan struct{}
+	stopped chan struct{}
+	closeOnce sync.Once
+	closeErr error
+}
+
+// NewRollingFile will open (or create) the active file in dir
+func NewRollingFile(dir string, options RollingFileOptions) (*RollingFile, error) {
+	if len(options.Name) == 0 || strings.ContainsRune(options.Name, os.PathSeparator) {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid rolling file name '%s'", options.Name))
+	}
+	if options.MaxBytes < 0 || options.MaxAge < 0 || options.MaxFiles < 0 || options.FsyncInterval < 0 {
+		return nil, util.NewInvalidError("rolling file limits must be non-negative")
+	}
+	if options.Fsync == "" {
+		options.Fsync = FsyncNever
+	}
+	if options.Fsync == FsyncInterval && options.FsyncInterval == 0 {
+		options.FsyncInterval = DefaultFsyncInterval
+	}
+
+	f := &RollingFile{
+		dir: dir,
+		options: options,
+		rotatedName: regexp.MustCompile("^" + regexp.QuoteMeta(options.Name) +
+			`-\d{8}T\d{6}\.\d{9}Z\.` + regexp.QuoteMeta(options.Extension) + "(" +
+			regexp.QuoteMeta(options.Compression.extension()) + ")?$"),
+		signal: make(chan struct{}, 1),
+		done: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	go f.maintain()
+	return f, nil
+}
+
+// Path is the path of the active file
+func (f *RollingFile) Path() string {
+	return filepath.Join(f.dir, f.options.Name + "." + f.options.Extension)
+}
+
+func (f *RollingFile) open() error {
+	file, err := os.OpenFile(f.Path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.opened = time.Now()
+	if f.size == 0 && len(f.options.Header) > 0 {
+		n, err := f.file.Write(f.options.Header)
+		f.size += int64(n)
+		f.dirty = true
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// empty returns true if nothing but the header has been written to the active file
+func (f *RollingFile) empty() bool {
+	return f.size <= int64(len(f.options.Header))
+}
+
+// Write will append data to the active file, rotating it first if data would exceed MaxBytes or the
+// file has reached MaxAge.  Data is never split across files.
+func (f *RollingFile) Write(data []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return 0, util.NewInvalidError("rolling file is closed")
+	}
+	if !f.empty() && ((f.options.MaxBytes > 0 && f.size + int64(len(data)) > f.options.MaxBytes) ||
+		f.expired(time.Now())) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	f.dirty = true
+	if err == nil && f.options.Fsync == FsyncAlways {
+		err = f.sync()
+	}
+	return n, err
+}
+
+// Rotate will rotate the active file, if it is not empty
+func (f *RollingFile) Rotate() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil || f.empty() {
+		return nil
+	}
+	return f.rotate()
+}
+
+func (f *RollingFile) expired(now time.Time) bool {
+	return f.options.MaxAge > 0 && now.Sub(f.opened) >= f.options.MaxAge
+}
+
+func (f *RollingFile) sync() error {
+	f.dirty = false
+	return f.file.Sync()
+}
+
+// rotate must be called with the lock held
+func (f *RollingFile) rotate() error {
+	if err := f.sync(); err != nil {
+		return err
+	}
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	// Rotated names sort by time; bump the time in the unlikely case of a collision
+	now := time.Now().UTC()
+	var rotated string
+	for {
+		rotated = filepath.Join(f.dir, fmt.Sprintf("%s-%s.%s", f.options.Name,
+			now.Format(rotatedTimeLayout), f.options.Extension))
+		_, err := os.Stat(rotated)
+		_, compressedErr := os.Stat(rotated + f.options.Compression.extension())
+		if os.IsNotExist(err) && os.IsNotExist(compressedErr) {
+			break
+		}
+		now = now.Add(time.Nanosecond)
+	}
+	if err := os.Rename(f.Path(), rotated); err != nil {
+		if openErr := f.open(); openErr != nil {
+			return openErr
+		}
+		return err
+	}
+	f.pending = append(f.pending, rotated)
+	select {
+	case f.signal <- struct{}{}:
+	default:
+	}
+	return f.open()
+}
+
+// maintain will compress and remove rotated files, sync on the FsyncInterval and rotate on MaxAge
+func (f *RollingFile) maintain() {
+	defer close(f.stopped)
+	var tick <-chan time.Time
+	interval := f.options.MaxAge / 2
+	if f.options.Fsync == FsyncInterval && (interval == 0 || f.options.FsyncInterval < interval) {
+		interval = f.options.FsyncInterval
+	}
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case now := <-tick:
+			f.lock.Lock()
+			if f.file != nil {
+				if !f.empty() && f.expired(now) {
+					_ = f.rotate()
+				} else if f.dirty && f.options.Fsync == FsyncInterval {
+					_ = f.sync()
+				}
+			}
+			f.lock.Unlock()
+		case <-f.signal:
+			_ = f.processRotated()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// processRotated will compress the pending rotated files and apply the retention
+func (f *RollingFile) processRotated() error {
+	f.lock.Lock()
+	pending := f.pending
+	f.pending = nil
+	f.lock.Unlock()
+
+	var lastErr error
+	for _, path := range pending {
+		if err := f.compress(path); err != nil {
+			lastErr = err
+		}
+	}
+	if err := f.removeExpired(); err != nil {
+		lastErr = err
+	}
+	return lastErr
+}
+
+// compress will replace the file with its compressed version
+func (f *RollingFile) compress(path string) error {
+	if f.options.Compression == CompressionNone {
+		return nil
+	}
+	compressedPath := path + f.options.Compression.extension()
+	if err := compressFile(path, compressedPath + ".tmp", f.options.Compression); err != nil {
+		_ = os.Remove(compressedPath + ".tmp")
+		return err
+	}
+	if err := os.Rename(compressedPath + ".tmp", compressedPath); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+func compressFile(src, dst string, compression Compression) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	writer, err := compression.newWriter(out)
+	if err == nil {
+		if _, err = io.Copy(writer, in); err == nil {
+			err = writer.Close()
+		}
+	}
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// RotatedFiles returns the paths of the rotated files, oldest first
+func (f *RollingFile) RotatedFiles() ([]string, error) {
+	infos, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, info := range infos {
+		if !info.IsDir() && f.rotatedName.MatchString(info.Name()) {
+			paths = append(paths, filepath.Join(f.dir, info.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func (f *RollingFile) removeExpired() error {
+	if f.options.MaxFiles == 0 {
+		return nil
+	}
+	paths, err := f.RotatedFiles()
+	if err != nil {
+		return err
+	}
+	var lastErr error
+	for i := 0; i < len(paths) - f.options.MaxFiles; i++ {
+		if err = os.Remove(paths[i]); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// Close will sync and close the active file, and then compress and remove rotated files.  The active
+// file is not rotated, so writes continue in it when the file is reopened.
+func (f *RollingFile) Close() error {
+	f.closeOnce.Do(func() {
+		close(f.done)
+		<-f.stopped
+
+		f.lock.Lock()
+		if f.file != nil {
+			f.closeErr = f.sync()
+			if err := f.file.Close(); f.closeErr == nil {
+				f.closeErr = err
+			}
+			f.file = nil
+		}
+		f.lock.Unlock()
+
+		if err := f.processRotated(); f.closeErr == nil {
+			f.closeErr = err
+		}
+	})
+	return f.closeErr
+}
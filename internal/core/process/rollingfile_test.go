@@ -0,0 +1,205 @@
+package process_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTempDir(t *testing.T, prefix string) string {
+	dir, err := ioutil.TempDir("", prefix)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return dir
+}
+
+func TestRollingFileRotation(t *testing.T) {
+	dir := newTempDir(t, "rollingfile")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	rollingFile, err := process.NewRollingFile(dir, process.RollingFileOptions{
+		Name: "events",
+		Extension: "ndjson",
+		MaxBytes: 20,
+		MaxFiles: 2,
+		Fsync: process.FsyncAlways,
+		Compression: process.CompressionGzip,
+	})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// Files of another rolling file with the same prefix are neither counted nor removed
+	other := filepath.Join(dir, "events-debug-20210301T000000.000000000Z.ndjson.gz")
+	assert.Nil(t, ioutil.WriteFile(other, []byte{}, 0644))
+
+	// Each file holds two 9 byte records, so the first three files are rotated and the oldest is removed
+	for i := 0; i < 8; i++ {
+		_, err = rollingFile.Write([]byte(fmt.Sprintf("record-%d\n", i)))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, rollingFile.Close())
+	_, err = rollingFile.Write([]byte("closed\n"))
+	assert.Error(t, err)
+
+	rotated, err := rollingFile.RotatedFiles()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rotated))
+	for i, path := range rotated {
+		assert.Regexp(t, `events-\d{8}T\d{6}\.\d{9}Z\.ndjson\.gz$`, path)
+		fp, err := os.Open(path)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		gzipReader, err := gzip.NewReader(fp)
+		assert.Nil(t, err)
+		b, err := ioutil.ReadAll(gzipReader)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("record-%d\nrecord-%d\n", 2*i+2, 2*i+3), string(b))
+		_ = fp.Close()
+	}
+	b, err := ioutil.ReadFile(rollingFile.Path())
+	assert.Nil(t, err)
+	assert.Equal(t, "record-6\nrecord-7\n", string(b))
+	assert.FileExists(t, other)
+
+	// Reopening appends to the active file
+	rollingFile, err = process.NewRollingFile(dir, process.RollingFileOptions{Name: "events", Extension: "ndjson"})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = rollingFile.Write([]byte("record-8\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, rollingFile.Close())
+	b, err = ioutil.ReadFile(rollingFile.Path())
+	assert.Nil(t, err)
+	assert.Equal(t, "record-6\nrecord-7\nrecord-8\n", string(b))
+}
+
+func TestRollingFileMaxAge(t *testing.T) {
+	dir := newTempDir(t, "rollingfile")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	rollingFile, err := process.NewRollingFile(dir, process.RollingFileOptions{
+		Name: "events",
+		Extension: "ndjson",
+		MaxAge: 50 * time.Millisecond,
+		Fsync: process.FsyncInterval,
+	})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = rollingFile.Write([]byte("record-0\n"))
+	assert.Nil(t, err)
+
+	// An idle file is rotated once it expires
+	time.Sleep(200 * time.Millisecond)
+	rotated, err := rollingFile.RotatedFiles()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rotated))
+	assert.Nil(t, rollingFile.Close())
+
+	_, err = process.NewRollingFile(dir, process.RollingFileOptions{Name: "../events", Extension: "ndjson"})
+	assert.Error(t, err)
+	_, err = process.ParseFsyncPolicy("sometimes")
+	assert.Error(t, err)
+}
+
+func TestLocalFileTeeRollingFile(t *testing.T) {
+	dir := newTempDir(t, "rollingtee")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	tee, err := process.NewLocalFileTee("tee", dir, core.TrueCondition, nil, nil,
+		process.WithRollingFile(process.RollingFileOptions{MaxBytes: 1024}))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// Outputs from concurrent goroutines are never interleaved or split
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := tee.Process(context.Background(), map[string]interface{}{"i": i, "j": j})
+				assert.Nil(t, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Nil(t, tee.Close())
+
+	paths, err := filepath.Glob(filepath.Join(dir, "tee*.ndjson"))
+	assert.Nil(t, err)
+	assert.True(t, len(paths) > 1)
+	numLines := 0
+	for _, path := range paths {
+		fp, err := os.Open(path)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		scanner := bufio.NewScanner(fp)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			assert.Nil(t, json.Unmarshal(scanner.Bytes(), &line))
+			numLines++
+		}
+		_ = fp.Close()
+	}
+	assert.Equal(t, 500, numLines)
+}
+
+func TestLocalFileTeeRollingFileHeader(t *testing.T) {
+	dir := newTempDir(t, "rollingheader")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	serializer, err := process.NewTeeSerializer(process.TeeFormatCsv, process.TeeSerializerOptions{
+		Columns: []string{"i"},
+		Header: true,
+	})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	tee, err := process.NewLocalFileTee("tee", dir, core.TrueCondition, nil, nil,
+		process.WithTeeSerializer(serializer), process.WithRollingFile(process.RollingFileOptions{MaxBytes: 6}))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	for i := 0; i < 4; i++ {
+		_, err := tee.Process(context.Background(), map[string]interface{}{"i": float64(i)})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tee.Close())
+
+	// Each file starts with the header, followed by the two rows that fit
+	paths, err := filepath.Glob(filepath.Join(dir, "tee*.csv"))
+	assert.Nil(t, err)
+	var contents []string
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		contents = append(contents, string(content))
+	}
+	assert.ElementsMatch(t, []string{"i\n0\n1\n", "i\n2\n3\n"}, contents)
+}
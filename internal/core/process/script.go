@@ -0,0 +1,47 @@
+package process
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/util"
+)
+
+// Script runs a precompiled script over each event.  The script returns the modified event or
+// drops it, which stops the pipeline in the same way as an unsatisfied continuation.
+type Script struct {
+	name string
+	script *core.Script
+	condition *core.Condition
+}
+
+func NewScript(name string, script *core.Script, condition *core.Condition) *Script {
+	return &Script{
+		name: name,
+		script: script,
+		condition: condition,
+	}
+}
+
+func (s Script) Name() string {
+	return s.name
+}
+
+func (s *Script) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+	shouldRun, err := s.condition.Evaluate(in)
+	if err != nil {
+		return in, PipelineProcessError(s, err, "evaluating condition")
+	}
+	if !shouldRun {
+		return in, nil
+	}
+
+	out, dropped, err := s.script.Run(ctx, in)
+	if err != nil {
+		return nil, PipelineProcessError(s, err, "")
+	}
+	if dropped {
+		err = util.NewContinuationNotSatisfied("script dropped the event")
+		return nil, PipelineProcessError(s, err, "")
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package process
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/kmgreen2/agglo/pkg/storage"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScriptFromObjectStore(t *testing.T) {
+	objectStore, err := storage.NewObjectStoreFromConnectionString("mem:testScripts")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	src := "def process(event):\n    if event['n'] < 0:\n        return DROP\n    event['n'] *= 2\n    return event\n"
+	err = objectStore.Put(context.Background(), "double.star", bytes.NewBufferString(src))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "pipelines": [{"name": "script-pipeline", "processes": [{"name": "double"}, {"name": "double-again"}]}],
+  "processDefinitions": [
+    {"script": {"name": "double", "path": "double.star", "objectStore": "scripts", "maxSteps": 1000}},
+    {"script": {"name": "double-again", "path": "double.star", "objectStore": "scripts", "maxSteps": 1000}}
+  ],
+  "externalSystems": [
+    {"externalType": "ExternalObjectStore", "name": "scripts", "connectionString": "mem:testScripts"}
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	var scripts []*Script
+	for _, p := range pipelines.Underlying()[0].processes {
+		if script, ok := p.(*Script); ok {
+			scripts = append(scripts, script)
+		}
+	}
+	assert.Equal(t, 2, len(scripts))
+	assert.Same(t, scripts[0].script, scripts[1].script)
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"n": float64(3)})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(12), out["n"])
+
+	_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"n": float64(-1)})
+	assert.True(t, errors.Is(err, &util.ContinuationNotSatisfied{}))
+}
@@ -3,6 +3,9 @@ package process
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"github.com/golang/protobuf/jsonpb"
 	gUuid "github.com/google/uuid"
@@ -14,16 +17,25 @@ import (
 	"github.com/kmgreen2/agglo/pkg/storage"
 	"github.com/kmgreen2/agglo/pkg/util"
 	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/source"
 	"github.com/kmgreen2/agglo/pkg/kvs"
 	"github.com/kmgreen2/agglo/generated/proto"
 	"github.com/kmgreen2/agglo/pkg/observability"
 	"github.com/kmgreen2/agglo/pkg/streaming"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/proto"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -58,6 +70,17 @@ func protoAggregationTypeToInternal(in api.AggregationType) core.AggregationType
 	}
 }
 
+func protoAnomalyScoringToInternal(in api.AnomalyScoring) core.AnomalyScoring {
+	switch in {
+	case api.AnomalyScoring_ScoreZ:
+		return core.ScoreZ
+	case api.AnomalyScoring_ScoreMAD:
+		return core.ScoreMAD
+	default:
+		return -1
+	}
+}
+
 func buildExpression(expression *api.Expression) (core.Expression, error) {
 	var err error
 	var lhs, rhs interface{}
@@ -235,7 +258,385 @@ func buildCondition(condition *api.Condition) (*core.Condition, error) {
 	}
 }
 
-func buildTransformer(transformerSpec *api.Transformer) (*Transformer, error) {
+func protoFoldTypeToInternal(foldType api.FoldType) (core.FoldType, error) {
+	switch foldType {
+	case api.FoldType_FoldMin:
+		return core.FoldMin, nil
+	case api.FoldType_FoldMax:
+		return core.FoldMax, nil
+	case api.FoldType_FoldCount:
+		return core.FoldCount, nil
+	case api.FoldType_FoldSum:
+		return core.FoldSum, nil
+	case api.FoldType_FoldAvg:
+		return core.FoldAvg, nil
+	case api.FoldType_FoldConcat:
+		return core.FoldConcat, nil
+	case api.FoldType_FoldDistinct:
+		return core.FoldDistinct, nil
+	case api.FoldType_FoldFirst:
+		return core.FoldFirst, nil
+	case api.FoldType_FoldLast:
+		return core.FoldLast, nil
+	}
+	msg := fmt.Sprintf("invalid built-in fold type: %v", foldType)
+	return 0, util.NewInvalidError(msg)
+}
+
+func buildFoldSpec(foldType api.FoldType, conditionSpec *api.Condition, separator string) (core.FoldSpec, error) {
+	internalType, err := protoFoldTypeToInternal(foldType)
+	if err != nil {
+		return core.FoldSpec{}, err
+	}
+	condition, err := buildCondition(conditionSpec)
+	if err != nil {
+		return core.FoldSpec{}, err
+	}
+	return core.FoldSpec{
+		Type: internalType,
+		Condition: condition,
+		Separator: separator,
+	}, nil
+}
+
+// buildTeeOptions returns no options if the spec is nil, so outputs are delivered inline.  Each call
+// creates a new buffer, so it is called for each destination of a tee.  If destination is set, the
+// durable buffer's queuePath is a directory, with a queue for each destination.
+func buildTeeOptions(deliverySpec *api.TeeDelivery, destination string) ([]TeeOption, error) {
+	if deliverySpec == nil {
+		return nil, nil
+	}
+	var options []TeeOption
+	if deliverySpec.Retry != nil {
+		options = append(options, WithTeeRetry(int(deliverySpec.Retry.MaxRetries),
+			time.Duration(deliverySpec.Retry.InitialDelayInMs) * time.Millisecond,
+			time.Duration(deliverySpec.Retry.MaxDelayInMs) * time.Millisecond))
+	}
+	if deliverySpec.CircuitBreaker != nil {
+		if deliverySpec.CircuitBreaker.FailureThreshold <= 0 {
+			return nil, util.NewInvalidError("circuit breaker failureThreshold must be positive")
+		}
+		options = append(options, WithTeeCircuitBreaker(int(deliverySpec.CircuitBreaker.FailureThreshold),
+			time.Duration(deliverySpec.CircuitBreaker.ResetTimeoutInMs) * time.Millisecond,
+			deliverySpec.CircuitBreaker.Spool))
+	}
+	if deliverySpec.Async {
+		options = append(options, WithTeeAsync())
+	}
+	if deliverySpec.Buffer != nil {
+		// Only async and spooled outputs are buffered, so a buffer would never be used
+		if !deliverySpec.Async && (deliverySpec.CircuitBreaker == nil || !deliverySpec.CircuitBreaker.Spool) {
+			return nil, util.NewInvalidError("a delivery buffer requires async or a spooling circuit breaker")
+		}
+		capacity := int(deliverySpec.Buffer.Capacity)
+		if capacity <= 0 {
+			capacity = DefaultTeeBufferCapacity
+		}
+		if len(deliverySpec.Buffer.QueuePath) == 0 {
+			options = append(options, WithTeeBuffer(NewMemoryTeeBuffer(capacity)))
+		} else {
+			queuePath := deliverySpec.Buffer.QueuePath
+			if len(destination) > 0 {
+				if err := os.MkdirAll(queuePath, 0755); err != nil {
+					return nil, err
+				}
+				queuePath = filepath.Join(queuePath, destination)
+			}
+			buffer, err := NewDurableTeeBuffer(queuePath, capacity)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, WithTeeBuffer(buffer))
+		}
+	}
+	return options, nil
+}
+
+// buildTeeSerializer will load the descriptor set or schema referenced by the format
+func buildTeeSerializer(formatSpec *api.TeeFormat) (TeeSerializer, error) {
+	name := formatSpec.Name
+	if len(name) == 0 {
+		name = TeeFormatJson
+	}
+	options := TeeSerializerOptions{
+		Columns: formatSpec.Columns,
+		Header: formatSpec.Header,
+		MessageName: formatSpec.MessageName,
+		Schema: formatSpec.Schema,
+	}
+	if len(formatSpec.DescriptorSetPath) > 0 {
+		descriptorSet, err := os.Open(formatSpec.DescriptorSetPath)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = descriptorSet.Close()
+		}()
+		if options.Files, err = core.LoadDescriptorSet(descriptorSet); err != nil {
+			return nil, err
+		}
+	}
+	if len(formatSpec.SchemaPath) > 0 {
+		schema, err := ioutil.ReadFile(formatSpec.SchemaPath)
+		if err != nil {
+			return nil, err
+		}
+		options.Schema = string(schema)
+	}
+	return NewTeeSerializer(name, options)
+}
+
+func buildObjectTeeOptions(objectSpec *api.ObjectOutput) ([]TeeOption, error) {
+	compression, err := ParseCompression(objectSpec.Compression)
+	if err != nil {
+		return nil, err
+	}
+	options := []TeeOption{WithObjectCompression(compression)}
+	if len(objectSpec.KeyTemplate) > 0 {
+		keyTemplate, err := NewObjectKeyTemplate(objectSpec.KeyTemplate)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, WithObjectKeyTemplate(keyTemplate))
+	}
+	if objectSpec.BatchMaxBytes > 0 || objectSpec.BatchMaxAgeInMs > 0 {
+		options = append(options, WithObjectBatching(int(objectSpec.BatchMaxBytes),
+			time.Duration(objectSpec.BatchMaxAgeInMs) * time.Millisecond))
+	}
+	return options, nil
+}
+
+// buildHttpSecret requires exactly one of file and env
+func buildHttpSecret(kind, file, env string) (HttpSecret, error) {
+	if len(file) > 0 && len(env) > 0 || len(file) == 0 && len(env) == 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("%s requires either a file or an environment variable", kind))
+	}
+	if len(file) > 0 {
+		return SecretFromFile(file), nil
+	}
+	return SecretFromEnv(env), nil
+}
+
+// buildPubSubTeeOptions returns the options of a pub/sub tee
+func buildPubSubTeeOptions(pubSubSpec *api.PubSubOutput) ([]TeeOption, error) {
+	var options []TeeOption
+	if len(pubSubSpec.KeyTemplate) > 0 {
+		keyTemplate, err := core.NewEventTemplate("key", pubSubSpec.KeyTemplate)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, WithPubSubKeyTemplate(keyTemplate))
+	}
+	return options, nil
+}
+
+// buildHttpTeeOptions returns the options of an HTTP tee and its client, which is http.DefaultClient unless
+// a timeout or TLS is set
+func buildHttpTeeOptions(httpSpec *api.HttpOutput) ([]TeeOption, common.HTTPClient, error) {
+	var options []TeeOption
+	if len(httpSpec.Method) > 0 {
+		options = append(options, WithHttpMethod(httpSpec.Method))
+	}
+	if len(httpSpec.UrlTemplate) > 0 {
+		url, err := core.NewEventTemplate("url", httpSpec.UrlTemplate)
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, WithHttpURLTemplate(url))
+	}
+	for name, value := range httpSpec.Headers {
+		header, err := core.NewEventTemplate(name, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, WithHttpHeaderTemplate(name, header))
+	}
+	if auth := httpSpec.Auth; auth != nil {
+		if auth.Basic != nil {
+			password, err := buildHttpSecret("basic auth", auth.Basic.PasswordFile, auth.Basic.PasswordEnv)
+			if err != nil {
+				return nil, nil, err
+			}
+			options = append(options, WithHttpAuth(NewHttpBasicAuth(auth.Basic.Username, password)))
+		}
+		if auth.Bearer != nil {
+			token, err := buildHttpSecret("bearer auth", auth.Bearer.TokenFile, auth.Bearer.TokenEnv)
+			if err != nil {
+				return nil, nil, err
+			}
+			options = append(options, WithHttpAuth(NewHttpBearerAuth(token)))
+		}
+		if auth.Hmac != nil {
+			secret, err := buildHttpSecret("hmac auth", auth.Hmac.SecretFile, auth.Hmac.SecretEnv)
+			if err != nil {
+				return nil, nil, err
+			}
+			hmacAuth, err := NewHttpHmacAuth(secret, auth.Hmac.Header, auth.Hmac.Algorithm)
+			if err != nil {
+				return nil, nil, err
+			}
+			options = append(options, WithHttpAuth(hmacAuth))
+		}
+	}
+	if len(httpSpec.AcceptedStatusCodes) > 0 {
+		codes := make([]int, len(httpSpec.AcceptedStatusCodes))
+		for i, code := range httpSpec.AcceptedStatusCodes {
+			codes[i] = int(code)
+		}
+		options = append(options, WithHttpAcceptedStatusCodes(codes...))
+	}
+	if httpSpec.CaptureResponse {
+		options = append(options, WithHttpCaptureResponse())
+	}
+
+	var client common.HTTPClient = http.DefaultClient
+	if httpSpec.Tls != nil || httpSpec.TimeoutInMs > 0 {
+		var tlsConfig *tls.Config
+		if httpSpec.Tls != nil {
+			var err error
+			tlsConfig, err = NewHttpTLSConfig(httpSpec.Tls.CertFile, httpSpec.Tls.KeyFile, httpSpec.Tls.CaFile,
+				httpSpec.Tls.InsecureSkipVerify)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		client = NewHttpClient(time.Duration(httpSpec.TimeoutInMs) * time.Millisecond, tlsConfig)
+	}
+	return options, client, nil
+}
+
+func buildRollingFileOptions(rollingSpec *api.RollingFileOutput) (RollingFileOptions, error) {
+	compression, err := ParseCompression(rollingSpec.Compression)
+	if err != nil {
+		return RollingFileOptions{}, err
+	}
+	fsync, err := ParseFsyncPolicy(rollingSpec.Fsync)
+	if err != nil {
+		return RollingFileOptions{}, err
+	}
+	return RollingFileOptions{
+		Name: rollingSpec.Name,
+		MaxBytes: rollingSpec.MaxBytes,
+		MaxAge: time.Duration(rollingSpec.MaxAgeInMs) * time.Millisecond,
+		MaxFiles: int(rollingSpec.MaxFiles),
+		Fsync: fsync,
+		FsyncInterval: time.Duration(rollingSpec.FsyncIntervalInMs) * time.Millisecond,
+		Compression: compression,
+	}, nil
+}
+
+// buildSandbox returns nil if the spec is nil, so commands run without a sandbox
+func buildSandbox(sandboxSpec *api.Sandbox) *util.Sandbox {
+	if sandboxSpec == nil {
+		return nil
+	}
+	sandbox := &util.Sandbox{
+		EnvAllowlist: sandboxSpec.EnvAllowlist,
+		WorkingDir: sandboxSpec.WorkingDir,
+		Timeout: time.Duration(sandboxSpec.TimeoutInMs) * time.Millisecond,
+		CPUSeconds: sandboxSpec.CpuSeconds,
+		MemoryBytes: sandboxSpec.MemoryBytes,
+		OpenFiles: sandboxSpec.OpenFiles,
+		MaxOutputBytes: int(sandboxSpec.MaxOutputBytes),
+	}
+	if sandboxSpec.Credential != nil {
+		sandbox.Credential = &syscall.Credential{Uid: sandboxSpec.Credential.Uid,
+			Gid: sandboxSpec.Credential.Gid}
+	}
+	return sandbox
+}
+
+// buildCoProcessPool will create a pool of long-lived workers for the executable.  The pool's Close
+// function is added to shutdownFns.
+func buildCoProcessPool(path string, cmdArgs []string, coProcess *api.CoProcess, sandbox *util.Sandbox,
+	shutdownFns *[]func() error) *util.CoProcessPool {
+	pool := util.NewCoProcessPool(path, util.WithCoProcessArgs(cmdArgs...),
+		util.WithNumWorkers(int(coProcess.NumWorkers)),
+		util.WithCallTimeout(time.Duration(coProcess.TimeoutInMs) * time.Millisecond),
+		util.WithCoProcessSandbox(sandbox))
+	*shutdownFns = append(*shutdownFns, pool.Close)
+	return pool
+}
+
+// buildScript will load and compile a script.  Scripts with the same source and limits are compiled
+// once and shared.
+func buildScript(scriptSpec *api.Script, externalObjectStore map[string]storage.ObjectStore,
+	scripts map[string]*core.Script) (*core.Script, error) {
+	var src []byte
+	var err error
+
+	cacheKey := fmt.Sprintf("%s:%s:%d:%d", scriptSpec.ObjectStore, scriptSpec.Path, scriptSpec.MaxSteps,
+		scriptSpec.MaxEventBytes)
+	if script, ok := scripts[cacheKey]; ok {
+		return script, nil
+	}
+
+	if len(scriptSpec.ObjectStore) > 0 {
+		objectStore, ok := externalObjectStore[scriptSpec.ObjectStore]
+		if !ok {
+			msg := fmt.Sprintf("object store is not defined: %s", scriptSpec.ObjectStore)
+			return nil, util.NewInvalidError(msg)
+		}
+		reader, err := objectStore.Get(context.Background(), scriptSpec.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "buildScript error")
+		}
+		src, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "buildScript error")
+		}
+	} else {
+		src, err = ioutil.ReadFile(scriptSpec.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "buildScript error")
+		}
+	}
+
+	script, err := core.NewScript(scriptSpec.Path, src, core.WithScriptMaxSteps(scriptSpec.MaxSteps),
+		core.WithScriptMaxEventBytes(scriptSpec.MaxEventBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "buildScript error")
+	}
+	scripts[cacheKey] = script
+	return script, nil
+}
+
+func buildLineParser(parserSpec *api.Parser) (core.LineParser, error) {
+	switch parserSpec.ParserType {
+	case api.ParserType_ParseGrok:
+		library := core.NewGrokLibrary()
+		if len(parserSpec.PatternsPath) > 0 {
+			fp, err := os.Open(parserSpec.PatternsPath)
+			if err != nil {
+				return nil, errors.Wrap(err, "buildLineParser error")
+			}
+			defer func() {
+				_ = fp.Close()
+			}()
+			if err = library.Load(fp); err != nil {
+				return nil, errors.Wrap(err, "buildLineParser error")
+			}
+		}
+		for name, pattern := range parserSpec.PatternDefinitions {
+			if err := library.Add(name, pattern); err != nil {
+				return nil, errors.Wrap(err, "buildLineParser error")
+			}
+		}
+		return library.NewGrokParser(parserSpec.Patterns...)
+	case api.ParserType_ParseLogfmt:
+		return core.NewLogfmtParser(), nil
+	case api.ParserType_ParseKeyValue:
+		return core.NewKeyValueParser(parserSpec.PairSeparator, parserSpec.KvSeparator)
+	case api.ParserType_ParseRFC5424:
+		return core.NewSyslogParser(), nil
+	case api.ParserType_ParseCEF:
+		return core.NewCEFParser(), nil
+	}
+	msg := fmt.Sprintf("invalid parser type: %v", parserSpec.ParserType)
+	return nil, util.NewInvalidError(msg)
+}
+
+func buildTransformer(transformerSpec *api.Transformer, shutdownFns *[]func() error) (*Transformer, error) {
 	transformerSpecs := transformerSpec.Specs
 	transformer := NewTransformer(transformerSpec.Name, nil, ".", ".",
 		transformerSpec.ForwardInputFields)
@@ -272,17 +673,65 @@ func buildTransformer(transformerSpec *api.Transformer) (*Transformer, error) {
 		case api.TransformationType_TransformMap:
 			switch transformArgs := spec.Transformation.TransformArgs.(type) {
 			case *api.Transformation_MapArgs:
-				builder.AddFieldTransformation(core.NewExecMapTransformation(transformArgs.MapArgs.Path))
+				sandbox := buildSandbox(transformArgs.MapArgs.Sandbox)
+				if transformArgs.MapArgs.CoProcess != nil {
+					pool := buildCoProcessPool(transformArgs.MapArgs.Path, nil, transformArgs.MapArgs.CoProcess,
+						sandbox, shutdownFns)
+					builder.AddFieldTransformation(core.NewCoProcessMapTransformation(pool))
+				} else {
+					builder.AddFieldTransformation(core.NewSandboxedExecMapTransformation(sandbox,
+						transformArgs.MapArgs.Path))
+				}
 			}
 		case api.TransformationType_TransformLeftFold:
 			switch transformArgs := spec.Transformation.TransformArgs.(type) {
 			case *api.Transformation_LeftFoldArgs:
-				builder.AddFieldTransformation(core.NewExecMapTransformation(transformArgs.LeftFoldArgs.Path))
+				foldArgs := transformArgs.LeftFoldArgs
+				if foldArgs.FoldType == api.FoldType_FoldExec {
+					sandbox := buildSandbox(foldArgs.Sandbox)
+					if foldArgs.CoProcess != nil {
+						pool := buildCoProcessPool(foldArgs.Path, nil, foldArgs.CoProcess, sandbox, shutdownFns)
+						builder.AddFieldTransformation(core.NewCoProcessLeftFoldTransformation(pool))
+					} else {
+						builder.AddFieldTransformation(core.NewSandboxedExecLeftFoldTransformation(sandbox,
+							foldArgs.Path))
+					}
+					break
+				}
+				foldSpec, err := buildFoldSpec(foldArgs.FoldType, foldArgs.Condition, foldArgs.Separator)
+				if err != nil {
+					return nil, errors.Wrap(err, "buildTransformer error")
+				}
+				fold, err := core.NewLeftFoldTransformation(foldSpec)
+				if err != nil {
+					return nil, errors.Wrap(err, "buildTransformer error")
+				}
+				builder.AddFieldTransformation(fold)
 			}
 		case api.TransformationType_TransformRightFold:
 			switch transformArgs := spec.Transformation.TransformArgs.(type) {
 			case *api.Transformation_RightFoldArgs:
-				builder.AddFieldTransformation(core.NewExecMapTransformation(transformArgs.RightFoldArgs.Path))
+				foldArgs := transformArgs.RightFoldArgs
+				if foldArgs.FoldType == api.FoldType_FoldExec {
+					sandbox := buildSandbox(foldArgs.Sandbox)
+					if foldArgs.CoProcess != nil {
+						pool := buildCoProcessPool(foldArgs.Path, nil, foldArgs.CoProcess, sandbox, shutdownFns)
+						builder.AddFieldTransformation(core.NewCoProcessRightFoldTransformation(pool))
+					} else {
+						builder.AddFieldTransformation(core.NewSandboxedExecRightFoldTransformation(sandbox,
+							foldArgs.Path))
+					}
+					break
+				}
+				foldSpec, err := buildFoldSpec(foldArgs.FoldType, foldArgs.Condition, foldArgs.Separator)
+				if err != nil {
+					return nil, errors.Wrap(err, "buildTransformer error")
+				}
+				fold, err := core.NewRightFoldTransformation(foldSpec)
+				if err != nil {
+					return nil, errors.Wrap(err, "buildTransformer error")
+				}
+				builder.AddFieldTransformation(fold)
 			}
 		case api.TransformationType_TransformPopHead:
 			builder.AddFieldTransformation(&core.PopHeadTransformation{})
@@ -294,6 +743,93 @@ func buildTransformer(transformerSpec *api.Transformer) (*Transformer, error) {
 	return transformer, nil
 }
 
+func buildJob(jobSpec *api.Job, shutdownFns *[]func() error) (core.Job, error) {
+	if jobSpec == nil {
+		return nil, util.NewInvalidError("a spawner must define a job")
+	}
+	numDefined := 0
+	for _, defined := range []bool{jobSpec.Runnable != nil, jobSpec.Http != nil, jobSpec.Grpc != nil} {
+		if defined {
+			numDefined++
+		}
+	}
+	if numDefined > 1 {
+		return nil, util.NewInvalidError("a job must define only one of runnable, http or grpc")
+	}
+	var retryNum int
+	var retryDelay time.Duration
+	if jobSpec.Retry != nil {
+		retryNum = int(jobSpec.Retry.MaxRetries)
+		retryDelay = time.Duration(jobSpec.Retry.InitialDelayInMs) * time.Millisecond
+	}
+
+	switch {
+	case jobSpec.Http != nil:
+		options := []core.HttpJobOption{
+			core.WithHttpJobHeaders(jobSpec.Http.Headers),
+			core.WithHttpJobRetry(retryNum, retryDelay),
+		}
+		if len(jobSpec.Http.Method) > 0 {
+			options = append(options, core.WithHttpJobMethod(jobSpec.Http.Method))
+		}
+		if len(jobSpec.Http.BodyTemplate) > 0 {
+			options = append(options, core.WithHttpJobBody(jobSpec.Http.BodyTemplate))
+		}
+		return core.NewHttpJob(http.DefaultClient, jobSpec.Http.Url, options...)
+	case jobSpec.Grpc != nil:
+		descriptorSet, err := os.Open(jobSpec.Grpc.DescriptorSetPath)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = descriptorSet.Close()
+		}()
+		files, err := core.LoadDescriptorSet(descriptorSet)
+		if err != nil {
+			return nil, err
+		}
+		dialOption := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))
+		if jobSpec.Grpc.Insecure {
+			dialOption = grpc.WithInsecure()
+		}
+		conn, err := grpc.Dial(jobSpec.Grpc.Endpoint, dialOption)
+		if err != nil {
+			return nil, err
+		}
+		*shutdownFns = append(*shutdownFns, conn.Close)
+		options := []core.GrpcJobOption{
+			core.WithGrpcJobMetadata(jobSpec.Grpc.Metadata),
+			core.WithGrpcJobRetry(retryNum, retryDelay),
+		}
+		if len(jobSpec.Grpc.RequestTemplate) > 0 {
+			options = append(options, core.WithGrpcJobRequest(jobSpec.Grpc.RequestTemplate))
+		}
+		return core.NewGrpcJob(conn, files, jobSpec.Grpc.Method, options...)
+	case jobSpec.Runnable != nil:
+		sandbox := buildSandbox(jobSpec.Runnable.Sandbox)
+		if jobSpec.Runnable.CoProcess != nil {
+			pool := buildCoProcessPool(jobSpec.Runnable.PathToExec, jobSpec.Runnable.CmdArgs,
+				jobSpec.Runnable.CoProcess, sandbox, shutdownFns)
+			return core.NewCoProcessJob(pool), nil
+		}
+		return core.NewSandboxedCmdJob(sandbox, jobSpec.Runnable.PathToExec, jobSpec.Runnable.CmdArgs...), nil
+	}
+	return nil, util.NewInvalidError("a job must define a runnable, http or grpc")
+}
+
+// configVersion returns the configured version or, if not set, a hash of the config
+func configVersion(pipelinesPb *api.Pipelines) (string, error) {
+	if len(pipelinesPb.Version) > 0 {
+		return pipelinesPb.Version, nil
+	}
+	pbBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(pipelinesPb)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(pbBytes)
+	return hex.EncodeToString(sum[:])[:12], nil
+}
+
 func PipelinesFromJson(pipelineJson []byte) (*Pipelines, error) {
 	var pipelinesPb api.Pipelines
 	byteBuffer := bytes.NewBuffer(pipelineJson)
@@ -315,6 +851,9 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 	externalHttp := make(map[string]string)
 	externalLocalFile := make(map[string]string)
 	processes := make(map[string]PipelineProcess)
+	scripts := make(map[string]*core.Script)
+	// Pipelines that are referenced by processes, e.g. Absence outputs, are set once they are built
+	pipelineRefs := make(map[string]*pipelineRef)
 
 	// Get Uuid
 	partitionUuid, err := gUuid.Parse(pipelinesPb.PartitionUuid)
@@ -322,6 +861,27 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 		return nil, errors.Wrap(err, "PipelinesFromJson error")
 	}
 
+	version, err := configVersion(pipelinesPb)
+	if err != nil {
+		return nil, errors.Wrap(err, "PipelinesFromJson error")
+	}
+	core.SetConfigVersion(version)
+
+	if len(pipelinesPb.NtpServer) > 0 {
+		syncInterval := time.Duration(pipelinesPb.NtpSyncIntervalInSec)*time.Second
+		// An unreachable NTP server should not stop binge from starting, so the local clock is used until
+		// a sync succeeds
+		if err = core.SyncClock(pipelinesPb.NtpServer); err != nil {
+			zap.L().Warn(fmt.Sprintf("using the local clock until the NTP sync succeeds: %s", err.Error()))
+			if syncInterval <= 0 {
+				syncInterval = core.DefaultClockSyncRetryInterval
+			}
+		}
+		if syncInterval > 0 {
+			shutdownFns = append(shutdownFns, core.StartClockSync(pipelinesPb.NtpServer, syncInterval))
+		}
+	}
+
 	// Get external systems
 	for _, externalSystem := range pipelinesPb.ExternalSystems {
 		switch externalSystem.ExternalType {
@@ -333,12 +893,18 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 			}
 			shutdownFns = append(shutdownFns, externalKVStores[externalSystem.Name].Close)
 		case api.ExternalType_ExternalPubSub:
-			externalPublisher[externalSystem.Name], err = streaming.NewMemPublisher(streaming.NewMemPubSub(),
-				externalSystem.ConnectionString)
+			publisher, err := streaming.NewPublisherFromConnectionString(externalSystem.ConnectionString)
 			if err != nil {
 				return nil, errors.Wrap(err, "PipelinesFromJson error")
 			}
-			shutdownFns = append(shutdownFns, externalPublisher[externalSystem.Name].Close)
+			externalPublisher[externalSystem.Name] = publisher
+			shutdownFns = append(shutdownFns, func() error {
+				flushErr := publisher.Flush(context.Background(), streaming.DefaultFlushTimeout)
+				if err := publisher.Close(); err != nil {
+					return err
+				}
+				return flushErr
+			})
 		case api.ExternalType_ExternalHttp:
 			externalHttp[externalSystem.Name] = externalSystem.ConnectionString
 		case api.ExternalType_ExternalLocalFile:
@@ -371,7 +937,11 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 				if err != nil {
 					return nil, errors.Wrap(err, "PipelinesFromJson error")
 				}
-				annotatorBuilder.Add(core.NewAnnotation(annotation.FieldKey, annotation.Value, condition))
+				coreAnnotation, err := core.NewTemplatedAnnotation(annotation.FieldKey, annotation.Value, condition)
+				if err != nil {
+					return nil, errors.Wrap(err, "PipelinesFromJson error")
+				}
+				annotatorBuilder.Add(coreAnnotation)
 			}
 			processes[procDef.Annotator.Name] = annotatorBuilder.Build()
 		case *api.ProcessDefinition_Aggregator:
@@ -422,7 +992,7 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 				msg := fmt.Sprintf("name conflict in process definitions: %s", procDef.Transformer.Name)
 				return nil, util.NewInvalidError(msg)
 			}
-			transformer, err := buildTransformer(procDef.Transformer)
+			transformer, err := buildTransformer(procDef.Transformer, &shutdownFns)
 			if err != nil {
 				 return nil, errors.Wrap(err, "PipelinesFromJson error")
 			}
@@ -452,44 +1022,178 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 				return nil, errors.Wrap(err, "PipelinesFromJson error")
 			}
 
-			if external, ok := externalKVStores[procDef.Tee.OutputConnectorRef]; ok {
-				processes[procDef.Tee.Name] = NewKVTee(procDef.Tee.Name, external, condition, transformer,
-					procDef.Tee.AdditionalBody.AsMap())
-			} else if external, ok := externalPublisher[procDef.Tee.OutputConnectorRef]; ok {
-				processes[procDef.Tee.Name] = NewPubSubTee(procDef.Tee.Name, external, condition, transformer,
-					procDef.Tee.AdditionalBody.AsMap())
-			} else if external, ok := externalObjectStore[procDef.Tee.OutputConnectorRef]; ok {
-				processes[procDef.Tee.Name] = NewObjectStoreTee(procDef.Tee.Name, external, condition, transformer,
-					procDef.Tee.AdditionalBody.AsMap())
-			} else if external, ok := externalHttp[procDef.Tee.OutputConnectorRef]; ok {
-				processes[procDef.Tee.Name] = NewHttpTee(procDef.Tee.Name, http.DefaultClient, external, condition,
-					transformer,
-					procDef.Tee.AdditionalBody.AsMap())
-			} else if external, ok := externalLocalFile[procDef.Tee.OutputConnectorRef]; ok {
-				if processes[procDef.Tee.Name], err = NewLocalFileTee(procDef.Tee.Name, external, condition,
-					transformer, procDef.Tee.AdditionalBody.AsMap()); err != nil {
+			var formatOptions []TeeOption
+			if procDef.Tee.Format != nil {
+				serializer, err := buildTeeSerializer(procDef.Tee.Format)
+				if err != nil {
 					return nil, errors.Wrap(err, "PipelinesFromJson error")
 				}
-			} else if external, ok := externalSearchIndex[procDef.Tee.OutputConnectorRef]; ok {
-				processes[procDef.Tee.Name] = NewSearchIndexTee(procDef.Tee.Name, external, condition, transformer,
-					procDef.Tee.AdditionalBody.AsMap())
-			} else {
-				msg := fmt.Sprintf("%v is not a valid external reference", procDef.Tee.TransformerRef)
+				formatOptions = append(formatOptions, WithTeeSerializer(serializer))
+			}
+			refs := procDef.Tee.OutputConnectorRefs
+			if len(refs) == 0 {
+				refs = []string{procDef.Tee.OutputConnectorRef}
+			} else if len(procDef.Tee.OutputConnectorRef) > 0 {
+				msg := fmt.Sprintf("tee %s: set either outputConnectorRef or outputConnectorRefs", procDef.Tee.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+
+			// Connector options only apply to the destinations of their type
+			var objectOptions, rollingOptions, httpOptions, pubSubOptions []TeeOption
+			var httpClient common.HTTPClient = http.DefaultClient
+			if procDef.Tee.ObjectOutput != nil {
+				if objectOptions, err = buildObjectTeeOptions(procDef.Tee.ObjectOutput); err != nil {
+					return nil, errors.Wrap(err, "PipelinesFromJson error")
+				}
+			}
+			if procDef.Tee.RollingFile != nil {
+				options, err := buildRollingFileOptions(procDef.Tee.RollingFile)
+				if err != nil {
+					return nil, errors.Wrap(err, "PipelinesFromJson error")
+				}
+				rollingOptions = []TeeOption{WithRollingFile(options)}
+			}
+			if procDef.Tee.HttpOutput != nil {
+				if httpOptions, httpClient, err = buildHttpTeeOptions(procDef.Tee.HttpOutput); err != nil {
+					return nil, errors.Wrap(err, "PipelinesFromJson error")
+				}
+			}
+			if procDef.Tee.PubSubOutput != nil {
+				if pubSubOptions, err = buildPubSubTeeOptions(procDef.Tee.PubSubOutput); err != nil {
+					return nil, errors.Wrap(err, "PipelinesFromJson error")
+				}
+			}
+
+			var destinations []*Tee
+			var hasObjectStore, hasLocalFile, hasHttp, hasPublisher bool
+			destinationNames := make(map[string]bool)
+			for _, ref := range refs {
+				name := procDef.Tee.Name
+				if len(procDef.Tee.OutputConnectorRefs) > 0 {
+					name = fmt.Sprintf("%s.%s", procDef.Tee.Name, ref)
+				}
+				if destinationNames[name] {
+					msg := fmt.Sprintf("tee %s: duplicate output connector %s", procDef.Tee.Name, ref)
+					return nil, util.NewInvalidError(msg)
+				}
+				destinationNames[name] = true
+
+				// Each destination has its own delivery buffer
+				var destination string
+				if len(procDef.Tee.OutputConnectorRefs) > 0 {
+					destination = ref
+				}
+				teeOptions, err := buildTeeOptions(procDef.Tee.Delivery, destination)
+				if err != nil {
+					return nil, errors.Wrap(err, "PipelinesFromJson error")
+				}
+				teeOptions = append(teeOptions, formatOptions...)
+				withOptions := func(options []TeeOption) []TeeOption {
+					return append(append([]TeeOption{}, teeOptions...), options...)
+				}
+
+				var tee *Tee
+				if external, ok := externalKVStores[ref]; ok {
+					tee = NewKVTee(name, external, condition, transformer, procDef.Tee.AdditionalBody.AsMap(),
+						teeOptions...)
+				} else if external, ok := externalPublisher[ref]; ok {
+					hasPublisher = true
+					tee = NewPubSubTee(name, external, condition, transformer, procDef.Tee.AdditionalBody.AsMap(),
+						withOptions(pubSubOptions)...)
+				} else if external, ok := externalObjectStore[ref]; ok {
+					hasObjectStore = true
+					tee = NewObjectStoreTee(name, external, condition, transformer,
+						procDef.Tee.AdditionalBody.AsMap(), withOptions(objectOptions)...)
+				} else if external, ok := externalHttp[ref]; ok {
+					hasHttp = true
+					tee = NewHttpTee(name, httpClient, external, condition, transformer,
+						procDef.Tee.AdditionalBody.AsMap(), withOptions(httpOptions)...)
+				} else if external, ok := externalLocalFile[ref]; ok {
+					hasLocalFile = true
+					if tee, err = NewLocalFileTee(name, external, condition, transformer,
+						procDef.Tee.AdditionalBody.AsMap(), withOptions(rollingOptions)...); err != nil {
+						return nil, errors.Wrap(err, "PipelinesFromJson error")
+					}
+				} else if external, ok := externalSearchIndex[ref]; ok {
+					tee = NewSearchIndexTee(name, external, condition, transformer,
+						procDef.Tee.AdditionalBody.AsMap(), teeOptions...)
+				} else {
+					msg := fmt.Sprintf("%v is not a valid external reference", ref)
+					return nil, util.NewInvalidError(msg)
+				}
+				destinations = append(destinations, tee)
+			}
+			if procDef.Tee.ObjectOutput != nil && !hasObjectStore {
+				msg := fmt.Sprintf("tee %s: objectOutput requires an object store connector", procDef.Tee.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			if procDef.Tee.RollingFile != nil && !hasLocalFile {
+				msg := fmt.Sprintf("tee %s: rollingFile requires a local file connector", procDef.Tee.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			if procDef.Tee.HttpOutput != nil && !hasHttp {
+				msg := fmt.Sprintf("tee %s: httpOutput requires an HTTP connector", procDef.Tee.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+
+			if procDef.Tee.PubSubOutput != nil && !hasPublisher {
+				msg := fmt.Sprintf("tee %s: pubSubOutput requires a pubsub connector", procDef.Tee.Name)
 				return nil, util.NewInvalidError(msg)
 			}
 
+			if len(procDef.Tee.OutputConnectorRefs) == 0 {
+				processes[procDef.Tee.Name] = destinations[0]
+				if procDef.Tee.Delivery != nil || procDef.Tee.ObjectOutput != nil || procDef.Tee.RollingFile != nil {
+					shutdownFns = append(shutdownFns, destinations[0].Close)
+				}
+			} else {
+				mode, err := ParseTeeMode(procDef.Tee.Mode)
+				if err != nil {
+					return nil, errors.Wrap(err, "PipelinesFromJson error")
+				}
+				multiTee := NewMultiTee(procDef.Tee.Name, destinations, mode, condition, transformer)
+				processes[procDef.Tee.Name] = multiTee
+				shutdownFns = append(shutdownFns, multiTee.Close)
+			}
+
 		case *api.ProcessDefinition_Spawner:
 			if _, ok := processes[procDef.Spawner.Name]; ok {
 				msg := fmt.Sprintf("name conflict in process definitions: %s", procDef.Spawner.Name)
 				return nil, util.NewInvalidError(msg)
 			}
-			job := core.NewCmdJob(procDef.Spawner.Job.Runnable.PathToExec, procDef.Spawner.Job.Runnable.CmdArgs...)
+			job, err := buildJob(procDef.Spawner.Job, &shutdownFns)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
 			condition, err := buildCondition(procDef.Spawner.Condition)
 			if err != nil {
 				return nil, errors.Wrap(err, "PipelinesFromJson error")
 			}
-			processes[procDef.Spawner.Name] = NewSpawner(procDef.Spawner.Name, job, condition,
-				time.Duration(procDef.Spawner.DelayInMs) * time.Millisecond, procDef.Spawner.DoSync)
+			delay := time.Duration(procDef.Spawner.DelayInMs) * time.Millisecond
+			options := []SpawnerOption{
+				WithSpawnerTimeout(time.Duration(procDef.Spawner.TimeoutInMs) * time.Millisecond),
+				WithSpawnerMaxConcurrency(int(procDef.Spawner.MaxConcurrency)),
+			}
+			if procDef.Spawner.MaxOutputBytes > 0 {
+				options = append(options, WithSpawnerMaxOutputBytes(int(procDef.Spawner.MaxOutputBytes)))
+			}
+			if len(procDef.Spawner.QueuePath) == 0 {
+				processes[procDef.Spawner.Name] = NewSpawner(procDef.Spawner.Name, job, condition, delay,
+					procDef.Spawner.DoSync, options...)
+				break
+			}
+			if procDef.Spawner.DoSync {
+				msg := fmt.Sprintf("spawner %s: queuePath is only supported for asynchronous jobs",
+					procDef.Spawner.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			spawner, err := NewDurableSpawner(procDef.Spawner.Name, job, condition, delay,
+				procDef.Spawner.QueuePath, options...)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			shutdownFns = append(shutdownFns, spawner.Close)
+			processes[procDef.Spawner.Name] = spawner
 		case *api.ProcessDefinition_Continuation:
 			if _, ok := processes[procDef.Continuation.Name]; ok {
 				msg := fmt.Sprintf("name conflict in process definitions: %s", procDef.Continuation.Name)
@@ -500,6 +1204,42 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 				return nil, errors.Wrap(err, "PipelinesFromJson error")
 			}
 			processes[procDef.Continuation.Name] = NewContinuation(procDef.Continuation.Name, condition)
+		case *api.ProcessDefinition_Script:
+			if _, ok := processes[procDef.Script.Name]; ok {
+				msg := fmt.Sprintf("name conflict in process definitions: %s", procDef.Script.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			condition, err := buildCondition(procDef.Script.Condition)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			script, err := buildScript(procDef.Script, externalObjectStore, scripts)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			processes[procDef.Script.Name] = NewScript(procDef.Script.Name, script, condition)
+		case *api.ProcessDefinition_Parser:
+			if _, ok := processes[procDef.Parser.Name]; ok {
+				msg := fmt.Sprintf("name conflict in process definitions: %s", procDef.Parser.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			condition, err := buildCondition(procDef.Parser.Condition)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			lineParser, err := buildLineParser(procDef.Parser)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			sourceField := procDef.Parser.SourceField
+			if len(sourceField) == 0 {
+				sourceField = "message"
+			}
+			processes[procDef.Parser.Name], err = NewParser(procDef.Parser.Name, lineParser, sourceField,
+				procDef.Parser.TargetField, condition, procDef.Parser.FailOnError)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
 		case *api.ProcessDefinition_Entwine:
 			var ok bool
 			var objectStore storage.ObjectStore
@@ -534,6 +1274,81 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 			if err != nil {
 				return nil, errors.Wrap(err, "PipelinesFromJson error")
 			}
+		case *api.ProcessDefinition_Absence:
+			if _, ok := processes[procDef.Absence.Name]; ok {
+				msg := fmt.Sprintf("name conflict in process definitions: %s", procDef.Absence.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			condition, err := buildCondition(procDef.Absence.Condition)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			kvStore, ok := externalKVStores[procDef.Absence.StateStore]
+			if !ok {
+				msg := fmt.Sprintf("unknown kvStore for %s: %s", procDef.Absence.Name, procDef.Absence.StateStore)
+				return nil, util.NewInvalidError(msg)
+			}
+			output, err := buildAbsenceOutput(procDef.Absence, processes, pipelineRefs)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			absence, err := NewAbsence(procDef.Absence.Name, procDef.Absence.KeyFields, condition, kvStore,
+				time.Duration(procDef.Absence.ThresholdInMs) * time.Millisecond, output,
+				WithAbsenceCheckInterval(time.Duration(procDef.Absence.CheckIntervalInMs) * time.Millisecond))
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			shutdownFns = append(shutdownFns, absence.Close)
+			processes[procDef.Absence.Name] = absence
+		case *api.ProcessDefinition_ChangeDetector:
+			if _, ok := processes[procDef.ChangeDetector.Name]; ok {
+				msg := fmt.Sprintf("name conflict in process definitions: %s", procDef.ChangeDetector.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			condition, err := buildCondition(procDef.ChangeDetector.Condition)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			kvStore, ok := externalKVStores[procDef.ChangeDetector.StateStore]
+			if !ok {
+				msg := fmt.Sprintf("unknown kvStore for %s: %s", procDef.ChangeDetector.Name,
+					procDef.ChangeDetector.StateStore)
+				return nil, util.NewInvalidError(msg)
+			}
+			var fields []ChangeField
+			for _, field := range procDef.ChangeDetector.Fields {
+				fields = append(fields, ChangeField{
+					Path: field.Path,
+					AbsoluteDeadband: field.AbsoluteDeadband,
+					RelativeDeadband: field.RelativeDeadband,
+				})
+			}
+			processes[procDef.ChangeDetector.Name], err = NewChangeDetector(procDef.ChangeDetector.Name,
+				procDef.ChangeDetector.KeyFields, fields, condition, kvStore)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+		case *api.ProcessDefinition_AnomalyDetector:
+			if _, ok := processes[procDef.AnomalyDetector.Name]; ok {
+				msg := fmt.Sprintf("name conflict in process definitions: %s", procDef.AnomalyDetector.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			condition, err := buildCondition(procDef.AnomalyDetector.Condition)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			kvStore, ok := externalKVStores[procDef.AnomalyDetector.StateStore]
+			if !ok {
+				msg := fmt.Sprintf("unknown kvStore for %s: %s", procDef.AnomalyDetector.Name,
+					procDef.AnomalyDetector.StateStore)
+				return nil, util.NewInvalidError(msg)
+			}
+			detection, err := buildAnomalyDetection(procDef.AnomalyDetector)
+			if err != nil {
+				return nil, errors.Wrap(err, "PipelinesFromJson error")
+			}
+			processes[procDef.AnomalyDetector.Name] = NewAnomalyDetector(procDef.AnomalyDetector.Name, detection,
+				condition, state.NewKvStateStore(kvStore), procDef.AnomalyDetector.OnlyAnomalies)
 		}
 	}
 
@@ -585,7 +1400,294 @@ func PipelinesFromPb(pipelinesPb *api.Pipelines)  (*Pipelines, error) {
 
 		builtPipelines = append(builtPipelines, pipelineBuilder.Get())
 	}
-	return NewPipelines(builtPipelines, shutdownFns), nil
+
+	for name, ref := range pipelineRefs {
+		for _, pipeline := range builtPipelines {
+			if pipeline.Name() == name {
+				ref.pipeline.Store(pipeline)
+			}
+		}
+		if ref.pipeline.Load() == nil {
+			msg := fmt.Sprintf("cannot find pipeline: %s", name)
+			return nil, util.NewInvalidError(msg)
+		}
+	}
+
+	sources, err := buildSources(pipelinesPb, externalKVStores, externalObjectStore, externalLocalFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "PipelinesFromJson error")
+	}
+	return NewPipelines(builtPipelines, shutdownFns, sources...), nil
+}
+
+// buildAnomalyDetection returns the detection of an AnomalyDetector, using the defaults for unset options
+func buildAnomalyDetection(detectorSpec *api.AnomalyDetector) (*core.AnomalyDetection, error) {
+	options := []core.AnomalyOption{
+		core.WithAnomalyScoring(protoAnomalyScoringToInternal(detectorSpec.Scoring)),
+	}
+	if detectorSpec.DecayFactor != 0 {
+		options = append(options, core.WithAnomalyDecayFactor(detectorSpec.DecayFactor))
+	}
+	if detectorSpec.WarmUp != 0 {
+		options = append(options, core.WithAnomalyWarmUp(detectorSpec.WarmUp))
+	}
+	if detectorSpec.Threshold != 0 {
+		options = append(options, core.WithAnomalyThreshold(detectorSpec.Threshold))
+	}
+	if detectorSpec.SeasonPeriodInMs != 0 || detectorSpec.SeasonBuckets != 0 {
+		options = append(options, core.WithAnomalySeason(
+			time.Duration(detectorSpec.SeasonPeriodInMs) * time.Millisecond, int(detectorSpec.SeasonBuckets),
+			detectorSpec.TimeKey))
+	}
+	if len(detectorSpec.Key) == 0 {
+		msg := fmt.Sprintf("anomaly detector %s: key is required", detectorSpec.Name)
+		return nil, util.NewInvalidError(msg)
+	}
+	return core.NewAnomalyDetection(detectorSpec.Key, detectorSpec.GroupByKeys, options...)
+}
+
+// pipelineRef is a pipeline that is referenced by a process, and set once the pipelines are built
+type pipelineRef struct {
+	pipeline atomic.Value
+}
+
+// buildAbsenceOutput returns the output of an Absence, which is a previously defined Tee or a pipeline
+func buildAbsenceOutput(absenceSpec *api.Absence, processes map[string]PipelineProcess,
+	pipelineRefs map[string]*pipelineRef) (AbsenceOutput, error) {
+	if len(absenceSpec.OutputTee) > 0 && len(absenceSpec.OutputPipeline) > 0 {
+		msg := fmt.Sprintf("absence %s: only one of outputTee and outputPipeline may be set", absenceSpec.Name)
+		return nil, util.NewInvalidError(msg)
+	}
+	if len(absenceSpec.OutputTee) > 0 {
+		proc, ok := processes[absenceSpec.OutputTee]
+		if !ok {
+			msg := fmt.Sprintf("absence %s: cannot find tee: %s", absenceSpec.Name, absenceSpec.OutputTee)
+			return nil, util.NewInvalidError(msg)
+		}
+		switch proc.(type) {
+		case *Tee, *MultiTee:
+		default:
+			msg := fmt.Sprintf("absence %s: %s is not a tee", absenceSpec.Name, absenceSpec.OutputTee)
+			return nil, util.NewInvalidError(msg)
+		}
+		return ProcessAbsenceOutput(proc), nil
+	}
+	if len(absenceSpec.OutputPipeline) == 0 {
+		msg := fmt.Sprintf("absence %s: one of outputTee and outputPipeline must be set", absenceSpec.Name)
+		return nil, util.NewInvalidError(msg)
+	}
+	ref, ok := pipelineRefs[absenceSpec.OutputPipeline]
+	if !ok {
+		ref = &pipelineRef{}
+		pipelineRefs[absenceSpec.OutputPipeline] = ref
+	}
+	return func(ctx context.Context, in map[string]interface{}) error {
+		pipeline, ok := ref.pipeline.Load().(*Pipeline)
+		if !ok {
+			return util.NewInvalidError(fmt.Sprintf("pipeline %s is not built", absenceSpec.OutputPipeline))
+		}
+		return PipelineAbsenceOutput(pipeline)(ctx, in)
+	}, nil
+}
+
+func buildSources(pipelinesPb *api.Pipelines, externalKVStores map[string]kvs.KVStore,
+	externalObjectStore map[string]storage.ObjectStore, externalLocalFile map[string]string) ([]*PipelineSource,
+	error) {
+	var sources []*PipelineSource
+	pipelineNames := make(map[string]bool)
+	for _, pipeline := range pipelinesPb.Pipelines {
+		pipelineNames[pipeline.Name] = true
+	}
+	sourceNames := make(map[string]bool)
+	for _, sourceSpec := range pipelinesPb.Sources {
+		if len(sourceSpec.Name) == 0 || sourceNames[sourceSpec.Name] {
+			msg := fmt.Sprintf("sources must have unique names: '%s'", sourceSpec.Name)
+			return nil, util.NewInvalidError(msg)
+		}
+		sourceNames[sourceSpec.Name] = true
+		for _, name := range sourceSpec.Pipelines {
+			if !pipelineNames[name] {
+				msg := fmt.Sprintf("source %s: cannot find pipeline: %s", sourceSpec.Name, name)
+				return nil, util.NewInvalidError(msg)
+			}
+		}
+
+		var built source.Source
+		switch spec := sourceSpec.SourceSpec.(type) {
+		case *api.Source_Http:
+			if spec.Http.Port <= 0 {
+				msg := fmt.Sprintf("source %s: http sources require a port", sourceSpec.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			built = source.NewHttpSource(sourceSpec.Name, int(spec.Http.Port), spec.Http.Path,
+				int(spec.Http.MaxConnections))
+		case *api.Source_Subscriber:
+			subscriber, err := streaming.NewSubscriberFromConnectionString(spec.Subscriber.ConnectionString)
+			if err != nil {
+				return nil, err
+			}
+			var deadLetter streaming.Publisher
+			if len(spec.Subscriber.DeadLetterConnectionString) > 0 {
+				deadLetter, err = streaming.NewPublisherFromConnectionString(spec.Subscriber.DeadLetterConnectionString)
+				if err != nil {
+					return nil, err
+				}
+			}
+			built = source.NewSubscriberSource(sourceSpec.Name, subscriber, deadLetter,
+				int(spec.Subscriber.MaxRetries))
+		case *api.Source_Syslog:
+			var err error
+			if built, err = buildListenerSource(sourceSpec.Name, spec.Syslog, source.NewSyslogSource); err != nil {
+				return nil, err
+			}
+		case *api.Source_JsonLines:
+			var err error
+			if built, err = buildListenerSource(sourceSpec.Name, spec.JsonLines, source.NewJsonLinesSource); err != nil {
+				return nil, err
+			}
+		case *api.Source_ObjectStore:
+			var err error
+			if built, err = buildObjectStoreSource(sourceSpec.Name, spec.ObjectStore, externalKVStores,
+				externalObjectStore); err != nil {
+				return nil, err
+			}
+		case *api.Source_Schedule:
+			if len(sourceSpec.Pipelines) == 0 {
+				msg := fmt.Sprintf("source %s: schedule sources require pipelines", sourceSpec.Name)
+				return nil, util.NewInvalidError(msg)
+			}
+			var err error
+			if built, err = buildScheduleSource(sourceSpec.Name, spec.Schedule, externalKVStores); err != nil {
+				return nil, err
+			}
+		case *api.Source_FileTail:
+			var err error
+			if built, err = buildFileTailSource(sourceSpec.Name, spec.FileTail, externalKVStores,
+				externalLocalFile); err != nil {
+				return nil, err
+			}
+		default:
+			msg := fmt.Sprintf("source %s: unknown source type", sourceSpec.Name)
+			return nil, util.NewInvalidError(msg)
+		}
+		sources = append(sources, &PipelineSource{built, sourceSpec.Pipelines})
+	}
+	return sources, nil
+}
+
+func buildListenerSource(name string, spec *api.ListenerSource, newSource func(name, network, address string,
+	options ...source.NetworkOption) (*source.NetworkSource, error)) (source.Source, error) {
+	if len(spec.Address) == 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("source %s: listener sources require an address", name))
+	}
+	network := spec.Network
+	if len(network) == 0 {
+		network = "udp"
+	}
+	return newSource(name, network, spec.Address, source.WithMaxConnections(int(spec.MaxConnections)),
+		source.WithMaxMessageSize(int(spec.MaxMessageSize)))
+}
+
+func buildScheduleSource(name string, spec *api.ScheduleSource,
+	externalKVStores map[string]kvs.KVStore) (source.Source, error) {
+	options := []source.ScheduleOption{source.WithSchedulePayload(spec.Payload.AsMap())}
+	if len(spec.Timezone) > 0 {
+		location, err := time.LoadLocation(spec.Timezone)
+		if err != nil {
+			return nil, util.NewInvalidError(fmt.Sprintf("source %s: %s", name, err.Error()))
+		}
+		options = append(options, source.WithScheduleLocation(location))
+	}
+	if len(spec.LeaderStoreRef) > 0 {
+		kvStore, ok := externalKVStores[spec.LeaderStoreRef]
+		if !ok {
+			msg := fmt.Sprintf("source %s: cannot find leader store: %s", name, spec.LeaderStoreRef)
+			return nil, util.NewInvalidError(msg)
+		}
+		options = append(options, source.WithLeaderElection(kvStore,
+			time.Duration(spec.LockTimeoutInMs) * time.Millisecond))
+	}
+	return source.NewScheduleSource(name, spec.Expression, options...)
+}
+
+func buildObjectStoreSource(name string, spec *api.ObjectStoreSource, externalKVStores map[string]kvs.KVStore,
+	externalObjectStore map[string]storage.ObjectStore) (source.Source, error) {
+	objectStore, ok := externalObjectStore[spec.ObjectStoreRef]
+	if !ok {
+		msg := fmt.Sprintf("source %s: cannot find object store: %s", name, spec.ObjectStoreRef)
+		return nil, util.NewInvalidError(msg)
+	}
+	var claims kvs.KVStore
+	if len(spec.ClaimStoreRef) > 0 {
+		if claims, ok = externalKVStores[spec.ClaimStoreRef]; !ok {
+			msg := fmt.Sprintf("source %s: cannot find claim store: %s", name, spec.ClaimStoreRef)
+			return nil, util.NewInvalidError(msg)
+		}
+	}
+	format, err := source.ParseObjectFormat(spec.Format)
+	if err != nil {
+		return nil, err
+	}
+	options := []source.ObjectStoreSourceOption{
+		source.WithObjectFormat(format),
+		source.WithCsvColumns(spec.CsvColumns),
+		source.WithCsvHeaderMapping(spec.CsvHeaderMapping),
+		source.WithObjectDonePrefix(spec.DonePrefix),
+		source.WithObjectFailedPrefix(spec.FailedPrefix),
+	}
+	if spec.PollIntervalInMs > 0 {
+		options = append(options, source.WithObjectPollInterval(time.Duration(spec.PollIntervalInMs) * time.Millisecond))
+	}
+	if spec.ClaimTimeoutInMs > 0 {
+		options = append(options, source.WithClaimTimeout(time.Duration(spec.ClaimTimeoutInMs) * time.Millisecond))
+	}
+	if spec.MaxRetries > 0 {
+		options = append(options, source.WithObjectMaxRetries(int(spec.MaxRetries)))
+	}
+	return source.NewObjectStoreSource(name, objectStore, spec.Prefix, claims, options...)
+}
+
+func buildFileTailSource(name string, spec *api.FileTailSource, externalKVStores map[string]kvs.KVStore,
+	externalLocalFile map[string]string) (source.Source, error) {
+	format, err := source.ParseTailFormat(spec.Format)
+	if err != nil {
+		return nil, err
+	}
+	options := []source.FileTailOption{source.WithTailFormat(format)}
+	if spec.PollIntervalInMs > 0 {
+		options = append(options, source.WithTailPollInterval(time.Duration(spec.PollIntervalInMs) * time.Millisecond))
+	}
+	if ref := spec.OffsetConnectorRef; len(ref) > 0 {
+		if kvStore, ok := externalKVStores[ref]; ok {
+			options = append(options, source.WithTailOffsets(source.NewKVTailOffsetStore(kvStore,
+				fmt.Sprintf("tail:%s:", name))))
+		} else if path, ok := externalLocalFile[ref]; ok {
+			options = append(options, source.WithTailOffsets(source.NewLocalFileTailOffsetStore(
+				filepath.Join(path, name + ".offsets.json"))))
+		} else {
+			msg := fmt.Sprintf("source %s: cannot find offset connector: %s", name, ref)
+			return nil, util.NewInvalidError(msg)
+		}
+	}
+	if multiline := spec.Multiline; multiline != nil {
+		if (len(multiline.StartPattern) > 0) == (len(multiline.ContinuationPattern) > 0) {
+			msg := fmt.Sprintf("source %s: multiline requires one of startPattern or continuationPattern", name)
+			return nil, util.NewInvalidError(msg)
+		}
+		rule := source.MultilineRule{
+			MaxLines: int(multiline.MaxLines),
+			Timeout: time.Duration(multiline.TimeoutInMs) * time.Millisecond,
+		}
+		if len(multiline.StartPattern) > 0 {
+			if rule.StartPattern, err = regexp.Compile(multiline.StartPattern); err != nil {
+				return nil, util.NewInvalidError(fmt.Sprintf("source %s: %s", name, err.Error()))
+			}
+		} else if rule.ContinuationPattern, err = regexp.Compile(multiline.ContinuationPattern); err != nil {
+			return nil, util.NewInvalidError(fmt.Sprintf("source %s: %s", name, err.Error()))
+		}
+		options = append(options, source.WithTailMultiline(rule))
+	}
+	return source.NewFileTailSource(name, spec.Patterns, options...)
 }
 
 func processKey(pipelineName, processName string) string {
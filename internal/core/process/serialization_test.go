@@ -1,11 +1,28 @@
 package process
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kmgreen2/agglo/generated/proto"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/pkg/storage"
+	"github.com/kmgreen2/agglo/pkg/streaming"
+	"github.com/kmgreen2/agglo/pkg/util"
 	"github.com/kmgreen2/agglo/test"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestPipelinesBasic(t *testing.T) {
@@ -60,3 +77,925 @@ func TestPipelinesBasic(t *testing.T) {
 
 	_ = os.Remove(tmpFile)
 }
+
+func TestPipelinesBuiltinFolds(t *testing.T) {
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "pipelines": [{"name": "fold-pipeline", "processes": [{"name": "fold-transform"}]}],
+  "processDefinitions": [
+    {
+      "transformer": {
+        "name": "fold-transform",
+        "specs": [
+          {
+            "sourceField": "prices",
+            "targetField": "avgPrice",
+            "transformation": {
+              "transformationType": "TransformLeftFold",
+              "leftFoldArgs": {"foldType": "FoldAvg"}
+            }
+          },
+          {
+            "sourceField": "prices",
+            "targetField": "numExpensive",
+            "transformation": {
+              "transformationType": "TransformRightFold",
+              "rightFoldArgs": {
+                "foldType": "FoldCount",
+                "condition": {
+                  "expression": {
+                    "comparator": {
+                      "lhs": {"variable": {"name": "value"}},
+                      "rhs": {"numeric": 5},
+                      "op": "GreaterThan"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        ]
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{
+		"prices": []interface{}{float64(2), float64(6), float64(10)},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(6), out["avgPrice"])
+	assert.Equal(t, float64(2), out["numExpensive"])
+}
+
+func TestPipelinesParser(t *testing.T) {
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "pipelines": [{"name": "parser-pipeline", "processes": [{"name": "grok"}]}],
+  "processDefinitions": [
+    {
+      "parser": {
+        "name": "grok",
+        "targetField": "access",
+        "patterns": ["%{IP:client} %{VERB:method} %{NUMBER:status:int}"],
+        "patternDefinitions": {"VERB": "(?:GET|POST)"},
+        "failOnError": true
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"message": "10.0.0.1 POST 201"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"client": "10.0.0.1", "method": "POST", "status": float64(201)},
+		out["access"])
+
+	_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"message": "10.0.0.1 DELETE 201"})
+	assert.Error(t, err)
+}
+
+func TestPipelinesTemplatedAnnotations(t *testing.T) {
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "version": "2021-03-01",
+  "pipelines": [{"name": "annotate-pipeline", "processes": [{"name": "provenance"}]}],
+  "processDefinitions": [
+    {
+      "annotator": {
+        "name": "provenance",
+        "annotations": [
+          {"fieldKey": "deviceKey", "value": "{{.device.site}}-{{.device.id}}"},
+          {"fieldKey": "configVersion", "value": "derived:configVersion"}
+        ]
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{
+		"device": map[string]interface{}{"site": "sfo", "id": "a1"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "sfo-a1", out["deviceKey"])
+	assert.Equal(t, "2021-03-01", out["configVersion"])
+
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, "{{.device.site}}", "{{.device.site", 1)))
+	assert.Error(t, err)
+}
+
+func TestPipelinesNtpUnreachable(t *testing.T) {
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "ntpServer": "127.0.0.1",
+  "pipelines": [{"name": "annotate-pipeline", "processes": [{"name": "stamp"}]}],
+  "processDefinitions": [
+    {"annotator": {"name": "stamp", "annotations": [{"fieldKey": "time", "value": "derived:ntpTime"}]}}
+  ]
+}`
+
+	// The local clock is used until the NTP server can be reached
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() { _ = pipelines.Shutdown() }()
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{})
+	assert.Nil(t, err)
+	ntpTime, err := time.Parse(time.RFC3339, out["time"].(string))
+	assert.Nil(t, err)
+	assert.InDelta(t, 0, time.Now().Sub(ntpTime).Seconds(), 5)
+}
+
+func TestPipelinesHttpJob(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "pipelines": [{"name": "http-pipeline", "processes": [{"name": "trigger"}]}],
+  "processDefinitions": [
+    {
+      "spawner": {
+        "name": "trigger",
+        "doSync": true,
+        "job": {
+          "http": {"url": "` + server.URL + `", "bodyTemplate": "{\"id\": \"{{.id}}\"}"},
+          "retry": {"maxRetries": 2, "initialDelayInMs": 10}
+        }
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": "a"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id": "a"}`, body)
+	spawnOutput := out[SpawnMetadataKey].([]map[string]interface{})
+	status := spawnOutput[0]["trigger"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"statusCode": float64(200), "body": map[string]interface{}{"ok": true}},
+		status["output"])
+
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"http"`, `"unknown"`, 1)))
+	assert.Error(t, err)
+}
+
+func sandboxedJobConfig(script string, timeoutInMs int) []byte {
+	scriptJson, _ := json.Marshal(script)
+	return []byte(fmt.Sprintf(`{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "pipelines": [{"name": "sandbox-pipeline", "processes": [{"name": "sandboxed"}]}],
+  "processDefinitions": [
+    {
+      "spawner": {
+        "name": "sandboxed",
+        "doSync": true,
+        "job": {
+          "runnable": {
+            "pathToExec": "sh",
+            "cmdArgs": ["-c", %s],
+            "sandbox": {"envAllowlist": ["PATH"], "workingDir": "%s", "timeoutInMs": %d}
+          }
+        }
+      }
+    }
+  ]
+}`, string(scriptJson), os.TempDir(), timeoutInMs))
+}
+
+func TestPipelinesSandboxedJob(t *testing.T) {
+	_ = os.Setenv("SANDBOX_SECRET", "secret")
+	defer func() {
+		_ = os.Unsetenv("SANDBOX_SECRET")
+	}()
+
+	pipelines, err := PipelinesFromJson(sandboxedJobConfig(
+		`echo "{\"dir\": \"$(pwd)\", \"secret\": \"$SANDBOX_SECRET\"}"`, 5000))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": "a"})
+	assert.Nil(t, err)
+	spawnOutput := out[SpawnMetadataKey].([]map[string]interface{})
+	status := spawnOutput[0]["sandboxed"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"dir": os.TempDir(), "secret": ""}, status["output"])
+
+	// The sandbox timeout fails the job
+	pipelines, err = PipelinesFromJson(sandboxedJobConfig("sleep 10", 100))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": "a"})
+	assert.Error(t, err)
+}
+
+func TestBuildJobDefinesOne(t *testing.T) {
+	var shutdownFns []func() error
+	_, err := buildJob(&api.Job{}, &shutdownFns)
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+	_, err = buildJob(&api.Job{
+		Runnable: &api.Runnable{PathToExec: "cat"},
+		Http: &api.HttpJob{Url: "http://localhost"},
+	}, &shutdownFns)
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+	job, err := buildJob(&api.Job{Runnable: &api.Runnable{PathToExec: "cat"}}, &shutdownFns)
+	assert.Nil(t, err)
+	assert.NotNil(t, job)
+}
+
+func TestBuildSandboxCredential(t *testing.T) {
+	assert.Nil(t, buildSandbox(&api.Sandbox{}).Credential)
+	// Only setting uid still runs as gid 0, and root can be requested
+	sandbox := buildSandbox(&api.Sandbox{Credential: &api.Credential{Uid: 1000}})
+	assert.Equal(t, &syscall.Credential{Uid: 1000, Gid: 0}, sandbox.Credential)
+	sandbox = buildSandbox(&api.Sandbox{Credential: &api.Credential{}})
+	assert.Equal(t, &syscall.Credential{Uid: 0, Gid: 0}, sandbox.Credential)
+}
+
+func TestPipelinesTeeDelivery(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		received <- string(b)
+	}))
+	defer server.Close()
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [{"name": "webhook", "connectionString": "` + server.URL + `", "externalType": "ExternalHttp"}],
+  "pipelines": [{"name": "tee-pipeline", "processes": [{"name": "forward"}]}],
+  "processDefinitions": [
+    {
+      "tee": {
+        "name": "forward",
+        "outputConnectorRef": "webhook",
+        "delivery": {
+          "retry": {"maxRetries": 2, "initialDelayInMs": 10, "maxDelayInMs": 100},
+          "circuitBreaker": {"failureThreshold": 5, "resetTimeoutInMs": 1000},
+          "async": true,
+          "buffer": {"capacity": 10}
+        }
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": "a"})
+	assert.Nil(t, err)
+	teeOutput := out[TeeMetadataKey].([]map[string]interface{})
+	assert.Equal(t, TeeDeliveryBuffered, teeOutput[0]["delivery"])
+	assert.Contains(t, <-received, `"id":"a"`)
+	assert.Nil(t, pipelines.Shutdown())
+
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"failureThreshold": 5`,
+		`"failureThreshold": 0`, 1)))
+	assert.Error(t, err)
+
+	// Buffers are only used by async or spooled outputs
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"async": true,`, "", 1)))
+	assert.Error(t, err)
+}
+
+func TestPipelinesTeeFormat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "teeformat")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [{"name": "drop", "connectionString": "` + dir + `", "externalType": "ExternalLocalFile"}],
+  "pipelines": [{"name": "csv-pipeline", "processes": [{"name": "csv"}]}],
+  "processDefinitions": [
+    {
+      "tee": {
+        "name": "csv",
+        "outputConnectorRef": "drop",
+        "format": {"name": "csv", "columns": ["id", "device.site"]}
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{
+		"id": "a",
+		"device": map[string]interface{}{"site": "sfo"},
+	})
+	assert.Nil(t, err)
+	uuid := out[TeeMetadataKey].([]map[string]interface{})[0]["uuid"].(string)
+	fileBytes, err := ioutil.ReadFile(dir + "/" + uuid + ".csv")
+	assert.Nil(t, err)
+	assert.Equal(t, "a,sfo\n", string(fileBytes))
+
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"name": "csv", "columns"`,
+		`"name": "xml", "columns"`, 1)))
+	assert.Error(t, err)
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"columns": ["id", "device.site"]`,
+		`"schemaPath": "/does/not/exist"`, 1)))
+	assert.Error(t, err)
+}
+
+func TestPipelinesMultiTeeAsync(t *testing.T) {
+	var lock sync.Mutex
+	received := make(map[string][]string)
+	newServer := func(name string, status int, delay time.Duration) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			time.Sleep(delay)
+			lock.Lock()
+			received[name] = append(received[name], string(b))
+			lock.Unlock()
+			w.WriteHeader(status)
+		}))
+	}
+	good := newServer("good", http.StatusOK, 0)
+	defer good.Close()
+	// A slow, failing destination falls behind, so a shared buffer would be drained by the other destination
+	bad := newServer("bad", http.StatusInternalServerError, 10 * time.Millisecond)
+	defer bad.Close()
+
+	queueDir, err := ioutil.TempDir("", "testMultiTeeAsync")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer os.RemoveAll(queueDir)
+
+	for _, buffer := range []string{`{"capacity": 100}`, `{"capacity": 100, "queuePath": "` + queueDir + `"}`} {
+		received = make(map[string][]string)
+		configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [
+    {"name": "good", "connectionString": "` + good.URL + `", "externalType": "ExternalHttp"},
+    {"name": "bad", "connectionString": "` + bad.URL + `", "externalType": "ExternalHttp"}
+  ],
+  "pipelines": [{"name": "multi-pipeline", "processes": [{"name": "fanout"}]}],
+  "processDefinitions": [
+    {
+      "tee": {
+        "name": "fanout",
+        "outputConnectorRefs": ["good", "bad"],
+        "delivery": {"async": true, "buffer": ` + buffer + `}
+      }
+    }
+  ]
+}`
+
+		pipelines, err := PipelinesFromJson([]byte(configJson))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		for i := 0; i < 20; i++ {
+			_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": i})
+			assert.Nil(t, err)
+		}
+		assert.Nil(t, pipelines.Shutdown())
+
+		// Outputs that fail for one destination are never delivered to the other
+		lock.Lock()
+		assert.Equal(t, 20, len(received["good"]), buffer)
+		assert.Equal(t, 20, len(received["bad"]), buffer)
+		lock.Unlock()
+	}
+	for _, destination := range []string{"good", "bad"} {
+		_, err = os.Stat(filepath.Join(queueDir, destination))
+		assert.Nil(t, err)
+	}
+}
+
+func TestPipelinesObjectOutput(t *testing.T) {
+	objectStore, err := storage.NewObjectStoreFromConnectionString("mem:testObjectOutput")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [
+    {"name": "archive", "connectionString": "mem:testObjectOutput", "externalType": "ExternalObjectStore"},
+    {"name": "drop", "connectionString": "/tmp", "externalType": "ExternalLocalFile"}
+  ],
+  "pipelines": [{"name": "archive-pipeline", "processes": [{"name": "archive"}]}],
+  "processDefinitions": [
+    {
+      "tee": {
+        "name": "archive",
+        "outputConnectorRef": "archive",
+        "objectOutput": {"compression": "gzip", "batchMaxBytes": 1048576, "batchMaxAgeInMs": 60000}
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	var keys []string
+	for _, id := range []string{"a", "b"} {
+		out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": id})
+		assert.Nil(t, err)
+		response := out[TeeMetadataKey].([]map[string]interface{})[0]["response"].(map[string]interface{})
+		keys = append(keys, response["objectKey"].(string))
+	}
+	assert.Equal(t, keys[0], keys[1])
+	assert.Regexp(t, `^pipeline=archive-pipeline/dt=.+\.json\.gz$`, keys[0])
+
+	// Shutting down writes the batch
+	assert.Nil(t, pipelines.Shutdown())
+	reader, err := objectStore.Get(context.Background(), keys[0])
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	gzipReader, err := gzip.NewReader(reader)
+	assert.Nil(t, err)
+	b, err := ioutil.ReadAll(gzipReader)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, strings.Count(string(b), "\n"))
+
+	for _, invalid := range []string{`"compression": "lz4"`, `"keyTemplate": "{{.id"`} {
+		_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"compression": "gzip"`, invalid, 1)))
+		assert.Error(t, err)
+	}
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"outputConnectorRef": "archive"`,
+		`"outputConnectorRef": "drop"`, 1)))
+	assert.Error(t, err)
+}
+
+func TestPipelinesRollingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rollingfile")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [
+    {"name": "drop", "connectionString": "` + dir + `", "externalType": "ExternalLocalFile"},
+    {"name": "webhook", "connectionString": "http://localhost", "externalType": "ExternalHttp"}
+  ],
+  "pipelines": [{"name": "rolling-pipeline", "processes": [{"name": "spool"}]}],
+  "processDefinitions": [
+    {
+      "tee": {
+        "name": "spool",
+        "outputConnectorRef": "drop",
+        "rollingFile": {"name": "events", "maxBytes": 1048576, "maxFiles": 5, "fsync": "always", "compression": "zstd"}
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	for _, id := range []string{"a", "b"} {
+		_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": id})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, pipelines.Shutdown())
+	fileBytes, err := ioutil.ReadFile(dir + "/events.ndjson")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, strings.Count(string(fileBytes), "\n"))
+
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"fsync": "always"`, `"fsync": "sometimes"`, 1)))
+	assert.Error(t, err)
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"compression": "zstd"`, `"compression": "lz4"`, 1)))
+	assert.Error(t, err)
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"outputConnectorRef": "drop"`,
+		`"outputConnectorRef": "webhook"`, 1)))
+	assert.Error(t, err)
+}
+
+func TestPipelinesHttpOutput(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	assert.Nil(t, os.Setenv("TEST_PIPELINES_HTTP_TOKEN", "secret-token"))
+	defer func() {
+		_ = os.Unsetenv("TEST_PIPELINES_HTTP_TOKEN")
+	}()
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [{"name": "webhook", "connectionString": "` + server.URL + `", "externalType": "ExternalHttp"}],
+  "pipelines": [{"name": "alert-pipeline", "processes": [{"name": "alert"}]}],
+  "processDefinitions": [
+    {
+      "tee": {
+        "name": "alert",
+        "outputConnectorRef": "webhook",
+        "httpOutput": {
+          "method": "PUT",
+          "urlTemplate": "` + server.URL + `/alerts/{{.id}}",
+          "headers": {"X-Severity": "{{.severity}}"},
+          "auth": {"bearer": {"tokenEnv": "TEST_PIPELINES_HTTP_TOKEN"}},
+          "timeoutInMs": 1000,
+          "acceptedStatusCodes": [200],
+          "captureResponse": true
+        }
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": "a", "severity": "high"})
+	assert.Nil(t, err)
+	req := <-requests
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "/alerts/a", req.URL.Path)
+	assert.Equal(t, "high", req.Header.Get("X-Severity"))
+	assert.Equal(t, "Bearer secret-token", req.Header.Get("Authorization"))
+	assert.Equal(t, map[string]interface{}{"statusCode": float64(200), "body": "ok"},
+		out[TeeMetadataKey].([]map[string]interface{})[0]["response"])
+
+	for _, invalid := range []string{
+		`"bearer": {}`,
+		`"bearer": {"tokenFile": "/tmp/token", "tokenEnv": "TOKEN"}`,
+		`"hmac": {"secretEnv": "TOKEN", "algorithm": "md5"}`,
+	} {
+		_, err = PipelinesFromJson([]byte(strings.Replace(configJson,
+			`"bearer": {"tokenEnv": "TEST_PIPELINES_HTTP_TOKEN"}`, invalid, 1)))
+		assert.Error(t, err)
+	}
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `{{.severity}}`, `{{.severity`, 1)))
+	assert.Error(t, err)
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"timeoutInMs": 1000`,
+		`"tls": {"caFile": "/does/not/exist"}`, 1)))
+	assert.Error(t, err)
+}
+
+func TestPipelinesMultiTee(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	objectStore, err := storage.NewObjectStoreFromConnectionString("mem:testMultiTee")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [
+    {"name": "archive", "connectionString": "mem:testMultiTee", "externalType": "ExternalObjectStore"},
+    {"name": "webhook", "connectionString": "` + server.URL + `", "externalType": "ExternalHttp"}
+  ],
+  "pipelines": [{"name": "multi-pipeline", "processes": [{"name": "fanout"}]}],
+  "processDefinitions": [
+    {
+      "tee": {
+        "name": "fanout",
+        "outputConnectorRefs": ["archive", "webhook"],
+        "mode": "atLeastOne",
+        "objectOutput": {"keyTemplate": "events/{{uuid}}{{ext}}"}
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": "a"})
+	assert.Nil(t, err)
+	teeOutputs := out[TeeMetadataKey].([]map[string]interface{})
+	assert.Equal(t, "fanout.archive", teeOutputs[0]["destination"])
+	objectKey := teeOutputs[0]["response"].(map[string]interface{})["objectKey"].(string)
+	_, err = objectStore.Get(context.Background(), objectKey)
+	assert.Nil(t, err)
+	assert.Equal(t, "fanout.webhook", teeOutputs[1]["destination"])
+	assert.NotNil(t, teeOutputs[1]["error"])
+	assert.Nil(t, pipelines.Shutdown())
+
+	// With allOrNothing, the object is deleted when the webhook fails
+	pipelines, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"atLeastOne"`, `"allOrNothing"`, 1)))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"id": "b"})
+	assert.Error(t, err)
+	keys, err := objectStore.List(context.Background(), "events/")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{objectKey}, keys)
+
+	for _, invalid := range []string{
+		`"outputConnectorRefs": ["archive", "archive"]`,
+		`"outputConnectorRefs": ["archive", "missing"]`,
+		`"outputConnectorRef": "archive", "outputConnectorRefs": ["archive", "webhook"]`,
+		`"outputConnectorRefs": ["webhook"]`,
+	} {
+		_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"outputConnectorRefs": ["archive", "webhook"]`,
+			invalid, 1)))
+		assert.Error(t, err)
+	}
+	_, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"atLeastOne"`, `"mostly"`, 1)))
+	assert.Error(t, err)
+}
+
+func TestPipelinesPubSub(t *testing.T) {
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [{"name": "events", "connectionString": "mem:testPipelinesPubSub", "externalType": "ExternalPubSub"}],
+  "pipelines": [{"name": "publish-pipeline", "processes": [{"name": "publish"}]}],
+  "processDefinitions": [
+    {
+      "tee": {
+        "name": "publish",
+        "outputConnectorRef": "events",
+        "pubSubOutput": {"keyTemplate": "{{.deviceId}}"}
+      }
+    }
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"deviceId": "sensor-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"messageKey": "sensor-1"},
+		out[TeeMetadataKey].([]map[string]interface{})[0]["response"])
+	assert.Nil(t, pipelines.Shutdown())
+
+	replayer, err := streaming.NewMemReplayer(streaming.DefaultMemPubSub(), "testPipelinesPubSub", 0, 0)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	var payloads []string
+	_ = replayer.Replay(context.Background(), func(ctx context.Context, payload []byte) error {
+		payloads = append(payloads, string(payload))
+		return nil
+	})
+	assert.Equal(t, 1, len(payloads))
+	assert.Contains(t, payloads[0], `"deviceId":"sensor-1"`)
+
+	for _, invalid := range []struct{old, new string}{
+		{`mem:testPipelinesPubSub`, `testPipelinesPubSub`},
+		{`mem:testPipelinesPubSub`, `kafka:topicName=testPipelinesPubSub`},
+		{`{{.deviceId}}`, `{{.deviceId`},
+		{`"externalType": "ExternalPubSub"`, `"externalType": "ExternalKVStore"`},
+	} {
+		_, err = PipelinesFromJson([]byte(strings.Replace(configJson, invalid.old, invalid.new, 1)))
+		assert.Error(t, err, invalid.new)
+	}
+}
+
+func TestPipelinesSources(t *testing.T) {
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [
+    {"name": "offsets", "connectionString": "mem:offsets", "externalType": "ExternalKVStore"},
+    {"name": "checkpoints", "connectionString": "/tmp", "externalType": "ExternalLocalFile"},
+    {"name": "partners", "connectionString": "mem:testPipelinesSources", "externalType": "ExternalObjectStore"}
+  ],
+  "pipelines": [{"name": "pipeline1", "processes": []}, {"name": "pipeline2", "processes": []}],
+  "sources": [
+    {"name": "webhook", "http": {"port": 8090, "path": "/events", "maxConnections": 8}},
+    {"name": "events", "pipelines": ["pipeline2"], "subscriber": {"connectionString": "mem:testPipelinesSources",
+      "deadLetterConnectionString": "mem:testPipelinesSourcesDead", "maxRetries": 2}},
+    {"name": "logs", "fileTail": {"patterns": ["/var/log/app/*.log"], "format": "text",
+      "offsetConnectorRef": "offsets", "multiline": {"startPattern": "^\\d{4}-", "timeoutInMs": 500}}},
+    {"name": "audit", "fileTail": {"patterns": ["/var/log/audit.log"], "offsetConnectorRef": "checkpoints",
+      "pollIntervalInMs": 100}},
+    {"name": "appliances", "syslog": {"address": ":5514"}},
+    {"name": "devices", "jsonLines": {"network": "tcp", "address": ":5170", "maxConnections": 16,
+      "maxMessageSize": 4096}},
+    {"name": "drops", "objectStore": {"objectStoreRef": "partners", "prefix": "inbox/", "claimStoreRef": "offsets",
+      "format": "csv", "csvHeaderMapping": {"Device Name": "device"}, "donePrefix": "done/", "maxRetries": 5}},
+    {"name": "flush", "pipelines": ["pipeline1"], "schedule": {"expression": "*/5 * * * *",
+      "timezone": "America/New_York", "payload": {"action": "flush"}, "leaderStoreRef": "offsets"}}
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	sources := pipelines.Sources()
+	assert.Equal(t, 8, len(sources))
+	assert.Equal(t, "webhook", sources[0].Name())
+	assert.Empty(t, sources[0].Pipelines)
+	assert.Equal(t, "events", sources[1].Name())
+	assert.Equal(t, []string{"pipeline2"}, sources[1].Pipelines)
+
+	for _, invalid := range []struct{old, new string}{
+		{`"name": "events"`, `"name": "webhook"`},
+		{`["pipeline2"]`, `["pipeline3"]`},
+		{`"port": 8090, `, ``},
+		{`mem:testPipelinesSources"`, `testPipelinesSources"`},
+		{`mem:testPipelinesSourcesDead`, `testPipelinesSourcesDead`},
+		{`"format": "text"`, `"format": "xml"`},
+		{`"offsetConnectorRef": "offsets"`, `"offsetConnectorRef": "missing"`},
+		{`"startPattern": "^\\d{4}-"`, `"startPattern": "("`},
+		{`"startPattern": "^\\d{4}-", `, ``},
+		{`"/var/log/app/*.log"`, `"/var/log/[a-"`},
+		{`"address": ":5514"`, `"network": "udp"`},
+		{`"network": "tcp"`, `"network": "unix"`},
+		{`"objectStoreRef": "partners"`, `"objectStoreRef": "missing"`},
+		{`"claimStoreRef": "offsets"`, `"claimStoreRef": "missing"`},
+		{`"format": "csv"`, `"format": "xlsx"`},
+		{`"donePrefix": "done/"`, `"donePrefix": "in"`},
+		{`"expression": "*/5 * * * *"`, `"expression": "*/5 * * *"`},
+		{`"America/New_York"`, `"Mars/Olympus_Mons"`},
+		{`"leaderStoreRef": "offsets"`, `"leaderStoreRef": "missing"`},
+		{`"name": "flush", "pipelines": ["pipeline1"], `, `"name": "flush", `},
+	} {
+		_, err = PipelinesFromJson([]byte(strings.Replace(configJson, invalid.old, invalid.new, 1)))
+		assert.Error(t, err, invalid.new)
+	}
+}
+
+func TestPipelinesAbsence(t *testing.T) {
+	received := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		received <- string(b)
+	}))
+	defer server.Close()
+
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [
+    {"name": "lastSeen", "connectionString": "mem:lastSeen", "externalType": "ExternalKVStore"},
+    {"name": "webhook", "connectionString": "` + server.URL + `", "externalType": "ExternalHttp"}
+  ],
+  "pipelines": [
+    {"name": "heartbeats", "processes": [{"name": "silent"}]},
+    {"name": "alerts", "processes": [{"name": "notify"}]}
+  ],
+  "processDefinitions": [
+    {"tee": {"name": "notify", "outputConnectorRef": "webhook"}},
+    {"absence": {"name": "silent", "keyFields": ["device"], "stateStore": "lastSeen", "thresholdInMs": 100,
+      "checkIntervalInMs": 10, "outputPipeline": "alerts"}}
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"device": "d1"})
+	assert.Nil(t, err)
+	absent := <-received
+	assert.Contains(t, absent, `"status":"absent"`)
+	assert.Contains(t, absent, `"device":"d1"`)
+
+	_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"device": "d1"})
+	assert.Nil(t, err)
+	assert.Contains(t, <-received, `"status":"recovered"`)
+	assert.Nil(t, pipelines.Shutdown())
+
+	pipelines, err = PipelinesFromJson([]byte(strings.Replace(configJson, `"outputPipeline": "alerts"`,
+		`"outputTee": "notify"`, 1)))
+	assert.Nil(t, err)
+	assert.Nil(t, pipelines.Shutdown())
+
+	for _, invalid := range []struct{old, new string}{
+		{`"stateStore": "lastSeen"`, `"stateStore": "missing"`},
+		{`"keyFields": ["device"], `, ``},
+		{`"thresholdInMs": 100,`, ``},
+		{`"outputPipeline": "alerts"`, `"outputPipeline": "missing"`},
+		{`"outputPipeline": "alerts"`, `"outputTee": "missing"`},
+		{`, "outputPipeline": "alerts"`, ``},
+		{`"outputPipeline": "alerts"`, `"outputPipeline": "alerts", "outputTee": "notify"`},
+	} {
+		_, err = PipelinesFromJson([]byte(strings.Replace(configJson, invalid.old, invalid.new, 1)))
+		assert.Error(t, err, invalid.new)
+	}
+}
+
+func TestPipelinesChangeDetector(t *testing.T) {
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [{"name": "lastValues", "connectionString": "mem:lastValues", "externalType": "ExternalKVStore"}],
+  "pipelines": [{"name": "readings", "processes": [{"name": "changes"}]}],
+  "processDefinitions": [
+    {"changeDetector": {"name": "changes", "keyFields": ["sensor"], "stateStore": "lastValues",
+      "fields": [{"path": "temperature", "absoluteDeadband": 0.5}, {"path": "state"}]}}
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	diffKey := common.InternalKeyFromPrefix(common.ChangeDiffPrefix, "changes")
+
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{"sensor": "s1", "temperature": 20})
+	assert.Nil(t, err)
+	assert.Contains(t, out, diffKey)
+	_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{"sensor": "s1", "temperature": 20.2})
+	assert.True(t, errors.Is(err, &util.ContinuationNotSatisfied{}))
+	assert.Nil(t, pipelines.Shutdown())
+
+	for _, invalid := range []struct{old, new string}{
+		{`"stateStore": "lastValues"`, `"stateStore": "missing"`},
+		{`"absoluteDeadband": 0.5`, `"absoluteDeadband": -0.5`},
+		{`{"path": "temperature", "absoluteDeadband": 0.5}, {"path": "state"}`, ``},
+	} {
+		_, err = PipelinesFromJson([]byte(strings.Replace(configJson, invalid.old, invalid.new, 1)))
+		assert.Error(t, err, invalid.new)
+	}
+}
+
+func TestPipelinesAnomalyDetector(t *testing.T) {
+	configJson := `{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [{"name": "baselines", "connectionString": "mem:baselines", "externalType": "ExternalKVStore"}],
+  "pipelines": [{"name": "requests", "processes": [{"name": "anomalies"}]}],
+  "processDefinitions": [
+    {"anomalyDetector": {"name": "anomalies", "key": "latency", "groupByKeys": ["host"], "stateStore": "baselines",
+      "decayFactor": 0.2, "warmUp": 5, "threshold": 4, "scoring": "ScoreMAD", "seasonPeriodInMs": 86400000,
+      "seasonBuckets": 24, "timeKey": "time", "onlyAnomalies": true}}
+  ]
+}`
+
+	pipelines, err := PipelinesFromJson([]byte(configJson))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	for i := 0; i < 5; i++ {
+		_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{
+			"host": "a", "latency": 100 + i % 2, "time": "2021-03-01T13:30:00Z",
+		})
+		assert.True(t, errors.Is(err, &util.ContinuationNotSatisfied{}))
+	}
+	out, err := pipelines.Underlying()[0].RunSync(map[string]interface{}{
+		"host": "a", "latency": 500, "time": "2021-03-01T13:45:00Z",
+	})
+	assert.Nil(t, err)
+	score := out[common.InternalKeyFromPrefix(common.AnomalyPrefix, "anomalies")].(map[string]interface{})
+	assert.Equal(t, true, score["anomalous"])
+	// Another hour of the day has its own baseline
+	_, err = pipelines.Underlying()[0].RunSync(map[string]interface{}{
+		"host": "a", "latency": 500, "time": "2021-03-01T14:30:00Z",
+	})
+	assert.True(t, errors.Is(err, &util.ContinuationNotSatisfied{}))
+	assert.Nil(t, pipelines.Shutdown())
+
+	for _, invalid := range []struct{old, new string}{
+		{`"stateStore": "baselines"`, `"stateStore": "missing"`},
+		{`"key": "latency", `, ``},
+		{`"decayFactor": 0.2`, `"decayFactor": 2`},
+		{`"threshold": 4`, `"threshold": -4`},
+		{`"seasonBuckets": 24`, `"seasonBuckets": -1`},
+		{`"seasonPeriodInMs": 86400000,`, ``},
+	} {
+		_, err = PipelinesFromJson([]byte(strings.Replace(configJson, invalid.old, invalid.new, 1)))
+		assert.Error(t, err, invalid.new)
+	}
+}
@@ -2,67 +2,399 @@ package process
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/kmgreen2/agglo/internal/common"
 	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/observability"
 	"github.com/kmgreen2/agglo/pkg/util"
 	"reflect"
+	"sync"
 	"time"
 )
 
 var SpawnMetadataKey string = string(common.SpawnMetadataKey)
 
+// DefaultSpawnerMaxOutputBytes is the default limit on the stderr recorded for a command
+const DefaultSpawnerMaxOutputBytes = 4096
+
+type SpawnerOption func(spawner *Spawner)
+
+// WithSpawnerTimeout sets the maximum time a job may run.  Commands are killed when they time out.
+func WithSpawnerTimeout(timeout time.Duration) SpawnerOption {
+	return func(spawner *Spawner) {
+		spawner.timeout = timeout
+	}
+}
+
+// WithSpawnerMaxConcurrency limits the number of jobs that run at once.  Additional jobs wait for a
+// running job to complete.
+func WithSpawnerMaxConcurrency(maxConcurrency int) SpawnerOption {
+	return func(spawner *Spawner) {
+		if maxConcurrency > 0 {
+			spawner.slots = make(chan struct{}, maxConcurrency)
+		}
+	}
+}
+
+// WithSpawnerMaxOutputBytes sets the limit on the stderr recorded for a command.  Stdout is recorded as the
+// decoded output.
+func WithSpawnerMaxOutputBytes(maxOutputBytes int) SpawnerOption {
+	return func(spawner *Spawner) {
+		spawner.maxOutputBytes = maxOutputBytes
+	}
+}
+
 type Spawner struct {
 	name string
 	job       core.Job
 	condition *core.Condition
 	delay     time.Duration
 	doSync    bool
+	timeout time.Duration
+	maxOutputBytes int
+	slots chan struct{}
+	emitter *observability.Emitter
+
+	// Durable spawners persist jobs in queue until they have run
+	queue *util.DurableQueue
+	recovered []*util.QueueItem
+	notify chan struct{}
+	done chan struct{}
+	stopped chan struct{}
+	running sync.WaitGroup
+	closeOnce sync.Once
 }
 
-func NewSpawner(name string, job core.Job, condition *core.Condition, delay time.Duration, doSync bool) *Spawner {
-	return &Spawner{
+func NewSpawner(name string, job core.Job, condition *core.Condition, delay time.Duration, doSync bool,
+	options ...SpawnerOption) *Spawner {
+	spawner := &Spawner{
 		name: name,
 		job: job,
 		condition: condition,
 		delay: delay,
 		doSync: doSync,
+		maxOutputBytes: DefaultSpawnerMaxOutputBytes,
+		emitter: observability.NewEmitter("agglo/spawner"),
+	}
+	for _, option := range options {
+		option(spawner)
+	}
+	// Async and durable jobs have no caller to return their status to, so every job is counted and timed
+	spawner.emitter.AddMetric(name + ".latency", observability.Int64Recorder)
+	spawner.emitter.AddMetric(name + ".success", observability.Int64Counter)
+	spawner.emitter.AddMetric(name + ".failure", observability.Int64Counter)
+	return spawner
+}
+
+// spawnTask is a job persisted by a durable spawner
+type spawnTask struct {
+	RunAt int64 `json:"runAt"`
+	In map[string]interface{} `json:"in"`
+}
+
+// NewDurableSpawner will create a spawner that runs jobs asynchronously and persists them in a durable
+// queue at queuePath until they have run, so delayed and queued jobs survive a restart.  Jobs that were
+// queued or running when the previous instance stopped are run when the spawner is created.  Jobs are
+// run at least once.  Close must be called to stop the spawner.
+func NewDurableSpawner(name string, job core.Job, condition *core.Condition, delay time.Duration,
+	queuePath string, options ...SpawnerOption) (*Spawner, error) {
+	spawner := NewSpawner(name, job, condition, delay, false, options...)
+	spawner.notify = make(chan struct{}, 1)
+	spawner.done = make(chan struct{})
+	spawner.stopped = make(chan struct{})
+
+	// Jobs that were running stay in the inflight queue until they run again and are acked
+	recoverFunc := func(itemBytes []byte) error {
+		item, err := util.QueueItemFromBytes(itemBytes)
+		if err != nil {
+			return err
+		}
+		spawner.recovered = append(spawner.recovered, item)
+		return util.NewConflictError("recovered jobs are acked after they run")
+	}
+
+	// The queue file is locked while open, so forcing the state transition is only needed to recover
+	// a queue that was not closed
+	var err error
+	spawner.queue, err = util.OpenDurableQueue(queuePath, recoverFunc, true)
+	if err != nil {
+		return nil, err
 	}
+
+	go spawner.dispatch()
+	return spawner, nil
 }
 
-func (s Spawner) Name() string {
+func (s *Spawner) Name() string {
 	return s.name
 }
 
-func (s Spawner) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+func (s *Spawner) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
 	out := util.CopyableMap(in).DeepCopy()
 	shouldRun, err := s.condition.Evaluate(out)
 	if err != nil {
 		return out, PipelineProcessError(s, err, "evaluating condition")
 	}
-	if shouldRun {
-		f := s.job.Run(s.delay, s.doSync, out)
-		if s.doSync {
-			result := f.Get()
-			if result.Error() != nil {
-				return in, PipelineProcessError(s, result.Error(), "running job")
+	if !shouldRun {
+		return out, nil
+	}
+
+	if s.doSync {
+		if err = sleepWithContext(ctx, s.delay); err != nil {
+			return in, PipelineProcessError(s, err, "delaying job")
+		}
+		release, err := s.acquire(ctx)
+		if err != nil {
+			return in, PipelineProcessError(s, err, "waiting to run job")
+		}
+		status, jobErr := s.runJob(ctx, out)
+		release()
+		if status == nil {
+			return in, PipelineProcessError(s, jobErr, "running job")
+		}
+		if err = s.addStatus(out, status); err != nil {
+			return nil, err
+		}
+		if jobErr != nil {
+			// The status of a failed command is recorded, so it is available to fail-able pipelines
+			return out, PipelineProcessError(s, jobErr, "running job")
+		}
+		return out, nil
+	}
+
+	jobIn := util.CopyableMap(out).DeepCopy()
+	if s.queue != nil {
+		taskBytes, err := json.Marshal(&spawnTask{
+			RunAt: time.Now().Add(s.delay).UnixNano(),
+			In: jobIn,
+		})
+		if err != nil {
+			return in, PipelineProcessError(s, err, "encoding job")
+		}
+		if err = s.queue.Enqueue(taskBytes); err != nil {
+			return in, PipelineProcessError(s, err, "queueing job")
+		}
+		select {
+		case s.notify <- struct{}{}:
+		default:
+		}
+		return out, nil
+	}
+
+	go func() {
+		_ = sleepWithContext(context.Background(), s.delay)
+		release, _ := s.acquire(context.Background())
+		defer release()
+		// There is no caller to return the status to, so runJob only emits it as metrics
+		_, _ = s.runJob(context.Background(), jobIn)
+	}()
+	return out, nil
+}
+
+// acquire will wait until the number of running jobs is below the concurrency limit.  The returned
+// function must be called when the job completes.
+func (s *Spawner) acquire(ctx context.Context) (func(), error) {
+	if s.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.slots <- struct{}{}:
+		return func() {
+			<-s.slots
+		}, nil
+	case <-ctx.Done():
+		return nil, util.NewCancelledError()
+	}
+}
+
+// addStatus will append the status of a job to the spawn metadata in out
+func (s *Spawner) addStatus(out map[string]interface{}, status map[string]interface{}) error {
+	if _, ok := out[SpawnMetadataKey]; !ok {
+		out[SpawnMetadataKey] = make([]map[string]interface{}, 0)
+	}
+
+	switch outVal := out[SpawnMetadataKey].(type) {
+	case []map[string]interface{}:
+		spawnResult := map[string]interface{} {
+			s.name: status,
+		}
+		out[SpawnMetadataKey] = append(outVal, spawnResult)
+	default:
+		msg := fmt.Sprintf("detected corrupted %s in map when spawning.  expected []map[string]string, got %v",
+			SpawnMetadataKey, reflect.TypeOf(outVal))
+		return util.NewInternalError(msg)
+	}
+	return nil
+}
+
+// runJob will run the job, subject to the timeout, and return its status.  The status is also returned
+// with the error of a command that was started, so the exit code and stderr of failed commands are
+// recorded.
+func (s *Spawner) runJob(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+	var result *util.FutureResult
+	begin := time.Now()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+		result = s.job.Run(ctx, 0, false, in).GetWithTimeout(s.timeout)
+	} else {
+		result = s.job.Run(ctx, 0, false, in).Get()
+	}
+	duration := time.Now().Sub(begin)
+	s.emitter.RecordInt64(s.name + ".latency", duration.Milliseconds())
+
+	err := result.Error()
+	if err != nil {
+		s.emitter.AddInt64(s.name + ".failure", 1)
+		// Commands time out on their own, but the wait also times out for jobs that ignore the context
+		if errors.Is(err, context.DeadlineExceeded) {
+			msg := fmt.Sprintf("job timed out after %d ms", duration.Milliseconds())
+			return nil, util.NewTimedOutError(msg)
+		}
+		var cmdErr *core.CmdError
+		if errors.As(err, &cmdErr) {
+			return s.cmdStatus(cmdErr.Result, duration), err
+		}
+		return nil, err
+	}
+	s.emitter.AddInt64(s.name + ".success", 1)
+
+	switch val := result.Value().(type) {
+	case *core.CmdResult:
+		return s.cmdStatus(val, duration), nil
+	default:
+		return map[string]interface{}{
+			"durationMs": float64(duration.Milliseconds()),
+			"output": val,
+		}, nil
+	}
+}
+
+func (s *Spawner) cmdStatus(result *core.CmdResult, duration time.Duration) map[string]interface{} {
+	return map[string]interface{}{
+		"durationMs": float64(duration.Milliseconds()),
+		"output": result.Output,
+		"exitCode": float64(result.Status.ExitCode),
+		"stderr": truncateOutput(result.Status.Stderr, s.maxOutputBytes),
+	}
+}
+
+func truncateOutput(output []byte, maxBytes int) string {
+	if maxBytes >= 0 && len(output) > maxBytes {
+		return string(output[:maxBytes])
+	}
+	return string(output)
+}
+
+func sleepWithContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return util.NewCancelledError()
+	}
+}
+
+// dispatch will run recovered jobs and then run queued jobs as they become due, until the spawner is
+// closed
+func (s *Spawner) dispatch() {
+	defer close(s.stopped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-s.done
+		cancel()
+	}()
+
+	next := func() (*util.QueueItem, error) {
+		if len(s.recovered) > 0 {
+			item := s.recovered[0]
+			s.recovered = s.recovered[1:]
+			return item, nil
+		}
+		return s.queue.Dequeue()
+	}
+
+	for {
+		item, err := next()
+		if err != nil {
+			wait := time.Second
+			if errors.Is(err, &util.EmptyQueue{}) {
+				wait = -1
 			}
-			if _, ok := out[SpawnMetadataKey]; !ok {
-				out[SpawnMetadataKey] = make([]map[string]interface{}, 0)
+			if !s.waitForWork(wait) {
+				return
 			}
+			continue
+		}
 
-			switch outVal := out[SpawnMetadataKey].(type) {
-			case []map[string]interface{}:
-				spawnResult := map[string]interface{} {
-					s.name: result.Value(),
-				}
-				out[SpawnMetadataKey] = append(outVal, spawnResult)
-			default:
-				msg := fmt.Sprintf("detected corrupted %s in map when spawning.  expected []map[string]string, got %v",
-					SpawnMetadataKey, reflect.TypeOf(outVal))
-				return nil, util.NewInternalError(msg)
-			}
+		var task spawnTask
+		if err = json.Unmarshal(item.Data, &task); err != nil {
+			// A corrupt task will never run, so drop it
+			_ = s.queue.Ack(item)
+			continue
+		}
+
+		// Unfinished jobs stay in the inflight queue, so they run again on restart
+		if sleepWithContext(ctx, time.Unix(0, task.RunAt).Sub(time.Now())) != nil {
+			return
+		}
+		release, err := s.acquire(ctx)
+		if err != nil {
+			return
 		}
+
+		// Failed jobs are not retried, so every job that runs is acked
+		s.running.Add(1)
+		go func(item *util.QueueItem, in map[string]interface{}) {
+			defer s.running.Done()
+			defer release()
+			_, _ = s.runJob(context.Background(), in)
+			_ = s.queue.Ack(item)
+		}(item, task.In)
 	}
-	return out, nil
+}
+
+// waitForWork will wait until a job is queued, the wait elapses or the spawner is closed.  A negative
+// wait only ends when a job is queued or the spawner is closed.  Returns false if the spawner is closed.
+func (s *Spawner) waitForWork(wait time.Duration) bool {
+	var timeout <-chan time.Time
+	if wait >= 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-s.notify:
+		return true
+	case <-timeout:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+// Close will stop a durable spawner, wait for running jobs and close its queue.  Jobs that have not
+// started are run when the queue is reopened.
+func (s *Spawner) Close() error {
+	if s.queue == nil {
+		return nil
+	}
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		<-s.stopped
+		s.running.Wait()
+		err = s.queue.Close()
+	})
+	return err
 }
@@ -2,11 +2,15 @@ package process_test
 
 import (
 	"context"
+	"errors"
 	"github.com/kmgreen2/agglo/internal/common"
 	"github.com/kmgreen2/agglo/internal/core"
 	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/pkg/util"
 	"github.com/kmgreen2/agglo/test"
 	"github.com/stretchr/testify/assert"
+	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -90,4 +94,171 @@ func TestSpawnerHappyPathDelaySync(t *testing.T) {
 	assert.Nil(t, err)
 	delete(out, string(common.SpawnMetadataKey))
 	assert.Equal(t, in, out)
-}
\ No newline at end of file
+}
+
+func TestSpawnerCmdStatus(t *testing.T) {
+	job := core.NewCmdJob("sh", "-c", "cat; echo 0123456789 >&2")
+	spawner := process.NewSpawner("foo", job, core.TrueCondition, -1, true,
+		process.WithSpawnerMaxOutputBytes(4))
+
+	out, err := spawner.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+
+	spawnOutput := out[string(common.SpawnMetadataKey)].([]map[string]interface{})
+	status := spawnOutput[0]["foo"].(map[string]interface{})
+	assert.Equal(t, float64(0), status["exitCode"])
+	assert.Equal(t, "0123", status["stderr"])
+	assert.NotContains(t, status, "stdout")
+	assert.Equal(t, map[string]interface{}{"a": "b"}, status["output"])
+	assert.Contains(t, status, "durationMs")
+
+	job = core.NewCmdJob("sh", "-c", "echo failed >&2; exit 3")
+	spawner = process.NewSpawner("foo", job, core.TrueCondition, -1, true)
+	out, err = spawner.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exit status 3: failed")
+
+	// The status of a failed command is recorded
+	spawnOutput = out[string(common.SpawnMetadataKey)].([]map[string]interface{})
+	status = spawnOutput[0]["foo"].(map[string]interface{})
+	assert.Equal(t, float64(3), status["exitCode"])
+	assert.Equal(t, "failed\n", status["stderr"])
+	assert.Contains(t, status, "durationMs")
+}
+
+func TestSpawnerTimeout(t *testing.T) {
+	job := core.NewCmdJob("sleep", "10")
+	spawner := process.NewSpawner("foo", job, core.TrueCondition, -1, true,
+		process.WithSpawnerTimeout(100*time.Millisecond))
+
+	start := time.Now()
+	_, err := spawner.Process(context.Background(), map[string]interface{}{})
+	assert.True(t, errors.Is(err, &util.TimedOutError{}))
+	assert.True(t, time.Now().Sub(start) < 5*time.Second)
+
+	spawner = process.NewSpawner("foo", core.NewLocalJob(test.NewSleepRunnable(10)), core.TrueCondition, -1, true,
+		process.WithSpawnerTimeout(100*time.Millisecond))
+	_, err = spawner.Process(context.Background(), map[string]interface{}{})
+	assert.True(t, errors.Is(err, &util.TimedOutError{}))
+}
+
+func TestSpawnerMaxConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	job := core.NewCmdJob("sleep", "0.2")
+	countingJob := &countingJob{job: job, running: &running, maxRunning: &maxRunning}
+	spawner := process.NewSpawner("foo", countingJob, core.TrueCondition, -1, false,
+		process.WithSpawnerMaxConcurrency(2))
+
+	for i := 0; i < 6; i++ {
+		_, err := spawner.Process(context.Background(), map[string]interface{}{})
+		assert.Nil(t, err)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&running))
+}
+
+func TestDurableSpawnerRecovery(t *testing.T) {
+	queuePath := "/tmp/testDurableSpawner"
+	_ = os.Remove(queuePath)
+	defer func() {
+		_ = os.Remove(queuePath)
+	}()
+
+	// Queue a delayed job and stop the spawner before it runs
+	ran := make(chan map[string]interface{}, 4)
+	job := core.NewLocalJob(test.NewFuncRunnable(func(arg map[string]interface{}) {
+		ran <- arg
+	}))
+	spawner, err := process.NewDurableSpawner("foo", job, core.TrueCondition, 500*time.Millisecond, queuePath)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = spawner.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+	time.Sleep(50 * time.Millisecond)
+	assert.Nil(t, spawner.Close())
+	time.Sleep(500 * time.Millisecond)
+	assert.Len(t, ran, 0)
+
+	// The job is recovered and run when the queue is reopened
+	spawner, err = process.NewDurableSpawner("foo", job, core.TrueCondition, 0, queuePath)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = spawner.Close()
+	}()
+
+	select {
+	case arg := <-ran:
+		assert.Equal(t, "b", arg["a"])
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "recovered job did not run")
+	}
+
+	_, err = spawner.Process(context.Background(), map[string]interface{}{"c": "d"})
+	assert.Nil(t, err)
+	select {
+	case arg := <-ran:
+		assert.Equal(t, "d", arg["c"])
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "queued job did not run")
+	}
+}
+
+func TestDurableSpawnerCloseWaitsForJobs(t *testing.T) {
+	queuePath := "/tmp/testDurableSpawnerClose"
+	_ = os.Remove(queuePath)
+	defer func() {
+		_ = os.Remove(queuePath)
+	}()
+
+	started := make(chan struct{}, 1)
+	var finished int32
+	job := core.NewLocalJob(test.NewFuncRunnable(func(arg map[string]interface{}) {
+		started <- struct{}{}
+		time.Sleep(300 * time.Millisecond)
+		atomic.StoreInt32(&finished, 1)
+	}))
+	spawner, err := process.NewDurableSpawner("foo", job, core.TrueCondition, 0, queuePath)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = spawner.Process(context.Background(), map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+	<-started
+
+	// The running job completes and is acked before the queue is closed
+	assert.Nil(t, spawner.Close())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&finished))
+
+	spawner, err = process.NewDurableSpawner("foo", job, core.TrueCondition, 0, queuePath)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Nil(t, spawner.Close())
+	assert.Len(t, started, 0)
+}
+
+type countingJob struct {
+	job core.Job
+	running *int32
+	maxRunning *int32
+}
+
+func (j *countingJob) Run(ctx context.Context, delay time.Duration, sync bool,
+	inData ...interface{}) util.Future {
+	n := atomic.AddInt32(j.running, 1)
+	for {
+		max := atomic.LoadInt32(j.maxRunning)
+		if n <= max || atomic.CompareAndSwapInt32(j.maxRunning, max, n) {
+			break
+		}
+	}
+	f := j.job.Run(ctx, delay, sync, inData...)
+	f.Get()
+	atomic.AddInt32(j.running, -1)
+	return f
+}
@@ -13,9 +13,7 @@ import (
 	"github.com/kmgreen2/agglo/internal/core"
 	"github.com/kmgreen2/agglo/pkg/kvs"
 	"github.com/kmgreen2/agglo/pkg/streaming"
-	"github.com/pkg/errors"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"reflect"
 	"strings"
@@ -34,30 +32,23 @@ type Tee struct {
 	outputType string
 	connectionString string
 	additionalBody map[string]interface{}
+	delivery *teeDelivery
+	closeFn func() error
+	// compensateFn removes an output, when a multi-destination tee is all-or-nothing
+	compensateFn func(ctx context.Context, key string, response map[string]interface{}) error
 }
 
 // NewKVTee will create a Tee processor that stores maps in the provided KVStore
 // Note: the returned map will contain the UUID of the KV entry with key "_uuid_key"
 func NewKVTee(name string, kvStore kvs.KVStore, condition *core.Condition, transformer *Transformer,
-	additionalBody map[string]interface{}) *Tee {
+	additionalBody map[string]interface{}, options ...TeeOption) *Tee {
+	settings := newTeeSettings(options...)
 	outputFunc := func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
-		var err error
-		payload := in
-		byteBuffer := bytes.NewBuffer([]byte{})
-		encoder := json.NewEncoder(byteBuffer)
-
-		if len(additionalBody) > 0 {
-			payload, err = util.MergeMaps(in, additionalBody)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		err = encoder.Encode(payload)
+		payload, err := serializeDocument(settings.serializer, in, additionalBody)
 		if err != nil {
 			return nil, err
 		}
-		return nil, kvStore.Put(ctx, key, byteBuffer.Bytes())
+		return nil, kvStore.Put(ctx, key, payload)
 	}
 
 	if transformer == nil {
@@ -75,32 +66,61 @@ func NewKVTee(name string, kvStore kvs.KVStore, condition *core.Condition, trans
 		"kvstore",
 		kvStore.ConnectionString(),
 		additionalBody,
+		newTeeDelivery(name, outputFunc, settings.policy),
+		nil,
+		func(ctx context.Context, key string, response map[string]interface{}) error {
+			return kvStore.Delete(ctx, key)
+		},
 	}
 }
 
-// NewLocalfileTee will create a Tee processor that writes maps to a local file system
+// NewLocalfileTee will create a Tee processor that writes maps to a local file system, either one file
+// per map or, WithRollingFile, appended to a rolling file
 func NewLocalFileTee(name string ,path string, condition *core.Condition, transformer *Transformer,
-	additionalBody map[string]interface{}) (*Tee, error) {
+	additionalBody map[string]interface{}, options ...TeeOption) (*Tee, error) {
+	settings := newTeeSettings(options...)
 	if d, err := os.Stat(path); err != nil || !d.IsDir() {
 		msg := fmt.Sprintf("'%s is not a valid path", path)
 		return nil, util.NewInvalidError(msg)
 	}
 	outputFunc := func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
-		var err error
-		payload := in
-		byteBuffer := bytes.NewBuffer([]byte{})
-		encoder := json.NewEncoder(byteBuffer)
-		if len(additionalBody) > 0 {
-			payload, err = util.MergeMaps(in, additionalBody)
-			if err != nil {
-				return nil, err
+		payload, err := serializeDocument(settings.serializer, in, additionalBody)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ioutil.WriteFile(fmt.Sprintf("%s/%s.%s", path, key, settings.serializer.FileExtension()), payload,
+			0644)
+	}
+	var closeFn func() error
+	if settings.rolling != nil {
+		rollingOptions := *settings.rolling
+		if len(rollingOptions.Name) == 0 {
+			rollingOptions.Name = name
+		}
+		if len(rollingOptions.Extension) == 0 {
+			rollingOptions.Extension = settings.serializer.FileExtension()
+			if rollingOptions.Extension == "json" {
+				rollingOptions.Extension = "ndjson"
 			}
 		}
-		err = encoder.Encode(payload)
+		header, err := serializerHeader(settings.serializer)
 		if err != nil {
 			return nil, err
 		}
-		return nil, ioutil.WriteFile(fmt.Sprintf("%s/%s.json", path, key), byteBuffer.Bytes(), 0644)
+		rollingOptions.Header = header
+		rollingFile, err := NewRollingFile(path, rollingOptions)
+		if err != nil {
+			return nil, err
+		}
+		outputFunc = func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
+			payload, err := serializePayload(settings.serializer, in, additionalBody)
+			if err != nil {
+				return nil, err
+			}
+			_, err = rollingFile.Write(payload)
+			return nil, err
+		}
+		closeFn = rollingFile.Close
 	}
 
 	if transformer == nil {
@@ -118,30 +138,18 @@ func NewLocalFileTee(name string ,path string, condition *core.Condition, transf
 		"localfile",
 		path,
 		additionalBody,
+		newTeeDelivery(name, outputFunc, settings.policy),
+		closeFn,
+		nil,
 	}, nil
 }
 
 // NewPubSubTee will create a Tee processor that publishes maps using the provided
-// publisher.
+// publisher.  Message keys are set with WithPubSubKeyTemplate.
 func NewPubSubTee(name string, publisher streaming.Publisher, condition *core.Condition, transformer *Transformer,
-	additionalBody map[string]interface{}) *Tee {
-	outputFunc := func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
-		var err error
-		payload := in
-		byteBuffer := bytes.NewBuffer([]byte{})
-		encoder := json.NewEncoder(byteBuffer)
-		if len(additionalBody) > 0 {
-			payload, err = util.MergeMaps(in, additionalBody)
-			if err != nil {
-				return nil, err
-			}
-		}
-		err = encoder.Encode(payload)
-		if err != nil {
-			return nil, err
-		}
-		return nil, publisher.Publish(ctx, byteBuffer.Bytes())
-	}
+	additionalBody map[string]interface{}, options ...TeeOption) *Tee {
+	settings := newTeeSettings(options...)
+	outputFunc := newPubSubOutputFunc(publisher, settings, additionalBody)
 
 	if transformer == nil {
 		transformation := core.NewTransformation(
@@ -158,64 +166,19 @@ func NewPubSubTee(name string, publisher streaming.Publisher, condition *core.Co
 		"pubsub",
 		publisher.ConnectionString(),
 		additionalBody,
+		newTeeDelivery(name, outputFunc, settings.policy),
+		nil,
+		nil,
 	}
 }
 
 // NewHttpTee will create a tee processor that posts JSON-encoded
-// maps to a specified endpoint
+// maps to a specified endpoint.  The request and the handling of the response are configured with
+// the WithHttp options.
 func NewHttpTee(name string, client common.HTTPClient, url string, condition *core.Condition, transformer *Transformer,
-	additionalBody map[string]interface{}) *Tee {
-	outputFunc := func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
-		var err error
-		payload := in
-		byteBuffer := bytes.NewBuffer([]byte{})
-		encoder := json.NewEncoder(byteBuffer)
-		if len(additionalBody) > 0 {
-			payload, err = util.MergeMaps(in, additionalBody)
-			if err != nil {
-				return nil, err
-			}
-		}
-		err = encoder.Encode(payload)
-		if err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, byteBuffer)
-		if err != nil {
-			return nil, err
-		}
-		headers := http.Header{}
-		headers.Set("Content-Type", "application/json")
-		req.Header = headers
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		if resp.StatusCode >= 300 {
-			msg := fmt.Sprintf("error status %d posting to url '%s'", resp.StatusCode, url)
-			return nil, util.NewInternalError(msg)
-		}
-
-		if resp.Body != nil {
-			bodyBytes, err := ioutil.ReadAll(resp.Body)
-			if err != nil {
-				return nil, errors.Wrap(err, "NewHttpTee: error reading response body")
-			}
-
-			if len(bodyBytes) > 0 {
-				respMap, err := util.JsonToMap(bodyBytes)
-				if err != nil {
-					return nil, errors.Wrap(err, "NewHttpTee: error converting response body to JSON")
-				}
-				return respMap, nil
-			} else {
-				return nil, nil
-			}
-		} else {
-			return nil, nil
-		}
-	}
+	additionalBody map[string]interface{}, options ...TeeOption) *Tee {
+	settings := newTeeSettings(options...)
+	outputFunc := newHttpOutputFunc(client, url, settings, additionalBody)
 
 	if transformer == nil {
 		transformation := core.NewTransformation(
@@ -232,11 +195,15 @@ func NewHttpTee(name string, client common.HTTPClient, url string, condition *co
 		"web",
 		url,
 		additionalBody,
+		newTeeDelivery(name, outputFunc, settings.policy),
+		nil,
+		nil,
 	}
 }
 
 func NewSearchIndexTee(name string, searchIndex search.Index, condition *core.Condition, transformer *Transformer,
-	additionalBody map[string]interface{}) *Tee {
+	additionalBody map[string]interface{}, options ...TeeOption) *Tee {
+	settings := newTeeSettings(options...)
 
 	outputFunc := func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
 		var err error
@@ -322,31 +289,19 @@ func NewSearchIndexTee(name string, searchIndex search.Index, condition *core.Co
 		"searchIndex",
 		searchIndex.ConnectionString(),
 		additionalBody,
+		newTeeDelivery(name, outputFunc, settings.policy),
+		nil,
+		nil,
 	}
 }
 
+// NewObjectStoreTee will create a Tee processor that writes maps to the provided object store.  By
+// default, each map is written to an object keyed by the Tee's generated key.  Key templates, compression
+// and batching are set with WithObjectKeyTemplate, WithObjectCompression and WithObjectBatching.
 func NewObjectStoreTee(name string, objectStore storage.ObjectStore, condition *core.Condition,
-	transformer *Transformer, additionalBody map[string]interface{}) *Tee {
-
-	outputFunc := func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error) {
-		var err error
-		payload := in
-		byteBuffer := bytes.NewBuffer([]byte{})
-		encoder := json.NewEncoder(byteBuffer)
-
-		if len(additionalBody) > 0 {
-			payload, err = util.MergeMaps(in, additionalBody)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		err = encoder.Encode(payload)
-		if err != nil {
-			return nil, err
-		}
-		return nil, objectStore.Put(ctx, key, byteBuffer)
-	}
+	transformer *Transformer, additionalBody map[string]interface{}, options ...TeeOption) *Tee {
+	settings := newTeeSettings(options...)
+	outputFunc, closeFn, compensateFn := newObjectOutputFunc(name, objectStore, settings, additionalBody)
 
 	if transformer == nil {
 		transformation := core.NewTransformation(
@@ -363,6 +318,9 @@ func NewObjectStoreTee(name string, objectStore storage.ObjectStore, condition *
 		"object",
 		objectStore.ConnectionString(),
 		additionalBody,
+		newTeeDelivery(name, outputFunc, settings.policy),
+		closeFn,
+		compensateFn,
 	}
 }
 
@@ -370,6 +328,29 @@ func (t Tee) Name() string {
 	return t.name
 }
 
+// DeliveryStats returns the delivery metrics of a Tee with a delivery policy
+func (t Tee) DeliveryStats() TeeDeliveryStats {
+	if t.delivery == nil {
+		return TeeDeliveryStats{}
+	}
+	return t.delivery.stats()
+}
+
+// Close will deliver any buffered outputs (waiting up to DefaultTeeCloseTimeout), close the buffer and
+// write any batched outputs
+func (t Tee) Close() error {
+	var err error
+	if t.delivery != nil {
+		err = t.delivery.Close()
+	}
+	if t.closeFn != nil {
+		if closeErr := t.closeFn(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
 // Process processes an input map by sending it to the appropriate system and
 // returns a copy of the provided map annotated with information about the backing system
 func (t Tee) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
@@ -394,33 +375,58 @@ func (t Tee) Process(ctx context.Context, in map[string]interface{}) (map[string
 		return nil, PipelineProcessError(t, err, "transforming fields")
 	}
 
-	respMap, err := t.outputFunc(ctx, uuid.String(), teeOut)
+	respMap, deliveryStatus, err := t.deliver(ctx, uuid.String(), teeOut)
 	if err != nil {
 		return nil, PipelineProcessError(t, err, "running output function")
 	}
 
+	if err = appendTeeOutputs(out, t.teeOutput(uuid.String(), respMap, deliveryStatus)); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+
+}
+
+// deliver will write the output inline or, with a delivery policy, according to the policy
+func (t Tee) deliver(ctx context.Context, key string, teeOut map[string]interface{}) (map[string]interface{},
+	string, error) {
+	if t.delivery == nil {
+		respMap, err := t.outputFunc(ctx, key, teeOut)
+		return respMap, "", err
+	}
+	return t.delivery.output(ctx, key, teeOut)
+}
+
+// teeOutput is the entry for an output in TeeMetadataKey
+func (t Tee) teeOutput(key string, respMap map[string]interface{}, deliveryStatus string) map[string]interface{} {
+	teeOutputMap := map[string]interface{}{
+		"uuid": key,
+		"outputType": t.outputType,
+		"connectionString": t.connectionString,
+	}
+	if respMap != nil && len(respMap) > 0 {
+		teeOutputMap["response"] = respMap
+	}
+	if len(deliveryStatus) > 0 {
+		teeOutputMap["delivery"] = deliveryStatus
+	}
+	return teeOutputMap
+}
 
+// appendTeeOutputs will append the entries to TeeMetadataKey
+func appendTeeOutputs(out map[string]interface{}, teeOutputs ...map[string]interface{}) error {
 	if _, ok := out[TeeMetadataKey]; !ok {
 		out[TeeMetadataKey] = make([]map[string]interface{}, 0)
 	}
 
 	switch outVal := out[TeeMetadataKey].(type) {
 	case []map[string]interface{}:
-		teeOutputMap := map[string]interface{}{
-			"uuid": uuid.String(),
-			"outputType": t.outputType,
-			"connectionString": t.connectionString,
-		}
-		if respMap != nil && len(respMap) > 0 {
-			teeOutputMap["response"] = respMap
-		}
-		out[TeeMetadataKey] = append(outVal, teeOutputMap)
+		out[TeeMetadataKey] = append(outVal, teeOutputs...)
 	default:
 		msg := fmt.Sprintf("detected corrupted %s in map when teeing.  expected []map[string]string, got %v",
 			TeeMetadataKey, reflect.TypeOf(outVal))
-		return nil, util.NewInternalError(msg)
+		return util.NewInternalError(msg)
 	}
-
-	return out, nil
-
+	return nil
 }
@@ -0,0 +1,561 @@
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/observability"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTeeBufferCapacity is the capacity of the in-memory buffer used when a Tee is async or spools,
+// but no buffer is provided
+const DefaultTeeBufferCapacity = 1024
+
+// DefaultTeeCloseTimeout is how long Close waits for buffered outputs to be delivered
+const DefaultTeeCloseTimeout = 5 * time.Second
+
+// The delivery status recorded in the tee metadata, when an output is not delivered inline
+const (
+	TeeDeliveryBuffered = "buffered"
+	TeeDeliverySpooled = "spooled"
+	TeeDeliverySkipped = "skipped"
+	TeeDeliveryDropped = "dropped"
+)
+
+// TeeBuffer holds the outputs that a Tee delivers in the background
+type TeeBuffer interface {
+	// Push will add an output, or return an OutOfBoundsError if the buffer is full
+	Push(item []byte) error
+	// Pop will return the oldest output and a function to call once it is delivered, or an EmptyQueue
+	// error if the buffer is empty
+	Pop() ([]byte, func() error, error)
+	Len() int
+	Close() error
+}
+
+type memoryTeeBuffer struct {
+	lock sync.Mutex
+	items [][]byte
+	capacity int
+}
+
+// NewMemoryTeeBuffer will create a bounded, in-memory buffer.  Buffered outputs are lost if binge exits.
+func NewMemoryTeeBuffer(capacity int) TeeBuffer {
+	return &memoryTeeBuffer{capacity: capacity}
+}
+
+func (b *memoryTeeBuffer) Push(item []byte) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if len(b.items) >= b.capacity {
+		return util.NewOutOfBoundsError(fmt.Sprintf("tee buffer is full (%d outputs)", b.capacity))
+	}
+	b.items = append(b.items, item)
+	return nil
+}
+
+func (b *memoryTeeBuffer) Pop() ([]byte, func() error, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if len(b.items) == 0 {
+		return nil, nil, util.NewEmptyQueue("empty")
+	}
+	item := b.items[0]
+	b.items = b.items[1:]
+	return item, func() error { return nil }, nil
+}
+
+func (b *memoryTeeBuffer) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.items)
+}
+
+func (b *memoryTeeBuffer) Close() error {
+	return nil
+}
+
+type durableTeeBuffer struct {
+	lock sync.Mutex
+	queue *util.DurableQueue
+	recovered []*util.QueueItem
+	capacity int
+}
+
+// NewDurableTeeBuffer will create a bounded buffer backed by a DurableQueue at path.  Outputs that were
+// not delivered before binge exited are delivered when the buffer is reopened.
+func NewDurableTeeBuffer(path string, capacity int) (TeeBuffer, error) {
+	buffer := &durableTeeBuffer{capacity: capacity}
+
+	// Outputs that were being delivered stay in the inflight queue until they are delivered again
+	recoverFunc := func(itemBytes []byte) error {
+		item, err := util.QueueItemFromBytes(itemBytes)
+		if err != nil {
+			return err
+		}
+		buffer.recovered = append(buffer.recovered, item)
+		return util.NewConflictError("recovered outputs are acked after they are delivered")
+	}
+
+	var err error
+	buffer.queue, err = util.OpenDurableQueue(path, recoverFunc, true)
+	if err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+func (b *durableTeeBuffer) Push(item []byte) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if int(b.queue.Length()) + len(b.recovered) >= b.capacity {
+		return util.NewOutOfBoundsError(fmt.Sprintf("tee buffer is full (%d outputs)", b.capacity))
+	}
+	return b.queue.Enqueue(item)
+}
+
+func (b *durableTeeBuffer) Pop() ([]byte, func() error, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	var item *util.QueueItem
+	if len(b.recovered) > 0 {
+		item = b.recovered[0]
+		b.recovered = b.recovered[1:]
+	} else {
+		var err error
+		if item, err = b.queue.Dequeue(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return item.Data, func() error { return b.queue.Ack(item) }, nil
+}
+
+func (b *durableTeeBuffer) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return int(b.queue.Length()) + len(b.recovered)
+}
+
+func (b *durableTeeBuffer) Close() error {
+	return b.queue.Close()
+}
+
+// circuitBreaker opens after threshold consecutive failures.  Once resetTimeout has passed, a single
+// attempt is allowed: success closes the breaker and failure keeps it open for another resetTimeout.
+type circuitBreaker struct {
+	lock sync.Mutex
+	threshold int
+	resetTimeout time.Duration
+	failures int
+	openedAt time.Time
+	probing bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if !b.probing && time.Now().Sub(b.openedAt) >= b.resetTimeout {
+		b.probing = true
+		return true
+	}
+	return false
+}
+
+func (b *circuitBreaker) isOpen() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.failures >= b.threshold
+}
+
+// untilReset returns the time to wait before asking for an attempt again.  The wait is never shorter than
+// a tenth of resetTimeout, so waiting on a breaker whose single attempt is held by another goroutine does
+// not spin.
+func (b *circuitBreaker) untilReset() time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	minWait := b.resetTimeout / 10
+	if minWait < time.Millisecond {
+		minWait = time.Millisecond
+	}
+	if wait := b.resetTimeout - time.Now().Sub(b.openedAt); wait > minWait {
+		return wait
+	}
+	return minWait
+}
+
+func (b *circuitBreaker) success() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *circuitBreaker) failure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// teeSettings are the options of a Tee
+type teeSettings struct {
+	serializer TeeSerializer
+	policy *teeDeliveryPolicy
+	object objectTeeSettings
+	rolling *RollingFileOptions
+	http httpTeeSettings
+	pubSub pubSubTeeSettings
+}
+
+func newTeeSettings(options ...TeeOption) *teeSettings {
+	settings := &teeSettings{serializer: jsonSerializer{"application/json"}}
+	for _, option := range options {
+		option(settings)
+	}
+	return settings
+}
+
+func (settings *teeSettings) deliveryPolicy() *teeDeliveryPolicy {
+	if settings.policy == nil {
+		settings.policy = &teeDeliveryPolicy{}
+	}
+	return settings.policy
+}
+
+type TeeOption func(settings *teeSettings)
+
+// WithTeeSerializer sets the encoding of the payload (default is JSON).  Search index tees ignore the
+// serializer.
+func WithTeeSerializer(serializer TeeSerializer) TeeOption {
+	return func(settings *teeSettings) {
+		settings.serializer = serializer
+	}
+}
+
+// WithTeeRetry will retry failed outputs up to maxRetries times, backing off exponentially (with
+// jitter) from initialDelay up to maxDelay
+func WithTeeRetry(maxRetries int, initialDelay, maxDelay time.Duration) TeeOption {
+	return func(settings *teeSettings) {
+		policy := settings.deliveryPolicy()
+		policy.maxRetries = maxRetries
+		policy.initialDelay = initialDelay
+		policy.maxDelay = maxDelay
+	}
+}
+
+// WithTeeCircuitBreaker will open a circuit breaker after failureThreshold consecutive failed outputs.
+// While it is open, outputs are skipped or, if spool is true, buffered and delivered in the background
+// once the breaker closes.  Failed outputs are also spooled, rather than failing the pipeline.
+func WithTeeCircuitBreaker(failureThreshold int, resetTimeout time.Duration, spool bool) TeeOption {
+	return func(settings *teeSettings) {
+		policy := settings.deliveryPolicy()
+		policy.breaker = &circuitBreaker{threshold: failureThreshold, resetTimeout: resetTimeout}
+		policy.spool = spool
+	}
+}
+
+// WithTeeAsync will buffer all outputs and deliver them in the background, so the pipeline does not
+// wait for the output system
+func WithTeeAsync() TeeOption {
+	return func(settings *teeSettings) {
+		settings.deliveryPolicy().async = true
+	}
+}
+
+// WithTeeBuffer sets the buffer used by async and spooling Tees (default is an in-memory buffer of
+// DefaultTeeBufferCapacity outputs).  Outputs are dropped when the buffer is full.
+func WithTeeBuffer(buffer TeeBuffer) TeeOption {
+	return func(settings *teeSettings) {
+		settings.deliveryPolicy().buffer = buffer
+	}
+}
+
+type teeEntry struct {
+	Key string `json:"key"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// TeeDeliveryStats is a snapshot of a Tee's delivery metrics
+type TeeDeliveryStats struct {
+	BufferDepth int
+	Dropped int64
+	Skipped int64
+	CircuitOpen bool
+}
+
+// teeDeliveryPolicy is set by the delivery options
+type teeDeliveryPolicy struct {
+	maxRetries int
+	initialDelay time.Duration
+	maxDelay time.Duration
+	breaker *circuitBreaker
+	spool bool
+	async bool
+	buffer TeeBuffer
+}
+
+// teeDelivery applies a Tee's delivery policy to its output function
+type teeDelivery struct {
+	teeDeliveryPolicy
+	name string
+	outputFunc func(ctx context.Context, key string, in map[string]interface{}) (map[string]interface{}, error)
+	closeTimeout time.Duration
+	emitter *observability.Emitter
+	dropped int64
+	skipped int64
+	notify chan struct{}
+	done chan struct{}
+	stopped chan struct{}
+	cancel context.CancelFunc
+	closeOnce sync.Once
+}
+
+// newTeeDelivery returns nil if there is no policy, in which case outputs are delivered inline
+func newTeeDelivery(name string, outputFunc func(ctx context.Context, key string,
+	in map[string]interface{}) (map[string]interface{}, error), policy *teeDeliveryPolicy) *teeDelivery {
+	if policy == nil {
+		return nil
+	}
+	delivery := &teeDelivery{
+		teeDeliveryPolicy: *policy,
+		name: name,
+		outputFunc: outputFunc,
+		closeTimeout: DefaultTeeCloseTimeout,
+		emitter: observability.NewEmitter("agglo/tee"),
+	}
+	delivery.emitter.AddMetric(name + ".dropped", observability.Int64Counter)
+	delivery.emitter.AddMetric(name + ".skipped", observability.Int64Counter)
+
+	if !delivery.async && !delivery.spool {
+		return delivery
+	}
+	if delivery.buffer == nil {
+		delivery.buffer = NewMemoryTeeBuffer(DefaultTeeBufferCapacity)
+	}
+	delivery.emitter.AddMetric(name + ".bufferDepth", observability.Float64Gauge)
+	delivery.emitter.GaugeFloat64(name + ".bufferDepth", float64(delivery.buffer.Len()))
+
+	var ctx context.Context
+	ctx, delivery.cancel = context.WithCancel(context.Background())
+	delivery.notify = make(chan struct{}, 1)
+	delivery.done = make(chan struct{})
+	delivery.stopped = make(chan struct{})
+	go delivery.flush(ctx)
+	return delivery
+}
+
+// backoff returns the delay before the next retry, with jitter, and the following delay
+func (d *teeDelivery) backoff(delay time.Duration) (time.Duration, time.Duration) {
+	next := delay * 2
+	if d.maxDelay > 0 && next > d.maxDelay {
+		next = d.maxDelay
+	}
+	if delay <= 1 {
+		return delay, next
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2))), next
+}
+
+func (d *teeDelivery) deliver(ctx context.Context, key string, in map[string]interface{}) (
+	map[string]interface{}, error) {
+	var wait time.Duration
+	delay := d.initialDelay
+	for i := 0; ; i++ {
+		resp, err := d.outputFunc(ctx, key, in)
+		if err == nil || i >= d.maxRetries {
+			return resp, err
+		}
+		wait, delay = d.backoff(delay)
+		if sleepWithContext(ctx, wait) != nil {
+			return nil, err
+		}
+	}
+}
+
+// enqueue will buffer an output for the background flush
+func (d *teeDelivery) enqueue(status, key string, in map[string]interface{}) (string, error) {
+	entryBytes, err := json.Marshal(&teeEntry{key, in})
+	if err != nil {
+		return "", err
+	}
+	if err = d.buffer.Push(entryBytes); err != nil {
+		if errors.As(err, new(*util.OutOfBoundsError)) {
+			atomic.AddInt64(&d.dropped, 1)
+			d.emitter.AddInt64(d.name + ".dropped", 1)
+			return TeeDeliveryDropped, nil
+		}
+		return "", err
+	}
+	d.emitter.GaugeFloat64(d.name + ".bufferDepth", 1)
+	select {
+	case d.notify <- struct{}{}:
+	default:
+	}
+	return status, nil
+}
+
+// output will deliver, buffer or skip the output, according to the policy.  The returned status is
+// empty if the output was delivered inline.
+func (d *teeDelivery) output(ctx context.Context, key string, in map[string]interface{}) (
+	map[string]interface{}, string, error) {
+	if d.async {
+		status, err := d.enqueue(TeeDeliveryBuffered, key, in)
+		return nil, status, err
+	}
+	if d.breaker != nil && !d.breaker.allow() {
+		if d.spool {
+			status, err := d.enqueue(TeeDeliverySpooled, key, in)
+			return nil, status, err
+		}
+		atomic.AddInt64(&d.skipped, 1)
+		d.emitter.AddInt64(d.name + ".skipped", 1)
+		return nil, TeeDeliverySkipped, nil
+	}
+
+	resp, err := d.deliver(ctx, key, in)
+	if d.breaker != nil {
+		if err != nil {
+			d.breaker.failure()
+		} else {
+			d.breaker.success()
+		}
+	}
+	if err != nil && d.spool {
+		status, err := d.enqueue(TeeDeliverySpooled, key, in)
+		return nil, status, err
+	}
+	return resp, "", err
+}
+
+// flush will deliver buffered outputs until the Tee is closed and the buffer is empty.  Outputs that
+// fail after retries are dropped, unless the circuit breaker is open, in which case they are held until
+// it allows another attempt.
+func (d *teeDelivery) flush(ctx context.Context) {
+	defer close(d.stopped)
+	for {
+		item, ack, err := d.buffer.Pop()
+		if err != nil {
+			wait := time.Second
+			if errors.Is(err, &util.EmptyQueue{}) {
+				wait = -1
+			}
+			if !d.waitForWork(wait) {
+				return
+			}
+			continue
+		}
+		d.emitter.GaugeFloat64(d.name + ".bufferDepth", -1)
+
+		var entry teeEntry
+		if err = json.Unmarshal(item, &entry); err != nil {
+			_ = ack()
+			atomic.AddInt64(&d.dropped, 1)
+			d.emitter.AddInt64(d.name + ".dropped", 1)
+			continue
+		}
+
+		for {
+			if d.breaker != nil && !d.breaker.allow() {
+				if sleepWithContext(ctx, d.breaker.untilReset()) != nil {
+					return
+				}
+				continue
+			}
+			_, err = d.deliver(ctx, entry.Key, entry.Payload)
+			if ctx.Err() != nil {
+				// Outputs in a durable buffer are delivered when it is reopened
+				return
+			}
+			if err == nil {
+				if d.breaker != nil {
+					d.breaker.success()
+				}
+				break
+			}
+			if d.breaker != nil {
+				d.breaker.failure()
+				if d.breaker.isOpen() {
+					continue
+				}
+			}
+			atomic.AddInt64(&d.dropped, 1)
+			d.emitter.AddInt64(d.name + ".dropped", 1)
+			break
+		}
+		_ = ack()
+	}
+}
+
+// waitForWork will wait until an output is buffered or the wait elapses.  A negative wait only ends
+// when an output is buffered or the Tee is closed.  Returns false if the Tee is closed and the buffer
+// is empty.
+func (d *teeDelivery) waitForWork(wait time.Duration) bool {
+	var timeout <-chan time.Time
+	if wait >= 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-d.notify:
+		return true
+	case <-timeout:
+		return true
+	case <-d.done:
+		return d.buffer.Len() > 0
+	}
+}
+
+func (d *teeDelivery) stats() TeeDeliveryStats {
+	stats := TeeDeliveryStats{
+		Dropped: atomic.LoadInt64(&d.dropped),
+		Skipped: atomic.LoadInt64(&d.skipped),
+	}
+	if d.buffer != nil {
+		stats.BufferDepth = d.buffer.Len()
+	}
+	if d.breaker != nil {
+		stats.CircuitOpen = d.breaker.isOpen()
+	}
+	return stats
+}
+
+// Close will wait up to closeTimeout for buffered outputs to be delivered, then stop the background
+// flush and close the buffer
+func (d *teeDelivery) Close() error {
+	if d.buffer == nil {
+		return nil
+	}
+	var err error
+	d.closeOnce.Do(func() {
+		// A buffer without async or spooling is never flushed, so there is nothing to stop
+		if d.done == nil {
+			err = d.buffer.Close()
+			return
+		}
+		close(d.done)
+		timer := time.NewTimer(d.closeTimeout)
+		defer timer.Stop()
+		select {
+		case <-d.stopped:
+		case <-timer.C:
+			d.cancel()
+			<-d.stopped
+		}
+		d.cancel()
+		err = d.buffer.Close()
+	})
+	return err
+}
@@ -0,0 +1,202 @@
+package process_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails requests while healthy is 0 and counts the requests it accepts
+type flakyServer struct {
+	*httptest.Server
+	healthy int32
+	calls int32
+	accepted int32
+}
+
+func newFlakyServer(healthy bool) *flakyServer {
+	server := &flakyServer{}
+	if healthy {
+		server.healthy = 1
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&server.calls, 1)
+		if atomic.LoadInt32(&server.healthy) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		atomic.AddInt32(&server.accepted, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server
+}
+
+func teeDeliveryStatus(out map[string]interface{}) interface{} {
+	return out[process.TeeMetadataKey].([]map[string]interface{})[0]["delivery"]
+}
+
+func TestTeeRetry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	tee := process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil, nil,
+		process.WithTeeRetry(3, time.Millisecond, 5*time.Millisecond))
+	out, err := tee.Process(context.Background(), map[string]interface{}{"a": 1})
+	assert.Nil(t, err)
+	assert.Nil(t, teeDeliveryStatus(out))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, -10)
+	_, err = tee.Process(context.Background(), map[string]interface{}{"a": 1})
+	assert.Error(t, err)
+	assert.Equal(t, int32(-6), atomic.LoadInt32(&calls))
+}
+
+func TestTeeUnusedBuffer(t *testing.T) {
+	server := newFlakyServer(true)
+	defer server.Close()
+
+	// Outputs are delivered inline, and closing the tee closes the buffer
+	tee := process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil, nil,
+		process.WithTeeRetry(1, time.Millisecond, time.Millisecond),
+		process.WithTeeBuffer(process.NewMemoryTeeBuffer(10)))
+	out, err := tee.Process(context.Background(), map[string]interface{}{"a": 1})
+	assert.Nil(t, err)
+	assert.Nil(t, teeDeliveryStatus(out))
+	assert.Nil(t, tee.Close())
+}
+
+func TestTeeCircuitBreakerSkip(t *testing.T) {
+	server := newFlakyServer(false)
+	defer server.Close()
+
+	tee := process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil, nil,
+		process.WithTeeCircuitBreaker(2, 100*time.Millisecond, false))
+	for i := 0; i < 2; i++ {
+		_, err := tee.Process(context.Background(), map[string]interface{}{"a": 1})
+		assert.Error(t, err)
+	}
+
+	// The breaker is open, so outputs are skipped without calling the server
+	out, err := tee.Process(context.Background(), map[string]interface{}{"a": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, process.TeeDeliverySkipped, teeDeliveryStatus(out))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&server.calls))
+	assert.Equal(t, process.TeeDeliveryStats{Skipped: 1, CircuitOpen: true}, tee.DeliveryStats())
+
+	// After the reset timeout, a successful output closes the breaker
+	atomic.StoreInt32(&server.healthy, 1)
+	time.Sleep(150 * time.Millisecond)
+	out, err = tee.Process(context.Background(), map[string]interface{}{"a": 1})
+	assert.Nil(t, err)
+	assert.Nil(t, teeDeliveryStatus(out))
+	assert.False(t, tee.DeliveryStats().CircuitOpen)
+	assert.Nil(t, tee.Close())
+}
+
+func TestTeeCircuitBreakerSpool(t *testing.T) {
+	server := newFlakyServer(false)
+	defer server.Close()
+
+	tee := process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil, nil,
+		process.WithTeeCircuitBreaker(1, 50*time.Millisecond, true))
+
+	// The failed output and the output sent while the breaker is open are both spooled
+	for i := 0; i < 2; i++ {
+		out, err := tee.Process(context.Background(), map[string]interface{}{"a": i})
+		assert.Nil(t, err)
+		assert.Equal(t, process.TeeDeliverySpooled, teeDeliveryStatus(out))
+	}
+	assert.True(t, tee.DeliveryStats().CircuitOpen)
+
+	// Spooled outputs are delivered once the breaker allows it
+	atomic.StoreInt32(&server.healthy, 1)
+	assert.Nil(t, tee.Close())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&server.accepted))
+	assert.Equal(t, process.TeeDeliveryStats{}, tee.DeliveryStats())
+}
+
+func TestTeeAsync(t *testing.T) {
+	received := make(chan struct{}, 4)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+
+	tee := process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil, nil,
+		process.WithTeeAsync(), process.WithTeeBuffer(process.NewMemoryTeeBuffer(1)))
+
+	// The first output is being delivered, the second is buffered and the third is dropped
+	out, err := tee.Process(context.Background(), map[string]interface{}{"a": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, process.TeeDeliveryBuffered, teeDeliveryStatus(out))
+	<-received
+	out, err = tee.Process(context.Background(), map[string]interface{}{"a": 2})
+	assert.Nil(t, err)
+	assert.Equal(t, process.TeeDeliveryBuffered, teeDeliveryStatus(out))
+	out, err = tee.Process(context.Background(), map[string]interface{}{"a": 3})
+	assert.Nil(t, err)
+	assert.Equal(t, process.TeeDeliveryDropped, teeDeliveryStatus(out))
+	assert.Equal(t, process.TeeDeliveryStats{BufferDepth: 1, Dropped: 1}, tee.DeliveryStats())
+
+	close(release)
+	assert.Nil(t, tee.Close())
+	assert.Equal(t, 1, len(received))
+	assert.Equal(t, process.TeeDeliveryStats{Dropped: 1}, tee.DeliveryStats())
+}
+
+func TestDurableTeeBuffer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "teebuffer")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	path := filepath.Join(dir, "tee.db")
+
+	buffer, err := process.NewDurableTeeBuffer(path, 2)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Nil(t, buffer.Push([]byte("a")))
+	assert.Nil(t, buffer.Push([]byte("b")))
+	assert.Error(t, buffer.Push([]byte("c")))
+
+	// An output that was popped, but not acked, is returned again after reopening
+	item, _, err := buffer.Pop()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("a"), item)
+	assert.Nil(t, buffer.Close())
+
+	buffer, err = process.NewDurableTeeBuffer(path, 2)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, 2, buffer.Len())
+	for _, expected := range []string{"a", "b"} {
+		item, ack, err := buffer.Pop()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(expected), item)
+		assert.Nil(t, ack())
+	}
+	_, _, err = buffer.Pop()
+	assert.Error(t, err)
+	assert.Nil(t, buffer.Close())
+}
@@ -0,0 +1,371 @@
+package process
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/linkedin/goavro/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// The built-in Tee formats
+const (
+	TeeFormatJson = "json"
+	TeeFormatNdjson = "ndjson"
+	TeeFormatCsv = "csv"
+	TeeFormatMsgpack = "msgpack"
+	TeeFormatProtobuf = "protobuf"
+	TeeFormatAvro = "avro"
+)
+
+// TeeSerializer encodes the payloads of a Tee
+type TeeSerializer interface {
+	Serialize(payload map[string]interface{}) ([]byte, error)
+	// ContentType is the MIME type of the encoding, e.g. used by HTTP tees
+	ContentType() string
+	// FileExtension is the extension of files written by local file tees, e.g. "json"
+	FileExtension() string
+}
+
+// TeeHeaderSerializer is a serializer whose outputs follow a header, e.g. the column names of CSV.  Tees
+// write the header once at the start of each file, object or message.
+type TeeHeaderSerializer interface {
+	TeeSerializer
+	// Header returns the header, or nil if there is none
+	Header() ([]byte, error)
+}
+
+// TeeSerializerOptions configures a serializer.  Each format uses the options that apply to it.
+type TeeSerializerOptions struct {
+	// Columns are the field paths written, in order, by CSV
+	Columns []string
+	// Header will write the column names at the start of each CSV file, object or message
+	Header bool
+	// Files and MessageName are the descriptors and the full name of the protobuf message
+	Files *protoregistry.Files
+	MessageName string
+	// Schema is the Avro schema
+	Schema string
+}
+
+// TeeSerializerFactory creates a serializer for a format
+type TeeSerializerFactory func(options TeeSerializerOptions) (TeeSerializer, error)
+
+var teeSerializers = struct {
+	sync.RWMutex
+	factories map[string]TeeSerializerFactory
+}{
+	factories: map[string]TeeSerializerFactory{
+		TeeFormatJson: func(options TeeSerializerOptions) (TeeSerializer, error) {
+			return jsonSerializer{"application/json"}, nil
+		},
+		TeeFormatNdjson: func(options TeeSerializerOptions) (TeeSerializer, error) {
+			return jsonSerializer{"application/x-ndjson"}, nil
+		},
+		TeeFormatCsv: newCsvSerializer,
+		TeeFormatMsgpack: func(options TeeSerializerOptions) (TeeSerializer, error) {
+			return msgpackSerializer{}, nil
+		},
+		TeeFormatProtobuf: newProtobufSerializer,
+		TeeFormatAvro: newAvroSerializer,
+	},
+}
+
+// RegisterTeeSerializer will add (or replace) the factory for a format
+func RegisterTeeSerializer(format string, factory TeeSerializerFactory) {
+	teeSerializers.Lock()
+	defer teeSerializers.Unlock()
+	teeSerializers.factories[format] = factory
+}
+
+// TeeFormats returns the registered formats, sorted
+func TeeFormats() []string {
+	teeSerializers.RLock()
+	defer teeSerializers.RUnlock()
+	formats := make([]string, 0, len(teeSerializers.factories))
+	for format := range teeSerializers.factories {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// NewTeeSerializer will create a serializer for a registered format
+func NewTeeSerializer(format string, options TeeSerializerOptions) (TeeSerializer, error) {
+	teeSerializers.RLock()
+	factory, ok := teeSerializers.factories[format]
+	teeSerializers.RUnlock()
+	if !ok {
+		return nil, util.NewInvalidError(fmt.Sprintf("unknown tee format '%s'", format))
+	}
+	return factory(options)
+}
+
+// serializePayload will merge additionalBody into the payload and encode it
+func serializePayload(serializer TeeSerializer, in map[string]interface{},
+	additionalBody map[string]interface{}) ([]byte, error) {
+	var err error
+	payload := in
+	if len(additionalBody) > 0 {
+		payload, err = util.MergeMaps(in, additionalBody)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return serializer.Serialize(payload)
+}
+
+// serializerHeader returns the serializer's header, or nil if it has none
+func serializerHeader(serializer TeeSerializer) ([]byte, error) {
+	if headerSerializer, ok := serializer.(TeeHeaderSerializer); ok {
+		return headerSerializer.Header()
+	}
+	return nil, nil
+}
+
+// serializeDocument will serialize an output that is written on its own, preceded by the header
+func serializeDocument(serializer TeeSerializer, in map[string]interface{},
+	additionalBody map[string]interface{}) ([]byte, error) {
+	header, err := serializerHeader(serializer)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := serializePayload(serializer, in, additionalBody)
+	if err != nil || len(header) == 0 {
+		return payload, err
+	}
+	return append(header, payload...), nil
+}
+
+// jsonSerializer writes one JSON object per payload, followed by a newline
+type jsonSerializer struct {
+	contentType string
+}
+
+func (s jsonSerializer) Serialize(payload map[string]interface{}) ([]byte, error) {
+	byteBuffer := bytes.NewBuffer([]byte{})
+	encoder := json.NewEncoder(byteBuffer)
+	if err := encoder.Encode(payload); err != nil {
+		return nil, err
+	}
+	return byteBuffer.Bytes(), nil
+}
+
+func (s jsonSerializer) ContentType() string {
+	return s.contentType
+}
+
+func (s jsonSerializer) FileExtension() string {
+	if s.contentType == "application/x-ndjson" {
+		return "ndjson"
+	}
+	return "json"
+}
+
+// csvSerializer writes a row of the configured columns.  Missing fields are empty, and maps and
+// lists are JSON-encoded.
+type csvSerializer struct {
+	columns []string
+	paths []*util.Path
+	header bool
+}
+
+func newCsvSerializer(options TeeSerializerOptions) (TeeSerializer, error) {
+	if len(options.Columns) == 0 {
+		return nil, util.NewInvalidError("csv format requires columns")
+	}
+	serializer := &csvSerializer{columns: options.Columns, header: options.Header}
+	for _, column := range options.Columns {
+		path, err := util.NewPath(column)
+		if err != nil {
+			return nil, err
+		}
+		serializer.paths = append(serializer.paths, path)
+	}
+	return serializer, nil
+}
+
+func csvValue(v interface{}) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(val)
+		return string(b), err
+	default:
+		return fmt.Sprintf("%v", val), nil
+	}
+}
+
+func (s csvSerializer) Serialize(payload map[string]interface{}) ([]byte, error) {
+	row := make([]string, len(s.paths))
+	for i, path := range s.paths {
+		v, err := path.Get(payload)
+		if err != nil {
+			continue
+		}
+		if row[i], err = csvValue(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return csvRow(row)
+}
+
+// Header returns the row of column names, if the header is enabled
+func (s csvSerializer) Header() ([]byte, error) {
+	if !s.header {
+		return nil, nil
+	}
+	return csvRow(s.columns)
+}
+
+func csvRow(row []string) ([]byte, error) {
+	byteBuffer := bytes.NewBuffer([]byte{})
+	writer := csv.NewWriter(byteBuffer)
+	if err := writer.Write(row); err != nil {
+		return nil, err
+	}
+	writer.Flush()
+	return byteBuffer.Bytes(), writer.Error()
+}
+
+func (s csvSerializer) ContentType() string {
+	return "text/csv"
+}
+
+func (s csvSerializer) FileExtension() string {
+	return "csv"
+}
+
+type msgpackSerializer struct{}
+
+func (s msgpackSerializer) Serialize(payload map[string]interface{}) ([]byte, error) {
+	return msgpack.Marshal(payload)
+}
+
+func (s msgpackSerializer) ContentType() string {
+	return "application/msgpack"
+}
+
+func (s msgpackSerializer) FileExtension() string {
+	return "msgpack"
+}
+
+// protobufSerializer converts the payload to a message, using the JSON mapping, and writes the binary
+// encoding.  Fields that are not in the message are ignored.
+type protobufSerializer struct {
+	message protoreflect.MessageDescriptor
+}
+
+func newProtobufSerializer(options TeeSerializerOptions) (TeeSerializer, error) {
+	if options.Files == nil || len(options.MessageName) == 0 {
+		return nil, util.NewInvalidError("protobuf format requires a descriptor set and a message name")
+	}
+	desc, err := options.Files.FindDescriptorByName(protoreflect.FullName(options.MessageName))
+	if err != nil {
+		return nil, util.NewNotFoundError(fmt.Sprintf("protobuf message not found: '%s'", options.MessageName))
+	}
+	message, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, util.NewInvalidError(fmt.Sprintf("'%s' is not a protobuf message", options.MessageName))
+	}
+	return protobufSerializer{message}, nil
+}
+
+func (s protobufSerializer) Serialize(payload map[string]interface{}) ([]byte, error) {
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	message := dynamicpb.NewMessage(s.message)
+	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(jsonBytes, message); err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("cannot convert payload to %s: %s", s.message.FullName(),
+			err.Error()))
+	}
+	return proto.Marshal(message)
+}
+
+func (s protobufSerializer) ContentType() string {
+	return "application/x-protobuf"
+}
+
+func (s protobufSerializer) FileExtension() string {
+	return "pb"
+}
+
+// avroSerializer writes the binary encoding of a single datum.  The payload is converted using Avro's
+// JSON encoding, so union values must be wrapped, e.g. {"string": "a"}.  For record schemas, fields that
+// are not in the schema are ignored.
+type avroSerializer struct {
+	codec *goavro.Codec
+	fields []string
+}
+
+func newAvroSerializer(options TeeSerializerOptions) (TeeSerializer, error) {
+	if len(options.Schema) == 0 {
+		return nil, util.NewInvalidError("avro format requires a schema")
+	}
+	codec, err := goavro.NewCodec(options.Schema)
+	if err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid avro schema: %s", err.Error()))
+	}
+	var record struct {
+		Type interface{} `json:"type"`
+		Fields []struct {
+			Name string `json:"name"`
+		} `json:"fields"`
+	}
+	serializer := avroSerializer{codec: codec}
+	if json.Unmarshal([]byte(options.Schema), &record) == nil && record.Type == "record" {
+		for _, field := range record.Fields {
+			serializer.fields = append(serializer.fields, field.Name)
+		}
+	}
+	return serializer, nil
+}
+
+func (s avroSerializer) Serialize(payload map[string]interface{}) ([]byte, error) {
+	if s.fields != nil {
+		fields := make(map[string]interface{})
+		for _, name := range s.fields {
+			if v, ok := payload[name]; ok {
+				fields[name] = v
+			}
+		}
+		payload = fields
+	}
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	native, _, err := s.codec.NativeFromTextual(jsonBytes)
+	if err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("cannot convert payload to avro: %s", err.Error()))
+	}
+	return s.codec.BinaryFromNative(nil, native)
+}
+
+func (s avroSerializer) ContentType() string {
+	return "avro/binary"
+}
+
+func (s avroSerializer) FileExtension() string {
+	return "avro"
+}
@@ -0,0 +1,200 @@
+package process_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCsvTeeSerializer(t *testing.T) {
+	serializer, err := process.NewTeeSerializer(process.TeeFormatCsv, process.TeeSerializerOptions{
+		Columns: []string{"id", "device.site", "tags", "count", "missing"},
+		Header: true,
+	})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	out, err := serializer.Serialize(map[string]interface{}{
+		"id": "a,b",
+		"device": map[string]interface{}{"site": "sfo"},
+		"tags": []interface{}{"x"},
+		"count": float64(2),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "\"a,b\",sfo,\"[\"\"x\"\"]\",2,\n", string(out))
+	assert.Equal(t, "text/csv", serializer.ContentType())
+
+	// The header is written once per file, object or message, rather than before each row
+	header, err := serializer.(process.TeeHeaderSerializer).Header()
+	assert.Nil(t, err)
+	assert.Equal(t, "id,device.site,tags,count,missing\n", string(header))
+
+	_, err = process.NewTeeSerializer(process.TeeFormatCsv, process.TeeSerializerOptions{})
+	assert.Error(t, err)
+}
+
+func TestMsgpackTeeSerializer(t *testing.T) {
+	serializer, err := process.NewTeeSerializer(process.TeeFormatMsgpack, process.TeeSerializerOptions{})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	payload := map[string]interface{}{"id": "a", "count": float64(2)}
+	out, err := serializer.Serialize(payload)
+	assert.Nil(t, err)
+
+	var decoded map[string]interface{}
+	assert.Nil(t, msgpack.Unmarshal(out, &decoded))
+	assert.Equal(t, payload, decoded)
+}
+
+func TestProtobufTeeSerializer(t *testing.T) {
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto)},
+	})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	serializer, err := process.NewTeeSerializer(process.TeeFormatProtobuf, process.TeeSerializerOptions{
+		Files: files,
+		MessageName: "grpc.health.v1.HealthCheckRequest",
+	})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// Fields that are not in the message are ignored
+	out, err := serializer.Serialize(map[string]interface{}{"service": "binge", "other": 1})
+	assert.Nil(t, err)
+	var request healthpb.HealthCheckRequest
+	assert.Nil(t, proto.Unmarshal(out, &request))
+	assert.Equal(t, "binge", request.Service)
+
+	_, err = serializer.Serialize(map[string]interface{}{"service": 1})
+	assert.Error(t, err)
+
+	for _, name := range []string{"grpc.health.v1.Missing", "grpc.health.v1.Health", ""} {
+		_, err = process.NewTeeSerializer(process.TeeFormatProtobuf, process.TeeSerializerOptions{
+			Files: files,
+			MessageName: name,
+		})
+		assert.Error(t, err)
+	}
+	_, err = process.NewTeeSerializer(process.TeeFormatProtobuf, process.TeeSerializerOptions{
+		Files: new(protoregistry.Files),
+		MessageName: "grpc.health.v1.HealthCheckRequest",
+	})
+	assert.Error(t, err)
+}
+
+func TestAvroTeeSerializer(t *testing.T) {
+	schema := `{"type": "record", "name": "Event", "fields": [
+		{"name": "id", "type": "string"},
+		{"name": "count", "type": "long"}
+	]}`
+	serializer, err := process.NewTeeSerializer(process.TeeFormatAvro, process.TeeSerializerOptions{Schema: schema})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// Fields that are not in the schema are ignored
+	out, err := serializer.Serialize(map[string]interface{}{"id": "a", "count": float64(2), "other": true})
+	assert.Nil(t, err)
+	codec, err := goavro.NewCodec(schema)
+	assert.Nil(t, err)
+	native, _, err := codec.NativeFromBinary(out)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "a", "count": int64(2)}, native)
+
+	_, err = serializer.Serialize(map[string]interface{}{"id": "a"})
+	assert.Error(t, err)
+
+	_, err = process.NewTeeSerializer(process.TeeFormatAvro, process.TeeSerializerOptions{Schema: "{"})
+	assert.Error(t, err)
+}
+
+type upperSerializer struct{}
+
+func (s upperSerializer) Serialize(payload map[string]interface{}) ([]byte, error) {
+	return []byte(payload["id"].(string) + "!"), nil
+}
+
+func (s upperSerializer) ContentType() string {
+	return "text/plain"
+}
+
+func (s upperSerializer) FileExtension() string {
+	return "txt"
+}
+
+func TestRegisterTeeSerializer(t *testing.T) {
+	_, err := process.NewTeeSerializer("exclaim", process.TeeSerializerOptions{})
+	assert.Error(t, err)
+
+	process.RegisterTeeSerializer("exclaim", func(options process.TeeSerializerOptions) (process.TeeSerializer,
+		error) {
+		return upperSerializer{}, nil
+	})
+	assert.Contains(t, process.TeeFormats(), "exclaim")
+	serializer, err := process.NewTeeSerializer("exclaim", process.TeeSerializerOptions{})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// Local file tees use the extension of the serializer
+	dir, err := ioutil.TempDir("", "teeserializer")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	tee, err := process.NewLocalFileTee("tee", dir, core.TrueCondition, nil, nil,
+		process.WithTeeSerializer(serializer))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	out, err := tee.Process(context.Background(), map[string]interface{}{"id": "a"})
+	assert.Nil(t, err)
+	uuid := out[process.TeeMetadataKey].([]map[string]interface{})[0]["uuid"].(string)
+	fileBytes, err := ioutil.ReadFile(filepath.Join(dir, uuid+".txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "a!", string(fileBytes))
+}
+
+func TestHttpTeeSerializer(t *testing.T) {
+	var contentType string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	serializer, err := process.NewTeeSerializer(process.TeeFormatMsgpack, process.TeeSerializerOptions{})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	tee := process.NewHttpTee("tee", http.DefaultClient, server.URL, core.TrueCondition, nil,
+		map[string]interface{}{"source": "binge"}, process.WithTeeSerializer(serializer))
+	_, err = tee.Process(context.Background(), map[string]interface{}{"id": "a"})
+	assert.Nil(t, err)
+	assert.Equal(t, "application/msgpack", contentType)
+
+	var decoded map[string]interface{}
+	assert.Nil(t, msgpack.Unmarshal(body, &decoded))
+	assert.Equal(t, map[string]interface{}{"id": "a", "source": "binge"}, decoded)
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"github.com/kmgreen2/agglo/pkg/util"
 	"github.com/kmgreen2/agglo/internal/core"
-	"strings"
 )
 
 type Transformer struct {
@@ -23,6 +22,9 @@ type TransformerSpec struct {
 	transformation *core.Transformation
 }
 
+// NewTransformer creates a Transformer.  Source and target fields are paths (see util.Path) split by
+// fieldSeparator.  If indexSeparator is a bracket pair, such as "[]", array indexes may also be written
+// as "items[0]"; otherwise, indexes are regular path components ("items.0").
 func NewTransformer(name string, specs []*TransformerSpec, fieldSeparator, indexSeparator string,
 	forwardInputFields bool) *Transformer {
 	return &Transformer{
@@ -50,34 +52,8 @@ func (t *Transformer) AddSpec(sourceField, targetField string, transformation *c
 	})
 }
 
-func (t Transformer) dictFromPath(key string, in map[string]interface{}) (map[string]interface{}, error) {
-	fieldNames := strings.Split(key, t.fieldSeparator)
-	var curr map[string]interface{} = in
-	for i, fieldName := range fieldNames {
-		if i != len(fieldNames) - 1 {
-			if currValue, ok := curr[fieldName]; ok {
-				curr = currValue.(map[string]interface{})
-			} else {
-				msg := fmt.Sprintf("could not find '%s' in the map", key)
-				return nil, util.NewNotFoundError(msg)
-			}
-		} else if _, ok := curr[fieldName]; !ok {
-			msg := fmt.Sprintf("could not find '%s' in the map", key)
-			return nil, util.NewNotFoundError(msg)
-		}
-	}
-	return curr, nil
-}
-
-func (t Transformer) valueFromPath(key string, in map[string]interface{}) (interface{}, error) {
-	fieldNames := strings.Split(key, t.fieldSeparator)
-	var curr map[string]interface{} = in
-	for i, fieldName := range fieldNames {
-		if i != len(fieldNames) - 1 {
-			curr = curr[fieldName].(map[string]interface{})
-		}
-	}
-	return curr[fieldNames[len(fieldNames)-1]], nil
+func (t Transformer) path(field string) (*util.Path, error) {
+	return util.NewPath(field, util.WithFieldSeparator(t.fieldSeparator), util.WithIndexSeparator(t.indexSeparator))
 }
 
 func (t Transformer) createPathAndTransform(sourceField, targetField string, transformation *core.Transformation, in,
@@ -102,39 +78,33 @@ func (t Transformer) createPathAndTransform(sourceField, targetField string, tra
 		}
 	}
 
-	sourceDict, err := t.dictFromPath(sourceField, in)
+	sourcePath, err := t.path(sourceField)
+	if err != nil {
+		return err
+	}
+	targetPath, err := t.path(targetField)
 	if err != nil {
 		return err
 	}
 
+	sourceValue, err := sourcePath.Get(in)
+	if err != nil {
+		return err
+	}
 
-	fieldNames := strings.Split(targetField, t.fieldSeparator)
-	var curr map[string]interface{} = out
-	for i, fieldName := range fieldNames {
-		should, err := transformation.ShouldTransform(util.Flatten(in))
-		if err != nil {
-			return err
-		}
-		if !should {
-			continue
-		}
-		if _, ok := curr[fieldName]; !ok {
-			curr[fieldName] = make(map[string]interface{})
-		}
-		if i < len(fieldNames) - 1 {
-			curr = curr[fieldName].(map[string]interface{})
-		} else {
-			sourceFields := strings.Split(sourceField, t.fieldSeparator)
-			sourceField := sourceFields[len(sourceFields)-1]
-			result, err := transformation.Transform(core.NewTransformable(sourceDict[sourceField]))
-			if err != nil {
-				return err
-			}
-			curr[fieldName] = result.Value()
-		}
+	should, err := transformation.ShouldTransform(in)
+	if err != nil {
+		return err
+	}
+	if !should {
+		return nil
 	}
 
-	return nil
+	result, err := transformation.Transform(core.NewTransformable(sourceValue))
+	if err != nil {
+		return err
+	}
+	return targetPath.Set(out, result.Value())
 }
 
 func (t Transformer) Process(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
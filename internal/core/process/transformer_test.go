@@ -111,3 +111,33 @@ func TestCopyNoneAndTransformTransformer(t *testing.T) {
 	assert.Equal(t, float64(5), out["bMax"])
 	assert.Equal(t, float64(1), out["eCount"])
 }
+
+func TestPathTransformer(t *testing.T) {
+	in := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"price": float64(1)},
+			map[string]interface{}{"price": float64(2)},
+			map[string]interface{}{"price": float64(4)},
+		},
+		"tags": []interface{}{"x", "y"},
+	}
+	transformer := process.NewTransformer("fooTransformer", nil, ".", "[]", false)
+	transformer.AddSpec("items.*.price", "total",
+		core.NewTransformationBuilder().AddFieldTransformation(core.SumTransformation{}).Get())
+	transformer.AddSpec("items[-1].price", "last.price",
+		core.NewTransformationBuilder().AddFieldTransformation(core.CopyTransformation{}).Get())
+	transformer.AddSpec("items.0.price", "first",
+		core.NewTransformationBuilder().AddFieldTransformation(core.CopyTransformation{}).Get())
+	transformer.AddSpec("items.5.price", "missing",
+		core.NewTransformationBuilder().AddFieldTransformation(core.CopyTransformation{}).Get())
+	transformer.AddSpec("tags.0.foo", "missing",
+		core.NewTransformationBuilder().AddFieldTransformation(core.CopyTransformation{}).Get())
+
+	out, err := transformer.Process(context.Background(), in)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(7), out["total"])
+	assert.Equal(t, float64(4), out["last"].(map[string]interface{})["price"])
+	assert.Equal(t, float64(1), out["first"])
+	_, ok := out["missing"]
+	assert.False(t, ok)
+}
@@ -0,0 +1,355 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// jobRetry is the retry policy of a remote job.  Retries back off exponentially from initialDelay.
+type jobRetry struct {
+	num int
+	initialDelay time.Duration
+}
+
+func (retry jobRetry) futureOptions() []util.FutureOption {
+	if retry.num > 0 {
+		return []util.FutureOption{util.WithRetry(retry.num, retry.initialDelay)}
+	}
+	return nil
+}
+
+// templatedValues renders a map of templates, such as headers, over an event
+type templatedValues map[string]*template.Template
+
+func newTemplatedValues(values map[string]string) (templatedValues, error) {
+	out := make(templatedValues)
+	for k, v := range values {
+		tmpl, err := newEventTemplate(k, v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = tmpl
+	}
+	return out, nil
+}
+
+func (values templatedValues) render(in map[string]interface{}) (map[string]string, error) {
+	out := make(map[string]string)
+	for k, tmpl := range values {
+		v, err := executeEventTemplate(tmpl, in)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// renderRequest returns the JSON-encoded event, or the rendered template if one is set
+func renderRequest(tmpl *template.Template, inData interface{}) ([]byte, error) {
+	in, ok := inData.(map[string]interface{})
+	if !ok {
+		return nil, util.NewInvalidError(fmt.Sprintf("remote jobs expect a map, got %T", inData))
+	}
+	if tmpl == nil {
+		return json.Marshal(in)
+	}
+	out, err := executeEventTemplate(tmpl, in)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+// remoteRunnable calls a function with the event
+type remoteRunnable struct {
+	call func(ctx context.Context, inData interface{}) (interface{}, error)
+	inData interface{}
+}
+
+func (runnable *remoteRunnable) Run(ctx context.Context) (interface{}, error) {
+	return runnable.call(ctx, runnable.inData)
+}
+
+func (runnable *remoteRunnable) SetInData(inData interface{}) error {
+	runnable.inData = inData
+	return nil
+}
+
+func runRemote(ctx context.Context, call func(context.Context, interface{}) (interface{}, error),
+	retry jobRetry, delay time.Duration, sync bool, inData ...interface{}) util.Future {
+	if len(inData) != 1 {
+		completable := util.NewCompletable()
+		msg := fmt.Sprintf("expected 1 inData varadic arg to Run, got %d", len(inData))
+		_ = completable.Fail(context.Background(), util.NewInvalidError(msg))
+		return completable.Future()
+	}
+	return run(ctx, &remoteRunnable{call: call}, delay, sync, inData[0], retry.futureOptions()...)
+}
+
+type HttpJobOption func(job *HttpJob) error
+
+// WithHttpJobMethod sets the request method (default POST)
+func WithHttpJobMethod(method string) HttpJobOption {
+	return func(job *HttpJob) error {
+		job.method = method
+		return nil
+	}
+}
+
+// WithHttpJobHeaders sets the request headers.  Header values are templates over the event.
+func WithHttpJobHeaders(headers map[string]string) HttpJobOption {
+	return func(job *HttpJob) (err error) {
+		job.headers, err = newTemplatedValues(headers)
+		return err
+	}
+}
+
+// WithHttpJobBody sets the template for the request body (default is the JSON-encoded event), e.g.
+// `{"id": "{{.id}}", "event": {{json .}}}`
+func WithHttpJobBody(body string) HttpJobOption {
+	return func(job *HttpJob) (err error) {
+		job.body, err = newEventTemplate("body", body)
+		return err
+	}
+}
+
+// WithHttpJobRetry will retry failed requests up to num times, backing off exponentially from
+// initialDelay
+func WithHttpJobRetry(num int, initialDelay time.Duration) HttpJobOption {
+	return func(job *HttpJob) error {
+		job.retry = jobRetry{num, initialDelay}
+		return nil
+	}
+}
+
+// HttpJob sends the event to a URL.  Responses with a status of 300 or above fail the job.  The value of
+// the future is a map containing the statusCode and the body, which is decoded if it is JSON.
+type HttpJob struct {
+	client common.HTTPClient
+	url string
+	method string
+	headers templatedValues
+	body *template.Template
+	retry jobRetry
+}
+
+func NewHttpJob(client common.HTTPClient, url string, options ...HttpJobOption) (*HttpJob, error) {
+	job := &HttpJob{
+		client: client,
+		url: url,
+		method: http.MethodPost,
+	}
+	for _, option := range options {
+		if err := option(job); err != nil {
+			return nil, err
+		}
+	}
+	return job, nil
+}
+
+func (j HttpJob) call(ctx context.Context, inData interface{}) (interface{}, error) {
+	body, err := renderRequest(j.body, inData)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := j.headers.render(inData.(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, j.method, j.url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var respBytes []byte
+	if resp.Body != nil {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if respBytes, err = ioutil.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode >= 300 {
+		msg := fmt.Sprintf("error status %d sending to url '%s': %s", resp.StatusCode, j.url, string(respBytes))
+		return nil, util.NewInternalError(msg)
+	}
+
+	out := map[string]interface{}{
+		"statusCode": float64(resp.StatusCode),
+	}
+	if len(respBytes) > 0 {
+		var respBody interface{}
+		if json.Unmarshal(respBytes, &respBody) == nil {
+			out["body"] = respBody
+		} else {
+			out["body"] = string(respBytes)
+		}
+	}
+	return out, nil
+}
+
+func (j HttpJob) Run(ctx context.Context, delay time.Duration, sync bool, inData ...interface{}) util.Future {
+	return runRemote(ctx, j.call, j.retry, delay, sync, inData...)
+}
+
+// LoadDescriptorSet will read a serialized FileDescriptorSet, such as the output of
+// `protoc --include_imports --descriptor_set_out`
+func LoadDescriptorSet(reader io.Reader) (*protoregistry.Files, error) {
+	setBytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	var descriptorSet descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(setBytes, &descriptorSet); err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid descriptor set: %s", err.Error()))
+	}
+	files, err := protodesc.NewFiles(&descriptorSet)
+	if err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid descriptor set: %s", err.Error()))
+	}
+	return files, nil
+}
+
+type GrpcJobOption func(job *GrpcJob) error
+
+// WithGrpcJobMetadata sets the request metadata.  Values are templates over the event.
+func WithGrpcJobMetadata(md map[string]string) GrpcJobOption {
+	return func(job *GrpcJob) (err error) {
+		job.metadata, err = newTemplatedValues(md)
+		return err
+	}
+}
+
+// WithGrpcJobRequest sets the template for the JSON-encoded request (default is the event)
+func WithGrpcJobRequest(request string) GrpcJobOption {
+	return func(job *GrpcJob) (err error) {
+		job.request, err = newEventTemplate("request", request)
+		return err
+	}
+}
+
+// WithGrpcJobRetry will retry failed calls up to num times, backing off exponentially from
+// initialDelay
+func WithGrpcJobRetry(num int, initialDelay time.Duration) GrpcJobOption {
+	return func(job *GrpcJob) error {
+		job.retry = jobRetry{num, initialDelay}
+		return nil
+	}
+}
+
+// GrpcJob makes a unary gRPC call.  The request is decoded from JSON, using the method's descriptor,
+// and fields that are not in the request message are ignored.  The value of the future is the
+// JSON-decoded response.
+type GrpcJob struct {
+	conn grpc.ClientConnInterface
+	fullMethod string
+	method protoreflect.MethodDescriptor
+	metadata templatedValues
+	request *template.Template
+	retry jobRetry
+}
+
+// NewGrpcJob will create a job that calls method, e.g. "package.Service/Method", which must be
+// described in files.
+func NewGrpcJob(conn grpc.ClientConnInterface, files *protoregistry.Files, method string,
+	options ...GrpcJobOption) (*GrpcJob, error) {
+	idx := strings.LastIndex(method, "/")
+	if idx < 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("gRPC method must be 'package.Service/Method', got '%s'",
+			method))
+	}
+	serviceName, methodName := strings.TrimPrefix(method[:idx], "/"), method[idx+1:]
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, util.NewNotFoundError(fmt.Sprintf("gRPC service not found: '%s'", serviceName))
+	}
+	service, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, util.NewInvalidError(fmt.Sprintf("'%s' is not a gRPC service", serviceName))
+	}
+	methodDesc := service.Methods().ByName(protoreflect.Name(methodName))
+	if methodDesc == nil {
+		return nil, util.NewNotFoundError(fmt.Sprintf("gRPC method not found: '%s'", method))
+	}
+	if methodDesc.IsStreamingClient() || methodDesc.IsStreamingServer() {
+		return nil, util.NewInvalidError(fmt.Sprintf("gRPC method must be unary: '%s'", method))
+	}
+
+	job := &GrpcJob{
+		conn: conn,
+		fullMethod: "/" + serviceName + "/" + methodName,
+		method: methodDesc,
+	}
+	for _, option := range options {
+		if err := option(job); err != nil {
+			return nil, err
+		}
+	}
+	return job, nil
+}
+
+func (j GrpcJob) call(ctx context.Context, inData interface{}) (interface{}, error) {
+	reqBytes, err := renderRequest(j.request, inData)
+	if err != nil {
+		return nil, err
+	}
+	md, err := j.metadata.render(inData.(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	if len(md) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(md))
+	}
+
+	req := dynamicpb.NewMessage(j.method.Input())
+	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(reqBytes, req); err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("cannot convert request to %s: %s",
+			j.method.Input().FullName(), err.Error()))
+	}
+	resp := dynamicpb.NewMessage(j.method.Output())
+	if err = j.conn.Invoke(ctx, j.fullMethod, req, resp); err != nil {
+		return nil, err
+	}
+
+	respBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return util.JsonToMap(respBytes)
+}
+
+func (j GrpcJob) Run(ctx context.Context, delay time.Duration, sync bool, inData ...interface{}) util.Future {
+	return runRemote(ctx, j.call, j.retry, delay, sync, inData...)
+}
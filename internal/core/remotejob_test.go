@@ -0,0 +1,153 @@
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHttpJob(t *testing.T) {
+	var body, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		auth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"accepted": true}`))
+	}))
+	defer server.Close()
+
+	job, err := core.NewHttpJob(http.DefaultClient, server.URL,
+		core.WithHttpJobHeaders(map[string]string{"Authorization": "Bearer {{.token}}"}),
+		core.WithHttpJobBody(`{"id": "{{.id}}", "event": {{json .}}}`))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	result := job.Run(context.Background(), -1, true, map[string]interface{}{"id": "a", "token": "xyz"}).Get()
+	assert.Nil(t, result.Error())
+	assert.Equal(t, map[string]interface{}{
+		"statusCode": float64(200),
+		"body": map[string]interface{}{"accepted": true},
+	}, result.Value())
+	assert.Equal(t, `{"id": "a", "event": {"id":"a","token":"xyz"}}`, body)
+	assert.Equal(t, "Bearer xyz", auth)
+
+	// Missing template fields fail the job
+	result = job.Run(context.Background(), -1, true, map[string]interface{}{"id": "a"}).Get()
+	assert.Error(t, result.Error())
+
+	_, err = core.NewHttpJob(http.DefaultClient, server.URL, core.WithHttpJobBody("{{.id"))
+	assert.Error(t, err)
+}
+
+func TestHttpJobRetry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	job, err := core.NewHttpJob(http.DefaultClient, server.URL, core.WithHttpJobRetry(3, 10*time.Millisecond))
+	assert.Nil(t, err)
+	result := job.Run(context.Background(), -1, true, map[string]interface{}{"id": "a"}).Get()
+	assert.Nil(t, result.Error())
+	assert.Equal(t, map[string]interface{}{"statusCode": float64(http.StatusAccepted)}, result.Value())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	job, err = core.NewHttpJob(http.DefaultClient, server.URL, core.WithHttpJobRetry(1, 10*time.Millisecond))
+	assert.Nil(t, err)
+	result = job.Run(context.Background(), -1, true, map[string]interface{}{"id": "a"}).Get()
+	assert.Error(t, result.Error())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+type metadataHealthServer struct {
+	*health.Server
+	tokens chan []string
+}
+
+func (s *metadataHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (
+	*healthpb.HealthCheckResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.tokens <- md.Get("token")
+	return s.Server.Check(ctx, req)
+}
+
+func TestGrpcJob(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	healthServer := &metadataHealthServer{health.NewServer(), make(chan []string, 4)}
+	healthServer.SetServingStatus("binge", healthpb.HealthCheckResponse_SERVING)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	descriptorSet := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto)},
+	}
+	setBytes, err := proto.Marshal(descriptorSet)
+	assert.Nil(t, err)
+	files, err := core.LoadDescriptorSet(bytes.NewBuffer(setBytes))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	job, err := core.NewGrpcJob(conn, files, "grpc.health.v1.Health/Check",
+		core.WithGrpcJobMetadata(map[string]string{"token": "{{.token}}"}))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// Fields that are not in the request message are ignored
+	result := job.Run(context.Background(), -1, true,
+		map[string]interface{}{"service": "binge", "token": "xyz", "other": 1}).Get()
+	assert.Nil(t, result.Error())
+	assert.Equal(t, map[string]interface{}{"status": "SERVING"}, result.Value())
+	assert.Equal(t, []string{"xyz"}, <-healthServer.tokens)
+
+	job, err = core.NewGrpcJob(conn, files, "/grpc.health.v1.Health/Check",
+		core.WithGrpcJobRequest(`{"service": "{{.name}}"}`))
+	assert.Nil(t, err)
+	result = job.Run(context.Background(), -1, true, map[string]interface{}{"name": "unknown"}).Get()
+	assert.Error(t, result.Error())
+
+	_, err = core.NewGrpcJob(conn, files, "grpc.health.v1.Health/Missing")
+	assert.Error(t, err)
+	_, err = core.NewGrpcJob(conn, files, "grpc.health.v1.Health/Watch")
+	assert.Error(t, err)
+	_, err = core.NewGrpcJob(conn, files, "Check")
+	assert.Error(t, err)
+}
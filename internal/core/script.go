@@ -0,0 +1,300 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkjson"
+	"math"
+	"reflect"
+	"sort"
+)
+
+const (
+	// ScriptEntryPoint is the function that every script must define.  It is called with the event
+	// and must return the (modified) event or ScriptDrop.
+	ScriptEntryPoint = "process"
+	// ScriptDrop is a predeclared value that a script returns to drop an event
+	ScriptDrop = "DROP"
+)
+
+// scriptDropValue is the value bound to ScriptDrop
+type scriptDropValue struct{}
+
+func (d scriptDropValue) String() string        { return ScriptDrop }
+func (d scriptDropValue) Type() string          { return "drop" }
+func (d scriptDropValue) Freeze()               {}
+func (d scriptDropValue) Truth() starlark.Bool  { return starlark.True }
+func (d scriptDropValue) Hash() (uint32, error) { return 0, nil }
+
+var scriptPredeclared = starlark.StringDict{
+	ScriptDrop: scriptDropValue{},
+	"json": starlarkjson.Module,
+}
+
+type ScriptOption func(script *Script)
+
+// WithScriptMaxSteps sets the maximum number of execution steps for each call to the script
+func WithScriptMaxSteps(maxSteps uint64) ScriptOption {
+	return func(script *Script) {
+		script.maxSteps = maxSteps
+	}
+}
+
+// WithScriptMaxEventBytes sets the maximum estimated size, in bytes, of the event passed to and returned
+// from the script.  This is not a memory limit: Starlark does not account for allocations, and a single
+// step can allocate a large value, so nothing bounds the memory allocated while the script runs.
+func WithScriptMaxEventBytes(maxBytes uint64) ScriptOption {
+	return func(script *Script) {
+		script.maxEventBytes = maxBytes
+	}
+}
+
+// Script is a precompiled Starlark program.  The program's globals are initialized and frozen once,
+// so a Script can be shared by any number of goroutines.
+type Script struct {
+	name string
+	maxSteps uint64
+	maxEventBytes uint64
+	process *starlark.Function
+}
+
+// NewScript will compile and initialize the script source.  The script must define
+// process(event), which returns the modified event or DROP.
+func NewScript(name string, src []byte, options ...ScriptOption) (*Script, error) {
+	script := &Script{
+		name: name,
+	}
+
+	for _, option := range options {
+		option(script)
+	}
+
+	_, program, err := starlark.SourceProgram(name, src, scriptPredeclared.Has)
+	if err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("script '%s' failed to compile: %s", name, err.Error()))
+	}
+
+	thread := script.newThread()
+	globals, err := program.Init(thread, scriptPredeclared)
+	if err != nil {
+		return nil, util.NewInvalidError(fmt.Sprintf("script '%s' failed to initialize: %s", name,
+			err.Error()))
+	}
+	globals.Freeze()
+
+	if process, ok := globals[ScriptEntryPoint].(*starlark.Function); ok {
+		script.process = process
+	} else {
+		msg := fmt.Sprintf("script '%s' must define %s(event)", name, ScriptEntryPoint)
+		return nil, util.NewInvalidError(msg)
+	}
+	return script, nil
+}
+
+func (script *Script) newThread() *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: script.name,
+		Load: func(thread *starlark.Thread, module string) (starlark.StringDict, error) {
+			return nil, fmt.Errorf("load is not supported in scripts")
+		},
+	}
+	if script.maxSteps > 0 {
+		thread.SetMaxExecutionSteps(script.maxSteps)
+	}
+	return thread
+}
+
+// Name returns the name of the script
+func (script *Script) Name() string {
+	return script.name
+}
+
+// Run will call the script on the input.  If the script drops the event, then the returned map is
+// nil and dropped is true.
+func (script *Script) Run(ctx context.Context, in map[string]interface{}) (out map[string]interface{},
+	dropped bool, err error) {
+	if err = script.checkEventSize(in); err != nil {
+		return nil, false, err
+	}
+
+	event, err := ToStarlark(in)
+	if err != nil {
+		return nil, false, err
+	}
+
+	thread := script.newThread()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-done:
+		}
+	}()
+
+	result, err := starlark.Call(thread, script.process, starlark.Tuple{event}, nil)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, false, util.NewTimedOutError(fmt.Sprintf("script '%s' timed out", script.name))
+		}
+		if script.maxSteps > 0 && thread.ExecutionSteps() >= script.maxSteps {
+			msg := fmt.Sprintf("script '%s' exceeded the step limit: %d", script.name, script.maxSteps)
+			return nil, false, util.NewOutOfBoundsError(msg)
+		}
+		return nil, false, util.NewInternalError(fmt.Sprintf("script '%s' failed: %s", script.name,
+			err.Error()))
+	}
+
+	if _, ok := result.(scriptDropValue); ok {
+		return nil, true, nil
+	}
+
+	outVal, err := FromStarlark(result)
+	if err != nil {
+		return nil, false, err
+	}
+	out, ok := outVal.(map[string]interface{})
+	if !ok {
+		msg := fmt.Sprintf("script '%s' must return a dict or %s, got %s", script.name, ScriptDrop,
+			result.Type())
+		return nil, false, util.NewInvalidError(msg)
+	}
+	if err = script.checkEventSize(out); err != nil {
+		return nil, false, err
+	}
+	return out, false, nil
+}
+
+func (script *Script) checkEventSize(v map[string]interface{}) error {
+	if script.maxEventBytes == 0 {
+		return nil
+	}
+	if size := estimateSize(v); size > script.maxEventBytes {
+		msg := fmt.Sprintf("script '%s' exceeded the event size limit: %d > %d bytes", script.name, size,
+			script.maxEventBytes)
+		return util.NewOutOfBoundsError(msg)
+	}
+	return nil
+}
+
+// estimateSize returns a rough estimate of the number of bytes used by a JSON-like value
+func estimateSize(v interface{}) uint64 {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		size := uint64(48)
+		for k, elem := range val {
+			size += uint64(len(k)) + 16 + estimateSize(elem)
+		}
+		return size
+	case []interface{}:
+		size := uint64(24)
+		for _, elem := range val {
+			size += estimateSize(elem)
+		}
+		return size
+	case string:
+		return uint64(len(val)) + 16
+	}
+	return 16
+}
+
+// ToStarlark will convert a JSON-like Go value to a Starlark value
+func ToStarlark(v interface{}) (starlark.Value, error) {
+	switch val := v.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(val), nil
+	case string:
+		return starlark.String(val), nil
+	case int:
+		return starlark.MakeInt(val), nil
+	case int64:
+		return starlark.MakeInt64(val), nil
+	case float64:
+		return starlark.Float(val), nil
+	case map[string]interface{}:
+		dict := starlark.NewDict(len(val))
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			elem, err := ToStarlark(val[k])
+			if err != nil {
+				return nil, err
+			}
+			if err = dict.SetKey(starlark.String(k), elem); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	case []interface{}:
+		elems := make([]starlark.Value, len(val))
+		for i, elem := range val {
+			var err error
+			if elems[i], err = ToStarlark(elem); err != nil {
+				return nil, err
+			}
+		}
+		return starlark.NewList(elems), nil
+	}
+	if numeric, err := util.GetNumeric(v); err == nil {
+		return starlark.Float(numeric), nil
+	}
+	msg := fmt.Sprintf("cannot convert %v to a script value", reflect.TypeOf(v))
+	return nil, util.NewInvalidError(msg)
+}
+
+// FromStarlark will convert a Starlark value to a JSON-like Go value.  Numbers are converted to
+// float64, to match values decoded from JSON.
+func FromStarlark(v starlark.Value) (interface{}, error) {
+	switch val := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(val), nil
+	case starlark.String:
+		return string(val), nil
+	case starlark.Int:
+		if i, ok := val.Int64(); ok {
+			return float64(i), nil
+		}
+		return val.Float(), nil
+	case starlark.Float:
+		if math.IsNaN(float64(val)) || math.IsInf(float64(val), 0) {
+			return nil, util.NewInvalidError(fmt.Sprintf("script returned a non-finite number: %v", val))
+		}
+		return float64(val), nil
+	case *starlark.Dict:
+		out := make(map[string]interface{}, val.Len())
+		for _, item := range val.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				msg := fmt.Sprintf("script dict keys must be strings, got %s", item[0].Type())
+				return nil, util.NewInvalidError(msg)
+			}
+			elem, err := FromStarlark(item[1])
+			if err != nil {
+				return nil, err
+			}
+			out[string(k)] = elem
+		}
+		return out, nil
+	case starlark.Indexable:
+		out := make([]interface{}, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			var err error
+			if out[i], err = FromStarlark(val.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	msg := fmt.Sprintf("cannot convert script value of type %s", v.Type())
+	return nil, util.NewInvalidError(msg)
+}
@@ -0,0 +1,114 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testScript = `
+def process(event):
+    if event.get("drop"):
+        return DROP
+    total = 0
+    for item in event.get("items", []):
+        total += item["price"]
+    event["total"] = total
+    event["tags"] = sorted(event.get("tags", []))
+    return event
+`
+
+func TestScript(t *testing.T) {
+	script, err := core.NewScript("test.star", []byte(testScript))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	in := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"price": float64(1.5)},
+			map[string]interface{}{"price": 2},
+		},
+		"tags": []interface{}{"b", "a"},
+		"name": "foo",
+		"empty": nil,
+	}
+
+	out, dropped, err := script.Run(context.Background(), in)
+	assert.Nil(t, err)
+	assert.False(t, dropped)
+	assert.Equal(t, float64(3.5), out["total"])
+	assert.Equal(t, []interface{}{"a", "b"}, out["tags"])
+	assert.Equal(t, "foo", out["name"])
+	assert.Nil(t, out["empty"])
+	assert.Equal(t, float64(2), out["items"].([]interface{})[1].(map[string]interface{})["price"])
+
+	out, dropped, err = script.Run(context.Background(), map[string]interface{}{"drop": true})
+	assert.Nil(t, err)
+	assert.True(t, dropped)
+	assert.Nil(t, out)
+}
+
+func TestScriptConcurrent(t *testing.T) {
+	script, err := core.NewScript("test.star", []byte(testScript))
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			out, _, err := script.Run(context.Background(), map[string]interface{}{
+				"items": []interface{}{map[string]interface{}{"price": i}},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, float64(i), out["total"])
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestScriptInvalid(t *testing.T) {
+	_, err := core.NewScript("bad.star", []byte("def process(event:\n"))
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+
+	_, err = core.NewScript("noentry.star", []byte("x = 1\n"))
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+
+	script, err := core.NewScript("badreturn.star", []byte("def process(event):\n    return 1\n"))
+	assert.Nil(t, err)
+	_, _, err = script.Run(context.Background(), map[string]interface{}{})
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+
+	script, err = core.NewScript("fail.star", []byte("def process(event):\n    return event['missing']\n"))
+	assert.Nil(t, err)
+	_, _, err = script.Run(context.Background(), map[string]interface{}{})
+	assert.Error(t, err)
+}
+
+func TestScriptLimits(t *testing.T) {
+	loop := "def process(event):\n    for i in range(1000000):\n        event['i'] = i\n    return event\n"
+	script, err := core.NewScript("loop.star", []byte(loop), core.WithScriptMaxSteps(1000))
+	assert.Nil(t, err)
+	_, _, err = script.Run(context.Background(), map[string]interface{}{})
+	assert.True(t, errors.As(err, new(*util.OutOfBoundsError)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	loop = "def process(event):\n    for i in range(100000000):\n        event['i'] = i\n    return event\n"
+	script, err = core.NewScript("loop.star", []byte(loop))
+	assert.Nil(t, err)
+	_, _, err = script.Run(ctx, map[string]interface{}{})
+	assert.True(t, errors.Is(err, &util.TimedOutError{}))
+
+	grow := "def process(event):\n    event['big'] = 'x' * 4096\n    return event\n"
+	script, err = core.NewScript("grow.star", []byte(grow), core.WithScriptMaxEventBytes(1024))
+	assert.Nil(t, err)
+	_, _, err = script.Run(context.Background(), map[string]interface{}{})
+	assert.True(t, errors.As(err, new(*util.OutOfBoundsError)))
+}
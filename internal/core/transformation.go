@@ -2,11 +2,13 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/kmgreen2/agglo/internal/common"
 	"github.com/kmgreen2/agglo/pkg/util"
 	"reflect"
 	"regexp"
+	"strings"
 )
 
 type Transformable struct {
@@ -96,7 +98,13 @@ type MapTransformation struct {
 }
 
 func NewExecMapTransformation(path string, cmdArgs ...string) *MapTransformation {
-	execRunnable := util.NewExecRunnable(util.WithPath(path), util.WithCmdArgs(cmdArgs...))
+	return NewSandboxedExecMapTransformation(nil, path, cmdArgs...)
+}
+
+// NewSandboxedExecMapTransformation will map using a command that runs in the sandbox
+func NewSandboxedExecMapTransformation(sandbox *util.Sandbox, path string, cmdArgs ...string) *MapTransformation {
+	execRunnable := util.NewExecRunnable(util.WithPath(path), util.WithCmdArgs(cmdArgs...),
+		util.WithSandbox(sandbox))
 
 	return &MapTransformation{
 		func (in interface{}) (interface{}, error) {
@@ -109,6 +117,15 @@ func NewExecMapTransformation(path string, cmdArgs ...string) *MapTransformation
 	}
 }
 
+// NewCoProcessMapTransformation will map each value using a pool of long-lived workers
+func NewCoProcessMapTransformation(pool *util.CoProcessPool) *MapTransformation {
+	return &MapTransformation{
+		func (in interface{}) (interface{}, error) {
+			return pool.Call(context.Background(), in)
+		},
+	}
+}
+
 func (t MapTransformation) Transform(in *Transformable) (*Transformable, error) {
 	if in.Kind() == reflect.Slice {
 		slice := in.Value().([]interface{})
@@ -145,28 +162,49 @@ type LeftFoldTransformation struct {
 	FoldFunc func(acc, v interface{}) (interface{}, error)
 }
 
+// execFoldFunc will add the accumulator to each map element, using the internal accumulator key,
+// before passing it to an external executable
+func execFoldFunc(call func(interface{}) (interface{}, error)) func(acc, v interface{}) (interface{}, error) {
+	return func (acc, in interface{}) (interface{}, error) {
+		switch val := in.(type) {
+		case map[string]interface{}:
+			if err := common.SetUsingInternalKey(common.AccumulatorKey, acc, val, false); err != nil {
+				return nil, util.NewInvalidError(err.Error())
+			}
+		default:
+			msg := fmt.Sprintf("expected map[string]interface{} argument to fold.  Got %v",
+				reflect.TypeOf(val))
+			return nil, util.NewInvalidError(msg)
+		}
+		return call(in)
+	}
+}
+
 func NewExecLeftFoldTransformation(path string) *LeftFoldTransformation {
-	execRunnable := util.NewExecRunnable(util.WithPath(path))
+	return NewSandboxedExecLeftFoldTransformation(nil, path)
+}
 
-	return &LeftFoldTransformation{
-		func (acc, in interface{}) (interface{}, error) {
-			switch val := in.(type) {
-			case map[string]interface{}:
-				if err := common.SetUsingInternalKey(common.AccumulatorKey, acc, val, false); err != nil {
-					return nil, util.NewInvalidError(err.Error())
-				}
-			default:
-				msg := fmt.Sprintf("expected map[string]interface{} argument to fold.  Got %v",
-					reflect.TypeOf(val))
-				return nil, util.NewInvalidError(msg)
-			}
+// NewSandboxedExecLeftFoldTransformation will fold using a command that runs in the sandbox
+func NewSandboxedExecLeftFoldTransformation(sandbox *util.Sandbox, path string) *LeftFoldTransformation {
+	execRunnable := util.NewExecRunnable(util.WithPath(path), util.WithSandbox(sandbox))
 
+	return &LeftFoldTransformation{
+		execFoldFunc(func(in interface{}) (interface{}, error) {
 			err := execRunnable.SetInData(in)
 			if err != nil {
 				return nil, err
 			}
 			return execRunnable.Run(context.Background())
-		},
+		}),
+	}
+}
+
+// NewCoProcessLeftFoldTransformation will fold using a pool of long-lived workers
+func NewCoProcessLeftFoldTransformation(pool *util.CoProcessPool) *LeftFoldTransformation {
+	return &LeftFoldTransformation{
+		execFoldFunc(func(in interface{}) (interface{}, error) {
+			return pool.Call(context.Background(), in)
+		}),
 	}
 }
 
@@ -181,7 +219,7 @@ func (t LeftFoldTransformation) Transform(in *Transformable) (*Transformable, er
 				return nil, err
 			}
 		}
-		return &Transformable{acc}, nil
+		return &Transformable{foldResult(acc)}, nil
 	} else {
 		return nil, fmt.Errorf("")
 	}
@@ -192,27 +230,30 @@ type RightFoldTransformation struct {
 }
 
 func NewExecRightFoldTransformation(path string) *RightFoldTransformation {
-	execRunnable := util.NewExecRunnable(util.WithPath(path))
+	return NewSandboxedExecRightFoldTransformation(nil, path)
+}
 
-	return &RightFoldTransformation{
-		func (acc, in interface{}) (interface{}, error) {
-			switch val := in.(type) {
-			case map[string]interface{}:
-				if err := common.SetUsingInternalKey(common.AccumulatorKey, acc, val, false); err != nil {
-					return nil, util.NewInvalidError(err.Error())
-				}
-			default:
-				msg := fmt.Sprintf("expected map[string]interface{} argument to fold.  Got %v",
-					reflect.TypeOf(val))
-				return nil, util.NewInvalidError(msg)
-			}
+// NewSandboxedExecRightFoldTransformation will fold using a command that runs in the sandbox
+func NewSandboxedExecRightFoldTransformation(sandbox *util.Sandbox, path string) *RightFoldTransformation {
+	execRunnable := util.NewExecRunnable(util.WithPath(path), util.WithSandbox(sandbox))
 
+	return &RightFoldTransformation{
+		execFoldFunc(func(in interface{}) (interface{}, error) {
 			err := execRunnable.SetInData(in)
 			if err != nil {
 				return nil, err
 			}
 			return execRunnable.Run(context.Background())
-		},
+		}),
+	}
+}
+
+// NewCoProcessRightFoldTransformation will fold using a pool of long-lived workers
+func NewCoProcessRightFoldTransformation(pool *util.CoProcessPool) *RightFoldTransformation {
+	return &RightFoldTransformation{
+		execFoldFunc(func(in interface{}) (interface{}, error) {
+			return pool.Call(context.Background(), in)
+		}),
 	}
 }
 
@@ -227,7 +268,7 @@ func (t RightFoldTransformation) Transform(in *Transformable) (*Transformable, e
 				return nil, err
 			}
 		}
-		return &Transformable{acc}, nil
+		return &Transformable{foldResult(acc)}, nil
 	} else {
 		return nil, fmt.Errorf("")
 	}
@@ -289,7 +330,7 @@ func foldMaxFunc(acc, v interface{}) (interface{}, error) {
 func foldCountFunc(matcher func(interface{}) bool) func (acc, v interface{}) (interface{}, error) {
 	return func(acc, v interface{}) (interface{}, error) {
 		if acc == nil {
-			acc = 0
+			acc = float64(0)
 			if matcher(v) {
 				acc = float64(1)
 			}
@@ -306,6 +347,262 @@ func foldCountFunc(matcher func(interface{}) bool) func (acc, v interface{}) (in
 	}
 }
 
+func foldSumFunc(acc, v interface{}) (interface{}, error) {
+	if acc == nil {
+		acc = float64(0)
+	}
+	accVal, vVal, err := util.NumericResolver(acc, v)
+	if err != nil {
+		return 0, err
+	}
+	return accVal + vVal, nil
+}
+
+// resultAccumulator is an accumulator that needs a final computation once every element
+// has been folded (e.g. the average of the elements)
+type resultAccumulator interface {
+	Result() interface{}
+}
+
+func foldResult(acc interface{}) interface{} {
+	if r, ok := acc.(resultAccumulator); ok {
+		return r.Result()
+	}
+	return acc
+}
+
+type avgAccumulator struct {
+	sum float64
+	num float64
+}
+
+func (a *avgAccumulator) Result() interface{} {
+	return a.sum / a.num
+}
+
+func foldAvgFunc(acc, v interface{}) (interface{}, error) {
+	vVal, err := util.GetNumeric(v)
+	if err != nil {
+		return 0, err
+	}
+	avgAcc, ok := acc.(*avgAccumulator)
+	if !ok {
+		avgAcc = &avgAccumulator{}
+	}
+	avgAcc.sum += vVal
+	avgAcc.num++
+	return avgAcc, nil
+}
+
+type concatAccumulator struct {
+	separator string
+	values []string
+}
+
+func (a *concatAccumulator) Result() interface{} {
+	return strings.Join(a.values, a.separator)
+}
+
+// foldConcatFunc will concatenate list elements into a single list and join all other
+// elements into a string using the separator
+func foldConcatFunc(separator string) func(acc, v interface{}) (interface{}, error) {
+	return func(acc, v interface{}) (interface{}, error) {
+		switch vVal := v.(type) {
+		case []interface{}:
+			if acc == nil {
+				acc = make([]interface{}, 0)
+			}
+			if accVal, ok := acc.([]interface{}); ok {
+				return append(accVal, vVal...), nil
+			}
+		default:
+			if acc == nil {
+				acc = &concatAccumulator{separator: separator}
+			}
+			if accVal, ok := acc.(*concatAccumulator); ok {
+				accVal.values = append(accVal.values, fmt.Sprintf("%v", vVal))
+				return accVal, nil
+			}
+		}
+		msg := fmt.Sprintf("cannot concat lists and non-list values, got %v", reflect.TypeOf(v))
+		return nil, util.NewInvalidError(msg)
+	}
+}
+
+func distinctKey(v interface{}) (string, error) {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	return fmt.Sprintf("%T:%v", v, v), nil
+}
+
+type distinctAccumulator struct {
+	seen map[string]bool
+	values []interface{}
+}
+
+func (a *distinctAccumulator) Result() interface{} {
+	return a.values
+}
+
+func foldDistinctFunc(acc, v interface{}) (interface{}, error) {
+	distinctAcc, ok := acc.(*distinctAccumulator)
+	if !ok {
+		distinctAcc = &distinctAccumulator{seen: make(map[string]bool), values: make([]interface{}, 0)}
+	}
+	key, err := distinctKey(v)
+	if err != nil {
+		return nil, err
+	}
+	if !distinctAcc.seen[key] {
+		distinctAcc.seen[key] = true
+		distinctAcc.values = append(distinctAcc.values, v)
+	}
+	return distinctAcc, nil
+}
+
+type firstAccumulator struct {
+	value interface{}
+}
+
+func (a *firstAccumulator) Result() interface{} {
+	return a.value
+}
+
+func foldFirstFunc(matcher func(interface{}) bool) func (acc, v interface{}) (interface{}, error) {
+	return func(acc, v interface{}) (interface{}, error) {
+		if acc != nil {
+			return acc, nil
+		}
+		if matcher(v) {
+			return &firstAccumulator{v}, nil
+		}
+		return nil, nil
+	}
+}
+
+func foldLastFunc(matcher func(interface{}) bool) func (acc, v interface{}) (interface{}, error) {
+	return func(acc, v interface{}) (interface{}, error) {
+		if matcher(v) {
+			return v, nil
+		}
+		return acc, nil
+	}
+}
+
+// ConditionMatcher returns a matcher that evaluates the condition against each element of a fold.
+// Map elements are evaluated directly; any other element is evaluated as {"value": element}.  A nil
+// condition matches everything.
+func ConditionMatcher(condition *Condition) func(interface{}) bool {
+	if condition == nil {
+		condition = TrueCondition
+	}
+	return func(v interface{}) bool {
+		var in map[string]interface{}
+		switch vVal := v.(type) {
+		case map[string]interface{}:
+			in = vVal
+		default:
+			in = map[string]interface{}{"value": v}
+		}
+		matched, err := condition.Evaluate(in)
+		return err == nil && matched
+	}
+}
+
+type FoldType int
+
+const (
+	FoldMin FoldType = iota
+	FoldMax
+	FoldCount
+	FoldSum
+	FoldAvg
+	FoldConcat
+	FoldDistinct
+	FoldFirst
+	FoldLast
+)
+
+func (t FoldType) String() string {
+	switch t {
+	case FoldMin:
+		return "FoldMin"
+	case FoldMax:
+		return "FoldMax"
+	case FoldCount:
+		return "FoldCount"
+	case FoldSum:
+		return "FoldSum"
+	case FoldAvg:
+		return "FoldAvg"
+	case FoldConcat:
+		return "FoldConcat"
+	case FoldDistinct:
+		return "FoldDistinct"
+	case FoldFirst:
+		return "FoldFirst"
+	case FoldLast:
+		return "FoldLast"
+	}
+	return "Unknown"
+}
+
+// FoldSpec describes a built-in fold.  Condition is used by FoldCount, FoldFirst and FoldLast
+// to select elements and Separator is used by FoldConcat to join non-list elements.
+type FoldSpec struct {
+	Type FoldType
+	Condition *Condition
+	Separator string
+}
+
+func (spec FoldSpec) foldFunc() (func(acc, v interface{}) (interface{}, error), error) {
+	switch spec.Type {
+	case FoldMin:
+		return foldMinFunc, nil
+	case FoldMax:
+		return foldMaxFunc, nil
+	case FoldCount:
+		return foldCountFunc(ConditionMatcher(spec.Condition)), nil
+	case FoldSum:
+		return foldSumFunc, nil
+	case FoldAvg:
+		return foldAvgFunc, nil
+	case FoldConcat:
+		return foldConcatFunc(spec.Separator), nil
+	case FoldDistinct:
+		return foldDistinctFunc, nil
+	case FoldFirst:
+		return foldFirstFunc(ConditionMatcher(spec.Condition)), nil
+	case FoldLast:
+		return foldLastFunc(ConditionMatcher(spec.Condition)), nil
+	}
+	return nil, util.NewInvalidError(fmt.Sprintf("invalid fold type: %v", spec.Type))
+}
+
+// NewLeftFoldTransformation will create a left fold using one of the built-in fold functions
+func NewLeftFoldTransformation(spec FoldSpec) (*LeftFoldTransformation, error) {
+	foldFunc, err := spec.foldFunc()
+	if err != nil {
+		return nil, err
+	}
+	return &LeftFoldTransformation{foldFunc}, nil
+}
+
+// NewRightFoldTransformation will create a right fold using one of the built-in fold functions
+func NewRightFoldTransformation(spec FoldSpec) (*RightFoldTransformation, error) {
+	foldFunc, err := spec.foldFunc()
+	if err != nil {
+		return nil, err
+	}
+	return &RightFoldTransformation{foldFunc}, nil
+}
+
 // The fold functions are mostly for illustration.
 // ToDo: Need to figure out the best way to serialize
 // and generalize the matcher functions, so more useful
@@ -203,6 +203,100 @@ func TestFoldMax(t *testing.T) {
 	doTestFolds(t, testSlice, float64(7), float64(7), core.LeftFoldMax, core.RightFoldMax)
 }
 
+func TestCoProcessTransformations(t *testing.T) {
+	pool := util.NewCoProcessPool("cat")
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	builder := core.NewTransformationBuilder()
+	builder.AddFieldTransformation(core.NewCoProcessMapTransformation(pool))
+	result, err := builder.Get().Transform(core.NewTransformable([]interface{}{"a", "b", "c"}))
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, result.Value())
+
+	// cat returns each element, with the accumulator, so the fold result is the last element
+	testSlice := []interface{} {
+		map[string]interface{}{"a": float64(1)},
+		map[string]interface{}{"a": float64(2)},
+	}
+	builder = core.NewTransformationBuilder()
+	builder.AddFieldTransformation(core.NewCoProcessLeftFoldTransformation(pool))
+	result, err = builder.Get().Transform(core.NewTransformable(testSlice))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(2), result.Value().(map[string]interface{})["a"])
+}
+
+func doTestBuiltinFolds(t *testing.T, testSlice []interface{}, expectedLeft, expectedRight interface{},
+	spec core.FoldSpec) {
+	left, err := core.NewLeftFoldTransformation(spec)
+	assert.Nil(t, err)
+	right, err := core.NewRightFoldTransformation(spec)
+	assert.Nil(t, err)
+	doTestFolds(t, testSlice, expectedLeft, expectedRight, left, right)
+}
+
+func TestFoldBuiltins(t *testing.T) {
+	testSlice := []interface{} {
+		1,
+		5,
+		7,
+		5,
+	}
+
+	doTestBuiltinFolds(t, testSlice, float64(18), float64(18), core.FoldSpec{Type: core.FoldSum})
+	doTestBuiltinFolds(t, testSlice, float64(4.5), float64(4.5), core.FoldSpec{Type: core.FoldAvg})
+	doTestBuiltinFolds(t, testSlice, "1,5,7,5", "5,7,5,1", core.FoldSpec{Type: core.FoldConcat,
+		Separator: ","})
+	doTestBuiltinFolds(t, testSlice, []interface{}{1, 5, 7}, []interface{}{5, 7, 1},
+		core.FoldSpec{Type: core.FoldDistinct})
+
+	listSlice := []interface{} {
+		[]interface{}{1, 2},
+		[]interface{}{3},
+	}
+	doTestBuiltinFolds(t, listSlice, []interface{}{1, 2, 3}, []interface{}{3, 1, 2},
+		core.FoldSpec{Type: core.FoldConcat})
+}
+
+func TestFoldConditions(t *testing.T) {
+	testSlice := []interface{} {
+		map[string]interface{}{"name": "a", "price": 1},
+		map[string]interface{}{"name": "b", "price": 10},
+		map[string]interface{}{"name": "c", "price": 20},
+		map[string]interface{}{"name": "d"},
+	}
+
+	expression := core.NewComparatorExpression(core.Variable("price"), 5, core.GreaterThan)
+	condition, err := core.NewCondition(expression)
+	assert.Nil(t, err)
+
+	doTestBuiltinFolds(t, testSlice, float64(2), float64(2), core.FoldSpec{Type: core.FoldCount,
+		Condition: condition})
+	doTestBuiltinFolds(t, testSlice, testSlice[1], testSlice[2], core.FoldSpec{Type: core.FoldFirst,
+		Condition: condition})
+	doTestBuiltinFolds(t, testSlice, testSlice[2], testSlice[1], core.FoldSpec{Type: core.FoldLast,
+		Condition: condition})
+
+	expression = core.NewComparatorExpression(core.Variable("value"), 5, core.LessThan)
+	condition, err = core.NewCondition(expression)
+	assert.Nil(t, err)
+	doTestBuiltinFolds(t, []interface{}{1, 5, 7, 3}, float64(2), float64(2),
+		core.FoldSpec{Type: core.FoldCount, Condition: condition})
+}
+
+func TestFoldInvalid(t *testing.T) {
+	_, err := core.NewLeftFoldTransformation(core.FoldSpec{Type: core.FoldType(100)})
+	assert.Error(t, err)
+
+	left, err := core.NewLeftFoldTransformation(core.FoldSpec{Type: core.FoldConcat})
+	assert.Nil(t, err)
+	builder := core.NewTransformationBuilder()
+	builder.AddFieldTransformation(left)
+	_, err = builder.Get().Transform(core.NewTransformable([]interface{}{"a", []interface{}{"b"}}))
+	assert.Error(t, err)
+}
+
 func TestSumTransformation(t *testing.T) {
 	testSlice := []interface{} {
 		10,
@@ -5,16 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	gUuid "github.com/google/uuid"
-	"github.com/kmgreen2/agglo/internal/common"
 	"github.com/kmgreen2/agglo/internal/core/process"
 	"github.com/kmgreen2/agglo/pkg/util"
-	"golang.org/x/net/netutil"
 	"github.com/pkg/errors"
-	"io/ioutil"
 	"go.uber.org/zap"
-	"net"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -24,9 +20,14 @@ type Daemon interface {
 	IsShutdown() bool
 }
 
+// RunPipelines runs the event through the pipelines of its source (every pipeline, by default)
 func RunPipelines(in map[string]interface{}, pipelines *process.Pipelines, logger *zap.Logger) error {
 	var err *util.PipelineError = nil
+	names := sourcePipelines(in, pipelines)
 	for _, pipeline := range pipelines.Underlying() {
+		if names != nil && !names[pipeline.Name()] {
+			continue
+		}
 		future := pipeline.RunAsync(in, process.WithLogger(logger))
 		result := future.Get()
 		if result.Error() != nil && !util.IsWarning(result.Error()) {
@@ -44,39 +45,52 @@ func RunPipelines(in map[string]interface{}, pipelines *process.Pipelines, logge
 }
 
 type StatelessDaemon struct {
-	srv *http.Server
 	maintenanceSrv *http.Server
 	pipelines *process.Pipelines
+	sources []*process.PipelineSource
 	logger *zap.Logger
-	daemonPort int
-	daemonPath string
 	maintenancePort int
 	maintenancePath string
-	maxConnections int
+	done chan struct{}
+	// lock protects shutdown and isRunning, which are read and written by Run, Shutdown and IsShutdown
+	lock sync.Mutex
 	shutdown bool
 	isRunning bool
 }
 
+// NewStatelessDaemon returns a daemon that runs the pipelines for the events of each source.  Events are
+// also POSTed to daemonPath on daemonPort, unless sources are configured and daemonPort is 0.
 func NewStatelessDaemon(daemonPort, maintenancePort int, daemonPath, maintenancePath string, maxConnections int,
 	pipelines *process.Pipelines) (Daemon, error) {
+	sources, err := daemonSources(pipelines, daemonPort, daemonPath, maxConnections)
+	if err != nil {
+		return nil, err
+	}
+	return newStatelessDaemon(maintenancePort, maintenancePath, sources, pipelines)
+}
+
+func newStatelessDaemon(maintenancePort int, maintenancePath string, sources []*process.PipelineSource,
+	pipelines *process.Pipelines) (*StatelessDaemon, error) {
 	logger, err := zap.NewProduction()
 	if err != nil {
 		return nil, err
 	}
 	return &StatelessDaemon{
 		pipelines: pipelines,
+		sources: sources,
 		logger: logger,
-		daemonPath: daemonPath,
-		daemonPort: daemonPort,
 		maintenancePath: maintenancePath,
 		maintenancePort: maintenancePort,
-		maxConnections: maxConnections,
+		done: make(chan struct{}),
 	}, nil
 }
 
-func setMaintenanceServerHandlers(path string, pipelines *process.Pipelines, logger *zap.Logger) {
+func setMaintenanceServerHandlers(mux *http.ServeMux, path string, pipelines *process.Pipelines,
+	sources []*process.PipelineSource, logger *zap.Logger) {
+	setSourceHandlers(mux, path, sources, logger)
+
 	checkPointsEndpoint := path + "/checkpoints"
-	http.HandleFunc(checkPointsEndpoint, func(resp http.ResponseWriter, req *http.Request) {
+	mux.HandleFunc(checkPointsEndpoint, func(resp http.ResponseWriter, req *http.Request) {
 		if req.Method == "GET" || len(req.Method) == 0 {
 			queryParams := req.URL.Query()
 			messageID := queryParams.Get("messageId")
@@ -116,95 +130,63 @@ func setMaintenanceServerHandlers(path string, pipelines *process.Pipelines, log
 	})
 }
 
-func (d *StatelessDaemon) runMaintenanceServer() error {
-	d.maintenanceSrv = &http.Server{Addr: fmt.Sprintf(":%d", d.maintenancePort)}
-
-	setMaintenanceServerHandlers(d.maintenancePath, d.pipelines, d.logger)
-
-	return d.maintenanceSrv.ListenAndServe()
+func (d *StatelessDaemon) newMaintenanceServer() *http.Server {
+	mux := http.NewServeMux()
+	setMaintenanceServerHandlers(mux, d.maintenancePath, d.pipelines, d.sources, d.logger)
+	return &http.Server{Addr: fmt.Sprintf(":%d", d.maintenancePort), Handler: mux}
 }
 
+// Run will run the sources until Shutdown is called or a source fails
 func (d *StatelessDaemon) Run() error {
-	l, err := net.Listen("tcp", fmt.Sprintf(":%d", d.daemonPort))
-	if err != nil {
-		return err
-	}
+	defer close(d.done)
 
-	defer l.Close()
-
-	l = netutil.LimitListener(l, d.maxConnections)
+	d.lock.Lock()
+	if d.shutdown {
+		d.lock.Unlock()
+		return nil
+	}
+	d.maintenanceSrv = d.newMaintenanceServer()
+	d.isRunning = true
+	d.lock.Unlock()
 
 	go func() {
-		_ = d.runMaintenanceServer()
+		_ = d.maintenanceSrv.ListenAndServe()
 	}()
 
-	d.srv = &http.Server{}
-
-	http.HandleFunc(d.daemonPath, func(resp http.ResponseWriter, req *http.Request) {
-		begin := time.Now()
-		defer func() {
-			elapsed := time.Now().Sub(begin)
-			d.logger.Info(fmt.Sprintf("Http handler: %d ms", elapsed.Milliseconds()))
-		}()
-		bodyBytes, err := ioutil.ReadAll(req.Body)
-		if err != nil {
-			resp.WriteHeader(400)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-		in, err := util.JsonToMap(bodyBytes)
-		if err != nil {
-			resp.WriteHeader(400)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-
-		// A globally unique ID is defined which will allow the re-player to fetch the
-		// checkpoint state, prior to replaying, among other future uses.
-		// It is also assumed that each pipeline mix in its own name to ensure the
-		// there are no conflicts with checkpoint state
-		messageID, err := gUuid.NewRandom()
-		if err != nil {
-			resp.WriteHeader(500)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-
-		common.MustSetUsingInternalKey(common.MessageIDKey, messageID.String(), in)
-
-		err = RunPipelines(in, d.pipelines, d.logger)
-		if err != nil {
-			resp.WriteHeader(500)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-
-		resp.WriteHeader(200)
-		_, _ = resp.Write([]byte("Success"))
-		return
-	})
-
-	d.isRunning = true
-	return d.srv.Serve(l)
+	return runSources(d.sources, func(ctx context.Context, in map[string]interface{}) error {
+		return RunPipelines(in, d.pipelines, d.logger)
+	}, d.logger)
 }
 
 func (d *StatelessDaemon) IsShutdown() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	return d.shutdown && !d.isRunning
 }
 
+// Shutdown will stop the sources, after the events that are being processed, and then shut down the
+// pipelines
 func (d *StatelessDaemon) Shutdown() error {
+	d.lock.Lock()
 	d.shutdown = true
-	defer func(){d.isRunning = false}()
+	isRunning := d.isRunning
+	d.lock.Unlock()
+	defer func() {
+		d.lock.Lock()
+		d.isRunning = false
+		d.lock.Unlock()
+	}()
+
 	d.logger.Info("shutting down daemon...")
-	if err := d.srv.Shutdown(context.Background()); err != nil {
-		d.logger.Error(err.Error())
+	stopSources(d.sources, d.logger)
+	if isRunning {
+		<-d.done
 	}
-	if err := d.maintenanceSrv.Shutdown(context.Background()); err != nil {
-		d.logger.Error(err.Error())
+	closeSources(d.sources, d.logger)
+	if d.maintenanceSrv != nil {
+		if err := d.maintenanceSrv.Shutdown(context.Background()); err != nil {
+			d.logger.Error(err.Error())
+		}
 	}
 	if err := d.pipelines.Shutdown(); err != nil {
 		d.logger.Error(err.Error())
@@ -214,23 +196,27 @@ func (d *StatelessDaemon) Shutdown() error {
 }
 
 type DurableDaemon struct {
-	srv *http.Server
 	pipelines *process.Pipelines
+	sources []*process.PipelineSource
 	maintenanceSrv *http.Server
 	logger *zap.Logger
-	daemonPort int
-	daemonPath string
 	maintenancePort int
 	maintenancePath string
-	maxConnections int
 	numThreads int
 	dQueue *util.DurableQueue
+	// waiterChannel wakes the worker loop when an event is queued or the daemon shuts down
 	waiterChannel chan bool
-	workerSleeping bool
+	done chan struct{}
+	// lock protects shutdown and isRunning, which are read and written by Run, Shutdown, IsShutdown and
+	// the worker loop
+	lock sync.Mutex
 	shutdown bool
 	isRunning bool
 }
 
+// NewDurableDaemon returns a daemon that queues the events of each source, and runs the pipelines for the
+// queued events.  Events are also POSTed to daemonPath on daemonPort, unless sources are configured and
+// daemonPort is 0.
 func NewDurableDaemon(daemonPort, maintenancePort int, daemonPath, maintenancePath string, maxConnections,
 	numThreads int,
 	dQueue *util.DurableQueue, pipelines *process.Pipelines) (Daemon, error) {
@@ -238,17 +224,20 @@ func NewDurableDaemon(daemonPort, maintenancePort int, daemonPath, maintenancePa
 	if err != nil {
 		return nil, err
 	}
+	sources, err := daemonSources(pipelines, daemonPort, daemonPath, maxConnections)
+	if err != nil {
+		return nil, err
+	}
 	return &DurableDaemon{
 		pipelines: pipelines,
+		sources: sources,
 		logger: logger,
-		daemonPath: daemonPath,
-		daemonPort: daemonPort,
 		maintenancePath: maintenancePath,
 		maintenancePort: maintenancePort,
 		numThreads: numThreads,
-		maxConnections: maxConnections,
 		dQueue: dQueue,
 		waiterChannel: make(chan bool, 1),
+		done: make(chan struct{}),
 	}, nil
 }
 
@@ -257,7 +246,7 @@ func (d *DurableDaemon) startWorkerLoop() {
 		threadChannel := make(chan bool, d.numThreads)
 		for {
 
-			if d.shutdown == true {
+			if d.isShuttingDown() {
 				break
 			}
 
@@ -265,13 +254,12 @@ func (d *DurableDaemon) startWorkerLoop() {
 			if err != nil {
 				// If the queue is empty, go to sleep until the producer queues up more messages
 				if errors.Is(err, &util.EmptyQueue{}) {
-					d.workerSleeping = true
 					<- d.waiterChannel
-					d.workerSleeping = false
-					continue
 				} else {
 					d.logger.Error(err.Error())
+					d.waitForWork(time.Second)
 				}
+				continue
 			}
 
 			begin := time.Now()
@@ -302,13 +290,37 @@ func (d *DurableDaemon) startWorkerLoop() {
 	}()
 }
 
-func (d *DurableDaemon) runMaintenanceServer() error {
-	d.maintenanceSrv = &http.Server{Addr: fmt.Sprintf(":%d", d.maintenancePort)}
+func (d *DurableDaemon) isShuttingDown() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.shutdown
+}
 
-	setMaintenanceServerHandlers(d.maintenancePath, d.pipelines, d.logger)
+// wake will wake the worker loop, if it is waiting.  The channel holds one wake-up, so an event queued
+// while the loop is running is not missed.
+func (d *DurableDaemon) wake() {
+	select {
+	case d.waiterChannel <- true:
+	default:
+	}
+}
+
+// waitForWork will wait until the worker loop is woken or the timeout elapses
+func (d *DurableDaemon) waitForWork(timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-d.waiterChannel:
+	case <-timer.C:
+	}
+}
+
+func (d *DurableDaemon) newMaintenanceServer() *http.Server {
+	mux := http.NewServeMux()
+	setMaintenanceServerHandlers(mux, d.maintenancePath, d.pipelines, d.sources, d.logger)
 
 	durableQueueHandler := d.maintenancePath + "/queue"
-	http.HandleFunc(durableQueueHandler, func(resp http.ResponseWriter, req *http.Request) {
+	mux.HandleFunc(durableQueueHandler, func(resp http.ResponseWriter, req *http.Request) {
 		respMap := make(map[string]interface{})
 		var unprocessed []map[string]interface{}
 		var inflight  []map[string]interface{}
@@ -375,105 +387,78 @@ func (d *DurableDaemon) runMaintenanceServer() error {
 		_, _ = resp.Write(respJson)
 	})
 
-	return d.maintenanceSrv.ListenAndServe()
+	return &http.Server{Addr: fmt.Sprintf(":%d", d.maintenancePort), Handler: mux}
 }
 
-func (d *DurableDaemon) Run() error {
-	l, err := net.Listen("tcp", fmt.Sprintf(":%d", d.daemonPort))
+// enqueue will durably queue an event, to be processed by the workers
+func (d *DurableDaemon) enqueue(ctx context.Context, in map[string]interface{}) error {
+	inBytes, err := util.MapToJson(in)
 	if err != nil {
 		return err
 	}
 
-	defer l.Close()
+	err = d.dQueue.Enqueue(inBytes)
+	if err != nil {
+		return err
+	}
 
-	l = netutil.LimitListener(l, d.maxConnections)
+	// If the worker thread is sleeping, wake it up
+	d.wake()
+	return nil
+}
 
-	d.srv = &http.Server{}
+// Run will run the sources until Shutdown is called or a source fails
+func (d *DurableDaemon) Run() error {
+	defer close(d.done)
+
+	d.lock.Lock()
+	if d.shutdown {
+		d.lock.Unlock()
+		return nil
+	}
+	d.maintenanceSrv = d.newMaintenanceServer()
+	d.isRunning = true
+	d.lock.Unlock()
 
 	go func() {
-		_ = d.runMaintenanceServer()
+		_ = d.maintenanceSrv.ListenAndServe()
 	}()
 
 	d.startWorkerLoop()
 
-	http.HandleFunc(d.daemonPath, func(resp http.ResponseWriter, req *http.Request) {
-		begin := time.Now()
-		defer func() {
-			elapsed := time.Now().Sub(begin)
-			d.logger.Info(fmt.Sprintf("Http handler: %d ms", elapsed.Milliseconds()))
-		}()
-		bodyBytes, err := ioutil.ReadAll(req.Body)
-		if err != nil {
-			resp.WriteHeader(400)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-		in, err := util.JsonToMap(bodyBytes)
-		if err != nil {
-			resp.WriteHeader(400)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-
-		// A globally unique ID is defined which will allow the re-player to fetch the
-		// checkpoint state, prior to replaying, among other future uses.
-		// It is also assumed that each pipeline mix in its own name to ensure the
-		// there are no conflicts with checkpoint state
-		messageID, err := gUuid.NewRandom()
-		if err != nil {
-			resp.WriteHeader(500)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-
-		common.MustSetUsingInternalKey(common.MessageIDKey, messageID.String(), in)
-
-		inBytes, err := util.MapToJson(in)
-		if err != nil {
-			resp.WriteHeader(500)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-
-		err = d.dQueue.Enqueue(inBytes)
-		if err != nil {
-			resp.WriteHeader(500)
-			_, _ = resp.Write([]byte(err.Error()))
-			d.logger.Error(err.Error())
-			return
-		}
-
-		// If the worker thread is sleeping, wake it up
-		if d.workerSleeping {
-			d.waiterChannel <- true
-		}
-
-		resp.WriteHeader(200)
-		_, _ = resp.Write([]byte("Success"))
-		return
-	})
-
-	d.isRunning = true
-	return d.srv.Serve(l)
+	return runSources(d.sources, d.enqueue, d.logger)
 }
 
 func (d *DurableDaemon) IsShutdown() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	return d.shutdown && !d.isRunning
 }
 
+// Shutdown will stop the sources, after the events that are being queued, and then close the queue and
+// shut down the pipelines
 func (d *DurableDaemon) Shutdown() error {
+	d.lock.Lock()
 	d.shutdown = true
-	defer func(){d.isRunning = false}()
+	isRunning := d.isRunning
+	d.lock.Unlock()
+	defer func() {
+		d.lock.Lock()
+		d.isRunning = false
+		d.lock.Unlock()
+	}()
+
 	d.logger.Info("shutting down daemon...")
-	if err := d.srv.Shutdown(context.Background()); err != nil {
-		d.logger.Error(err.Error())
+	stopSources(d.sources, d.logger)
+	d.wake()
+	if isRunning {
+		<-d.done
 	}
-	if err := d.maintenanceSrv.Shutdown(context.Background()); err != nil {
-		d.logger.Error(err.Error())
+	closeSources(d.sources, d.logger)
+	if d.maintenanceSrv != nil {
+		if err := d.maintenanceSrv.Shutdown(context.Background()); err != nil {
+			d.logger.Error(err.Error())
+		}
 	}
 	if err := d.dQueue.Close(); err != nil {
 		d.logger.Error(err.Error())
@@ -0,0 +1,178 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/internal/server"
+	"github.com/kmgreen2/agglo/pkg/streaming"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sourcesConfig has a pipeline for each of two subscriber sources, which tee to <prefix>Out<n>
+func sourcesConfig(prefix string) string {
+	return strings.ReplaceAll(`{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [
+    {"name": "out1", "connectionString": "mem:PREFIXOut1", "externalType": "ExternalPubSub"},
+    {"name": "out2", "connectionString": "mem:PREFIXOut2", "externalType": "ExternalPubSub"}
+  ],
+  "pipelines": [
+    {"name": "pipeline1", "processes": [{"name": "tee1"}]},
+    {"name": "pipeline2", "processes": [{"name": "tee2"}]}
+  ],
+  "processDefinitions": [
+    {"tee": {"name": "tee1", "outputConnectorRef": "out1"}},
+    {"tee": {"name": "tee2", "outputConnectorRef": "out2"}}
+  ],
+  "sources": [
+    {"name": "in1", "pipelines": ["pipeline1"], "subscriber": {"connectionString": "mem:PREFIXIn1"}},
+    {"name": "in2", "pipelines": ["pipeline2"], "subscriber": {"connectionString": "mem:PREFIXIn2"}}
+  ]
+}`, "PREFIX", prefix)
+}
+
+// waitForTopic waits until the topic has numMessages, and returns them
+func waitForTopic(t *testing.T, topic string, numMessages int) []string {
+	var payloads []string
+	for start := time.Now(); time.Since(start) < 5 * time.Second; time.Sleep(10 * time.Millisecond) {
+		if payloads = replayTopic(t, topic, int64(numMessages)); len(payloads) == numMessages {
+			break
+		}
+	}
+	return payloads
+}
+
+func testDaemonSources(t *testing.T, prefix string, newDaemon func(pipelines *process.Pipelines) server.Daemon) {
+	pipelines, err := process.PipelinesFromJson([]byte(sourcesConfig(prefix)))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, 2, len(pipelines.Sources()))
+	daemon := newDaemon(pipelines)
+	result := make(chan error, 1)
+	go func() {
+		result <- daemon.Run()
+	}()
+
+	for _, topic := range []string{prefix + "In1", prefix + "In2"} {
+		publisher, err := streaming.NewPublisherFromConnectionString("mem:" + topic)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Nil(t, publisher.Publish(context.Background(), []byte(`{"topic": "` + topic + `"}`)))
+	}
+
+	// Each source only runs its pipeline
+	for i, topic := range []string{prefix + "Out1", prefix + "Out2"} {
+		payloads := waitForTopic(t, topic, 1)
+		assert.Equal(t, 1, len(payloads))
+		in, err := util.JsonToMap([]byte(payloads[0]))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, []string{prefix + "In1", prefix + "In2"}[i], in["topic"])
+		assert.Equal(t, []string{"in1", "in2"}[i], in["internal:source"])
+		assert.NotNil(t, in["internal:messageID"])
+	}
+
+	assert.Nil(t, daemon.Shutdown())
+	assert.Nil(t, <-result)
+	assert.True(t, daemon.IsShutdown())
+}
+
+func TestStatelessDaemonSources(t *testing.T) {
+	testDaemonSources(t, "statelessDaemon", func(pipelines *process.Pipelines) server.Daemon {
+		daemon, err := server.NewStatelessDaemon(0, 0, "/binge", "/maintenance", 4, pipelines)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		return daemon
+	})
+}
+
+func TestDurableDaemonSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "durableDaemon")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	testDaemonSources(t, "durableDaemon", func(pipelines *process.Pipelines) server.Daemon {
+		dQueue, err := util.OpenDurableQueue(filepath.Join(dir, "queue.db"), func([]byte) error {
+			return nil
+		}, true)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		daemon, err := server.NewDurableDaemon(0, 0, "/binge", "/maintenance", 4, 2, dQueue, pipelines)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		return daemon
+	})
+}
+
+func TestDaemonCommandLineSource(t *testing.T) {
+	pipelines, err := process.PipelinesFromJson([]byte(sourcesConfig("commandLineSource")))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	// The command line HTTP source runs alongside the configured sources
+	daemon, err := server.NewStatelessDaemon(port, 0, "/binge", "/maintenance", 4, pipelines)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	result := make(chan error, 1)
+	go func() {
+		result <- daemon.Run()
+	}()
+
+	url := fmt.Sprintf("http://localhost:%d/binge", port)
+	for start := time.Now(); time.Since(start) < 5 * time.Second; time.Sleep(10 * time.Millisecond) {
+		resp, err := http.Post(url, "application/json", bytes.NewBufferString(`{"topic": "http"}`))
+		if err == nil {
+			_ = resp.Body.Close()
+			assert.Equal(t, 200, resp.StatusCode)
+			break
+		}
+	}
+
+	// Its events run through every pipeline
+	for _, topic := range []string{"commandLineSourceOut1", "commandLineSourceOut2"} {
+		payloads := waitForTopic(t, topic, 1)
+		assert.Equal(t, 1, len(payloads))
+		if len(payloads) == 1 {
+			assert.Contains(t, payloads[0], `"internal:source":"http"`)
+		}
+	}
+	assert.Nil(t, daemon.Shutdown())
+	assert.Nil(t, <-result)
+
+	// A configured source cannot share its name
+	pipelines, err = process.PipelinesFromJson([]byte(strings.Replace(sourcesConfig("commandLineConflict"),
+		`"name": "in1"`, `"name": "http"`, 1)))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = server.NewStatelessDaemon(port, 0, "/binge", "/maintenance", 4, pipelines)
+	assert.Error(t, err)
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	gUuid "github.com/google/uuid"
+	"github.com/kmgreen2/agglo/internal/common"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/internal/source"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// DefaultSourceName is the name of the HTTP source configured by the command line
+const DefaultSourceName = "http"
+
+// daemonSources returns the configured sources and an HTTP source configured by the command line.  If
+// sources are configured, the HTTP source only runs if daemonPort is set (non-zero).
+func daemonSources(pipelines *process.Pipelines, daemonPort int, daemonPath string,
+	maxConnections int) ([]*process.PipelineSource, error) {
+	sources := pipelines.Sources()
+	if len(sources) > 0 && daemonPort == 0 {
+		return sources, nil
+	}
+	for _, pipelineSource := range sources {
+		if pipelineSource.Name() == DefaultSourceName {
+			msg := fmt.Sprintf("source '%s' conflicts with the command line HTTP source", DefaultSourceName)
+			return nil, util.NewInvalidError(msg)
+		}
+	}
+	httpSource := &process.PipelineSource{
+		Source: source.NewHttpSource(DefaultSourceName, daemonPort, daemonPath, maxConnections),
+	}
+	return append(append([]*process.PipelineSource{}, sources...), httpSource), nil
+}
+
+// sourceHandler sets the source and a message ID on each event, before passing it to handler
+func sourceHandler(name string, handler source.Handler) source.Handler {
+	return func(ctx context.Context, in map[string]interface{}) error {
+		// A globally unique ID is defined which will allow the re-player to fetch the
+		// checkpoint state, prior to replaying, among other future uses.
+		// It is also assumed that each pipeline mix in its own name to ensure the
+		// there are no conflicts with checkpoint state.  Retried events keep their ID.
+		if _, ok := common.GetFromInternalKey(common.MessageIDKey, in); !ok {
+			messageID, err := gUuid.NewRandom()
+			if err != nil {
+				return err
+			}
+			common.MustSetUsingInternalKey(common.MessageIDKey, messageID.String(), in)
+		}
+		common.MustSetUsingInternalKey(common.SourceKey, name, in)
+		return handler(ctx, in)
+	}
+}
+
+// sourcePipelines returns the names of the pipelines that the event's source runs, or nil for every
+// pipeline
+func sourcePipelines(in map[string]interface{}, pipelines *process.Pipelines) map[string]bool {
+	name, ok := common.GetFromInternalKey(common.SourceKey, in)
+	if !ok {
+		return nil
+	}
+	for _, pipelineSource := range pipelines.Sources() {
+		if pipelineSource.Name() == name && len(pipelineSource.Pipelines) > 0 {
+			names := make(map[string]bool)
+			for _, pipelineName := range pipelineSource.Pipelines {
+				names[pipelineName] = true
+			}
+			return names
+		}
+	}
+	return nil
+}
+
+// runSources runs each source until they have all stopped.  If a source fails, the other sources are
+// stopped and its error is returned.
+func runSources(sources []*process.PipelineSource, handler source.Handler, logger *zap.Logger) error {
+	results := make(chan error, len(sources))
+	for _, pipelineSource := range sources {
+		go func(pipelineSource *process.PipelineSource) {
+			err := pipelineSource.Run(sourceHandler(pipelineSource.Name(), handler), logger)
+			if err != nil {
+				err = util.NewInternalError(fmt.Sprintf("source %s failed: %s", pipelineSource.Name(), err.Error()))
+			}
+			results <- err
+		}(pipelineSource)
+	}
+
+	var err error
+	for range sources {
+		if runErr := <-results; runErr != nil && err == nil {
+			logger.Error(runErr.Error())
+			err = runErr
+			stopSources(sources, logger)
+		}
+	}
+	return err
+}
+
+func stopSources(sources []*process.PipelineSource, logger *zap.Logger) {
+	for _, pipelineSource := range sources {
+		if err := pipelineSource.Stop(); err != nil {
+			logger.Error(err.Error())
+		}
+	}
+}
+
+func closeSources(sources []*process.PipelineSource, logger *zap.Logger) {
+	for _, pipelineSource := range sources {
+		if err := pipelineSource.Close(); err != nil {
+			logger.Error(err.Error())
+		}
+	}
+}
+
+// setSourceHandlers adds endpoints that list the sources, and pause and resume them.  The name query
+// parameter selects a source (default is every source).
+func setSourceHandlers(mux *http.ServeMux, path string, sources []*process.PipelineSource, logger *zap.Logger) {
+	sourcesEndpoint := path + "/sources"
+	mux.HandleFunc(sourcesEndpoint, func(resp http.ResponseWriter, req *http.Request) {
+		var sourceList []map[string]interface{}
+		for _, pipelineSource := range sources {
+			sourceList = append(sourceList, map[string]interface{}{
+				"name": pipelineSource.Name(),
+				"pipelines": pipelineSource.Pipelines,
+			})
+		}
+		respJson, err := util.MapToJson(map[string]interface{}{"sources": sourceList})
+		if err != nil {
+			resp.WriteHeader(500)
+			_, _ = resp.Write([]byte(err.Error()))
+			logger.Error(err.Error())
+			return
+		}
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(200)
+		_, _ = resp.Write(respJson)
+	})
+
+	for action, fn := range map[string]func(source.Source) error{
+		"pause": source.Source.Pause,
+		"resume": source.Source.Resume,
+	} {
+		endpoint, fn := sourcesEndpoint + "/" + action, fn
+		mux.HandleFunc(endpoint, func(resp http.ResponseWriter, req *http.Request) {
+			if req.Method != "POST" {
+				resp.WriteHeader(400)
+				msg := fmt.Sprintf("%s does not support '%s'", endpoint, req.Method)
+				_, _ = resp.Write([]byte(msg))
+				logger.Error(msg)
+				return
+			}
+			name := req.URL.Query().Get("name")
+			found := false
+			for _, pipelineSource := range sources {
+				if len(name) > 0 && pipelineSource.Name() != name {
+					continue
+				}
+				found = true
+				if err := fn(pipelineSource.Source); err != nil {
+					resp.WriteHeader(500)
+					_, _ = resp.Write([]byte(err.Error()))
+					logger.Error(err.Error())
+					return
+				}
+			}
+			if !found {
+				resp.WriteHeader(404)
+				_, _ = resp.Write([]byte(fmt.Sprintf("cannot find source: %s", name)))
+				return
+			}
+			resp.WriteHeader(200)
+			_, _ = resp.Write([]byte("Success"))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package server
+
+import (
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/internal/source"
+	"github.com/kmgreen2/agglo/pkg/streaming"
+)
+
+// NewSubscriberDaemon returns a stateless daemon that runs the pipelines for each message consumed by the
+// subscriber, and for the events of any configured sources.  See source.SubscriberSource for how messages
+// are committed, retried and dead-lettered.
+func NewSubscriberDaemon(maintenancePort int, maintenancePath string, subscriber streaming.CommittingSubscriber,
+	deadLetter streaming.Publisher, maxRetries int, pipelines *process.Pipelines) (Daemon, error) {
+	sources := append([]*process.PipelineSource{
+		{Source: source.NewSubscriberSource("subscriber", subscriber, deadLetter, maxRetries)},
+	}, pipelines.Sources()...)
+	return newStatelessDaemon(maintenancePort, maintenancePath, sources, pipelines)
+}
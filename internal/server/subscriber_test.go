@@ -0,0 +1,100 @@
+package server_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/core/process"
+	"github.com/kmgreen2/agglo/internal/server"
+	"github.com/kmgreen2/agglo/pkg/streaming"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func replayTopic(t *testing.T, topic string, numMessages int64) []string {
+	replayer, err := streaming.NewMemReplayer(streaming.DefaultMemPubSub(), topic, 0, numMessages - 1)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	var payloads []string
+	_ = replayer.Replay(context.Background(), func(ctx context.Context, payload []byte) error {
+		payloads = append(payloads, string(payload))
+		return nil
+	})
+	return payloads
+}
+
+func TestSubscriberDaemon(t *testing.T) {
+	var lock sync.Mutex
+	var bodies []string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		lock.Lock()
+		bodies = append(bodies, string(b))
+		lock.Unlock()
+		if strings.Contains(string(b), "poison") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer webhook.Close()
+
+	pipelines, err := process.PipelinesFromJson([]byte(`{
+  "partitionUuid": "938e16fe-a216-4fe1-92bd-dcab49646fb9",
+  "externalSystems": [{"name": "webhook", "connectionString": "` + webhook.URL + `", "externalType": "ExternalHttp"}],
+  "pipelines": [{"name": "forward-pipeline", "processes": [{"name": "forward"}]}],
+  "processDefinitions": [{"tee": {"name": "forward", "outputConnectorRef": "webhook"}}]
+}`))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	publisher, err := streaming.NewPublisherFromConnectionString("mem:subscriberDaemonIn")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	for _, payload := range []string{`{"id": "a"}`, `not json`, `{"id": "poison"}`, `{"id": "b"}`} {
+		assert.Nil(t, publisher.Publish(context.Background(), []byte(payload)))
+	}
+	subscriber, err := streaming.NewSubscriberFromConnectionString("mem:subscriberDaemonIn")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	deadLetter, err := streaming.NewPublisherFromConnectionString("mem:subscriberDaemonDead")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	daemon, err := server.NewSubscriberDaemon(0, "/maintenance", subscriber, deadLetter, 1, pipelines)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	result := make(chan error, 1)
+	go func() {
+		result <- daemon.Run()
+	}()
+
+	received := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, bodies...)
+	}
+	for start := time.Now(); len(received()) < 4 && time.Since(start) < 5 * time.Second; {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The poison message is tried twice, between a and b
+	payloads := received()
+	assert.Equal(t, 4, len(payloads))
+	for i, id := range []string{`"id":"a"`, `"id":"poison"`, `"id":"poison"`, `"id":"b"`} {
+		assert.Contains(t, payloads[i], id)
+	}
+	assert.Equal(t, []string{`not json`, `{"id": "poison"}`}, replayTopic(t, "subscriberDaemonDead", 2))
+
+	assert.Nil(t, daemon.Shutdown())
+	assert.Nil(t, <-result)
+	assert.True(t, daemon.IsShutdown())
+}
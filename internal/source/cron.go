@@ -0,0 +1,196 @@
+package source
+
+import (
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the times at which a schedule source fires
+type Schedule interface {
+	// Next returns the first time after t
+	Next(t time.Time) time.Time
+}
+
+// everySchedule fires at multiples of the interval since the Unix epoch, so replicas agree on the times
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (schedule everySchedule) Next(t time.Time) time.Time {
+	next := time.Unix(0, t.UnixNano() - t.UnixNano() % int64(schedule.interval)).Add(schedule.interval)
+	return next.In(t.Location())
+}
+
+// cronSchedule is a set of minutes, hours, days of the month, months and days of the week, as bits
+type cronSchedule struct {
+	minutes uint64
+	hours uint64
+	daysOfMonth uint64
+	months uint64
+	daysOfWeek uint64
+	// If both days of the month and days of the week are restricted, a day matching either fires
+	anyDay bool
+	location *time.Location
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly": "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly": "0 0 * * 0",
+	"@daily": "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly": "0 * * * *",
+}
+
+type cronField struct {
+	name string
+	min int
+	max int
+	names []string
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day of month", 1, 31, nil},
+	{"month", 1, 12, []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{"day of week", 0, 7, []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// ParseSchedule parses a cron expression ("minute hour day-of-month month day-of-week", with *, ranges,
+// lists, steps and month and day names), a descriptor such as @hourly or @daily, or "@every <duration>".
+// Cron expressions are evaluated in location (nil is UTC).
+func ParseSchedule(spec string, location *time.Location) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || interval <= 0 {
+			return nil, util.NewInvalidError(fmt.Sprintf("invalid schedule '%s': bad interval", spec))
+		}
+		return everySchedule{interval}, nil
+	}
+	expression := spec
+	if descriptor, ok := cronDescriptors[spec]; ok {
+		expression = descriptor
+	}
+
+	tokens := strings.Fields(expression)
+	if len(tokens) != len(cronFields) {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid schedule '%s': expected %d fields", spec,
+			len(cronFields)))
+	}
+	bits := make([]uint64, len(cronFields))
+	for i, token := range tokens {
+		var err error
+		if bits[i], err = cronFields[i].parse(token); err != nil {
+			return nil, util.NewInvalidError(fmt.Sprintf("invalid schedule '%s': %s", spec, err.Error()))
+		}
+	}
+	// Sunday is 0 or 7
+	if bits[4] & (1 << 7) != 0 {
+		bits[4] |= 1
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return &cronSchedule{
+		minutes: bits[0],
+		hours: bits[1],
+		daysOfMonth: bits[2],
+		months: bits[3],
+		daysOfWeek: bits[4],
+		anyDay: tokens[2] != "*" && tokens[4] != "*",
+		location: location,
+	}, nil
+}
+
+// parse returns the values of a field, e.g. "1-5", "*/15" or "mon,wed,fri", as bits
+func (field cronField) parse(token string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(token, ",") {
+		step := 1
+		if idx := strings.IndexByte(part, '/'); idx >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[idx+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("bad step in %s '%s'", field.name, part)
+			}
+			part = part[:idx]
+		}
+		low, high := field.min, field.max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if low, err = field.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = field.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				high = field.max
+			}
+			if high < low {
+				return 0, fmt.Errorf("bad range in %s '%s'", field.name, part)
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (field cronField) value(token string) (int, error) {
+	for i, name := range field.names {
+		if strings.EqualFold(token, name) {
+			return i + field.min, nil
+		}
+	}
+	v, err := strconv.Atoi(token)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("bad %s '%s'", field.name, token)
+	}
+	return v, nil
+}
+
+func (schedule *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := schedule.daysOfMonth & (1 << uint(t.Day())) != 0
+	dowMatch := schedule.daysOfWeek & (1 << uint(t.Weekday())) != 0
+	if schedule.anyDay {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func (schedule *cronSchedule) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	t = t.In(schedule.location).Truncate(time.Minute).Add(time.Minute)
+	// A schedule that cannot fire, e.g. on February 30th, gives up after 5 years
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if schedule.months & (1 << uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month() + 1, 1, 0, 0, 0, 0, schedule.location)
+			continue
+		}
+		if !schedule.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day() + 1, 0, 0, 0, 0, schedule.location)
+			continue
+		}
+		if schedule.hours & (1 << uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour() + 1, 0, 0, 0, schedule.location)
+			continue
+		}
+		if schedule.minutes & (1 << uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t.In(origLocation)
+	}
+	return time.Time{}
+}
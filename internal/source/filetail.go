@@ -0,0 +1,525 @@
+package source
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"go.uber.org/zap"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTailPollInterval is how often tailed files are checked for new lines
+	DefaultTailPollInterval = time.Second
+	// DefaultMultilineMaxLines is the maximum number of lines joined into one event
+	DefaultMultilineMaxLines = 500
+	// DefaultMultilineTimeout is how long a multi-line event waits for more lines before it is emitted
+	DefaultMultilineTimeout = time.Second
+	// fingerprintSize is the number of bytes, at the start of a file, that identify it
+	fingerprintSize = 1024
+)
+
+// TailFormat determines how lines are decoded into events
+type TailFormat string
+
+const (
+	// TailJson decodes each line as a JSON object.  Lines that are not JSON objects are decoded as text.
+	TailJson TailFormat = "json"
+	// TailText wraps each line in {"message": <line>}
+	TailText TailFormat = "text"
+)
+
+// ParseTailFormat returns the format with the name ("" is TailJson)
+func ParseTailFormat(name string) (TailFormat, error) {
+	switch TailFormat(name) {
+	case "":
+		return TailJson, nil
+	case TailJson, TailText:
+		return TailFormat(name), nil
+	}
+	return TailJson, util.NewInvalidError(fmt.Sprintf("unknown tail format '%s'", name))
+}
+
+// MultilineRule joins consecutive lines into one event, e.g. a stack trace.  Either a line matching
+// StartPattern begins a new event, or a line matching ContinuationPattern is appended to the current
+// event.
+type MultilineRule struct {
+	StartPattern *regexp.Regexp
+	ContinuationPattern *regexp.Regexp
+	// MaxLines is the maximum number of lines in an event (default DefaultMultilineMaxLines)
+	MaxLines int
+	// Timeout is how long an event waits for more lines before it is emitted (default DefaultMultilineTimeout)
+	Timeout time.Duration
+}
+
+func (rule *MultilineRule) startsEvent(line string) bool {
+	if rule.StartPattern != nil {
+		return rule.StartPattern.MatchString(line)
+	}
+	return rule.ContinuationPattern == nil || !rule.ContinuationPattern.MatchString(line)
+}
+
+// TailOffset is the position up to which a tailed file has been processed.  The file is identified by a
+// hash of its first FingerprintLength bytes, so a rotated or replaced file is detected across restarts.
+type TailOffset struct {
+	Offset int64 `json:"offset"`
+	Fingerprint string `json:"fingerprint"`
+	FingerprintLength int64 `json:"fingerprintLength"`
+}
+
+// TailOffsetStore persists the offsets of the files tailed by a source, keyed by path
+type TailOffsetStore interface {
+	Load(ctx context.Context) (map[string]TailOffset, error)
+	Save(ctx context.Context, path string, offset TailOffset) error
+}
+
+type kvTailOffsetStore struct {
+	kvStore kvs.KVStore
+	prefix string
+}
+
+// NewKVTailOffsetStore stores each file's offset in the KV store, at <prefix><path>
+func NewKVTailOffsetStore(kvStore kvs.KVStore, prefix string) TailOffsetStore {
+	return &kvTailOffsetStore{kvStore, prefix}
+}
+
+func (store *kvTailOffsetStore) Load(ctx context.Context) (map[string]TailOffset, error) {
+	keys, err := store.kvStore.List(ctx, store.prefix)
+	if err != nil {
+		return nil, err
+	}
+	offsets := make(map[string]TailOffset)
+	for _, key := range keys {
+		value, err := store.kvStore.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		var offset TailOffset
+		if err = json.Unmarshal(value, &offset); err != nil {
+			return nil, err
+		}
+		offsets[strings.TrimPrefix(key, store.prefix)] = offset
+	}
+	return offsets, nil
+}
+
+func (store *kvTailOffsetStore) Save(ctx context.Context, path string, offset TailOffset) error {
+	value, err := json.Marshal(offset)
+	if err != nil {
+		return err
+	}
+	return store.kvStore.Put(ctx, store.prefix + path, value)
+}
+
+type localFileTailOffsetStore struct {
+	path string
+	lock sync.Mutex
+	offsets map[string]TailOffset
+}
+
+// NewLocalFileTailOffsetStore stores the offsets in a JSON file, which is replaced on each save
+func NewLocalFileTailOffsetStore(path string) TailOffsetStore {
+	return &localFileTailOffsetStore{path: path}
+}
+
+func (store *localFileTailOffsetStore) Load(ctx context.Context) (map[string]TailOffset, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	store.offsets = make(map[string]TailOffset)
+	b, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return map[string]TailOffset{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, &store.offsets); err != nil {
+		return nil, err
+	}
+	offsets := make(map[string]TailOffset)
+	for path, offset := range store.offsets {
+		offsets[path] = offset
+	}
+	return offsets, nil
+}
+
+func (store *localFileTailOffsetStore) Save(ctx context.Context, path string, offset TailOffset) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if store.offsets == nil {
+		store.offsets = make(map[string]TailOffset)
+	}
+	store.offsets[path] = offset
+	b, err := json.Marshal(store.offsets)
+	if err != nil {
+		return err
+	}
+	// Write and rename, so a crash cannot leave a partial file
+	tmpPath := store.path + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, store.path)
+}
+
+// FileTailOption configures a FileTailSource
+type FileTailOption func(*FileTailSource)
+
+// WithTailFormat sets how lines are decoded (default TailJson)
+func WithTailFormat(format TailFormat) FileTailOption {
+	return func(s *FileTailSource) {
+		s.format = format
+	}
+}
+
+// WithTailMultiline joins lines into events with the rule
+func WithTailMultiline(rule MultilineRule) FileTailOption {
+	return func(s *FileTailSource) {
+		if rule.MaxLines <= 0 {
+			rule.MaxLines = DefaultMultilineMaxLines
+		}
+		if rule.Timeout <= 0 {
+			rule.Timeout = DefaultMultilineTimeout
+		}
+		s.multiline = &rule
+	}
+}
+
+// WithTailPollInterval sets how often files are checked for new lines (default DefaultTailPollInterval)
+func WithTailPollInterval(interval time.Duration) FileTailOption {
+	return func(s *FileTailSource) {
+		s.pollInterval = interval
+	}
+}
+
+// WithTailOffsets persists the offsets of the tailed files, so restarts neither duplicate nor skip lines
+func WithTailOffsets(offsets TailOffsetStore) FileTailOption {
+	return func(s *FileTailSource) {
+		s.offsets = offsets
+	}
+}
+
+// tailedFile is a file that is being followed
+type tailedFile struct {
+	path string
+	file *os.File
+	// readOffset is the position that has been read, including the pending lines
+	readOffset int64
+	committed TailOffset
+	pending []string
+	pendingEnd int64
+	lastLine time.Time
+}
+
+// FileTailSource follows the files that match glob patterns, emitting an event for each line (or
+// multi-line event) that is appended to them.  Files are read from their start, or from their persisted
+// offset.  A file that is renamed, e.g. by log rotation, is read to its end before the new file at the
+// path is followed, and a file that is truncated is read from its start.  An event is retried on the next
+// poll until the handler succeeds, and the lines that follow it wait for it.
+type FileTailSource struct {
+	name string
+	patterns []string
+	format TailFormat
+	multiline *MultilineRule
+	pollInterval time.Duration
+	offsets TailOffsetStore
+	stored map[string]TailOffset
+	files map[string]*tailedFile
+	lock sync.Mutex
+	paused bool
+	stopped bool
+	stopCh chan struct{}
+}
+
+// NewFileTailSource returns a source that follows the files matching the patterns (see filepath.Match)
+func NewFileTailSource(name string, patterns []string, options ...FileTailOption) (*FileTailSource, error) {
+	if len(patterns) == 0 {
+		return nil, util.NewInvalidError(fmt.Sprintf("file tail source %s requires a pattern", name))
+	}
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, util.NewInvalidError(fmt.Sprintf("invalid pattern '%s': %s", pattern, err.Error()))
+		}
+	}
+	s := &FileTailSource{
+		name: name,
+		patterns: patterns,
+		format: TailJson,
+		pollInterval: DefaultTailPollInterval,
+		files: make(map[string]*tailedFile),
+		stopCh: make(chan struct{}),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s, nil
+}
+
+func (s *FileTailSource) Name() string {
+	return s.name
+}
+
+func (s *FileTailSource) Run(handler Handler, logger *zap.Logger) error {
+	s.stored = make(map[string]TailOffset)
+	if s.offsets != nil {
+		stored, err := s.offsets.Load(context.Background())
+		if err != nil {
+			return err
+		}
+		s.stored = stored
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		if !s.isPaused() {
+			s.poll(handler, logger)
+		}
+		select {
+		case <-s.stopCh:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll will read the new lines of each matching file
+func (s *FileTailSource) poll(handler Handler, logger *zap.Logger) {
+	var paths []string
+	for _, pattern := range s.patterns {
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			if path, err := filepath.Abs(match); err == nil {
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	matched := make(map[string]bool)
+	for _, path := range paths {
+		if matched[path] {
+			continue
+		}
+		matched[path] = true
+		tf, ok := s.files[path]
+		if !ok {
+			var err error
+			if tf, err = s.open(path); err != nil {
+				logger.Error(fmt.Sprintf("cannot open %s: %s", path, err.Error()))
+				continue
+			}
+			s.files[path] = tf
+		}
+		s.follow(tf, handler, logger)
+	}
+
+	// Files that were removed are read to their end
+	for path, tf := range s.files {
+		if !matched[path] && s.read(tf, handler, logger, true) {
+			_ = tf.file.Close()
+			delete(s.files, path)
+		}
+	}
+}
+
+// open will open a file at its stored offset, if the stored offset is for the same file
+func (s *FileTailSource) open(path string) (*tailedFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	tf := &tailedFile{path: path, file: f}
+	if stored, ok := s.stored[path]; ok {
+		info, err := f.Stat()
+		if err == nil && info.Size() >= stored.Offset && s.fingerprintMatches(tf, stored) {
+			tf.readOffset = stored.Offset
+			tf.committed = stored
+		}
+	}
+	return tf, nil
+}
+
+func fingerprint(f *os.File, length int64) (string, error) {
+	b := make([]byte, length)
+	if _, err := f.ReadAt(b, 0); err != nil && err != io.EOF {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (s *FileTailSource) fingerprintMatches(tf *tailedFile, offset TailOffset) bool {
+	if offset.FingerprintLength == 0 {
+		return true
+	}
+	current, err := fingerprint(tf.file, offset.FingerprintLength)
+	return err == nil && current == offset.Fingerprint
+}
+
+// follow will detect rotation and truncation, and then read the new lines of the file
+func (s *FileTailSource) follow(tf *tailedFile, handler Handler, logger *zap.Logger) {
+	openInfo, err := tf.file.Stat()
+	if err != nil {
+		logger.Error(fmt.Sprintf("cannot stat %s: %s", tf.path, err.Error()))
+		return
+	}
+	if info, err := os.Stat(tf.path); err == nil && !os.SameFile(info, openInfo) {
+		// The file was rotated, so finish it before following the new file
+		if !s.read(tf, handler, logger, true) {
+			return
+		}
+		_ = tf.file.Close()
+		newFile, err := os.Open(tf.path)
+		if err != nil {
+			delete(s.files, tf.path)
+			return
+		}
+		*tf = tailedFile{path: tf.path, file: newFile}
+	} else if openInfo.Size() < tf.readOffset || !s.fingerprintMatches(tf, tf.committed) {
+		logger.Info(fmt.Sprintf("%s was truncated, reading from the start", tf.path))
+		*tf = tailedFile{path: tf.path, file: tf.file}
+	}
+	s.read(tf, handler, logger, false)
+}
+
+// read will handle the complete lines after readOffset.  If final, the file will not change, so a
+// trailing partial line and the pending lines are emitted.  It returns false if an event failed.
+func (s *FileTailSource) read(tf *tailedFile, handler Handler, logger *zap.Logger, final bool) bool {
+	if _, err := tf.file.Seek(tf.readOffset, io.SeekStart); err != nil {
+		logger.Error(fmt.Sprintf("cannot seek %s: %s", tf.path, err.Error()))
+		return false
+	}
+	reader := bufio.NewReader(tf.file)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && (!final || len(line) == 0) {
+			break
+		}
+		lineEnd := tf.readOffset + int64(len(line))
+		if !s.addLine(tf, strings.TrimRight(line, "\r\n"), lineEnd, handler, logger) {
+			return false
+		}
+		tf.readOffset = lineEnd
+		if err != nil {
+			break
+		}
+	}
+	if len(tf.pending) > 0 && (final || s.multiline == nil || time.Since(tf.lastLine) >= s.multiline.Timeout) {
+		return s.emit(tf, handler, logger)
+	}
+	return true
+}
+
+// addLine will add a line to the pending event, emitting the pending event first if the line starts a new
+// event
+func (s *FileTailSource) addLine(tf *tailedFile, line string, lineEnd int64, handler Handler,
+	logger *zap.Logger) bool {
+	if s.multiline == nil {
+		tf.pending = []string{line}
+		tf.pendingEnd = lineEnd
+		return s.emit(tf, handler, logger)
+	}
+	if len(tf.pending) > 0 && (s.multiline.startsEvent(line) || len(tf.pending) >= s.multiline.MaxLines) {
+		if !s.emit(tf, handler, logger) {
+			return false
+		}
+	}
+	tf.pending = append(tf.pending, line)
+	tf.pendingEnd = lineEnd
+	tf.lastLine = time.Now()
+	return true
+}
+
+// emit will handle the pending event and commit its offset.  If the event fails, the file is read from
+// the committed offset on the next poll.
+func (s *FileTailSource) emit(tf *tailedFile, handler Handler, logger *zap.Logger) bool {
+	text := strings.Join(tf.pending, "\n")
+	if err := handler(context.Background(), s.decode(text)); err != nil {
+		logger.Error(fmt.Sprintf("failed to process line of %s, retrying: %s", tf.path, err.Error()))
+		tf.readOffset = tf.committed.Offset
+		tf.pending = nil
+		return false
+	}
+	tf.pending = nil
+	tf.committed.Offset = tf.pendingEnd
+
+	if tf.committed.FingerprintLength < fingerprintSize {
+		if info, err := tf.file.Stat(); err == nil {
+			length := info.Size()
+			if length > fingerprintSize {
+				length = fingerprintSize
+			}
+			if value, err := fingerprint(tf.file, length); err == nil {
+				tf.committed.Fingerprint, tf.committed.FingerprintLength = value, length
+			}
+		}
+	}
+	if s.offsets != nil {
+		if err := s.offsets.Save(context.Background(), tf.path, tf.committed); err != nil {
+			logger.Error(fmt.Sprintf("cannot save offset of %s: %s", tf.path, err.Error()))
+		}
+	}
+	return true
+}
+
+func (s *FileTailSource) decode(text string) map[string]interface{} {
+	if s.format == TailJson {
+		if in, err := util.JsonToMap([]byte(text)); err == nil {
+			return in
+		}
+	}
+	return map[string]interface{}{"message": text}
+}
+
+func (s *FileTailSource) isPaused() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.paused
+}
+
+// Stop will stop following the files, after the current poll
+func (s *FileTailSource) Stop() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stopCh)
+	}
+	return nil
+}
+
+func (s *FileTailSource) Pause() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = true
+	return nil
+}
+
+func (s *FileTailSource) Resume() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = false
+	return nil
+}
+
+// Close will close the files
+func (s *FileTailSource) Close() error {
+	for path, tf := range s.files {
+		_ = tf.file.Close()
+		delete(s.files, path)
+	}
+	return nil
+}
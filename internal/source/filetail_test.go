@@ -0,0 +1,191 @@
+package source_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/source"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func appendLines(t *testing.T, path, text string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = f.WriteString(text)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+}
+
+// tailEvents runs the source until it has emitted n events
+func tailEvents(t *testing.T, s *source.FileTailSource, n int) []map[string]interface{} {
+	events := make(chan map[string]interface{}, n)
+	result := runSource(t, s, func(ctx context.Context, in map[string]interface{}) error {
+		events <- in
+		return nil
+	})
+	var received []map[string]interface{}
+	for len(received) < n {
+		select {
+		case in := <-events:
+			received = append(received, in)
+		case <-time.After(2 * time.Second):
+			assert.FailNow(t, fmt.Sprintf("received %d of %d events", len(received), n))
+		}
+	}
+	assert.Nil(t, s.Stop())
+	assert.Nil(t, <-result)
+	assert.Nil(t, s.Close())
+	return received
+}
+
+func newTailSource(t *testing.T, patterns []string, options ...source.FileTailOption) *source.FileTailSource {
+	options = append(options, source.WithTailPollInterval(10 * time.Millisecond))
+	s, err := source.NewFileTailSource("tail", patterns, options...)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return s
+}
+
+func TestFileTailSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filetail")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	logPath := filepath.Join(dir, "app.log")
+	offsets := source.NewLocalFileTailOffsetStore(filepath.Join(dir, "offsets.json"))
+	patterns := []string{filepath.Join(dir, "*.log")}
+
+	// Lines that are not JSON objects are wrapped, and the partial line is not emitted
+	appendLines(t, logPath, "{\"id\": 1}\nplain text\n{\"id\": ")
+	events := tailEvents(t, newTailSource(t, patterns, source.WithTailOffsets(offsets)), 2)
+	assert.Equal(t, []map[string]interface{}{{"id": float64(1)}, {"message": "plain text"}}, events)
+
+	// A restart continues from the stored offset
+	appendLines(t, logPath, "2}\n")
+	events = tailEvents(t, newTailSource(t, patterns, source.WithTailOffsets(offsets)), 1)
+	assert.Equal(t, []map[string]interface{}{{"id": float64(2)}}, events)
+
+	// A rotated file is identified by its contents, so the new file is read from its start
+	assert.Nil(t, os.Rename(logPath, filepath.Join(dir, "app.log.1")))
+	appendLines(t, logPath, "{\"id\": 3}\n")
+	events = tailEvents(t, newTailSource(t, patterns, source.WithTailOffsets(offsets),
+		source.WithTailFormat(source.TailText)), 1)
+	assert.Equal(t, []map[string]interface{}{{"message": "{\"id\": 3}"}}, events)
+
+	_, err = source.NewFileTailSource("tail", []string{"[a-"})
+	assert.Error(t, err)
+	_, err = source.ParseTailFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestFileTailRotationAndTruncation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filetail")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	logPath := filepath.Join(dir, "app.log")
+	appendLines(t, logPath, "a\n")
+
+	kvStore := kvs.NewMemKVStore()
+	tailSource := newTailSource(t, []string{logPath},
+		source.WithTailOffsets(source.NewKVTailOffsetStore(kvStore, "tail:")))
+	events := make(chan interface{}, 8)
+	result := runSource(t, tailSource, func(ctx context.Context, in map[string]interface{}) error {
+		events <- in["message"]
+		return nil
+	})
+	assert.Equal(t, "a", <-events)
+
+	// The rotated file is read to its end before the new file
+	appendLines(t, logPath, "b\n")
+	assert.Nil(t, os.Rename(logPath, logPath + ".1"))
+	appendLines(t, logPath, "c\n")
+	assert.Equal(t, "b", <-events)
+	assert.Equal(t, "c", <-events)
+
+	// A truncated file is read from its start
+	assert.Nil(t, os.Truncate(logPath, 0))
+	time.Sleep(50 * time.Millisecond)
+	appendLines(t, logPath, "d\n")
+	assert.Equal(t, "d", <-events)
+
+	assert.Nil(t, tailSource.Stop())
+	assert.Nil(t, <-result)
+	assert.Nil(t, tailSource.Close())
+
+	keys, err := kvStore.List(context.Background(), "tail:")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tail:" + logPath}, keys)
+}
+
+func TestFileTailMultiline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filetail")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	logPath := filepath.Join(dir, "app.log")
+	appendLines(t, logPath, "2021-01-01 error\n  at main.go:10\n  at main.go:20\n2021-01-01 done\n")
+
+	rule := source.MultilineRule{
+		StartPattern: regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `),
+		Timeout: 50 * time.Millisecond,
+	}
+	events := tailEvents(t, newTailSource(t, []string{logPath}, source.WithTailMultiline(rule)), 2)
+	assert.Equal(t, "2021-01-01 error\n  at main.go:10\n  at main.go:20", events[0]["message"])
+	// The last event is emitted after the timeout
+	assert.Equal(t, "2021-01-01 done", events[1]["message"])
+
+	rule = source.MultilineRule{ContinuationPattern: regexp.MustCompile(`^\s+at `), MaxLines: 2}
+	events = tailEvents(t, newTailSource(t, []string{logPath}, source.WithTailMultiline(rule)), 3)
+	assert.Equal(t, "2021-01-01 error\n  at main.go:10", events[0]["message"])
+	assert.Equal(t, "  at main.go:20", events[1]["message"])
+}
+
+func TestFileTailRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filetail")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	logPath := filepath.Join(dir, "app.log")
+	appendLines(t, logPath, "a\nb\n")
+
+	// b is retried, and is not skipped
+	var attempts []interface{}
+	done := make(chan struct{})
+	tailSource := newTailSource(t, []string{logPath})
+	result := runSource(t, tailSource, func(ctx context.Context, in map[string]interface{}) error {
+		attempts = append(attempts, in["message"])
+		if len(attempts) == 2 {
+			return fmt.Errorf("failed")
+		} else if len(attempts) == 3 {
+			close(done)
+		}
+		return nil
+	})
+	<-done
+	assert.Nil(t, tailSource.Stop())
+	assert.Nil(t, <-result)
+	assert.Nil(t, tailSource.Close())
+	assert.Equal(t, []interface{}{"a", "b", "b"}, attempts)
+}
@@ -0,0 +1,162 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/net/netutil"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultHttpPath = "/binge"
+	DefaultHttpMaxConnections = 64
+)
+
+// HttpSource reads events that are POSTed as JSON objects.  Requests are rejected with 503 while the
+// source is paused, and with 500 if the event fails.
+type HttpSource struct {
+	name string
+	port int
+	path string
+	maxConnections int
+	lock sync.Mutex
+	srv *http.Server
+	addr net.Addr
+	paused bool
+	stopped bool
+}
+
+// NewHttpSource returns a source that listens on port (0 is any free port).  An empty path is
+// DefaultHttpPath and maxConnections <= 0 is DefaultHttpMaxConnections.
+func NewHttpSource(name string, port int, path string, maxConnections int) *HttpSource {
+	if len(path) == 0 {
+		path = DefaultHttpPath
+	}
+	if maxConnections <= 0 {
+		maxConnections = DefaultHttpMaxConnections
+	}
+	return &HttpSource{
+		name: name,
+		port: port,
+		path: path,
+		maxConnections: maxConnections,
+	}
+}
+
+func (s *HttpSource) Name() string {
+	return s.name
+}
+
+// Addr returns the address the source listens on, once it is running
+func (s *HttpSource) Addr() net.Addr {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.addr
+}
+
+func (s *HttpSource) Run(handler Handler, logger *zap.Logger) error {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return err
+	}
+
+	defer l.Close()
+
+	l = netutil.LimitListener(l, s.maxConnections)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(s.path, func(resp http.ResponseWriter, req *http.Request) {
+		begin := time.Now()
+		defer func() {
+			elapsed := time.Now().Sub(begin)
+			logger.Info(fmt.Sprintf("Http handler: %d ms", elapsed.Milliseconds()))
+		}()
+		if s.isPaused() {
+			resp.WriteHeader(503)
+			_, _ = resp.Write([]byte(fmt.Sprintf("source %s is paused", s.name)))
+			return
+		}
+		bodyBytes, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			resp.WriteHeader(400)
+			_, _ = resp.Write([]byte(err.Error()))
+			logger.Error(err.Error())
+			return
+		}
+		in, err := util.JsonToMap(bodyBytes)
+		if err != nil {
+			resp.WriteHeader(400)
+			_, _ = resp.Write([]byte(err.Error()))
+			logger.Error(err.Error())
+			return
+		}
+
+		err = handler(req.Context(), in)
+		if err != nil {
+			resp.WriteHeader(500)
+			_, _ = resp.Write([]byte(err.Error()))
+			logger.Error(err.Error())
+			return
+		}
+
+		resp.WriteHeader(200)
+		_, _ = resp.Write([]byte("Success"))
+	})
+
+	s.lock.Lock()
+	if s.stopped {
+		s.lock.Unlock()
+		return nil
+	}
+	s.srv = &http.Server{Handler: mux}
+	s.addr = l.Addr()
+	s.lock.Unlock()
+
+	err = s.srv.Serve(l)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+func (s *HttpSource) isPaused() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.paused
+}
+
+// Stop will stop accepting requests and wait for the requests that are being handled
+func (s *HttpSource) Stop() error {
+	s.lock.Lock()
+	s.stopped = true
+	srv := s.srv
+	s.lock.Unlock()
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(context.Background())
+}
+
+func (s *HttpSource) Pause() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = true
+	return nil
+}
+
+func (s *HttpSource) Resume() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = false
+	return nil
+}
+
+func (s *HttpSource) Close() error {
+	return nil
+}
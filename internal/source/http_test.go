@@ -0,0 +1,61 @@
+package source_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/source"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runSource runs the source in the background, returning its result once it stops
+func runSource(t *testing.T, s source.Source, handler source.Handler) chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- s.Run(handler, zap.NewNop())
+	}()
+	return result
+}
+
+func TestHttpSource(t *testing.T) {
+	events := make(chan map[string]interface{}, 1)
+	httpSource := source.NewHttpSource("http", 0, "/events", 0)
+	result := runSource(t, httpSource, func(ctx context.Context, in map[string]interface{}) error {
+		if in["fail"] == true {
+			return fmt.Errorf("failed")
+		}
+		events <- in
+		return nil
+	})
+	for start := time.Now(); httpSource.Addr() == nil && time.Since(start) < time.Second; {
+		time.Sleep(time.Millisecond)
+	}
+	url := fmt.Sprintf("http://%s/events", httpSource.Addr().String())
+
+	post := func(body string) int {
+		resp, err := http.Post(url, "application/json", strings.NewReader(body))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, post(`{"a": "b"}`))
+	assert.Equal(t, map[string]interface{}{"a": "b"}, <-events)
+	assert.Equal(t, http.StatusInternalServerError, post(`{"fail": true}`))
+	assert.Equal(t, http.StatusBadRequest, post(`not json`))
+
+	assert.Nil(t, httpSource.Pause())
+	assert.Equal(t, http.StatusServiceUnavailable, post(`{"a": "b"}`))
+	assert.Nil(t, httpSource.Resume())
+	assert.Equal(t, http.StatusOK, post(`{"a": "b"}`))
+	<-events
+
+	assert.Nil(t, httpSource.Stop())
+	assert.Nil(t, <-result)
+	assert.Nil(t, httpSource.Close())
+}
@@ -0,0 +1,403 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/core"
+	"github.com/kmgreen2/agglo/pkg/observability"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"go.uber.org/zap"
+	"io"
+	"net"
+	"sync"
+)
+
+const (
+	DefaultNetworkMaxConnections = 64
+	DefaultMaxMessageSize = 64 * 1024
+)
+
+// NetworkOption configures a NetworkSource
+type NetworkOption func(*NetworkSource)
+
+// WithMaxConnections limits the number of open TCP connections (default DefaultNetworkMaxConnections).
+// Connections over the limit are closed.
+func WithMaxConnections(maxConnections int) NetworkOption {
+	return func(s *NetworkSource) {
+		if maxConnections > 0 {
+			s.maxConnections = maxConnections
+		}
+	}
+}
+
+// WithMaxMessageSize limits the size of a message in bytes (default DefaultMaxMessageSize).  A TCP
+// connection that sends a larger message is closed, and larger UDP datagrams are truncated.
+func WithMaxMessageSize(maxMessageSize int) NetworkOption {
+	return func(s *NetworkSource) {
+		if maxMessageSize > 0 {
+			s.maxMessageSize = maxMessageSize
+		}
+	}
+}
+
+// NetworkSource listens for messages over TCP or UDP, and decodes each message into an event.  Messages
+// cannot be rejected, so messages that cannot be decoded or fail are logged and dropped.  While the source
+// is paused, TCP connections are not read and UDP datagrams are dropped.
+//
+// The following metrics are emitted for each source: <name>.received, <name>.invalid (messages that cannot
+// be decoded), <name>.failed (events that failed), <name>.rejected (connections over the limit and
+// datagrams dropped while paused) and <name>.connections.
+type NetworkSource struct {
+	name string
+	network string
+	address string
+	decode func(message []byte) (map[string]interface{}, error)
+	readMessage func(reader *bufio.Reader, maxSize int) ([]byte, error)
+	splitDatagrams bool
+	maxConnections int
+	maxMessageSize int
+	emitter *observability.Emitter
+	lock sync.Mutex
+	resumed *sync.Cond
+	listener net.Listener
+	packetConn net.PacketConn
+	conns map[net.Conn]bool
+	addr net.Addr
+	paused bool
+	stopped bool
+	wg sync.WaitGroup
+}
+
+func newNetworkSource(name, network, address string, options ...NetworkOption) (*NetworkSource, error) {
+	switch network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return nil, util.NewInvalidError(fmt.Sprintf("source %s: unknown network '%s'", name, network))
+	}
+	s := &NetworkSource{
+		name: name,
+		network: network,
+		address: address,
+		maxConnections: DefaultNetworkMaxConnections,
+		maxMessageSize: DefaultMaxMessageSize,
+		emitter: observability.NewEmitter("agglo/source"),
+		conns: make(map[net.Conn]bool),
+	}
+	s.resumed = sync.NewCond(&s.lock)
+	for _, option := range options {
+		option(s)
+	}
+	for _, metric := range []string{".received", ".invalid", ".failed", ".rejected"} {
+		s.emitter.AddMetric(name + metric, observability.Int64Counter)
+	}
+	s.emitter.AddMetric(name + ".connections", observability.Float64Gauge)
+	return s, nil
+}
+
+// NewSyslogSource returns a source that listens for RFC5424 and RFC3164 syslog messages.  Each UDP
+// datagram is a message, and TCP messages are framed by octet counting or newlines (RFC6587).
+func NewSyslogSource(name, network, address string, options ...NetworkOption) (*NetworkSource, error) {
+	s, err := newNetworkSource(name, network, address, options...)
+	if err != nil {
+		return nil, err
+	}
+	s.decode = decodeSyslog
+	s.readMessage = readSyslogFrame
+	return s, nil
+}
+
+// NewJsonLinesSource returns a source that listens for newline-delimited JSON objects
+func NewJsonLinesSource(name, network, address string, options ...NetworkOption) (*NetworkSource, error) {
+	s, err := newNetworkSource(name, network, address, options...)
+	if err != nil {
+		return nil, err
+	}
+	s.decode = util.JsonToMap
+	s.readMessage = readLine
+	s.splitDatagrams = true
+	return s, nil
+}
+
+var (
+	rfc5424Parser = core.NewSyslogParser()
+	rfc3164Parser = core.NewBSDSyslogParser()
+)
+
+// decodeSyslog parses RFC5424 messages, whose priority is followed by a version, and otherwise RFC3164
+func decodeSyslog(message []byte) (map[string]interface{}, error) {
+	line := string(message)
+	if idx := bytes.IndexByte(message, '>'); idx > 0 && len(line) > idx+2 && line[idx+1] >= '1' &&
+		line[idx+1] <= '9' && line[idx+2] == ' ' {
+		return rfc5424Parser.Parse(line)
+	}
+	return rfc3164Parser.Parse(line)
+}
+
+func messageTooLarge(maxSize int) error {
+	return util.NewInvalidError(fmt.Sprintf("message is larger than %d bytes", maxSize))
+}
+
+// readLine returns the next line, which may be unterminated at the end of the stream
+func readLine(reader *bufio.Reader, maxSize int) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > maxSize + 1 {
+			return nil, messageTooLarge(maxSize)
+		} else if err == bufio.ErrBufferFull {
+			continue
+		} else if err == io.EOF && len(line) > 0 {
+			return line, nil
+		}
+		return line, err
+	}
+}
+
+// readSyslogFrame returns the next octet-counted ("<length> <message>") or newline-delimited message
+func readSyslogFrame(reader *bufio.Reader, maxSize int) ([]byte, error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	if first[0] < '1' || first[0] > '9' {
+		return readLine(reader, maxSize)
+	}
+	var length int
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		} else if b == ' ' {
+			break
+		} else if b < '0' || b > '9' {
+			return nil, util.NewInvalidError(fmt.Sprintf("invalid frame length: '%d%c'", length, b))
+		}
+		length = length * 10 + int(b - '0')
+		if length > maxSize {
+			return nil, messageTooLarge(maxSize)
+		}
+	}
+	message := make([]byte, length)
+	if _, err = io.ReadFull(reader, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (s *NetworkSource) Name() string {
+	return s.name
+}
+
+// Addr returns the address the source listens on, once it is running
+func (s *NetworkSource) Addr() net.Addr {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.addr
+}
+
+func (s *NetworkSource) Run(handler Handler, logger *zap.Logger) error {
+	switch s.network {
+	case "udp", "udp4", "udp6":
+		return s.runPackets(handler, logger)
+	}
+	return s.runStream(handler, logger)
+}
+
+func (s *NetworkSource) runStream(handler Handler, logger *zap.Logger) error {
+	l, err := net.Listen(s.network, s.address)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	if s.stopped {
+		s.lock.Unlock()
+		return l.Close()
+	}
+	s.listener = l
+	s.addr = l.Addr()
+	s.lock.Unlock()
+
+	defer s.wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.isStopped() {
+				return nil
+			}
+			_ = s.Stop()
+			return err
+		}
+		if !s.track(conn) {
+			s.emitter.AddInt64(s.name + ".rejected", 1)
+			logger.Warn(fmt.Sprintf("source %s: rejected connection from %s", s.name, conn.RemoteAddr()))
+			_ = conn.Close()
+			continue
+		}
+		s.wg.Add(1)
+		go s.serve(conn, handler, logger)
+	}
+}
+
+// track will add the connection, unless the source is stopped or at its connection limit
+func (s *NetworkSource) track(conn net.Conn) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped || len(s.conns) >= s.maxConnections {
+		return false
+	}
+	s.conns[conn] = true
+	s.emitter.GaugeFloat64(s.name + ".connections", 1)
+	return true
+}
+
+func (s *NetworkSource) untrack(conn net.Conn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_ = conn.Close()
+	if s.conns[conn] {
+		delete(s.conns, conn)
+		s.emitter.GaugeFloat64(s.name + ".connections", -1)
+	}
+}
+
+func (s *NetworkSource) serve(conn net.Conn, handler Handler, logger *zap.Logger) {
+	defer s.wg.Done()
+	defer s.untrack(conn)
+	reader := bufio.NewReader(conn)
+	for {
+		message, err := s.readMessage(reader, s.maxMessageSize)
+		if err != nil {
+			if _, ok := err.(*util.InvalidError); ok {
+				s.emitter.AddInt64(s.name + ".invalid", 1)
+				logger.Warn(fmt.Sprintf("source %s: closing connection from %s: %s", s.name, conn.RemoteAddr(),
+					err.Error()))
+			}
+			return
+		}
+		if !s.waitResumed() {
+			return
+		}
+		s.handle(message, handler, logger)
+	}
+}
+
+func (s *NetworkSource) runPackets(handler Handler, logger *zap.Logger) error {
+	conn, err := net.ListenPacket(s.network, s.address)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	if s.stopped {
+		s.lock.Unlock()
+		return conn.Close()
+	}
+	s.packetConn = conn
+	s.addr = conn.LocalAddr()
+	s.lock.Unlock()
+
+	buf := make([]byte, s.maxMessageSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if s.isStopped() {
+				return nil
+			}
+			return err
+		}
+		if s.isPaused() {
+			s.emitter.AddInt64(s.name + ".rejected", 1)
+			continue
+		}
+		if !s.splitDatagrams {
+			s.handle(buf[:n], handler, logger)
+			continue
+		}
+		for _, message := range bytes.Split(buf[:n], []byte("\n")) {
+			s.handle(message, handler, logger)
+		}
+	}
+}
+
+func (s *NetworkSource) handle(message []byte, handler Handler, logger *zap.Logger) {
+	message = bytes.TrimRight(message, "\r\n\x00")
+	if len(message) == 0 {
+		return
+	}
+	s.emitter.AddInt64(s.name + ".received", 1)
+	in, err := s.decode(message)
+	if err != nil {
+		s.emitter.AddInt64(s.name + ".invalid", 1)
+		logger.Warn(fmt.Sprintf("source %s: %s", s.name, err.Error()))
+		return
+	}
+	if err = handler(context.Background(), in); err != nil {
+		s.emitter.AddInt64(s.name + ".failed", 1)
+		logger.Error(fmt.Sprintf("source %s: %s", s.name, err.Error()))
+	}
+}
+
+// waitResumed blocks while the source is paused, and returns false if the source is stopped
+func (s *NetworkSource) waitResumed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for s.paused && !s.stopped {
+		s.resumed.Wait()
+	}
+	return !s.stopped
+}
+
+func (s *NetworkSource) isPaused() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.paused
+}
+
+func (s *NetworkSource) isStopped() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stopped
+}
+
+// Stop will stop listening and close the connections.  Run returns after the events that are being
+// handled.
+func (s *NetworkSource) Stop() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	if s.listener != nil {
+		_ = s.listener.Close()
+	}
+	if s.packetConn != nil {
+		_ = s.packetConn.Close()
+	}
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.resumed.Broadcast()
+	return nil
+}
+
+func (s *NetworkSource) Pause() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = true
+	return nil
+}
+
+func (s *NetworkSource) Resume() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = false
+	s.resumed.Broadcast()
+	return nil
+}
+
+func (s *NetworkSource) Close() error {
+	return nil
+}
@@ -0,0 +1,137 @@
+package source_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/source"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+// startNetworkSource runs the source, and returns its events and address once it is listening
+func startNetworkSource(t *testing.T, s *source.NetworkSource) (chan map[string]interface{}, chan error, net.Addr) {
+	events := make(chan map[string]interface{}, 8)
+	result := runSource(t, s, func(ctx context.Context, in map[string]interface{}) error {
+		events <- in
+		return nil
+	})
+	for start := time.Now(); s.Addr() == nil && time.Since(start) < time.Second; {
+		time.Sleep(time.Millisecond)
+	}
+	if s.Addr() == nil {
+		assert.FailNow(t, "source is not listening")
+	}
+	return events, result, s.Addr()
+}
+
+func dial(t *testing.T, network string, addr net.Addr) net.Conn {
+	conn, err := net.Dial(network, addr.String())
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return conn
+}
+
+func TestSyslogSource(t *testing.T) {
+	syslogSource, err := source.NewSyslogSource("syslog", "tcp", "127.0.0.1:0")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	events, result, addr := startNetworkSource(t, syslogSource)
+
+	// Octet-counted and newline-delimited frames, of either format, on one connection
+	rfc5424 := "<165>1 2003-10-11T22:14:15.003Z host app - ID47 - hello\nworld"
+	conn := dial(t, "tcp", addr)
+	_, err = conn.Write([]byte(fmt.Sprintf("%d %s", len(rfc5424), rfc5424) + "<34>Oct 11 22:14:15 gateway su: 'su root' failed\n"))
+	assert.Nil(t, err)
+	in := <-events
+	assert.Equal(t, "ID47", in["msgID"])
+	assert.Equal(t, "hello\nworld", in["message"])
+	in = <-events
+	assert.Equal(t, "gateway", in["hostname"])
+	assert.Equal(t, "su", in["appName"])
+	assert.Equal(t, float64(2), in["severity"])
+	assert.Nil(t, conn.Close())
+
+	assert.Nil(t, syslogSource.Stop())
+	assert.Nil(t, <-result)
+
+	// UDP datagrams are messages
+	syslogSource, err = source.NewSyslogSource("syslog", "udp", "127.0.0.1:0")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	events, result, addr = startNetworkSource(t, syslogSource)
+	conn = dial(t, "udp", addr)
+	_, err = conn.Write([]byte("not syslog"))
+	assert.Nil(t, err)
+	_, err = conn.Write([]byte("<13>link down on port 4"))
+	assert.Nil(t, err)
+	assert.Equal(t, "link down on port 4", (<-events)["message"])
+	assert.Nil(t, conn.Close())
+	assert.Nil(t, syslogSource.Stop())
+	assert.Nil(t, <-result)
+
+	_, err = source.NewSyslogSource("syslog", "unix", "/tmp/syslog.sock")
+	assert.Error(t, err)
+}
+
+func TestJsonLinesSource(t *testing.T) {
+	linesSource, err := source.NewJsonLinesSource("lines", "tcp", "127.0.0.1:0",
+		source.WithMaxConnections(1), source.WithMaxMessageSize(32))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	events, result, addr := startNetworkSource(t, linesSource)
+
+	conn := dial(t, "tcp", addr)
+	_, err = conn.Write([]byte("{\"id\": 1}\r\nnot json\n{\"id\": 2}\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), (<-events)["id"])
+	assert.Equal(t, float64(2), (<-events)["id"])
+
+	// Connections over the limit are closed
+	rejected := dial(t, "tcp", addr)
+	_ = rejected.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = rejected.Read(make([]byte, 1))
+	assert.Error(t, err)
+
+	// Paused connections are not read
+	assert.Nil(t, linesSource.Pause())
+	_, err = conn.Write([]byte("{\"id\": 3}\n"))
+	assert.Nil(t, err)
+	select {
+	case <-events:
+		assert.Fail(t, "event read while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Nil(t, linesSource.Resume())
+	assert.Equal(t, float64(3), (<-events)["id"])
+
+	// Messages over the size limit close the connection
+	_, err = conn.Write([]byte("{\"message\": \"longer than thirty-two bytes\"}\n"))
+	assert.Nil(t, err)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+
+	assert.Nil(t, linesSource.Stop())
+	assert.Nil(t, <-result)
+
+	// Each line of a datagram is an event
+	linesSource, err = source.NewJsonLinesSource("lines", "udp", "127.0.0.1:0")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	events, result, addr = startNetworkSource(t, linesSource)
+	conn = dial(t, "udp", addr)
+	_, err = conn.Write([]byte("{\"id\": 1}\n{\"id\": 2}"))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), (<-events)["id"])
+	assert.Equal(t, float64(2), (<-events)["id"])
+	assert.Nil(t, conn.Close())
+	assert.Nil(t, linesSource.Stop())
+	assert.Nil(t, <-result)
+}
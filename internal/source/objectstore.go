@@ -0,0 +1,576 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	gUuid "github.com/google/uuid"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/kmgreen2/agglo/pkg/storage"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultObjectPollInterval is how often the prefix is listed for new objects
+	DefaultObjectPollInterval = 10 * time.Second
+	// DefaultClaimTimeout is how long a claim is held without progress, before another replica takes it over
+	DefaultClaimTimeout = 5 * time.Minute
+	// DefaultObjectMaxRetries is the number of times an object is retried after a record fails
+	DefaultObjectMaxRetries = 3
+)
+
+// ObjectFormat determines how the records of an object are decoded
+type ObjectFormat string
+
+const (
+	// ObjectAuto chooses the format from the object's extension (.csv, .json, otherwise NDJSON)
+	ObjectAuto ObjectFormat = "auto"
+	// ObjectCsv decodes each row as a record, keyed by the header row
+	ObjectCsv ObjectFormat = "csv"
+	// ObjectNdjson decodes each JSON object as a record
+	ObjectNdjson ObjectFormat = "ndjson"
+	// ObjectJson decodes each object of a JSON array (or a single object) as a record
+	ObjectJson ObjectFormat = "json"
+)
+
+// ParseObjectFormat returns the format with the name ("" is ObjectAuto)
+func ParseObjectFormat(name string) (ObjectFormat, error) {
+	switch ObjectFormat(name) {
+	case "":
+		return ObjectAuto, nil
+	case ObjectAuto, ObjectCsv, ObjectNdjson, ObjectJson:
+		return ObjectFormat(name), nil
+	}
+	return ObjectAuto, util.NewInvalidError(fmt.Sprintf("unknown object format '%s'", name))
+}
+
+const (
+	claimClaimed = "claimed"
+	claimReleased = "released"
+	claimDone = "done"
+	claimFailed = "failed"
+)
+
+// objectClaim is stored at the marker key of an object.  Records is the number of records that have been
+// processed, which are skipped when the object is retried or taken over.
+type objectClaim struct {
+	Owner string `json:"owner"`
+	State string `json:"state"`
+	ClaimedAt int64 `json:"claimedAt"`
+	Records int64 `json:"records"`
+	Attempts int `json:"attempts"`
+}
+
+// heldClaim is a claim held by this source, and its stored value
+type heldClaim struct {
+	key string
+	claim objectClaim
+	value []byte
+	refreshed time.Time
+}
+
+// ObjectStoreSourceOption configures an ObjectStoreSource
+type ObjectStoreSourceOption func(*ObjectStoreSource)
+
+// WithObjectFormat sets how records are decoded (default ObjectAuto)
+func WithObjectFormat(format ObjectFormat) ObjectStoreSourceOption {
+	return func(s *ObjectStoreSource) {
+		s.format = format
+	}
+}
+
+// WithCsvColumns sets the keys of the columns of CSV objects, which do not have a header row
+func WithCsvColumns(columns []string) ObjectStoreSourceOption {
+	return func(s *ObjectStoreSource) {
+		s.csvColumns = columns
+	}
+}
+
+// WithCsvHeaderMapping renames the columns of CSV objects from their header (or WithCsvColumns) to keys.
+// Columns that are not mapped keep their name.
+func WithCsvHeaderMapping(mapping map[string]string) ObjectStoreSourceOption {
+	return func(s *ObjectStoreSource) {
+		s.csvHeaderMapping = mapping
+	}
+}
+
+// WithObjectPollInterval sets how often the prefix is listed (default DefaultObjectPollInterval)
+func WithObjectPollInterval(interval time.Duration) ObjectStoreSourceOption {
+	return func(s *ObjectStoreSource) {
+		s.pollInterval = interval
+	}
+}
+
+// WithClaimTimeout sets how long a claim is held without progress (default DefaultClaimTimeout)
+func WithClaimTimeout(timeout time.Duration) ObjectStoreSourceOption {
+	return func(s *ObjectStoreSource) {
+		s.claimTimeout = timeout
+	}
+}
+
+// WithObjectMaxRetries sets the number of times an object is retried (default DefaultObjectMaxRetries)
+func WithObjectMaxRetries(maxRetries int) ObjectStoreSourceOption {
+	return func(s *ObjectStoreSource) {
+		s.maxRetries = maxRetries
+	}
+}
+
+// WithObjectDonePrefix moves completed objects under the prefix, rather than only marking them as done
+func WithObjectDonePrefix(prefix string) ObjectStoreSourceOption {
+	return func(s *ObjectStoreSource) {
+		s.donePrefix = prefix
+	}
+}
+
+// WithObjectFailedPrefix moves failed objects under the prefix, rather than only marking them as failed
+func WithObjectFailedPrefix(prefix string) ObjectStoreSourceOption {
+	return func(s *ObjectStoreSource) {
+		s.failedPrefix = prefix
+	}
+}
+
+// ObjectStoreSource polls a prefix of an object store for new objects, and emits an event for each of
+// their records.  Each object is claimed with a marker key in a KV store, so only one replica processes
+// it, and is marked as done (or moved) once its records are processed.  If a record fails, the claim is
+// released and the object is retried from that record, and the object is marked as failed (or moved) once
+// its retries are exhausted.  A claim that makes no progress for the claim timeout, e.g. because its
+// replica died, is taken over.
+type ObjectStoreSource struct {
+	name string
+	objectStore storage.ObjectStore
+	prefix string
+	claims kvs.KVStore
+	claimPrefix string
+	owner string
+	format ObjectFormat
+	csvColumns []string
+	csvHeaderMapping map[string]string
+	pollInterval time.Duration
+	claimTimeout time.Duration
+	maxRetries int
+	donePrefix string
+	failedPrefix string
+	finished map[string]bool
+	lock sync.Mutex
+	resumed *sync.Cond
+	paused bool
+	stopped bool
+	stopCh chan struct{}
+}
+
+// NewObjectStoreSource returns a source that reads the objects under prefix.  Claims are stored in the
+// claims KV store, which is shared by the replicas (nil is an in-memory store, for a single replica).
+func NewObjectStoreSource(name string, objectStore storage.ObjectStore, prefix string, claims kvs.KVStore,
+	options ...ObjectStoreSourceOption) (*ObjectStoreSource, error) {
+	owner, err := gUuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	if claims == nil {
+		claims = kvs.NewMemKVStore()
+	}
+	s := &ObjectStoreSource{
+		name: name,
+		objectStore: objectStore,
+		prefix: prefix,
+		claims: claims,
+		claimPrefix: fmt.Sprintf("objectSource:%s:", name),
+		owner: owner.String(),
+		format: ObjectAuto,
+		pollInterval: DefaultObjectPollInterval,
+		claimTimeout: DefaultClaimTimeout,
+		maxRetries: DefaultObjectMaxRetries,
+		finished: make(map[string]bool),
+		stopCh: make(chan struct{}),
+	}
+	s.resumed = sync.NewCond(&s.lock)
+	for _, option := range options {
+		option(s)
+	}
+	for _, movePrefix := range []string{s.donePrefix, s.failedPrefix} {
+		if len(movePrefix) > 0 && (movePrefix == prefix || strings.HasPrefix(prefix, movePrefix)) {
+			msg := fmt.Sprintf("source %s: objects cannot be moved to '%s', which contains '%s'", name,
+				movePrefix, prefix)
+			return nil, util.NewInvalidError(msg)
+		}
+	}
+	return s, nil
+}
+
+func (s *ObjectStoreSource) Name() string {
+	return s.name
+}
+
+func (s *ObjectStoreSource) Run(handler Handler, logger *zap.Logger) error {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		if s.waitResumed() {
+			s.poll(handler, logger)
+		}
+		select {
+		case <-s.stopCh:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll will process each new object under the prefix
+func (s *ObjectStoreSource) poll(handler Handler, logger *zap.Logger) {
+	keys, err := s.objectStore.List(context.Background(), s.prefix)
+	if err != nil {
+		logger.Error(fmt.Sprintf("source %s: cannot list '%s': %s", s.name, s.prefix, err.Error()))
+		return
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if s.finished[key] || s.isMoved(key) {
+			continue
+		}
+		if !s.isActive() {
+			return
+		}
+		if err = s.process(key, handler, logger); err != nil {
+			logger.Error(fmt.Sprintf("source %s: %s: %s", s.name, key, err.Error()))
+		}
+	}
+}
+
+func (s *ObjectStoreSource) isMoved(key string) bool {
+	return (len(s.donePrefix) > 0 && strings.HasPrefix(key, s.donePrefix)) ||
+		(len(s.failedPrefix) > 0 && strings.HasPrefix(key, s.failedPrefix))
+}
+
+// claim returns the claim of the object, or nil if the object is claimed by another replica or finished
+func (s *ObjectStoreSource) claim(ctx context.Context, key string) (*heldClaim, error) {
+	var claim objectClaim
+	prev, err := s.claims.Get(ctx, s.claimPrefix + key)
+	if err == nil {
+		if err = json.Unmarshal(prev, &claim); err != nil {
+			return nil, err
+		}
+		switch claim.State {
+		case claimDone, claimFailed:
+			s.finished[key] = true
+			return nil, nil
+		case claimClaimed:
+			if time.Since(time.Unix(0, claim.ClaimedAt)) < s.claimTimeout {
+				return nil, nil
+			}
+		}
+	} else if !errors.Is(err, &util.NotFoundError{}) {
+		return nil, err
+	} else {
+		prev = nil
+	}
+
+	held := &heldClaim{key: key, claim: claim}
+	held.claim.Owner = s.owner
+	held.claim.State = claimClaimed
+	if err = s.updateClaim(ctx, held, prev); err != nil {
+		if errors.Is(err, &util.ConflictError{}) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return held, nil
+}
+
+// updateClaim will store the claim, if its value is still prev
+func (s *ObjectStoreSource) updateClaim(ctx context.Context, held *heldClaim, prev []byte) error {
+	held.refreshed = time.Now()
+	held.claim.ClaimedAt = held.refreshed.UnixNano()
+	value, err := json.Marshal(held.claim)
+	if err != nil {
+		return err
+	}
+	if err = s.claims.AtomicPut(ctx, s.claimPrefix + held.key, prev, value); err != nil {
+		return err
+	}
+	held.value = value
+	return nil
+}
+
+// process will claim the object and handle its records
+func (s *ObjectStoreSource) process(key string, handler Handler, logger *zap.Logger) error {
+	ctx := context.Background()
+	held, err := s.claim(ctx, key)
+	if err != nil || held == nil {
+		return err
+	}
+
+	reader, err := s.objectStore.Get(ctx, key)
+	if err != nil {
+		return s.release(ctx, held, held.claim.Records, err, logger)
+	}
+	defer closeReader(reader)
+	records, err := s.newRecordReader(key, reader)
+	if err != nil {
+		return s.finish(ctx, held, held.claim.Records, err, logger)
+	}
+
+	var n int64
+	for ; ; n++ {
+		in, err := records()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			// The object cannot be decoded, so retrying will not help
+			return s.finish(ctx, held, n, err, logger)
+		}
+		if n < held.claim.Records {
+			continue
+		}
+		// The claim is released rather than held while paused, so it does not expire and get taken over
+		if !s.isActive() {
+			return s.release(ctx, held, n, nil, logger)
+		}
+		if err = handler(ctx, in); err != nil {
+			return s.release(ctx, held, n, err, logger)
+		}
+		if time.Since(held.refreshed) > s.claimTimeout / 3 {
+			held.claim.Records = n + 1
+			if err = s.updateClaim(ctx, held, held.value); err != nil {
+				return errors.Wrap(err, "lost claim")
+			}
+		}
+	}
+	return s.finish(ctx, held, n, nil, logger)
+}
+
+// release will release the claim, so the object is retried from record n.  If failure is not nil, it
+// counts against the object's retries.
+func (s *ObjectStoreSource) release(ctx context.Context, held *heldClaim, n int64, failure error,
+	logger *zap.Logger) error {
+	if failure != nil {
+		held.claim.Attempts++
+		if held.claim.Attempts > s.maxRetries {
+			return s.finish(ctx, held, n, failure, logger)
+		}
+		logger.Warn(fmt.Sprintf("source %s: %s failed at record %d, retrying: %s", s.name, held.key, n,
+			failure.Error()))
+	}
+	held.claim.State = claimReleased
+	held.claim.Records = n
+	return s.updateClaim(ctx, held, held.value)
+}
+
+// finish will mark the object as done or, if failure is not nil, as failed, and move it if configured to
+func (s *ObjectStoreSource) finish(ctx context.Context, held *heldClaim, n int64, failure error,
+	logger *zap.Logger) error {
+	held.claim.State = claimDone
+	movePrefix := s.donePrefix
+	if failure != nil {
+		held.claim.State = claimFailed
+		movePrefix = s.failedPrefix
+		logger.Error(fmt.Sprintf("source %s: %s failed at record %d: %s", s.name, held.key, n, failure.Error()))
+	}
+	held.claim.Records = n
+	if err := s.updateClaim(ctx, held, held.value); err != nil {
+		return err
+	}
+	s.finished[held.key] = true
+	if len(movePrefix) > 0 {
+		return s.move(ctx, held.key, movePrefix + strings.TrimPrefix(held.key, s.prefix))
+	}
+	return nil
+}
+
+func (s *ObjectStoreSource) move(ctx context.Context, from, to string) error {
+	reader, err := s.objectStore.Get(ctx, from)
+	if err != nil {
+		return err
+	}
+	err = s.objectStore.Put(ctx, to, reader)
+	closeReader(reader)
+	if err != nil {
+		return err
+	}
+	return s.objectStore.Delete(ctx, from)
+}
+
+// closeReader will close a reader returned by the object store, if it needs closing
+func closeReader(reader io.Reader) {
+	if closer, ok := reader.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+// newRecordReader returns a function that returns the next record of the object, or io.EOF
+func (s *ObjectStoreSource) newRecordReader(key string, reader io.Reader) (func() (map[string]interface{},
+	error), error) {
+	format := s.format
+	if format == ObjectAuto {
+		switch {
+		case strings.HasSuffix(key, ".csv"):
+			format = ObjectCsv
+		case strings.HasSuffix(key, ".json"):
+			format = ObjectJson
+		default:
+			format = ObjectNdjson
+		}
+	}
+
+	switch format {
+	case ObjectCsv:
+		return s.newCsvReader(reader)
+	case ObjectJson:
+		return newJsonArrayReader(reader)
+	}
+	decoder := json.NewDecoder(reader)
+	return func() (map[string]interface{}, error) {
+		var in map[string]interface{}
+		if err := decoder.Decode(&in); err != nil {
+			return nil, err
+		}
+		return in, nil
+	}, nil
+}
+
+func (s *ObjectStoreSource) newCsvReader(reader io.Reader) (func() (map[string]interface{}, error), error) {
+	// Skip a UTF-8 byte order mark, which some spreadsheets write
+	buffered := bufio.NewReader(reader)
+	if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		_, _ = buffered.Discard(3)
+	}
+	csvReader := csv.NewReader(buffered)
+	csvReader.FieldsPerRecord = -1
+
+	columns := s.csvColumns
+	if len(columns) == 0 {
+		header, err := csvReader.Read()
+		if err == io.EOF {
+			return func() (map[string]interface{}, error) {
+				return nil, io.EOF
+			}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		columns = header
+	}
+	keys := make([]string, len(columns))
+	for i, column := range columns {
+		keys[i] = strings.TrimSpace(column)
+		if mapped, ok := s.csvHeaderMapping[keys[i]]; ok {
+			keys[i] = mapped
+		}
+	}
+
+	return func() (map[string]interface{}, error) {
+		row, err := csvReader.Read()
+		if err != nil {
+			return nil, err
+		}
+		if len(row) > len(keys) {
+			return nil, util.NewInvalidError(fmt.Sprintf("row has %d columns, but there are %d keys", len(row),
+				len(keys)))
+		}
+		in := make(map[string]interface{})
+		for i, value := range row {
+			if len(keys[i]) > 0 {
+				in[keys[i]] = value
+			}
+		}
+		return in, nil
+	}, nil
+}
+
+// newJsonArrayReader streams the objects of a JSON array, or a single JSON object
+func newJsonArrayReader(reader io.Reader) (func() (map[string]interface{}, error), error) {
+	buffered := bufio.NewReader(reader)
+	decoder := json.NewDecoder(buffered)
+	isArray := false
+	for {
+		b, err := buffered.Peek(1)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			_, _ = buffered.Discard(1)
+			continue
+		}
+		if b[0] == '[' {
+			if _, err = decoder.Token(); err != nil {
+				return nil, err
+			}
+			isArray = true
+		}
+		break
+	}
+
+	done := false
+	return func() (map[string]interface{}, error) {
+		if done || (isArray && !decoder.More()) {
+			return nil, io.EOF
+		}
+		var in map[string]interface{}
+		if err := decoder.Decode(&in); err != nil {
+			return nil, err
+		}
+		done = !isArray
+		return in, nil
+	}, nil
+}
+
+// waitResumed blocks while the source is paused, and returns false if the source is stopped
+func (s *ObjectStoreSource) waitResumed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for s.paused && !s.stopped {
+		s.resumed.Wait()
+	}
+	return !s.stopped
+}
+
+// isActive returns false if the source is paused or stopped
+func (s *ObjectStoreSource) isActive() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return !s.paused && !s.stopped
+}
+
+// Stop will stop reading objects, releasing the claim of the current object after its current record
+func (s *ObjectStoreSource) Stop() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stopCh)
+		s.resumed.Broadcast()
+	}
+	return nil
+}
+
+// Pause will stop reading objects until Resume is called, releasing the claim of the current object after
+// its current record, so other replicas can take it over
+func (s *ObjectStoreSource) Pause() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = true
+	return nil
+}
+
+func (s *ObjectStoreSource) Resume() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = false
+	s.resumed.Broadcast()
+	return nil
+}
+
+func (s *ObjectStoreSource) Close() error {
+	return nil
+}
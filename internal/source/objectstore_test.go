@@ -0,0 +1,263 @@
+package source_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/source"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/kmgreen2/agglo/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newMemObjectStore returns a new store with the objects; mem object stores are shared by name, so the name
+// is made unique
+func newMemObjectStore(t *testing.T, name string, objects map[string]string) storage.ObjectStore {
+	params, err := storage.NewMemObjectStoreBackendParams(storage.MemObjectStoreBackend,
+		fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	objectStore, err := storage.NewMemObjectStore(params)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	for key, value := range objects {
+		assert.Nil(t, objectStore.Put(context.Background(), key, bytes.NewBufferString(value)))
+	}
+	return objectStore
+}
+
+// closingObjectStore counts the readers returned by Get that are not closed
+type closingObjectStore struct {
+	storage.ObjectStore
+	lock sync.Mutex
+	open int
+}
+
+type closingReader struct {
+	io.Reader
+	store *closingObjectStore
+}
+
+func (r *closingReader) Close() error {
+	r.store.lock.Lock()
+	defer r.store.lock.Unlock()
+	r.store.open--
+	return nil
+}
+
+func (s *closingObjectStore) Get(ctx context.Context, key string) (io.Reader, error) {
+	reader, err := s.ObjectStore.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.open++
+	return &closingReader{Reader: reader, store: s}, nil
+}
+
+func (s *closingObjectStore) openReaders() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.open
+}
+
+func listKeys(t *testing.T, objectStore storage.ObjectStore, prefix string) []string {
+	keys, err := objectStore.List(context.Background(), prefix)
+	assert.Nil(t, err)
+	sort.Strings(keys)
+	return keys
+}
+
+func newObjectStoreSource(t *testing.T, objectStore storage.ObjectStore, claims kvs.KVStore,
+	options ...source.ObjectStoreSourceOption) *source.ObjectStoreSource {
+	options = append(options, source.WithObjectPollInterval(10 * time.Millisecond))
+	s, err := source.NewObjectStoreSource("objects", objectStore, "inbox/", claims, options...)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return s
+}
+
+// waitFor polls until the condition is true
+func waitFor(t *testing.T, condition func() bool) {
+	for start := time.Now(); !condition(); time.Sleep(5 * time.Millisecond) {
+		if time.Since(start) > 2 * time.Second {
+			assert.FailNow(t, "timed out")
+		}
+	}
+}
+
+func TestObjectStoreSource(t *testing.T) {
+	objectStore := newMemObjectStore(t, "objectStoreSource", map[string]string{
+		"inbox/a.csv": "\xef\xbb\xbfid,Device Name\n1,sensor\n2,\"gateway, west\"\n",
+		"inbox/b.ndjson": "{\"id\": \"3\"}\n{\"id\": \"4\"}\n",
+		"inbox/c.json": "[{\"id\": \"5\"}, {\"id\": \"6\"}]",
+		"inbox/d.json": "{\"id\": \"7\"}",
+	})
+
+	// Two replicas share the claims, so each record is read once
+	claims := kvs.NewMemKVStore()
+	var lock sync.Mutex
+	var records []map[string]interface{}
+	handler := func(ctx context.Context, in map[string]interface{}) error {
+		lock.Lock()
+		defer lock.Unlock()
+		records = append(records, in)
+		return nil
+	}
+	var replicas []*source.ObjectStoreSource
+	var results []chan error
+	for i := 0; i < 2; i++ {
+		replica := newObjectStoreSource(t, objectStore, claims, source.WithObjectDonePrefix("done/"),
+			source.WithCsvHeaderMapping(map[string]string{"Device Name": "device"}))
+		replicas = append(replicas, replica)
+		results = append(results, runSource(t, replica, handler))
+	}
+	waitFor(t, func() bool {
+		return len(listKeys(t, objectStore, "done/")) == 4
+	})
+	for i, replica := range replicas {
+		assert.Nil(t, replica.Stop())
+		assert.Nil(t, <-results[i])
+		assert.Nil(t, replica.Close())
+	}
+
+	assert.Empty(t, listKeys(t, objectStore, "inbox/"))
+	lock.Lock()
+	defer lock.Unlock()
+	sort.Slice(records, func(i, j int) bool {
+		return records[i]["id"].(string) < records[j]["id"].(string)
+	})
+	assert.Equal(t, []map[string]interface{}{
+		{"id": "1", "device": "sensor"},
+		{"id": "2", "device": "gateway, west"},
+		{"id": "3"}, {"id": "4"}, {"id": "5"}, {"id": "6"}, {"id": "7"},
+	}, records)
+
+	_, err := source.NewObjectStoreSource("objects", objectStore, "inbox/", nil,
+		source.WithObjectDonePrefix("inbox/"))
+	assert.Error(t, err)
+	_, err = source.ParseObjectFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestObjectStoreSourceRetries(t *testing.T) {
+	objectStore := newMemObjectStore(t, "objectStoreSourceRetries", map[string]string{
+		"inbox/a": "{\"id\": 1}\n{\"id\": 2}\n{\"id\": 3}\n",
+		"inbox/b": "{\"id\": 4}\n{\"id\": \"poison\"}\n",
+		"inbox/c": "1,2\n3,4\n",
+	})
+	claims := kvs.NewMemKVStore()
+
+	// A claim that has not made progress is taken over
+	assert.Nil(t, claims.Put(context.Background(), "objectSource:objects:inbox/c",
+		[]byte(fmt.Sprintf(`{"owner": "dead", "state": "claimed", "claimedAt": %d}`,
+			time.Now().Add(-time.Minute).UnixNano()))))
+
+	var lock sync.Mutex
+	var attempts []interface{}
+	failed := false
+	objectSource := newObjectStoreSource(t, objectStore, claims, source.WithObjectMaxRetries(1),
+		source.WithObjectFailedPrefix("failed/"), source.WithClaimTimeout(time.Second),
+		source.WithObjectFormat(source.ObjectNdjson))
+	result := runSource(t, objectSource, func(ctx context.Context, in map[string]interface{}) error {
+		lock.Lock()
+		defer lock.Unlock()
+		attempts = append(attempts, in["id"])
+		if in["id"] == float64(2) && !failed {
+			failed = true
+			return fmt.Errorf("failed")
+		} else if in["id"] == "poison" {
+			return fmt.Errorf("poison")
+		}
+		return nil
+	})
+	waitFor(t, func() bool {
+		return len(listKeys(t, objectStore, "failed/")) == 2
+	})
+	assert.Nil(t, objectSource.Stop())
+	assert.Nil(t, <-result)
+
+	// a is retried from its failed record, b fails after its retry and c cannot be decoded as NDJSON
+	assert.Equal(t, []string{"inbox/a"}, listKeys(t, objectStore, "inbox/"))
+	assert.Equal(t, []string{"failed/b", "failed/c"}, listKeys(t, objectStore, "failed/"))
+	lock.Lock()
+	defer lock.Unlock()
+	// Objects are retried on the next poll, without the records that succeeded
+	assert.Equal(t, []interface{}{float64(1), float64(2), float64(4), "poison", float64(2), float64(3),
+		"poison"}, attempts)
+	marker, err := claims.Get(context.Background(), "objectSource:objects:inbox/a")
+	assert.Nil(t, err)
+	assert.Contains(t, string(marker), `"state":"done"`)
+}
+
+func TestObjectStoreSourcePause(t *testing.T) {
+	objectStore := newMemObjectStore(t, "objectStoreSourcePause", map[string]string{
+		"inbox/a": "{\"id\": 1}\n{\"id\": 2}\n{\"id\": 3}\n",
+	})
+	claims := kvs.NewMemKVStore()
+
+	var lock sync.Mutex
+	var records []interface{}
+	var objectSource *source.ObjectStoreSource
+	objectSource = newObjectStoreSource(t, objectStore, claims, source.WithObjectFormat(source.ObjectNdjson))
+	result := runSource(t, objectSource, func(ctx context.Context, in map[string]interface{}) error {
+		lock.Lock()
+		defer lock.Unlock()
+		records = append(records, in["id"])
+		if len(records) == 1 {
+			assert.Nil(t, objectSource.Pause())
+		}
+		return nil
+	})
+
+	// The claim is released while paused, so it cannot expire while held
+	waitFor(t, func() bool {
+		marker, err := claims.Get(context.Background(), "objectSource:objects:inbox/a")
+		return err == nil && bytes.Contains(marker, []byte(`"state":"released"`))
+	})
+	lock.Lock()
+	assert.Equal(t, []interface{}{float64(1)}, records)
+	lock.Unlock()
+
+	assert.Nil(t, objectSource.Resume())
+	waitFor(t, func() bool {
+		marker, err := claims.Get(context.Background(), "objectSource:objects:inbox/a")
+		return err == nil && bytes.Contains(marker, []byte(`"state":"done"`))
+	})
+	assert.Nil(t, objectSource.Stop())
+	assert.Nil(t, <-result)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []interface{}{float64(1), float64(2), float64(3)}, records)
+}
+
+func TestObjectStoreSourceClosesReaders(t *testing.T) {
+	objectStore := &closingObjectStore{ObjectStore: newMemObjectStore(t, "objectStoreSourceClose",
+		map[string]string{
+			"inbox/a.ndjson": "{\"id\": \"1\"}\n",
+			"inbox/b.json": "[{\"id\": ",
+		})}
+
+	s := newObjectStoreSource(t, objectStore, kvs.NewMemKVStore(), source.WithObjectDonePrefix("done/"),
+		source.WithObjectFailedPrefix("failed/"))
+	result := runSource(t, s, func(ctx context.Context, in map[string]interface{}) error {
+		return nil
+	})
+	waitFor(t, func() bool {
+		return len(listKeys(t, objectStore, "done/")) == 1 && len(listKeys(t, objectStore, "failed/")) == 1
+	})
+	assert.Nil(t, s.Stop())
+	assert.Nil(t, <-result)
+	assert.Nil(t, s.Close())
+	assert.Equal(t, 0, objectStore.openReaders())
+}
@@ -0,0 +1,215 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/kmgreen2/agglo/pkg/state"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// ScheduleKey is the key of the schedule metadata in the events of a schedule source
+	ScheduleKey = "schedule"
+	// DefaultLeaderLockTimeout is how long a replica waits for the leader lock before skipping a tick
+	DefaultLeaderLockTimeout = 5 * time.Second
+)
+
+// ScheduleOption configures a ScheduleSource
+type ScheduleOption func(*ScheduleSource)
+
+// WithScheduleLocation sets the time zone of cron expressions (default UTC)
+func WithScheduleLocation(location *time.Location) ScheduleOption {
+	return func(s *ScheduleSource) {
+		s.location = location
+	}
+}
+
+// WithSchedulePayload adds fields to each event
+func WithSchedulePayload(payload map[string]interface{}) ScheduleOption {
+	return func(s *ScheduleSource) {
+		s.payload = payload
+	}
+}
+
+// WithLeaderElection ensures that only one replica fires each tick.  The replicas share the KV store, and
+// the replica that holds the lock first records the tick.
+func WithLeaderElection(kvStore kvs.KVStore, lockTimeout time.Duration) ScheduleOption {
+	return func(s *ScheduleSource) {
+		s.kvStore = kvStore
+		if lockTimeout > 0 {
+			s.lockTimeout = lockTimeout
+		}
+	}
+}
+
+// ScheduleSource emits a synthetic event on a schedule, e.g. to flush aggregates, send heartbeats or poll
+// an API.  Each event has the schedule's metadata in ScheduleKey:
+//
+// {"name": <source>, "expression": <schedule>, "scheduledTime": <RFC3339>, "firedTime": <RFC3339>}
+//
+// Ticks are skipped while the source is paused, or while an earlier event is being handled.  With leader
+// election, a tick is skipped if its replica cannot get the lock within the lock timeout.
+type ScheduleSource struct {
+	name string
+	expression string
+	schedule Schedule
+	location *time.Location
+	payload map[string]interface{}
+	kvStore kvs.KVStore
+	lockTimeout time.Duration
+	leaderLock state.DistributedLock
+	lock sync.Mutex
+	paused bool
+	stopped bool
+	stopCh chan struct{}
+}
+
+// NewScheduleSource returns a source that fires on the schedule (see ParseSchedule)
+func NewScheduleSource(name, expression string, options ...ScheduleOption) (*ScheduleSource, error) {
+	s := &ScheduleSource{
+		name: name,
+		expression: expression,
+		lockTimeout: DefaultLeaderLockTimeout,
+		stopCh: make(chan struct{}),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	var err error
+	if s.schedule, err = ParseSchedule(expression, s.location); err != nil {
+		return nil, err
+	}
+	if s.schedule.Next(time.Now()).IsZero() {
+		return nil, util.NewInvalidError(fmt.Sprintf("schedule '%s' never fires", expression))
+	}
+	if s.kvStore != nil {
+		s.leaderLock = state.NewKVDistributedLock("schedule:" + name, s.kvStore)
+	}
+	return s, nil
+}
+
+func (s *ScheduleSource) Name() string {
+	return s.name
+}
+
+func (s *ScheduleSource) lastTickKey() string {
+	return fmt.Sprintf("schedule:%s:lastTick", s.name)
+}
+
+func (s *ScheduleSource) Run(handler Handler, logger *zap.Logger) error {
+	next := s.schedule.Next(time.Now())
+	for {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.stopCh:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		tick := next
+		if !s.isPaused() {
+			s.fire(tick, handler, logger)
+		}
+		// Ticks that passed while the event was handled are skipped
+		now := time.Now()
+		if now.Before(tick) {
+			now = tick
+		}
+		next = s.schedule.Next(now)
+		if next.IsZero() {
+			return nil
+		}
+	}
+}
+
+// fire will handle the tick's event, if this replica is the leader for the tick
+func (s *ScheduleSource) fire(tick time.Time, handler Handler, logger *zap.Logger) {
+	ctx := context.Background()
+	if s.leaderLock != nil {
+		isLeader, err := s.claimTick(ctx, tick)
+		if err != nil {
+			logger.Error(fmt.Sprintf("source %s: skipping tick %s: %s", s.name, tick.Format(time.RFC3339),
+				err.Error()))
+			return
+		} else if !isLeader {
+			return
+		}
+	}
+
+	in := util.CopyableMap(s.payload).DeepCopy()
+	in[ScheduleKey] = map[string]interface{}{
+		"name": s.name,
+		"expression": s.expression,
+		"scheduledTime": tick.UTC().Format(time.RFC3339Nano),
+		"firedTime": time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if err := handler(ctx, in); err != nil {
+		logger.Error(fmt.Sprintf("source %s: tick %s failed: %s", s.name, tick.Format(time.RFC3339), err.Error()))
+	}
+}
+
+// claimTick will record the tick, while holding the leader lock, unless another replica has recorded it
+func (s *ScheduleSource) claimTick(ctx context.Context, tick time.Time) (bool, error) {
+	lockCtx, err := s.leaderLock.Lock(ctx, s.lockTimeout)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = s.leaderLock.Unlock(lockCtx)
+	}()
+
+	value, err := s.kvStore.Get(lockCtx, s.lastTickKey())
+	if err == nil {
+		lastTick, err := strconv.ParseInt(string(value), 10, 64)
+		if err == nil && lastTick >= tick.UnixNano() {
+			return false, nil
+		}
+	} else if !errors.Is(err, &util.NotFoundError{}) {
+		return false, err
+	}
+	if err = s.kvStore.Put(lockCtx, s.lastTickKey(), []byte(strconv.FormatInt(tick.UnixNano(), 10))); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *ScheduleSource) isPaused() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.paused
+}
+
+// Stop will stop firing, after the event that is being handled
+func (s *ScheduleSource) Stop() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stopCh)
+	}
+	return nil
+}
+
+func (s *ScheduleSource) Pause() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = true
+	return nil
+}
+
+func (s *ScheduleSource) Resume() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = false
+	return nil
+}
+
+func (s *ScheduleSource) Close() error {
+	return nil
+}
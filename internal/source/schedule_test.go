@@ -0,0 +1,110 @@
+package source_test
+
+import (
+	"context"
+	"github.com/kmgreen2/agglo/internal/source"
+	"github.com/kmgreen2/agglo/pkg/kvs"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		return parsed
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	for _, test := range []struct {
+		spec string
+		location *time.Location
+		after string
+		next string
+	}{
+		{"*/15 * * * *", nil, "2021-03-01T10:07:30Z", "2021-03-01T10:15:00Z"},
+		{"0 9-17/4 * * mon-fri", nil, "2021-03-05T17:30:00Z", "2021-03-08T09:00:00Z"},
+		{"30 2 1,15 * *", nil, "2021-03-01T02:30:00Z", "2021-03-15T02:30:00Z"},
+		{"0 0 * feb sun", nil, "2021-01-10T00:00:00Z", "2021-02-07T00:00:00Z"},
+		// Days of the month or of the week
+		{"0 0 13 * 5", nil, "2021-03-01T00:00:00Z", "2021-03-05T00:00:00Z"},
+		{"0 0 * * 7", nil, "2021-03-01T00:00:00Z", "2021-03-07T00:00:00Z"},
+		{"@daily", newYork, "2021-03-01T12:00:00Z", "2021-03-02T05:00:00Z"},
+		{"@every 10m", nil, "2021-03-01T10:07:30Z", "2021-03-01T10:10:00Z"},
+	} {
+		schedule, err := source.ParseSchedule(test.spec, test.location)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, at(test.next), schedule.Next(at(test.after)).UTC(), test.spec)
+	}
+
+	for _, spec := range []string{"* * * *", "60 * * * *", "* * * * funday", "5-1 * * * *", "*/0 * * * *",
+		"@every 0s", "@sometimes"} {
+		_, err = source.ParseSchedule(spec, nil)
+		assert.Error(t, err, spec)
+	}
+	_, err = source.NewScheduleSource("schedule", "0 0 30 2 *")
+	assert.Error(t, err)
+}
+
+func TestScheduleSource(t *testing.T) {
+	// Two replicas share a KV store, so each tick fires once
+	kvStore := kvs.NewMemKVStore()
+	var lock sync.Mutex
+	ticks := make(map[interface{}]int)
+	handler := func(ctx context.Context, in map[string]interface{}) error {
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Equal(t, "heartbeat", in["type"])
+		schedule := in[source.ScheduleKey].(map[string]interface{})
+		assert.Equal(t, "heartbeat", schedule["name"])
+		assert.Equal(t, "@every 50ms", schedule["expression"])
+		ticks[schedule["scheduledTime"]]++
+		return nil
+	}
+
+	var replicas []*source.ScheduleSource
+	var results []chan error
+	for i := 0; i < 2; i++ {
+		replica, err := source.NewScheduleSource("heartbeat", "@every 50ms",
+			source.WithSchedulePayload(map[string]interface{}{"type": "heartbeat"}),
+			source.WithLeaderElection(kvStore, time.Second))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		replicas = append(replicas, replica)
+		results = append(results, runSource(t, replica, handler))
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	// Paused replicas do not fire
+	for _, replica := range replicas {
+		assert.Nil(t, replica.Pause())
+	}
+	time.Sleep(10 * time.Millisecond)
+	lock.Lock()
+	numTicks := len(ticks)
+	lock.Unlock()
+	time.Sleep(150 * time.Millisecond)
+
+	for i, replica := range replicas {
+		assert.Nil(t, replica.Stop())
+		assert.Nil(t, <-results[i])
+		assert.Nil(t, replica.Close())
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	assert.True(t, numTicks >= 3, numTicks)
+	assert.Equal(t, numTicks, len(ticks))
+	for tick, count := range ticks {
+		assert.Equal(t, 1, count, tick)
+	}
+}
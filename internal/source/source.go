@@ -0,0 +1,25 @@
+package source
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+// Handler processes an event read by a source.  If it returns nil, the event has been processed (or
+// durably queued) and the source acknowledges it, e.g. by committing its offset or responding to its
+// request.  Otherwise, the source redelivers or rejects the event.
+type Handler func(ctx context.Context, in map[string]interface{}) error
+
+// Source reads events into the pipelines of a daemon
+type Source interface {
+	Name() string
+	// Run reads events and calls handler for each of them, until Stop is called or the source fails
+	Run(handler Handler, logger *zap.Logger) error
+	// Stop will stop reading events, after the events that are being handled
+	Stop() error
+	// Pause stops reading events until Resume is called, e.g. while downstream systems are overloaded
+	Pause() error
+	Resume() error
+	// Close releases the source's resources, after Run returns
+	Close() error
+}
@@ -0,0 +1,161 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/streaming"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"go.uber.org/zap"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSubscriberRetryDelay is the delay before the first retry of a message that failed
+	DefaultSubscriberRetryDelay = 100 * time.Millisecond
+	// MaxSubscriberRetryDelay caps the exponential backoff between retries
+	MaxSubscriberRetryDelay = 5 * time.Second
+)
+
+// SubscriberSource reads events from a subscriber, one at a time, and commits each message after it is
+// handled.  Failed messages are retried with exponential backoff.  Messages that are not JSON objects, or
+// that still fail after maxRetries, are published to the dead-letter publisher, unchanged, and committed.
+// Without a dead-letter publisher, such a message stops the source, so it is redelivered when binge
+// restarts.  A message that is still failing when the source is stopped is not dead-lettered or committed,
+// so it is also redelivered.
+type SubscriberSource struct {
+	name string
+	subscriber streaming.CommittingSubscriber
+	deadLetter streaming.Publisher
+	maxRetries int
+	lock sync.Mutex
+	stopped bool
+	// stopCh is closed by Stop, to interrupt retry delays
+	stopCh chan struct{}
+}
+
+// NewSubscriberSource returns a source for the subscriber.  deadLetter is optional.
+func NewSubscriberSource(name string, subscriber streaming.CommittingSubscriber, deadLetter streaming.Publisher,
+	maxRetries int) *SubscriberSource {
+	return &SubscriberSource{
+		name: name,
+		subscriber: subscriber,
+		deadLetter: deadLetter,
+		maxRetries: maxRetries,
+		stopCh: make(chan struct{}),
+	}
+}
+
+func (s *SubscriberSource) Name() string {
+	return s.name
+}
+
+func (s *SubscriberSource) Run(handler Handler, logger *zap.Logger) error {
+	if s.isStopped() {
+		return nil
+	}
+	err := s.subscriber.SubscribeAndCommit(func(ctx context.Context, payload []byte) error {
+		begin := time.Now()
+		defer func() {
+			elapsed := time.Now().Sub(begin)
+			logger.Info(fmt.Sprintf("Subscriber handler: %d ms", elapsed.Milliseconds()))
+		}()
+		in, err := util.JsonToMap(payload)
+		if err != nil {
+			return s.publishDeadLetter(ctx, payload, err, logger)
+		}
+
+		delay := DefaultSubscriberRetryDelay
+		for attempt := 0; ; attempt++ {
+			err = handler(ctx, in)
+			if err == nil {
+				return nil
+			}
+			if s.isStopped() {
+				// The message is not committed, so it is redelivered
+				return err
+			}
+			if attempt >= s.maxRetries {
+				break
+			}
+			logger.Warn(fmt.Sprintf("retrying message in %d ms: %s", delay.Milliseconds(), err.Error()))
+			if !s.sleep(delay) {
+				return err
+			}
+			if delay *= 2; delay > MaxSubscriberRetryDelay {
+				delay = MaxSubscriberRetryDelay
+			}
+		}
+		return s.publishDeadLetter(ctx, payload, err, logger)
+	})
+	if err != nil && s.isStopped() {
+		logger.Warn(fmt.Sprintf("source %s stopped before a message was handled, it will be redelivered: %s",
+			s.name, err.Error()))
+		return nil
+	}
+	return err
+}
+
+// sleep will wait for the delay, and returns false if the source is stopped first
+func (s *SubscriberSource) sleep(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-s.stopCh:
+		return false
+	}
+}
+
+func (s *SubscriberSource) publishDeadLetter(ctx context.Context, payload []byte, cause error,
+	logger *zap.Logger) error {
+	if s.deadLetter == nil {
+		return cause
+	}
+	logger.Error("publishing message to dead-letter topic: " + cause.Error())
+	return s.deadLetter.Publish(ctx, payload)
+}
+
+func (s *SubscriberSource) isStopped() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stopped
+}
+
+// Stop will stop the subscriber, after the current message is handled
+func (s *SubscriberSource) Stop() error {
+	s.lock.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stopCh)
+	}
+	s.lock.Unlock()
+	return s.subscriber.Stop()
+}
+
+func (s *SubscriberSource) Pause() error {
+	return s.subscriber.Pause()
+}
+
+func (s *SubscriberSource) Resume() error {
+	return s.subscriber.Resume()
+}
+
+// Close will close the subscriber, committing its offsets, and flush and close the dead-letter publisher
+func (s *SubscriberSource) Close() error {
+	var err error
+	if closer, ok := s.subscriber.(io.Closer); ok {
+		err = closer.Close()
+	}
+	if s.deadLetter != nil {
+		if flushErr := s.deadLetter.Flush(context.Background(), streaming.DefaultFlushTimeout); flushErr != nil {
+			err = flushErr
+		}
+		if closeErr := s.deadLetter.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package source_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/kmgreen2/agglo/internal/source"
+	"github.com/kmgreen2/agglo/pkg/streaming"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type countingPublisher struct {
+	published chan []byte
+}
+
+func (p *countingPublisher) Publish(ctx context.Context, b []byte) error {
+	p.published <- b
+	return nil
+}
+
+func (p *countingPublisher) Flush(ctx context.Context, timeout time.Duration) error {
+	return nil
+}
+
+func (p *countingPublisher) Close() error {
+	return nil
+}
+
+func (p *countingPublisher) ConnectionString() string {
+	return "counting"
+}
+
+func TestSubscriberSource(t *testing.T) {
+	publisher, err := streaming.NewPublisherFromConnectionString("mem:subscriberSource")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	for _, payload := range []string{`{"id": "a"}`, `{"id": "b"}`} {
+		assert.Nil(t, publisher.Publish(context.Background(), []byte(payload)))
+	}
+	subscriber, err := streaming.NewSubscriberFromConnectionString("mem:subscriberSource")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// Without a dead-letter publisher, b stops the source after it is retried
+	var attempts []interface{}
+	subscriberSource := source.NewSubscriberSource("subscriber", subscriber, nil, 2)
+	err = <-runSource(t, subscriberSource, func(ctx context.Context, in map[string]interface{}) error {
+		attempts = append(attempts, in["id"])
+		if in["id"] == "b" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "b", "b"}, attempts)
+
+	// b was not committed
+	events := make(chan interface{}, 1)
+	result := runSource(t, subscriberSource, func(ctx context.Context, in map[string]interface{}) error {
+		events <- in["id"]
+		return nil
+	})
+	assert.Equal(t, "b", <-events)
+	assert.Nil(t, subscriberSource.Stop())
+	assert.Nil(t, <-result)
+	assert.Nil(t, subscriberSource.Close())
+}
+
+func TestSubscriberSourceStopWhileRetrying(t *testing.T) {
+	publisher, err := streaming.NewPublisherFromConnectionString("mem:subscriberSourceStop")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Nil(t, publisher.Publish(context.Background(), []byte(`{"id": "c"}`)))
+	subscriber, err := streaming.NewSubscriberFromConnectionString("mem:subscriberSourceStop")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// Stopping interrupts the retry delay, and c is neither dead-lettered nor committed
+	deadLetter := &countingPublisher{published: make(chan []byte, 1)}
+	failures := make(chan struct{}, 16)
+	subscriberSource := source.NewSubscriberSource("subscriber", subscriber, deadLetter, 10)
+	result := runSource(t, subscriberSource, func(ctx context.Context, in map[string]interface{}) error {
+		failures <- struct{}{}
+		return fmt.Errorf("failed")
+	})
+	<-failures
+	assert.Nil(t, subscriberSource.Stop())
+	select {
+	case err = <-result:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "source did not stop while retrying")
+	}
+	assert.Len(t, deadLetter.published, 0)
+
+	// The subscriber redelivers c to the next source
+	events := make(chan interface{}, 1)
+	subscriberSource = source.NewSubscriberSource("subscriber", subscriber, deadLetter, 10)
+	result = runSource(t, subscriberSource, func(ctx context.Context, in map[string]interface{}) error {
+		events <- in["id"]
+		return nil
+	})
+	assert.Equal(t, "c", <-events)
+	assert.Nil(t, subscriberSource.Stop())
+	assert.Nil(t, <-result)
+	assert.Nil(t, subscriberSource.Close())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/kmgreen2/agglo/pkg/util"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -60,50 +61,61 @@ func NewKafkaPublisher(servers []string, topicName string, isSync bool) (*KafkaP
 	}, nil
 }
 
-func NewKafkaPublisherFromConnectionString(connectionString string) (*KafkaPublisher, error) {
+// parseKafkaConnectionString parses "servers=<host:port>,<host:port>,topicName=<topic>,<key>=<value>...",
+// returning the servers and the other entries.  Entries without a key continue the list of servers.
+func parseKafkaConnectionString(connectionString string) ([]string, map[string]string, error) {
 	var servers []string
-	var topicName string
-	var isSync bool
+	var lastKey string
+	entries := make(map[string]string)
 
 	connectionStringAry := strings.Split(connectionString, ",")
 	for _, entry := range connectionStringAry {
 		entryAry := strings.Split(entry, "=")
+		if len(entryAry) == 1 && lastKey == "servers" && len(entry) > 0 {
+			servers = append(servers, entry)
+			continue
+		}
 		if len(entryAry) != 2 {
-			return nil, util.NewInvalidError(fmt.Sprintf("invalid entry in connection string: %s", entry))
-		}
-		switch entryAry[0] {
-		case "servers":
-			servers = strings.Split(entryAry[1], ",")
-		case "topicName":
-			topicName = entryAry[1]
-		case "isSync":
-			if strings.Compare("true", strings.ToLower(entryAry[1])) == 0 {
-				isSync = true
-			} else if strings.Compare("false", strings.ToLower(entryAry[1])) == 0 {
-				isSync = false
-			} else {
-				return nil, util.NewInvalidError(
-					fmt.Sprintf("isSync should be 'true' or 'false', got %s", entryAry[1]))
-			}
+			return nil, nil, util.NewInvalidError(fmt.Sprintf("invalid entry in connection string: %s", entry))
 		}
+		lastKey = entryAry[0]
+		if entryAry[0] == "servers" {
+			servers = append(servers, entryAry[1])
+		} else {
+			entries[entryAry[0]] = entryAry[1]
+		}
+	}
+	if len(servers) == 0 || len(entries["topicName"]) == 0 {
+		return nil, nil, util.NewInvalidError(
+			fmt.Sprintf("kafka connection string requires servers and topicName: %s", connectionString))
 	}
+	return servers, entries, nil
+}
 
-	producer, err := kafka.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": servers,
-	})
+// NewKafkaPublisherFromConnectionString returns a publisher for
+// "servers=<host:port>,<host:port>,topicName=<topic>[,isSync=<bool>]"
+func NewKafkaPublisherFromConnectionString(connectionString string) (*KafkaPublisher, error) {
+	servers, entries, err := parseKafkaConnectionString(connectionString)
 	if err != nil {
 		return nil, err
 	}
-
-	return &KafkaPublisher{
-		producer,
-		servers,
-		topicName,
-		isSync,
-	}, nil
+	isSync := false
+	if value, ok := entries["isSync"]; ok {
+		if strings.Compare("true", strings.ToLower(value)) == 0 {
+			isSync = true
+		} else if strings.Compare("false", strings.ToLower(value)) != 0 {
+			return nil, util.NewInvalidError(fmt.Sprintf("isSync should be 'true' or 'false', got %s", value))
+		}
+	}
+	return NewKafkaPublisher(servers, entries["topicName"], isSync)
 }
 
 func (publisher *KafkaPublisher) Publish(ctx context.Context, b []byte) error {
+	return publisher.PublishWithKey(ctx, nil, b)
+}
+
+// PublishWithKey will publish a message with a key, which determines its partition
+func (publisher *KafkaPublisher) PublishWithKey(ctx context.Context, key, b []byte) error {
 	var deliveryChan chan kafka.Event = nil
 
 	if publisher.isSync {
@@ -112,6 +124,7 @@ func (publisher *KafkaPublisher) Publish(ctx context.Context, b []byte) error {
 
 	err := publisher.producer.Produce(&kafka.Message{
 		TopicPartition: kafka.TopicPartition{Topic: &publisher.topicName, Partition: kafka.PartitionAny},
+		Key: key,
 		Value: b,
 	}, deliveryChan)
 	if err != nil {
@@ -126,7 +139,7 @@ func (publisher *KafkaPublisher) Publish(ctx context.Context, b []byte) error {
 		case e := <-deliveryChan:
 			switch ev := e.(type) {
 			case *kafka.Message:
-				return nil
+				return ev.TopicPartition.Error
 			case kafka.Error:
 				return ev
 			default:
@@ -155,14 +168,23 @@ func (publisher *KafkaPublisher) ConnectionString() string {
 		strconv.FormatBool(publisher.isSync))
 }
 
+// DefaultKafkaSessionTimeoutMs is the session timeout of subscribers created from connection strings, when
+// one is not set
+const DefaultKafkaSessionTimeoutMs = 10000
+
 type KafkaSubscriber struct {
 	consumer *kafka.Consumer
 	servers []string
 	group string
 	topicName string
 	state SubscriberState
+	lock sync.Mutex
+	paused bool
+	handling bool
 }
 
+// NewKafkaSubscriber returns a subscriber in a consumer group.  Offsets are only committed for the
+// messages that have been handled.
 func NewKafkaSubscriber(servers []string, topicName, group string, sessionTimeoutMs int) (*KafkaSubscriber, error) {
 	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
 		"bootstrap.servers": strings.Join(servers, ","),
@@ -170,47 +192,201 @@ func NewKafkaSubscriber(servers []string, topicName, group string, sessionTimeou
 		"session.timeout.ms": sessionTimeoutMs,
 		"auto.offset.reset": "earliest",
 		"group.id": group,
+		"enable.auto.offset.store": false,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &KafkaSubscriber {
-		consumer,
-		servers,
-		group,
-		topicName,
-		SubscriberStopped,
+		consumer: consumer,
+		servers: servers,
+		group: group,
+		topicName: topicName,
+		state: SubscriberStopped,
 	}, nil
 }
 
+// NewKafkaSubscriberFromConnectionString returns a subscriber for
+// "servers=<host:port>,<host:port>,topicName=<topic>,group=<group>[,sessionTimeoutMs=<ms>]"
+func NewKafkaSubscriberFromConnectionString(connectionString string) (*KafkaSubscriber, error) {
+	servers, entries, err := parseKafkaConnectionString(connectionString)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries["group"]) == 0 {
+		return nil, util.NewInvalidError(
+			fmt.Sprintf("kafka subscriber connection string requires a group: %s", connectionString))
+	}
+	sessionTimeoutMs := DefaultKafkaSessionTimeoutMs
+	if value, ok := entries["sessionTimeoutMs"]; ok {
+		if sessionTimeoutMs, err = strconv.Atoi(value); err != nil {
+			return nil, util.NewInvalidError(fmt.Sprintf("sessionTimeoutMs should be an integer, got %s", value))
+		}
+	}
+	return NewKafkaSubscriber(servers, entries["topicName"], entries["group"], sessionTimeoutMs)
+}
+
 func (subscriber *KafkaSubscriber) Subscribe(handler func(ctx context.Context, payload []byte)) error {
-	err := subscriber.consumer.SubscribeTopics([]string{subscriber.topicName}, nil)
+	return subscriber.SubscribeAndCommit(func(ctx context.Context, payload []byte) error {
+		handler(ctx, payload)
+		return nil
+	})
+}
+
+// SubscribeAndCommit handles messages, one at a time, until Stop is called or the handler fails.  A
+// message's offset is stored after the handler succeeds, and stored offsets are committed in the
+// background and on Close.  While a message is handled, the assigned partitions are paused and the
+// consumer keeps polling, so slow pipelines do not get the consumer removed from its group.
+func (subscriber *KafkaSubscriber) SubscribeAndCommit(handler CommitHandler) error {
+	err := subscriber.consumer.SubscribeTopics([]string{subscriber.topicName}, subscriber.rebalance)
 	if err != nil {
 		return err
 	}
 
 	subscriber.state = SubscriberRunning
 
+	// Messages that were fetched before the partitions were paused are handled next, in order
+	var pending []*kafka.Message
 	for subscriber.state == SubscriberRunning {
-		event := subscriber.consumer.Poll(100)
-		switch e := event.(type) {
-		case *kafka.Message:
-			handler(context.Background(), e.Value)
-		case kafka.Error:
-			if e.Code() == kafka.ErrAllBrokersDown {
-				_ = subscriber.Stop()
-				return util.NewInternalError(e.Error())
+		var message *kafka.Message
+		if len(pending) > 0 {
+			message, pending = pending[0], pending[1:]
+		} else if message, err = subscriber.poll(); err != nil {
+			return err
+		}
+		if message == nil {
+			continue
+		}
+
+		result := make(chan error, 1)
+		go func() {
+			result <- handler(context.Background(), message.Value)
+		}()
+		if err = subscriber.setHandling(true); err != nil {
+			return err
+		}
+		for waiting := true; waiting; {
+			select {
+			case err = <-result:
+				waiting = false
+			default:
+				fetched, pollErr := subscriber.poll()
+				if pollErr != nil {
+					return pollErr
+				}
+				if fetched != nil {
+					pending = append(pending, fetched)
+				}
 			}
 		}
+		if err != nil {
+			_ = subscriber.Stop()
+			return err
+		}
+		if err = subscriber.store(message); err != nil {
+			return err
+		}
+		if err = subscriber.setHandling(false); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// poll returns the next message, if one is fetched within 100 ms
+func (subscriber *KafkaSubscriber) poll() (*kafka.Message, error) {
+	switch e := subscriber.consumer.Poll(100).(type) {
+	case *kafka.Message:
+		if e.TopicPartition.Error != nil {
+			return nil, e.TopicPartition.Error
+		}
+		return e, nil
+	case kafka.Error:
+		if e.Code() == kafka.ErrAllBrokersDown {
+			_ = subscriber.Stop()
+			return nil, util.NewInternalError(e.Error())
+		}
+	}
+	return nil, nil
+}
+
+// store will store the offset following the message, to be committed
+func (subscriber *KafkaSubscriber) store(message *kafka.Message) error {
+	next := message.TopicPartition
+	next.Offset++
+	_, err := subscriber.consumer.StoreOffsets([]kafka.TopicPartition{next})
+	if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.Code() == kafka.ErrState {
+		// The partition was revoked while the message was handled, so its new owner will redeliver it
+		return nil
+	}
+	return err
+}
+
+// rebalance will pause newly assigned partitions if the subscriber is paused
+func (subscriber *KafkaSubscriber) rebalance(consumer *kafka.Consumer, event kafka.Event) error {
+	switch e := event.(type) {
+	case kafka.AssignedPartitions:
+		if err := consumer.Assign(e.Partitions); err != nil {
+			return err
+		}
+		subscriber.lock.Lock()
+		defer subscriber.lock.Unlock()
+		if subscriber.paused || subscriber.handling {
+			return consumer.Pause(e.Partitions)
+		}
+	case kafka.RevokedPartitions:
+		return consumer.Unassign()
+	}
+	return nil
+}
+
+// Pause will stop fetching messages, after the current message, until Resume is called
+func (subscriber *KafkaSubscriber) Pause() error {
+	subscriber.lock.Lock()
+	defer subscriber.lock.Unlock()
+	subscriber.paused = true
+	return subscriber.updatePartitions()
+}
+
+// Resume will continue fetching messages after Pause
+func (subscriber *KafkaSubscriber) Resume() error {
+	subscriber.lock.Lock()
+	defer subscriber.lock.Unlock()
+	subscriber.paused = false
+	return subscriber.updatePartitions()
+}
+
+func (subscriber *KafkaSubscriber) setHandling(handling bool) error {
+	subscriber.lock.Lock()
+	defer subscriber.lock.Unlock()
+	subscriber.handling = handling
+	return subscriber.updatePartitions()
+}
+
+// updatePartitions pauses the assigned partitions while the subscriber is paused or handling a message
+func (subscriber *KafkaSubscriber) updatePartitions() error {
+	partitions, err := subscriber.consumer.Assignment()
+	if err != nil || len(partitions) == 0 {
+		return err
+	}
+	if subscriber.paused || subscriber.handling {
+		return subscriber.consumer.Pause(partitions)
+	}
+	return subscriber.consumer.Resume(partitions)
+}
+
 func (subscriber *KafkaSubscriber) Stop() error {
 	subscriber.state = SubscriberStopped
 	return nil
 }
 
+// Close will commit the stored offsets and leave the consumer group.  It should be called after
+// Subscribe or SubscribeAndCommit returns.
+func (subscriber *KafkaSubscriber) Close() error {
+	_ = subscriber.Stop()
+	return subscriber.consumer.Close()
+}
+
 func (subscriber *KafkaSubscriber)  Status() SubscriberState {
 	return subscriber.state
 }
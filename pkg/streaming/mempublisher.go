@@ -31,6 +31,11 @@ func (memPublisher *MemPublisher) Publish(ctx context.Context, payload []byte) e
 	return memPublisher.memPubSub.Publish(memPublisher.topic, payload)
 }
 
+// PublishWithKey will publish a payload.  Topics are not partitioned, so the key is ignored.
+func (memPublisher *MemPublisher) PublishWithKey(ctx context.Context, key, payload []byte) error {
+	return memPublisher.Publish(ctx, payload)
+}
+
 // Flush is a no-op, since all changes immediately take effect
 func (memPublisher *MemPublisher) Flush(ctx context.Context, timeout time.Duration) error {
 	return nil
@@ -63,6 +63,8 @@ func (memTopic *MemTopic) Publish(payload []byte) error {
 
 // Next will return the message at a given element
 func (memTopic *MemTopic) Get(index int64) ([]byte, error) {
+	memTopic.lock.Lock()
+	defer memTopic.lock.Unlock()
 	numMessages := len(memTopic.messageQueue)
 	if numMessages <= int(index) {
 		return nil, util.NewOutOfBoundsError(fmt.Sprintf("MemPubSub - index out of bounds: %d >= %d", index,
@@ -83,39 +85,53 @@ func (pubSub *MemPubSub) CreateTopic(name string) error {
 	return nil
 }
 
+// getTopic returns the topic, since topics may be created while others are used
+func (pubSub *MemPubSub) getTopic(topic string) (*MemTopic, bool) {
+	pubSub.lock.Lock()
+	defer pubSub.lock.Unlock()
+	memTopic, ok := pubSub.memTopics[topic]
+	return memTopic, ok
+}
+
 // Publish will publish a message to the specified topic
 func (pubSub *MemPubSub) Publish(topic string, payload []byte) error {
-	if _, ok := pubSub.memTopics[topic]; !ok {
+	memTopic, ok := pubSub.getTopic(topic)
+	if !ok {
 		return util.NewNotFoundError(fmt.Sprintf("MemPubSub - cannot publish to non-existent topic: %s",
 			topic))
 	}
-	err := pubSub.memTopics[topic].Publish(payload)
+	err := memTopic.Publish(payload)
 	if err != nil {
 		return err
 	}
-	pubSub.memTopics[topic].cond.Broadcast()
+	memTopic.cond.L.Lock()
+	defer memTopic.cond.L.Unlock()
+	memTopic.cond.Broadcast()
 	return nil
 }
 
 // HasTopic will return true if the topic exists and false otherwise
 func (pubSub *MemPubSub) HasTopic(topic string) bool {
-	_, ok := pubSub.memTopics[topic]
+	_, ok := pubSub.getTopic(topic)
 	return ok
 }
 
 // Next will get the next message based on the provided context and topic
 func (pubSub *MemPubSub) Next(ctx *SubscriberContext) ([]byte, error) {
-	if _, ok := pubSub.memTopics[ctx.topic]; !ok {
+	memTopic, ok := pubSub.getTopic(ctx.topic)
+	if !ok {
 		return nil, util.NewNotFoundError(fmt.Sprintf("MemPubSub - cannot subscribe from non-existent topic: %s",
 			ctx.topic))
 	}
 	if ctx.offset > ctx.maxOffset {
 		return nil, util.NewEndOfStreamError(fmt.Sprintf("MemPubSub - end of stream"))
 	}
-	message, err := pubSub.memTopics[ctx.topic].Get(ctx.offset)
+	memTopic.cond.L.Lock()
+	message, err := memTopic.Get(ctx.offset)
 	if errors.Is(err, &util.OutOfBoundsError{}) {
-		pubSub.memTopics[ctx.topic].cond.Wait()
+		memTopic.cond.Wait()
 	}
+	memTopic.cond.L.Unlock()
 	if err != nil {
 		return nil, err
 	}
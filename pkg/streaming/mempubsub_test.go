@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/kmgreen2/agglo/pkg/streaming"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"testing"
+	"time"
 )
 
 func TestBasicHappyPath(t *testing.T) {
@@ -74,3 +76,112 @@ func TestSubscribeNonTopic(t *testing.T) {
 func TestPublishNonTopic(t *testing.T) {
 
 }
+
+func TestPublisherFromConnectionString(t *testing.T) {
+	publisher, err := streaming.NewPublisherFromConnectionString("mem:fromConnectionString")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	// The topic already exists
+	_, err = streaming.NewPublisherFromConnectionString("mem:fromConnectionString")
+	assert.Nil(t, err)
+
+	keyedPublisher, ok := publisher.(streaming.KeyedPublisher)
+	assert.True(t, ok)
+	assert.Nil(t, publisher.Publish(context.Background(), []byte("a")))
+	assert.Nil(t, keyedPublisher.PublishWithKey(context.Background(), []byte("key"), []byte("b")))
+	assert.Nil(t, publisher.Flush(context.Background(), streaming.DefaultFlushTimeout))
+
+	replayer, err := streaming.NewMemReplayer(streaming.DefaultMemPubSub(), "fromConnectionString", 0, 1)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	var payloads []string
+	_ = replayer.Replay(context.Background(), func(ctx context.Context, payload []byte) error {
+		payloads = append(payloads, string(payload))
+		return nil
+	})
+	assert.Equal(t, []string{"a", "b"}, payloads)
+
+	for _, connectionString := range []string{
+		"fromConnectionString",
+		"nats:fromConnectionString",
+		"kafka:servers=localhost:9092",
+		"kafka:topicName=foo",
+		"kafka:servers=localhost:9092,topicName=foo,isSync=maybe",
+	} {
+		_, err = streaming.NewPublisherFromConnectionString(connectionString)
+		assert.Error(t, err, connectionString)
+	}
+}
+
+func TestMemSubscribeAndCommit(t *testing.T) {
+	subscriber, err := streaming.NewSubscriberFromConnectionString("mem:subscribeAndCommit")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	publisher, err := streaming.NewPublisherFromConnectionString("mem:subscribeAndCommit")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	for _, payload := range []string{"a", "b"} {
+		assert.Nil(t, publisher.Publish(context.Background(), []byte(payload)))
+	}
+
+	// b fails, so it is not committed
+	var handled []string
+	err = subscriber.SubscribeAndCommit(func(ctx context.Context, payload []byte) error {
+		handled = append(handled, string(payload))
+		if string(payload) == "b" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"a", "b"}, handled)
+
+	payloads := make(chan string, 2)
+	result := make(chan error, 1)
+	go func() {
+		result <- subscriber.SubscribeAndCommit(func(ctx context.Context, payload []byte) error {
+			payloads <- string(payload)
+			return nil
+		})
+	}()
+	assert.Equal(t, "b", <-payloads)
+
+	// Paused subscribers do not handle messages until they are resumed
+	assert.Nil(t, subscriber.Pause())
+	assert.Nil(t, publisher.Publish(context.Background(), []byte("c")))
+	select {
+	case payload := <-payloads:
+		assert.Fail(t, "handled a message while paused: " + payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Nil(t, subscriber.Resume())
+	assert.Equal(t, "c", <-payloads)
+
+	assert.Nil(t, subscriber.Stop())
+	assert.Nil(t, <-result)
+}
+
+func TestSubscriberFromConnectionString(t *testing.T) {
+	subscriber, err := streaming.NewSubscriberFromConnectionString(
+		"kafka:servers=localhost:9092,localhost:9093,topicName=events,group=binge,sessionTimeoutMs=6000")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "servers=localhost:9092,localhost:9093,topicName=events,group=binge",
+		subscriber.ConnectionString())
+	assert.Nil(t, subscriber.(io.Closer).Close())
+
+	for _, connectionString := range []string{
+		"events",
+		"nats:events",
+		"kafka:servers=localhost:9092,topicName=events",
+		"kafka:servers=localhost:9092,topicName=events,group=binge,sessionTimeoutMs=soon",
+	} {
+		_, err = streaming.NewSubscriberFromConnectionString(connectionString)
+		assert.Error(t, err, connectionString)
+	}
+}
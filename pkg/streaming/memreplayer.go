@@ -50,7 +50,6 @@ func (memReplayer *MemReplayer) Replay(ctx context.Context, handler func(ctx con
 			return err
 		}
 	}
-	return nil
 }
 
 // ConnectionString will return a string that can be parsed to connect to the underlying pub/sub system
@@ -11,6 +11,7 @@ type MemSubscriber struct {
 	memPubSub *MemPubSub
 	ctx *SubscriberContext
 	state SubscriberState
+	paused bool
 }
 
 // NewMemSubscriber will return a new MemSubscriber object
@@ -60,9 +61,80 @@ func (memSubscriber *MemSubscriber) Subscribe(handler func(ctx context.Context,
 	return nil
 }
 
+// SubscribeAndCommit handles messages, one at a time, until Stop is called or the handler fails.  The
+// subscriber's offset only advances after the handler succeeds, so a failed message is redelivered by the
+// next subscription.
+func (memSubscriber *MemSubscriber) SubscribeAndCommit(handler CommitHandler) error {
+	memTopic, ok := memSubscriber.memPubSub.getTopic(memSubscriber.ctx.topic)
+	if !ok {
+		return util.NewNotFoundError(fmt.Sprintf("MemSubscriber - cannot subscribe from non-existent topic: %s",
+			memSubscriber.ctx.topic))
+	}
+
+	memTopic.cond.L.Lock()
+	defer memTopic.cond.L.Unlock()
+	memSubscriber.state = SubscriberRunning
+	for {
+		var payload []byte
+		var err error
+		for memSubscriber.state == SubscriberRunning {
+			if !memSubscriber.paused {
+				if payload, err = memTopic.Get(memSubscriber.ctx.offset); err == nil {
+					break
+				}
+			}
+			memTopic.cond.Wait()
+		}
+		if memSubscriber.state != SubscriberRunning {
+			return nil
+		}
+
+		memTopic.cond.L.Unlock()
+		err = handler(util.ExtractPubSubContext(payload), payload)
+		memTopic.cond.L.Lock()
+		if err != nil {
+			memSubscriber.state = SubscriberStopped
+			return err
+		}
+		memSubscriber.ctx.offset++
+	}
+}
+
+// Pause will stop handling messages, after the current message, until Resume is called
+func (memSubscriber *MemSubscriber) Pause() error {
+	memSubscriber.setPaused(true)
+	return nil
+}
+
+// Resume will continue handling messages after Pause
+func (memSubscriber *MemSubscriber) Resume() error {
+	memSubscriber.setPaused(false)
+	return nil
+}
+
+func (memSubscriber *MemSubscriber) setPaused(paused bool) {
+	memTopic, ok := memSubscriber.memPubSub.getTopic(memSubscriber.ctx.topic)
+	if !ok {
+		return
+	}
+	memTopic.cond.L.Lock()
+	defer memTopic.cond.L.Unlock()
+	memSubscriber.paused = paused
+	memTopic.cond.Broadcast()
+}
+
 // Stop will stop the go routine that is consuming from the topic
 func (memSubscriber *MemSubscriber) Stop() error {
+	memTopic, ok := memSubscriber.memPubSub.getTopic(memSubscriber.ctx.topic)
+	if !ok {
+		memSubscriber.state = SubscriberStopped
+		return nil
+	}
+	// Wake a subscription that is waiting for messages
+	memTopic.cond.L.Lock()
+	defer memTopic.cond.L.Unlock()
 	memSubscriber.state = SubscriberStopped
+	memTopic.cond.Broadcast()
 	return nil
 }
 
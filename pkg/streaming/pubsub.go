@@ -2,9 +2,16 @@ package streaming
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"strings"
 	"time"
 )
 
+// DefaultFlushTimeout bounds how long a publisher's buffered messages are flushed when it is shut down
+const DefaultFlushTimeout = 10 * time.Second
+
 type Publisher interface {
 	Publish(ctx context.Context, b []byte) error
 	Flush(ctx context.Context, timeout time.Duration) error
@@ -19,6 +26,21 @@ type Subscriber interface {
 	ConnectionString() string
 }
 
+// CommitHandler handles a message.  The message is only committed if it returns nil.
+type CommitHandler func(ctx context.Context, payload []byte) error
+
+// CommittingSubscriber is a Subscriber that commits each message after it is handled, so a message that
+// fails is redelivered
+type CommittingSubscriber interface {
+	Subscriber
+	// SubscribeAndCommit handles messages, one at a time, until Stop is called or the handler fails, in
+	// which case the handler's error is returned and the message is not committed
+	SubscribeAndCommit(handler CommitHandler) error
+	// Pause stops consuming messages until Resume is called, e.g. while downstream systems are overloaded
+	Pause() error
+	Resume() error
+}
+
 type Replayer interface {
 	Replay(ctx context.Context, handler func(ctx context.Context, payload []byte) error) error
 	ConnectionString() string
@@ -28,4 +50,62 @@ type Replayer interface {
 // resources.
 type StreamingCtrl interface {
 	CreateTopic(ctx context.Context, topicName string) error
-}
\ No newline at end of file
+}
+
+// KeyedPublisher publishes messages with keys, e.g. to choose a Kafka partition
+type KeyedPublisher interface {
+	PublishWithKey(ctx context.Context, key, b []byte) error
+}
+
+var defaultMemPubSub = NewMemPubSub()
+
+// DefaultMemPubSub is the in-memory pubsub shared by the "mem" publishers (and subscribers) of a process
+func DefaultMemPubSub() *MemPubSub {
+	return defaultMemPubSub
+}
+
+// NewPublisherFromConnectionString returns the publisher for "<type>:<connStr>", which is
+// "mem:<topic>" (the topic is created in DefaultMemPubSub, if needed) or
+// "kafka:servers=<host:port>,<host:port>,topicName=<topic>[,isSync=<bool>]"
+func NewPublisherFromConnectionString(connectionString string) (Publisher, error) {
+	connectionStringAry := strings.SplitN(connectionString, ":", 2)
+	if len(connectionStringAry) < 2 {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid connection string, expected <type>:<connStr> got: %s",
+			connectionString))
+	}
+	switch connectionStringAry[0] {
+	case "mem":
+		topic := connectionStringAry[1]
+		err := defaultMemPubSub.CreateTopic(topic)
+		if err != nil && !errors.Is(err, &util.ConflictError{}) {
+			return nil, err
+		}
+		return NewMemPublisher(defaultMemPubSub, topic)
+	case "kafka":
+		return NewKafkaPublisherFromConnectionString(connectionStringAry[1])
+	}
+	return nil, util.NewInvalidError(fmt.Sprintf("invalid backend type: %s", connectionStringAry[0]))
+}
+
+// NewSubscriberFromConnectionString returns the subscriber for "<type>:<connStr>", which is
+// "mem:<topic>" (the topic is created in DefaultMemPubSub, if needed) or
+// "kafka:servers=<host:port>,<host:port>,topicName=<topic>,group=<group>[,sessionTimeoutMs=<ms>]"
+func NewSubscriberFromConnectionString(connectionString string) (CommittingSubscriber, error) {
+	connectionStringAry := strings.SplitN(connectionString, ":", 2)
+	if len(connectionStringAry) < 2 {
+		return nil, util.NewInvalidError(fmt.Sprintf("invalid connection string, expected <type>:<connStr> got: %s",
+			connectionString))
+	}
+	switch connectionStringAry[0] {
+	case "mem":
+		topic := connectionStringAry[1]
+		err := defaultMemPubSub.CreateTopic(topic)
+		if err != nil && !errors.Is(err, &util.ConflictError{}) {
+			return nil, err
+		}
+		return NewMemSubscriber(defaultMemPubSub, topic)
+	case "kafka":
+		return NewKafkaSubscriberFromConnectionString(connectionStringAry[1])
+	}
+	return nil, util.NewInvalidError(fmt.Sprintf("invalid backend type: %s", connectionStringAry[0]))
+}
@@ -0,0 +1,325 @@
+package util
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CoProcessRequest is written, as a single line of JSON, to the stdin of a co-process worker
+type CoProcessRequest struct {
+	ID string `json:"id"`
+	Data interface{} `json:"data"`
+}
+
+// CoProcessResponse is read, as a single line of JSON, from the stdout of a co-process worker.  A
+// non-empty Error will fail the call.
+type CoProcessResponse struct {
+	ID string `json:"id"`
+	Data interface{} `json:"data"`
+	Error string `json:"error,omitempty"`
+}
+
+type CoProcessOption func(pool *CoProcessPool)
+
+// WithCoProcessArgs sets the arguments passed to each worker
+func WithCoProcessArgs(cmdArgs ...string) CoProcessOption {
+	return func(pool *CoProcessPool) {
+		pool.cmdArgs = cmdArgs
+	}
+}
+
+// WithNumWorkers sets the number of workers in the pool (default: 1)
+func WithNumWorkers(numWorkers int) CoProcessOption {
+	return func(pool *CoProcessPool) {
+		if numWorkers > 0 {
+			pool.numWorkers = numWorkers
+		}
+	}
+}
+
+// WithCallTimeout sets the maximum amount of time to wait for a response.  A worker that does not
+// respond in time is killed and restarted on the next call.  The default is no timeout.
+func WithCallTimeout(timeout time.Duration) CoProcessOption {
+	return func(pool *CoProcessPool) {
+		pool.callTimeout = timeout
+	}
+}
+
+// WithCoProcessSandbox will run the workers in the sandbox.  The sandbox's timeout and output limit do
+// not apply, since workers are long-lived; use WithCallTimeout instead.
+func WithCoProcessSandbox(sandbox *Sandbox) CoProcessOption {
+	return func(pool *CoProcessPool) {
+		pool.sandbox = sandbox
+	}
+}
+
+type coProcessResult struct {
+	response *CoProcessResponse
+	err error
+}
+
+// coProcessWorker is a single long-lived process.  Each worker handles one request at a time.
+type coProcessWorker struct {
+	cmd *exec.Cmd
+	stdin io.WriteCloser
+	responses chan coProcessResult
+	done chan struct{}
+}
+
+func startCoProcessWorker(path string, cmdArgs []string, sandbox *Sandbox) (*coProcessWorker, error) {
+	cmd, err := sandbox.Command(path, cmdArgs...)
+	if err != nil {
+		return nil, err
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	worker := &coProcessWorker{
+		cmd: cmd,
+		stdin: stdin,
+		responses: make(chan coProcessResult, 1),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			response := &CoProcessResponse{}
+			if err := json.Unmarshal(scanner.Bytes(), response); err != nil {
+				msg := fmt.Sprintf("invalid response from co-process '%s': %s", path, err.Error())
+				worker.responses <- coProcessResult{err: NewInvalidError(msg)}
+				continue
+			}
+			worker.responses <- coProcessResult{response: response}
+		}
+		_ = cmd.Wait()
+		close(worker.done)
+	}()
+
+	return worker, nil
+}
+
+func (worker *coProcessWorker) kill() {
+	_ = worker.stdin.Close()
+	killProcessGroup(worker.cmd)
+	// Drain any pending responses, so the reader can finish
+	for {
+		select {
+		case <-worker.responses:
+		case <-worker.done:
+			return
+		}
+	}
+}
+
+func (worker *coProcessWorker) alive() bool {
+	select {
+	case <-worker.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// CoProcessPool is a pool of long-lived worker processes that exchange newline-delimited JSON over
+// stdin/stdout.  Each request is a CoProcessRequest and the worker must write exactly one
+// CoProcessResponse, with the same ID, for each request.  Workers are started lazily and are
+// restarted if they exit or time out.
+type CoProcessPool struct {
+	path string
+	cmdArgs []string
+	numWorkers int
+	callTimeout time.Duration
+	sandbox *Sandbox
+	workers chan *coProcessWorker
+	nextID uint64
+	lock sync.Mutex
+	closed bool
+}
+
+// NewCoProcessPool will create a pool of workers that run the executable at path
+func NewCoProcessPool(path string, options ...CoProcessOption) *CoProcessPool {
+	pool := &CoProcessPool{
+		path: path,
+		numWorkers: 1,
+	}
+
+	for _, option := range options {
+		option(pool)
+	}
+
+	// A nil entry is a slot for a worker that has not been started
+	pool.workers = make(chan *coProcessWorker, pool.numWorkers)
+	for i := 0; i < pool.numWorkers; i++ {
+		pool.workers <- nil
+	}
+
+	return pool
+}
+
+func (pool *CoProcessPool) requestID() (string, error) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	if pool.closed {
+		return "", NewClosedError(fmt.Sprintf("co-process pool for '%s' is closed", pool.path))
+	}
+	pool.nextID++
+	return strconv.FormatUint(pool.nextID, 10), nil
+}
+
+// Call will send inData to an idle worker and return the data from its response
+func (pool *CoProcessPool) Call(ctx context.Context, inData interface{}) (interface{}, error) {
+	id, err := pool.requestID()
+	if err != nil {
+		return nil, err
+	}
+
+	if pool.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pool.callTimeout)
+		defer cancel()
+	}
+
+	var worker *coProcessWorker
+	select {
+	case worker = <-pool.workers:
+	case <-ctx.Done():
+		return nil, pool.ctxError(ctx, id)
+	}
+
+	worker, out, err := pool.call(ctx, worker, id, inData)
+	pool.workers <- worker
+	return out, err
+}
+
+func (pool *CoProcessPool) ctxError(ctx context.Context, id string) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		msg := fmt.Sprintf("co-process '%s' request %s timed out", pool.path, id)
+		return NewTimedOutError(msg)
+	}
+	return NewCancelledError()
+}
+
+// call will run a single request on the worker.  The returned worker is put back in the pool, so it
+// is nil if the worker had to be killed.
+func (pool *CoProcessPool) call(ctx context.Context, worker *coProcessWorker, id string,
+	inData interface{}) (*coProcessWorker, interface{}, error) {
+	requestBytes, err := json.Marshal(&CoProcessRequest{ID: id, Data: inData})
+	if err != nil {
+		return worker, nil, err
+	}
+	requestBytes = append(requestBytes, '\n')
+
+	// A failed write means the worker exited before it received the request, so it is safe to
+	// retry once with a new worker
+	for attempt := 0; ; attempt++ {
+		if worker != nil && !worker.alive() {
+			worker = nil
+		}
+		if worker == nil {
+			worker, err = startCoProcessWorker(pool.path, pool.cmdArgs, pool.sandbox)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if _, err = worker.stdin.Write(requestBytes); err == nil {
+			break
+		}
+		worker.kill()
+		worker = nil
+		if attempt > 0 {
+			return nil, nil, Wrap(err, fmt.Sprintf("co-process '%s' request %s failed", pool.path, id))
+		}
+	}
+
+	for {
+		var result coProcessResult
+		select {
+		case result = <-worker.responses:
+		case <-worker.done:
+			// The worker may have responded right before exiting
+			select {
+			case result = <-worker.responses:
+			default:
+				msg := fmt.Sprintf("co-process '%s' exited while handling request %s", pool.path, id)
+				return nil, nil, NewInternalError(msg)
+			}
+		case <-ctx.Done():
+			worker.kill()
+			return nil, nil, pool.ctxError(ctx, id)
+		}
+
+		if result.err != nil {
+			return worker, nil, result.err
+		}
+		// Stale responses (e.g. unsolicited output) are discarded
+		if result.response.ID != id {
+			continue
+		}
+		if len(result.response.Error) > 0 {
+			msg := fmt.Sprintf("co-process '%s' request %s failed: %s", pool.path, id,
+				result.response.Error)
+			return worker, nil, NewInternalError(msg)
+		}
+		return worker, result.response.Data, nil
+	}
+}
+
+// Close will stop all of the workers.  Calls made after Close will fail.
+func (pool *CoProcessPool) Close() error {
+	pool.lock.Lock()
+	if pool.closed {
+		pool.lock.Unlock()
+		return nil
+	}
+	pool.closed = true
+	pool.lock.Unlock()
+
+	for i := 0; i < pool.numWorkers; i++ {
+		worker := <-pool.workers
+		if worker != nil {
+			worker.kill()
+		}
+	}
+	return nil
+}
+
+// CoProcessRunnable is a PartialRunnable that sends its input to a CoProcessPool
+type CoProcessRunnable struct {
+	pool *CoProcessPool
+	inData interface{}
+}
+
+// NewCoProcessRunnable will create a runnable that calls the provided pool
+func NewCoProcessRunnable(pool *CoProcessPool, inData interface{}) *CoProcessRunnable {
+	return &CoProcessRunnable{
+		pool: pool,
+		inData: inData,
+	}
+}
+
+func (runnable *CoProcessRunnable) Run(ctx context.Context) (interface{}, error) {
+	return runnable.pool.Call(ctx, runnable.inData)
+}
+
+func (runnable *CoProcessRunnable) SetInData(inData interface{}) error {
+	runnable.inData = inData
+	return nil
+}
@@ -0,0 +1,90 @@
+package util_test
+
+import (
+	"context"
+	"errors"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/kmgreen2/agglo/test"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCoProcessPool(t *testing.T) {
+	testJson := test.TestJson()
+	// cat echoes each request, which is a valid response to that request
+	pool := util.NewCoProcessPool("cat", util.WithNumWorkers(4))
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := pool.Call(context.Background(), testJson)
+			assert.Nil(t, err)
+			assert.Equal(t, testJson, result)
+		}()
+	}
+	wg.Wait()
+
+	result, err := util.NewCoProcessRunnable(pool, []interface{}{"a", "b"}).Run(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, result)
+}
+
+func TestCoProcessPoolRestart(t *testing.T) {
+	// head exits after the first response, so every call needs a new worker
+	pool := util.NewCoProcessPool("head", util.WithCoProcessArgs("-n", "1"))
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	for i := 0; i < 3; i++ {
+		result, err := pool.Call(context.Background(), "hello")
+		assert.Nil(t, err)
+		assert.Equal(t, "hello", result)
+	}
+}
+
+func TestCoProcessPoolCrash(t *testing.T) {
+	pool := util.NewCoProcessPool("true")
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	_, err := pool.Call(context.Background(), "hello")
+	assert.Error(t, err)
+}
+
+func TestCoProcessPoolTimeout(t *testing.T) {
+	pool := util.NewCoProcessPool("sleep", util.WithCoProcessArgs("10"),
+		util.WithCallTimeout(100*time.Millisecond))
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	start := time.Now()
+	_, err := pool.Call(context.Background(), "hello")
+	assert.True(t, errors.Is(err, &util.TimedOutError{}))
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
+
+func TestCoProcessPoolClosed(t *testing.T) {
+	pool := util.NewCoProcessPool("cat")
+	_, err := pool.Call(context.Background(), "hello")
+	assert.Nil(t, err)
+	assert.Nil(t, pool.Close())
+
+	_, err = pool.Call(context.Background(), "hello")
+	assert.True(t, errors.Is(err, &util.ClosedError{}))
+}
+
+func TestCoProcessPoolBadExec(t *testing.T) {
+	pool := util.NewCoProcessPool("foo/doesnotexist")
+	_, err := pool.Call(context.Background(), "hello")
+	assert.Error(t, err)
+}
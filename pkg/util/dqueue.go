@@ -109,6 +109,11 @@ func bytesToQueueItem(itemBytes []byte) (*QueueItem, error) {
 	return queueItem, nil
 }
 
+// QueueItemFromBytes will decode a serialized QueueItem, such as the items passed to a recover function
+func QueueItemFromBytes(itemBytes []byte) (*QueueItem, error) {
+	return bytesToQueueItem(itemBytes)
+}
+
 func int64ToBytes(v int64) ([]byte, error) {
 	byteBuffer := bytes.NewBuffer([]byte{})
 	encoder := gob.NewEncoder(byteBuffer)
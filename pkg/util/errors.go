@@ -294,6 +294,33 @@ func (e *ClosedError) Error() string {
 	return e.msg
 }
 
+// Is
+func (e *ClosedError) Is(other error) bool {
+	_, ok := other.(*ClosedError)
+	return ok
+}
+
+// ResourceLimitError represents an error condition when a command exceeds a resource limit
+type ResourceLimitError struct {
+	msg string
+}
+
+// NewResourceLimitError is the constructor for ResourceLimitError
+func NewResourceLimitError(msg string) *ResourceLimitError {
+	return &ResourceLimitError{msg}
+}
+
+// Error returns the string representation of the ResourceLimitError
+func (e *ResourceLimitError) Error() string {
+	return e.msg
+}
+
+// Is
+func (e *ResourceLimitError) Is(other error) bool {
+	_, ok := other.(*ResourceLimitError)
+	return ok
+}
+
 // EmptyQueue represents a condition when a queue is empty
 type EmptyQueue struct {
 	msg string
@@ -0,0 +1,421 @@
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultPathSeparator = "."
+	PathWildcard = "*"
+	pathEscape = '\\'
+)
+
+type pathComponent struct {
+	key string
+	wildcard bool
+}
+
+// Path is a reference into a nested structure of map[string]interface{} and []interface{} values.
+//
+// Components are separated by a field separator (default: "."), so "items.3.price" refers to the
+// "price" field of the fourth element of the "items" array.  Numeric components are treated as
+// indexes when the current value is an array and as keys when it is a map.  Negative indexes are
+// relative to the end of an array ("items.-1.price" is the last item).  The "*" component matches
+// every element of an array or every value in a map ("items.*.price").  A separator or "*" that is
+// part of a key can be escaped with a backslash.
+//
+// When the index separator is set to a bracket pair (e.g. "[]"), indexes may also be written as
+// "items[3].price".
+type Path struct {
+	raw string
+	components []pathComponent
+	hasWildcard bool
+	fieldSeparator string
+	indexSeparator string
+}
+
+type PathOption func(path *Path)
+
+// WithFieldSeparator sets the separator used between path components
+func WithFieldSeparator(fieldSeparator string) PathOption {
+	return func(path *Path) {
+		path.fieldSeparator = fieldSeparator
+	}
+}
+
+// WithIndexSeparator sets the separator used for array indexes.  If it is the same as the field
+// separator, then indexes are regular path components.  If it is a two-character pair, such as "[]",
+// then indexes can also be enclosed by the pair.
+func WithIndexSeparator(indexSeparator string) PathOption {
+	return func(path *Path) {
+		path.indexSeparator = indexSeparator
+	}
+}
+
+// NewPath will parse the provided path string
+func NewPath(path string, options ...PathOption) (*Path, error) {
+	p := &Path{
+		raw: path,
+		fieldSeparator: DefaultPathSeparator,
+		indexSeparator: DefaultPathSeparator,
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	if len(p.fieldSeparator) == 0 {
+		return nil, NewInvalidError("path field separator cannot be empty")
+	}
+
+	if len(path) == 0 {
+		return p, nil
+	}
+
+	components, err := p.parse(path)
+	if err != nil {
+		return nil, err
+	}
+	p.components = components
+	for _, component := range components {
+		if component.wildcard {
+			p.hasWildcard = true
+		}
+	}
+	return p, nil
+}
+
+// MustNewPath is the same as NewPath, but panics if the path is invalid.  It should only be used
+// with constant paths.
+func MustNewPath(path string, options ...PathOption) *Path {
+	p, err := NewPath(path, options...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return p
+}
+
+// NewPathFromComponents will create a path from pre-split components.  Components are taken
+// literally, so "*" and separators are never interpreted.
+func NewPathFromComponents(components []string) *Path {
+	p := &Path{
+		raw: strings.Join(components, DefaultPathSeparator),
+		fieldSeparator: DefaultPathSeparator,
+		indexSeparator: DefaultPathSeparator,
+	}
+	for _, component := range components {
+		p.components = append(p.components, pathComponent{key: component})
+	}
+	return p
+}
+
+func (p *Path) indexBrackets() (byte, byte, bool) {
+	if len(p.indexSeparator) == 2 && p.indexSeparator != p.fieldSeparator {
+		return p.indexSeparator[0], p.indexSeparator[1], true
+	}
+	return 0, 0, false
+}
+
+func (p *Path) parse(path string) ([]pathComponent, error) {
+	var components []pathComponent
+	var curr strings.Builder
+	escaped := false
+	currEscaped := false
+	openBracket, closeBracket, useBrackets := p.indexBrackets()
+
+	endComponent := func() {
+		key := curr.String()
+		components = append(components, pathComponent{
+			key: key,
+			wildcard: key == PathWildcard && !currEscaped,
+		})
+		curr.Reset()
+		currEscaped = false
+	}
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if escaped {
+			curr.WriteByte(c)
+			escaped = false
+			continue
+		}
+		if c == pathEscape {
+			escaped = true
+			currEscaped = true
+			continue
+		}
+		if strings.HasPrefix(path[i:], p.fieldSeparator) {
+			if curr.Len() == 0 && (i == 0 || !useBrackets || path[i-1] != closeBracket) {
+				msg := fmt.Sprintf("empty component in path '%s'", path)
+				return nil, NewInvalidError(msg)
+			}
+			if curr.Len() > 0 {
+				endComponent()
+			}
+			i += len(p.fieldSeparator) - 1
+			continue
+		}
+		if useBrackets && c == openBracket {
+			end := strings.IndexByte(path[i:], closeBracket)
+			if end < 0 {
+				msg := fmt.Sprintf("unterminated index in path '%s'", path)
+				return nil, NewInvalidError(msg)
+			}
+			index := path[i+1 : i+end]
+			if _, err := strconv.Atoi(index); err != nil && index != PathWildcard {
+				msg := fmt.Sprintf("invalid index '%s' in path '%s'", index, path)
+				return nil, NewInvalidError(msg)
+			}
+			if curr.Len() > 0 {
+				endComponent()
+			}
+			curr.WriteString(index)
+			endComponent()
+			i += end
+			continue
+		}
+		curr.WriteByte(c)
+	}
+
+	if escaped {
+		msg := fmt.Sprintf("trailing escape in path '%s'", path)
+		return nil, NewInvalidError(msg)
+	}
+	if curr.Len() > 0 {
+		endComponent()
+	} else if !useBrackets || path[len(path)-1] != closeBracket {
+		msg := fmt.Sprintf("empty component in path '%s'", path)
+		return nil, NewInvalidError(msg)
+	}
+	return components, nil
+}
+
+// String returns the path as it was provided
+func (p *Path) String() string {
+	return p.raw
+}
+
+// HasWildcard returns true if any component of the path is a wildcard
+func (p *Path) HasWildcard() bool {
+	return p.hasWildcard
+}
+
+// IsRoot returns true if the path is empty, which refers to the entire value
+func (p *Path) IsRoot() bool {
+	return len(p.components) == 0
+}
+
+// Components returns the keys of the path
+func (p *Path) Components() []string {
+	keys := make([]string, len(p.components))
+	for i, component := range p.components {
+		keys[i] = component.key
+	}
+	return keys
+}
+
+// Last returns the last key of the path
+func (p *Path) Last() string {
+	if len(p.components) == 0 {
+		return ""
+	}
+	return p.components[len(p.components)-1].key
+}
+
+func resolveIndex(key string, length int) (int, bool) {
+	idx, err := strconv.Atoi(key)
+	if err != nil {
+		return 0, false
+	}
+	if idx < 0 {
+		idx += length
+	}
+	if idx < 0 || idx >= length {
+		return 0, false
+	}
+	return idx, true
+}
+
+func sortedKeys(in map[string]interface{}) []string {
+	keys := make([]string, 0, len(in))
+	for k := range in {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (p *Path) get(in interface{}, depth int, matches []interface{}) ([]interface{}, error) {
+	if depth == len(p.components) {
+		return append(matches, in), nil
+	}
+	component := p.components[depth]
+
+	notFound := func() error {
+		msg := fmt.Sprintf("path '%s' not found: no value at '%s'", p.raw, component.key)
+		return NewNotFoundError(msg)
+	}
+
+	var children []interface{}
+	switch inVal := in.(type) {
+	case map[string]interface{}:
+		if component.wildcard {
+			for _, k := range sortedKeys(inVal) {
+				children = append(children, inVal[k])
+			}
+		} else if v, ok := inVal[component.key]; ok {
+			children = append(children, v)
+		} else {
+			return matches, notFound()
+		}
+	case []interface{}:
+		if component.wildcard {
+			children = append(children, inVal...)
+		} else if idx, ok := resolveIndex(component.key, len(inVal)); ok {
+			children = append(children, inVal[idx])
+		} else {
+			return matches, notFound()
+		}
+	case []map[string]interface{}:
+		if component.wildcard {
+			for _, v := range inVal {
+				children = append(children, v)
+			}
+		} else if idx, ok := resolveIndex(component.key, len(inVal)); ok {
+			children = append(children, inVal[idx])
+		} else {
+			return matches, notFound()
+		}
+	default:
+		msg := fmt.Sprintf("path '%s' not found: cannot resolve '%s' in %v", p.raw, component.key,
+			reflect.TypeOf(in))
+		return matches, NewNotFoundError(msg)
+	}
+
+	for _, child := range children {
+		var err error
+		matches, err = p.get(child, depth+1, matches)
+		// Wildcards match whatever exists, so missing values under a wildcard are skipped
+		if err != nil && !p.hasWildcard {
+			return matches, err
+		}
+	}
+	return matches, nil
+}
+
+// Get will return the value referenced by the path.  If the path contains a wildcard, then
+// all matching values are returned as a []interface{}.  A NotFoundError is returned if nothing
+// matches.
+func (p *Path) Get(in interface{}) (interface{}, error) {
+	if len(p.components) == 0 {
+		return in, nil
+	}
+	matches, err := p.get(in, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.hasWildcard {
+		if len(matches) == 0 {
+			msg := fmt.Sprintf("path '%s' did not match any values", p.raw)
+			return nil, NewNotFoundError(msg)
+		}
+		return matches, nil
+	}
+	return matches[0], nil
+}
+
+// Exists will return true if the path references a value in the provided value
+func (p *Path) Exists(in interface{}) bool {
+	_, err := p.Get(in)
+	return err == nil
+}
+
+// Set will set the value referenced by the path, creating intermediate maps as needed.  Array
+// elements can be replaced, but arrays are never grown.  Wildcards are not supported when setting
+// values.
+func (p *Path) Set(in map[string]interface{}, value interface{}) error {
+	if len(p.components) == 0 {
+		return NewInvalidError("cannot set the value of an empty path")
+	}
+	if p.hasWildcard {
+		msg := fmt.Sprintf("cannot set path '%s': wildcards are not supported when setting values", p.raw)
+		return NewInvalidError(msg)
+	}
+
+	var curr interface{} = in
+	for i, component := range p.components {
+		last := i == len(p.components)-1
+		switch currVal := curr.(type) {
+		case map[string]interface{}:
+			if last {
+				currVal[component.key] = value
+				return nil
+			}
+			if _, ok := currVal[component.key]; !ok {
+				currVal[component.key] = make(map[string]interface{})
+			}
+			curr = currVal[component.key]
+		case []interface{}:
+			idx, ok := resolveIndex(component.key, len(currVal))
+			if !ok {
+				msg := fmt.Sprintf("cannot set path '%s': index '%s' out of range for array of length %d",
+					p.raw, component.key, len(currVal))
+				return NewNotFoundError(msg)
+			}
+			if last {
+				currVal[idx] = value
+				return nil
+			}
+			curr = currVal[idx]
+		default:
+			msg := fmt.Sprintf("cannot set path '%s': intermediate value at '%s' is %v, not a map or array",
+				p.raw, strings.Join(p.Components()[:i], p.fieldSeparator), reflect.TypeOf(curr))
+			return NewInvalidError(msg)
+		}
+	}
+	return nil
+}
+
+// GetPath is a helper that parses a path using the default separators and returns the referenced value
+func GetPath(in map[string]interface{}, path string) (interface{}, error) {
+	p, err := NewPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.Get(in)
+}
+
+// SetPath is a helper that parses a path using the default separators and sets the referenced value
+func SetPath(in map[string]interface{}, path string, value interface{}) error {
+	p, err := NewPath(path)
+	if err != nil {
+		return err
+	}
+	return p.Set(in, value)
+}
+
+// LookupVariable will resolve a variable name against a map.  Keys that exactly match the name
+// take precedence, so both flattened and nested maps can be used.  Otherwise, the name is parsed
+// as a path.
+func LookupVariable(in map[string]interface{}, name string) (interface{}, error) {
+	if v, ok := in[name]; ok {
+		return v, nil
+	}
+	return GetPath(in, name)
+}
+
+// JoinPath will join components into a path using the default separator
+func JoinPath(components ...string) string {
+	var nonEmpty []string
+	for _, component := range components {
+		if len(component) > 0 {
+			nonEmpty = append(nonEmpty, component)
+		}
+	}
+	return strings.Join(nonEmpty, DefaultPathSeparator)
+}
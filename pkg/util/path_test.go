@@ -0,0 +1,156 @@
+package util_test
+
+import (
+	"errors"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/kmgreen2/agglo/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func itemsJson() map[string]interface{} {
+	return map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"price": float64(1), "name": "a"},
+			map[string]interface{}{"price": float64(2), "name": "b"},
+			map[string]interface{}{"name": "c"},
+			map[string]interface{}{"price": float64(4), "name": "d"},
+		},
+		"a.b": "dotted",
+	}
+}
+
+func TestPathGetIndex(t *testing.T) {
+	jsonMap := test.TestJson()
+
+	val, err := util.GetPath(jsonMap, "b.d.1")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(4), val)
+
+	val, err = util.GetPath(jsonMap, "i.0.j.1")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(9), val)
+
+	val, err = util.GetPath(jsonMap, "b.d.-1")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(5), val)
+
+	val, err = util.GetPath(jsonMap, "i.-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "k", val)
+
+	val, err = util.GetPath(jsonMap, "f")
+	assert.Nil(t, err)
+	assert.Equal(t, jsonMap["f"], val)
+}
+
+func TestPathGetNotFound(t *testing.T) {
+	jsonMap := test.TestJson()
+
+	for _, path := range []string{"b.d.3", "b.d.-4", "b.d.x", "a.b", "i.1.j", "z", "f.g.h.i"} {
+		_, err := util.GetPath(jsonMap, path)
+		assert.Error(t, err, path)
+		assert.True(t, errors.Is(err, &util.NotFoundError{}), path)
+	}
+}
+
+func TestPathGetWildcard(t *testing.T) {
+	jsonMap := itemsJson()
+
+	val, err := util.GetPath(jsonMap, "items.*.price")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{float64(1), float64(2), float64(4)}, val)
+
+	val, err = util.GetPath(jsonMap, "items.*.name")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "c", "d"}, val)
+
+	_, err = util.GetPath(jsonMap, "items.*.cost")
+	assert.True(t, errors.Is(err, &util.NotFoundError{}))
+
+	val, err = util.GetPath(test.TestJson(), "b.*")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"hello", []interface{}{float64(3), float64(4), float64(5)}}, val)
+}
+
+func TestPathEscape(t *testing.T) {
+	jsonMap := itemsJson()
+
+	val, err := util.GetPath(jsonMap, "a\\.b")
+	assert.Nil(t, err)
+	assert.Equal(t, "dotted", val)
+
+	p, err := util.NewPath("x.\\*")
+	assert.Nil(t, err)
+	assert.False(t, p.HasWildcard())
+	assert.Equal(t, []string{"x", "*"}, p.Components())
+}
+
+func TestPathBrackets(t *testing.T) {
+	jsonMap := itemsJson()
+
+	p, err := util.NewPath("items[1].price", util.WithIndexSeparator("[]"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"items", "1", "price"}, p.Components())
+	val, err := p.Get(jsonMap)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(2), val)
+
+	p, err = util.NewPath("items[-1]", util.WithIndexSeparator("[]"))
+	assert.Nil(t, err)
+	val, err = p.Get(jsonMap)
+	assert.Nil(t, err)
+	assert.Equal(t, "d", val.(map[string]interface{})["name"])
+
+	_, err = util.NewPath("items[x].price", util.WithIndexSeparator("[]"))
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+
+	_, err = util.NewPath("items[1.price", util.WithIndexSeparator("[]"))
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+}
+
+func TestPathInvalid(t *testing.T) {
+	for _, path := range []string{"a..b", ".a", "a.", "a\\"} {
+		_, err := util.NewPath(path)
+		assert.True(t, errors.Is(err, &util.InvalidError{}), path)
+	}
+}
+
+func TestPathSet(t *testing.T) {
+	jsonMap := itemsJson()
+
+	err := util.SetPath(jsonMap, "items.-1.price", float64(5))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(5), jsonMap["items"].([]interface{})[3].(map[string]interface{})["price"])
+
+	err = util.SetPath(jsonMap, "x.y.z", "new")
+	assert.Nil(t, err)
+	val, err := util.GetPath(jsonMap, "x.y.z")
+	assert.Nil(t, err)
+	assert.Equal(t, "new", val)
+
+	err = util.SetPath(jsonMap, "items.4.price", float64(5))
+	assert.True(t, errors.Is(err, &util.NotFoundError{}))
+
+	err = util.SetPath(jsonMap, "items.*.price", float64(5))
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+
+	err = util.SetPath(jsonMap, "x.y.z.w", "new")
+	assert.True(t, errors.Is(err, &util.InvalidError{}))
+}
+
+func TestLookupVariable(t *testing.T) {
+	jsonMap := test.TestJson()
+
+	val, err := util.LookupVariable(util.Flatten(jsonMap), "b.d.1")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(4), val)
+
+	val, err = util.LookupVariable(jsonMap, "b.d.1")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(4), val)
+
+	val, err = util.LookupVariable(jsonMap, "b.d.-1")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(5), val)
+}
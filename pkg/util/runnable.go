@@ -6,9 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
-	"io"
-	"os/exec"
 	"reflect"
+	"time"
 )
 
 type Runnable interface {
@@ -43,6 +42,13 @@ func WithPath(path string) ExecOption {
 	}
 }
 
+// WithSandbox will run the command in the sandbox
+func WithSandbox(sandbox *Sandbox) ExecOption {
+	return func (runnable *ExecRunnable) {
+		runnable.sandbox = sandbox
+	}
+}
+
 // ExecRunnable will run a command that accepts a JSON-encoded map on stdin
 // and returns a JSON-encoded map on stdout
 type ExecRunnable struct {
@@ -50,6 +56,7 @@ type ExecRunnable struct {
 	path    string
 	cmdArgs []string
 	inData  interface{}
+	sandbox *Sandbox
 }
 
 func NewExecRunnable(options ...ExecOption) *ExecRunnable {
@@ -63,49 +70,127 @@ func NewExecRunnable(options ...ExecOption) *ExecRunnable {
 	return runnable
 }
 
+// ExecStatus is the outcome of running a command
+type ExecStatus struct {
+	ExitCode int
+	Duration time.Duration
+	Stdout []byte
+	Stderr []byte
+}
+
 func (runnable *ExecRunnable) Run(ctx context.Context) (interface{}, error) {
+	out, _, err := runnable.RunWithStatus(ctx)
+	return out, err
+}
+
+// RunWithStatus will run the command and return the decoded output, along with the exit code, duration,
+// stdout and stderr of the command.  The command's process group is killed if the context is done, in
+// which case a TimedOutError or CancelledError is returned.  Sandbox violations return a TimedOutError,
+// an OutOfBoundsError (output limit) or a ResourceLimitError (CPU, memory or open file limit).  Exceeding
+// the memory or open file limits causes allocations or opens in the command to fail, which is only
+// reported as a ResourceLimitError if the command crashes or reports it on stderr.  The status is returned
+// whenever the command was started.
+func (runnable *ExecRunnable) RunWithStatus(ctx context.Context) (interface{}, *ExecStatus, error) {
 	var outMap map[string]interface{}
 
-	cmd := exec.Command(runnable.path, runnable.cmdArgs...)
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return nil, err
+	if ctx == nil {
+		ctx = runnable.ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
 	encodeBuffer := bytes.NewBuffer([]byte{})
 	switch val := runnable.inData.(type) {
 	case map[string]interface{}:
 		encoder := json.NewEncoder(encodeBuffer)
-		err = encoder.Encode(val)
+		err := encoder.Encode(val)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	case []interface{}:
 		encoder := json.NewEncoder(encodeBuffer)
-		err = encoder.Encode(val)
+		err := encoder.Encode(val)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	case string:
 		encodeBuffer.Write([]byte(val))
 	default:
 		msg := fmt.Sprintf("ExecRunnable arg must be map[string]interface{}, []interface{} or string, got %v",
 			reflect.TypeOf(val))
-		return nil, NewInvalidError(msg)
+		return nil, nil, NewInvalidError(msg)
+	}
+
+	if runnable.sandbox != nil && runnable.sandbox.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, runnable.sandbox.Timeout)
+		defer cancel()
 	}
 
+	cmd, err := runnable.sandbox.Command(runnable.path, runnable.cmdArgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxOutput := 0
+	if runnable.sandbox != nil {
+		maxOutput = runnable.sandbox.MaxOutputBytes
+	}
+	kill := func() {
+		killProcessGroup(cmd)
+	}
+	stdout := &limitedBuffer{max: maxOutput, exceeded: kill}
+	stderr := &limitedBuffer{max: maxOutput, exceeded: kill}
+	cmd.Stdin = encodeBuffer
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	begin := time.Now()
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+
+	// Kill the process group, rather than just the process, when the context is done
+	waitDone := make(chan struct{})
 	go func() {
-		_, _ = io.WriteString(stdin, encodeBuffer.String())
-		_ = stdin.Close()
+		select {
+		case <-ctx.Done():
+			kill()
+		case <-waitDone:
+		}
 	}()
+	err = cmd.Wait()
+	close(waitDone)
+
+	status := &ExecStatus{
+		ExitCode: cmd.ProcessState.ExitCode(),
+		Duration: time.Now().Sub(begin),
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}
 
-	out, err := cmd.CombinedOutput()
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		msg := fmt.Sprintf("'%s' timed out after %d ms", runnable.path, status.Duration.Milliseconds())
+		return nil, status, NewTimedOutError(msg)
+	case context.Canceled:
+		return nil, status, NewCancelledError()
+	}
+	if stdout.Overflow() || stderr.Overflow() {
+		msg := fmt.Sprintf("'%s' exceeded the output limit of %d bytes", runnable.path, maxOutput)
+		return nil, status, NewOutOfBoundsError(msg)
+	}
+	if limit := runnable.sandbox.limitExceeded(cmd.ProcessState, status.Stderr); len(limit) > 0 {
+		msg := fmt.Sprintf("'%s' exceeded %s: %s", runnable.path, limit, string(status.Stderr))
+		return nil, status, NewResourceLimitError(msg)
+	}
 	if err != nil {
-		return nil, errors.Wrap(err, string(out))
+		return nil, status, errors.Wrap(err, string(status.Stderr))
 	}
 
+	out := status.Stdout
 	if len(out) == 0 {
-		return outMap, nil
+		return outMap, status, nil
 	}
 
 	decodeBuffer := bytes.NewBuffer(out)
@@ -114,19 +199,19 @@ func (runnable *ExecRunnable) Run(ctx context.Context) (interface{}, error) {
 		decoder := json.NewDecoder(decodeBuffer)
 		err = decoder.Decode(&outMap)
 		if err != nil {
-			return nil, err
+			return nil, status, err
 		}
 	case []interface{}:
 		decoder := json.NewDecoder(decodeBuffer)
 		err = decoder.Decode(&outMap)
 		if err != nil {
-			return nil, err
+			return nil, status, err
 		}
 	case string:
 		decodeBuffer.Write([]byte(out))
 	}
 
-	return outMap, err
+	return outMap, status, err
 }
 
 func (runnable *ExecRunnable) SetInData(inData interface{}) error {
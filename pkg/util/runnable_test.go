@@ -2,12 +2,14 @@ package util_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/kmgreen2/agglo/pkg/util"
 	"github.com/kmgreen2/agglo/test"
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestExecRunnable(t *testing.T) {
@@ -56,4 +58,35 @@ func TestExecRunnableUnexpectedOutput(t *testing.T) {
 
 	_, err = runnable.Run(context.Background())
 	assert.Error(t, err)
-}
\ No newline at end of file
+}
+
+func TestExecRunnableStatus(t *testing.T) {
+	runnable := util.NewExecRunnable(util.WithPath("sh"), util.WithCmdArgs("-c", "cat; echo oops >&2"))
+	err := runnable.SetInData(map[string]interface{}{"a": "b"})
+	assert.Nil(t, err)
+
+	out, status, err := runnable.RunWithStatus(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "b"}, out)
+	assert.Equal(t, 0, status.ExitCode)
+	assert.Equal(t, "oops\n", string(status.Stderr))
+
+	runnable = util.NewExecRunnable(util.WithPath("sh"), util.WithCmdArgs("-c", "echo failed >&2; exit 3"))
+	_ = runnable.SetInData(map[string]interface{}{})
+	_, status, err = runnable.RunWithStatus(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 3, status.ExitCode)
+	assert.Equal(t, "failed\n", string(status.Stderr))
+}
+
+func TestExecRunnableTimeout(t *testing.T) {
+	runnable := util.NewExecRunnable(util.WithPath("sleep"), util.WithCmdArgs("10"))
+	_ = runnable.SetInData(map[string]interface{}{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := runnable.RunWithStatus(ctx)
+	assert.True(t, errors.Is(err, &util.TimedOutError{}))
+	assert.True(t, time.Now().Sub(start) < 5*time.Second)
+}
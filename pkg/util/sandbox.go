@@ -0,0 +1,169 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Sandbox restricts the environment and resources of a command
+type Sandbox struct {
+	// EnvAllowlist is the names of the environment variables passed to the command.  No other
+	// variables are passed.
+	EnvAllowlist []string
+	// WorkingDir is the working directory of the command (default is binge's working directory)
+	WorkingDir string
+	// Timeout is the maximum run time of the command, after which its process group is killed
+	Timeout time.Duration
+	// CPUSeconds, MemoryBytes and OpenFiles are rlimits on CPU time, virtual memory and open files.
+	// Exceeding the memory and open file limits is only detected when the command crashes or reports it.
+	CPUSeconds uint64
+	MemoryBytes uint64
+	OpenFiles uint64
+	// MaxOutputBytes limits the size of stdout and of stderr.  The command is killed if it writes more.
+	MaxOutputBytes int
+	// Credential, if set, runs the command as a different user and group
+	Credential *syscall.Credential
+}
+
+// ulimits returns a shell prelude that sets the rlimits
+func (sandbox *Sandbox) ulimits() string {
+	var limits []string
+	if sandbox.CPUSeconds > 0 {
+		// The soft limit sends SIGXCPU, which is reported as a ResourceLimitError
+		limits = append(limits, fmt.Sprintf("ulimit -S -t %d", sandbox.CPUSeconds),
+			fmt.Sprintf("ulimit -H -t %d", sandbox.CPUSeconds+1))
+	}
+	if sandbox.MemoryBytes > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -v %d", (sandbox.MemoryBytes+1023)/1024))
+	}
+	if sandbox.OpenFiles > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -n %d", sandbox.OpenFiles))
+	}
+	if len(limits) == 0 {
+		return ""
+	}
+	return strings.Join(limits, " && ") + " && "
+}
+
+func (sandbox *Sandbox) env() []string {
+	env := make([]string, 0, len(sandbox.EnvAllowlist))
+	for _, name := range sandbox.EnvAllowlist {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+// Command will create a command that runs in its own process group, subject to the sandbox.  A nil
+// sandbox only sets the process group.  Rlimits are set by a shell prelude before the command is exec'd,
+// so they apply from the first instruction.
+func (sandbox *Sandbox) Command(path string, args ...string) (*exec.Cmd, error) {
+	var cmd *exec.Cmd
+	if sandbox != nil && len(sandbox.ulimits()) > 0 {
+		// Resolve the path here, since the sandboxed environment may not have PATH
+		resolved, err := exec.LookPath(path)
+		if err != nil {
+			return nil, err
+		}
+		shArgs := append([]string{"-c", sandbox.ulimits() + `exec "$0" "$@"`, resolved}, args...)
+		cmd = exec.Command("/bin/sh", shArgs...)
+	} else {
+		cmd = exec.Command(path, args...)
+	}
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if sandbox == nil {
+		return cmd, nil
+	}
+	cmd.Dir = sandbox.WorkingDir
+	cmd.Env = sandbox.env()
+	cmd.SysProcAttr.Credential = sandbox.Credential
+	return cmd, nil
+}
+
+// killProcessGroup will kill a command started by Sandbox.Command, along with any children
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// Messages written by common runtimes and libraries when an allocation or an open fails
+var (
+	memoryLimitMessages = []string{"out of memory", "cannot allocate memory", "memoryerror"}
+	openFileLimitMessages = []string{"too many open files", "error 24"}
+)
+
+// limitExceeded describes the limit that a failed command exceeded, or returns "" if none was.  Exceeding
+// the CPU limit is signaled.  Exceeding the memory limit is detected from a SIGSEGV or SIGABRT, or from
+// stderr, as is exceeding the open file limit; a command that handles these failures itself, or exits
+// without reporting them, is not detected.
+func (sandbox *Sandbox) limitExceeded(state *os.ProcessState, stderr []byte) string {
+	if sandbox == nil || state == nil || state.Success() {
+		return ""
+	}
+	status, _ := state.Sys().(syscall.WaitStatus)
+	stderrText := strings.ToLower(string(stderr))
+	containsAny := func(messages []string) bool {
+		for _, message := range messages {
+			if strings.Contains(stderrText, message) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case sandbox.CPUSeconds > 0 && status.Signaled() && status.Signal() == syscall.SIGXCPU:
+		return fmt.Sprintf("the CPU limit of %d seconds", sandbox.CPUSeconds)
+	case sandbox.MemoryBytes > 0 && ((status.Signaled() && (status.Signal() == syscall.SIGSEGV ||
+		status.Signal() == syscall.SIGABRT)) || containsAny(memoryLimitMessages)):
+		return fmt.Sprintf("the memory limit of %d bytes", sandbox.MemoryBytes)
+	case sandbox.OpenFiles > 0 && containsAny(openFileLimitMessages):
+		return fmt.Sprintf("the open file limit of %d", sandbox.OpenFiles)
+	}
+	return ""
+}
+
+// limitedBuffer buffers up to max bytes (unlimited if max <= 0) and calls exceeded once if more is
+// written.  Writes never fail, so the command is not blocked on a full pipe.
+type limitedBuffer struct {
+	lock sync.Mutex
+	buf []byte
+	max int
+	exceeded func()
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.overflow {
+		return len(p), nil
+	}
+	if b.max > 0 && len(b.buf)+len(p) > b.max {
+		b.overflow = true
+		b.exceeded()
+		return len(p), nil
+	}
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf
+}
+
+func (b *limitedBuffer) Overflow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.overflow
+}
@@ -0,0 +1,122 @@
+package util_test
+
+import (
+	"context"
+	"errors"
+	"github.com/kmgreen2/agglo/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func runSandboxed(t *testing.T, sandbox *util.Sandbox, script string) (*util.ExecStatus, error) {
+	runnable := util.NewExecRunnable(util.WithPath("sh"), util.WithCmdArgs("-c", script),
+		util.WithSandbox(sandbox))
+	if err := runnable.SetInData(""); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, status, err := runnable.RunWithStatus(context.Background())
+	return status, err
+}
+
+func TestSandboxEnvironment(t *testing.T) {
+	_ = os.Setenv("SANDBOX_ALLOWED", "yes")
+	_ = os.Setenv("SANDBOX_DENIED", "no")
+	defer func() {
+		_ = os.Unsetenv("SANDBOX_ALLOWED")
+		_ = os.Unsetenv("SANDBOX_DENIED")
+	}()
+
+	sandbox := &util.Sandbox{
+		EnvAllowlist: []string{"SANDBOX_ALLOWED"},
+		WorkingDir: os.TempDir(),
+	}
+	status, err := runSandboxed(t, sandbox, `echo "$SANDBOX_ALLOWED,$SANDBOX_DENIED,$(pwd)"`)
+	assert.Nil(t, err)
+	assert.Equal(t, "yes,,"+os.TempDir()+"\n", string(status.Stdout))
+}
+
+func TestSandboxTimeout(t *testing.T) {
+	// The background sleep holds stdout open, so the whole process group must be killed
+	sandbox := &util.Sandbox{Timeout: 100 * time.Millisecond}
+	start := time.Now()
+	_, err := runSandboxed(t, sandbox, "sleep 10 & sleep 10")
+	assert.True(t, errors.Is(err, &util.TimedOutError{}))
+	assert.True(t, time.Now().Sub(start) < 5*time.Second)
+}
+
+func TestSandboxOutputLimit(t *testing.T) {
+	sandbox := &util.Sandbox{MaxOutputBytes: 1024}
+	start := time.Now()
+	status, err := runSandboxed(t, sandbox, "while :; do echo 0123456789; done")
+	assert.True(t, errors.As(err, new(*util.OutOfBoundsError)))
+	assert.True(t, len(status.Stdout) <= 1024)
+	assert.True(t, time.Now().Sub(start) < 5*time.Second)
+
+	status, err = runSandboxed(t, sandbox, "echo ok")
+	assert.Nil(t, err)
+	assert.Equal(t, "ok\n", string(status.Stdout))
+}
+
+func TestSandboxLimits(t *testing.T) {
+	sandbox := &util.Sandbox{
+		MemoryBytes: 512 * 1024 * 1024,
+		OpenFiles: 32,
+	}
+	status, err := runSandboxed(t, sandbox, "ulimit -v; ulimit -n")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"524288", "32"}, strings.Fields(string(status.Stdout)))
+
+	sandbox = &util.Sandbox{CPUSeconds: 1}
+	_, err = runSandboxed(t, sandbox, "while :; do :; done")
+	assert.True(t, errors.Is(err, &util.ResourceLimitError{}))
+
+	// The shell fails to allocate the string
+	sandbox = &util.Sandbox{MemoryBytes: 64 * 1024 * 1024}
+	_, err = runSandboxed(t, sandbox, `x=$(head -c 200000000 /dev/zero | tr "\0" a); echo ${#x}`)
+	assert.True(t, errors.Is(err, &util.ResourceLimitError{}))
+
+	sandbox = &util.Sandbox{OpenFiles: 5}
+	_, err = runSandboxed(t, sandbox, `for i in 3 4 5 6 7 8; do eval "exec $i</dev/null" || exit 1; done`)
+	assert.True(t, errors.Is(err, &util.ResourceLimitError{}))
+
+	// Other failures are not reported as exceeding a limit
+	_, err = runSandboxed(t, sandbox, "exit 1")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, &util.ResourceLimitError{}))
+}
+
+func TestSandboxCredential(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("changing the user requires root")
+	}
+	sandbox := &util.Sandbox{
+		Credential: &syscall.Credential{Uid: 65534, Gid: 65534},
+	}
+	status, err := runSandboxed(t, sandbox, "id -u; id -g")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"65534", "65534"}, strings.Fields(string(status.Stdout)))
+}
+
+func TestCoProcessPoolSandbox(t *testing.T) {
+	_ = os.Setenv("SANDBOX_DENIED", "no")
+	defer func() {
+		_ = os.Unsetenv("SANDBOX_DENIED")
+	}()
+
+	// Each worker echoes a response containing the value of SANDBOX_DENIED
+	script := `while read line; do echo "{\"id\": $(echo "$line" | sed 's/.*"id":\("[^"]*"\).*/\1/'), ` +
+		`\"data\": \"$SANDBOX_DENIED\"}"; done`
+	pool := util.NewCoProcessPool("sh", util.WithCoProcessArgs("-c", script),
+		util.WithCoProcessSandbox(&util.Sandbox{EnvAllowlist: []string{"PATH"}}))
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	out, err := pool.Call(context.Background(), map[string]interface{}{})
+	assert.Nil(t, err)
+	assert.Equal(t, "", out)
+}
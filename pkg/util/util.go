@@ -8,7 +8,6 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	api "github.com/kmgreen2/agglo/generated/proto"
-	"github.com/pkg/errors"
 	"hash"
 	"strings"
 	"sync"
@@ -16,6 +15,7 @@ import (
 	"fmt"
 	"reflect"
 	"math"
+	"strconv"
 )
 
 // Used to distinguish between different Digest algorithms
@@ -217,31 +217,22 @@ func NumericResolver(x, y interface{}) (float64, float64, error) {
 }
 
 func flatten(in interface{}, out map[string]interface{}, currKey string) {
-	var thisKey string
 	switch inVal := in.(type) {
 	case map[string]interface{}:
 		for k, _ := range inVal {
-			if len(currKey) == 0 {
-				thisKey = k
-			} else {
-				thisKey = fmt.Sprintf("%s.%s", currKey, k)
-			}
-			flatten(inVal[k], out, thisKey)
+			flatten(inVal[k], out, JoinPath(currKey, k))
 		}
 	case []interface{}:
 		for i, v := range inVal {
-			if len(currKey) == 0 {
-				thisKey = fmt.Sprintf("%d", i)
-			} else {
-				thisKey = fmt.Sprintf("%s.%d", currKey, i)
-			}
-			flatten(v, out, thisKey)
+			flatten(v, out, JoinPath(currKey, strconv.Itoa(i)))
 		}
 	default:
 		out[currKey] = in
 	}
 }
 
+// Flatten will return a single-level map keyed by the path (see Path) of each leaf value in the
+// provided map
 func Flatten(in map[string]interface{}) map[string]interface{} {
 	out := make(map[string]interface{})
 
@@ -249,65 +240,16 @@ func Flatten(in map[string]interface{}) map[string]interface{} {
 	return out
 }
 
-func updateMap(in interface{}, path []string, value interface{}) error {
-	switch inVal := in.(type) {
-	case map[string]interface{}:
-		if len(path) == 1 {
-			inVal[path[0]] = value
-			return nil
-		} else if len(path) > 1 {
-			if _, ok := inVal[path[0]]; !ok {
-				inVal[path[0]] = make(map[string]interface{})
-			}
-			return updateMap(inVal[path[0]], path[1:], value)
-		}
-	}
-	return &InvalidError{}
-}
-
+// UpdateMap will set the value at the provided path, creating intermediate maps as needed.  Path
+// components are taken literally and numeric components index into existing arrays.
 func UpdateMap(in map[string]interface{}, path []string, value interface{}) error {
-	err := updateMap(in, path, value)
-
-	if err != nil && errors.Is(err, &NotFoundError{}) {
-		msg := fmt.Sprintf("path '%v' not found in map", path)
-		return NewNotFoundError(msg)
-	} else if err != nil {
-		msg := fmt.Sprintf("intermediate value in path '%v' resolves to non-map value in map", path)
-		return NewInvalidError(msg)
-	}
-	return nil
-}
-
-func getMap(in interface{}, path []string) (interface{}, error) {
-	switch inVal := in.(type) {
-	case map[string]interface{}:
-		if len(path) == 1 {
-			if v, ok := inVal[path[0]]; ok {
-				return v, nil
-			}
-			return nil, &NotFoundError{}
-		} else if len(path) > 1 {
-			if v, ok := inVal[path[0]]; ok {
-				return getMap(v, path[1:])
-			} else {
-				return nil, &NotFoundError{}
-			}
-		}
-	}
-	return nil, &InvalidError{}
+	return NewPathFromComponents(path).Set(in, value)
 }
 
+// GetMap will get the value at the provided path.  Path components are taken literally and numeric
+// components index into arrays.
 func GetMap(in map[string]interface{}, path []string) (interface{}, error) {
-	val, err := getMap(in, path)
-
-	if err != nil && errors.Is(err, &NotFoundError{}) {
-		msg := fmt.Sprintf("path '%v' not found in map", path)
-		return nil, NewNotFoundError(msg)
-	} else if err != nil {
-		msg := fmt.Sprintf("intermediate value in path '%v' resolves to non-map value in map", path)
-		return nil, NewInvalidError(msg)
-	}
-	return val, nil
+	return NewPathFromComponents(path).Get(in)
 }
 
 func NumericEqual(lhs, rhs interface{}) bool {
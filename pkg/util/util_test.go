@@ -169,6 +169,13 @@ func TestUpdateMap(t *testing.T) {
 	assert.Equal(t, []int{1,2}, jsonMap["b"].(map[string]interface{})["e"].(map[string]interface{})["f"])
 
 	err = util.UpdateMap(jsonMap, []string{"i", "0", "j"}, []int{1,2})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1,2}, jsonMap["i"].([]interface{})[0].(map[string]interface{})["j"])
+
+	err = util.UpdateMap(jsonMap, []string{"i", "2", "j"}, []int{1,2})
+	assert.Error(t, err)
+
+	err = util.UpdateMap(jsonMap, []string{"a", "j"}, []int{1,2})
 	assert.Error(t, err)
 }
 
@@ -186,7 +193,14 @@ func TestGetMap(t *testing.T) {
 	_, err = util.GetMap(jsonMap, []string{"b", "e", "f"})
 	assert.Error(t, err)
 
-	_, err = util.GetMap(jsonMap, []string{"i", "0", "j"})
+	val, err = util.GetMap(jsonMap, []string{"i", "0", "j"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{float64(8),float64(9)}, val)
+
+	_, err = util.GetMap(jsonMap, []string{"i", "2", "j"})
+	assert.Error(t, err)
+
+	_, err = util.GetMap(jsonMap, []string{"a", "j"})
 	assert.Error(t, err)
 }
 